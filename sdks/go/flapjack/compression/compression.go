@@ -1,8 +1,162 @@
+// Package compression selects the compression of request bodies and the encodings accepted for response bodies.
+//
+// The Flapjack engine doesn't decompress request bodies, so compression is off by default (NONE) and must stay off
+// against a Flapjack server. It is meant for servers or proxies decoding the `Content-Encoding` of requests.
+//
+// Only gzip is built in, so that the client has no dependency. Other algorithms are enabled by registering an
+// implementation, for instance with github.com/klauspost/compress/zstd:
+//
+//	compression.Register(compression.ZSTD,
+//		func(w io.Writer) (io.WriteCloser, error) { return zstd.NewWriter(w) },
+//		func(r io.Reader) (io.ReadCloser, error) {
+//			d, err := zstd.NewReader(r)
+//			if err != nil {
+//				return nil, err
+//			}
+//
+//			return d.IOReadCloser(), nil
+//		},
+//	)
 package compression
 
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"sync"
+)
+
 type Compression int
 
 const (
 	NONE Compression = iota
 	GZIP
+	ZSTD
+	BROTLI
+)
+
+// Scope selects the calls to which compression applies.
+type Scope int
+
+const (
+	// ALL_CALLS compresses the request bodies of every call, searches included.
+	ALL_CALLS Scope = iota
+	// WRITE_CALLS only compresses the request bodies of write calls.
+	WRITE_CALLS
 )
+
+// WriterFunc creates a writer compressing into w.
+type WriterFunc func(w io.Writer) (io.WriteCloser, error)
+
+// ReaderFunc creates a reader decompressing r.
+type ReaderFunc func(r io.Reader) (io.ReadCloser, error)
+
+type algorithm struct {
+	newWriter WriterFunc
+	newReader ReaderFunc
+}
+
+var (
+	mu         sync.RWMutex
+	algorithms = map[Compression]algorithm{
+		GZIP: {
+			newWriter: func(w io.Writer) (io.WriteCloser, error) { return gzip.NewWriter(w), nil },
+			newReader: func(r io.Reader) (io.ReadCloser, error) { return gzip.NewReader(r) },
+		},
+	}
+)
+
+// Register sets the implementation of a compression algorithm, replacing the previous one if any.
+func Register(c Compression, newWriter WriterFunc, newReader ReaderFunc) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	algorithms[c] = algorithm{
+		newWriter: newWriter,
+		newReader: newReader,
+	}
+}
+
+// IsRegistered reports whether an implementation of the compression algorithm is available.
+func IsRegistered(c Compression) bool {
+	_, ok := lookup(c)
+
+	return ok
+}
+
+// ContentEncoding returns the name of the algorithm in the `Content-Encoding` and `Accept-Encoding` headers.
+func (c Compression) ContentEncoding() string {
+	switch c {
+	case GZIP:
+		return "gzip"
+	case ZSTD:
+		return "zstd"
+	case BROTLI:
+		return "br"
+	default:
+		return ""
+	}
+}
+
+// FromContentEncoding returns the algorithm of a `Content-Encoding` header value, NONE if unknown.
+func FromContentEncoding(contentEncoding string) Compression {
+	for _, c := range []Compression{GZIP, ZSTD, BROTLI} {
+		if c.ContentEncoding() == contentEncoding {
+			return c
+		}
+	}
+
+	return NONE
+}
+
+// Compress returns the data compressed with the given algorithm.
+func Compress(c Compression, data []byte) ([]byte, error) {
+	alg, ok := lookup(c)
+	if !ok {
+		return nil, fmt.Errorf("compression %q is not registered", c.ContentEncoding())
+	}
+
+	var buf bytes.Buffer
+
+	w, err := alg.newWriter(&buf)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create %s writer: %w", c.ContentEncoding(), err)
+	}
+
+	_, err = w.Write(data)
+	if err != nil {
+		return nil, fmt.Errorf("cannot compress with %s: %w", c.ContentEncoding(), err)
+	}
+
+	err = w.Close()
+	if err != nil {
+		return nil, fmt.Errorf("cannot compress with %s: %w", c.ContentEncoding(), err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// NewReader returns a reader decompressing r with the given algorithm.
+func NewReader(c Compression, r io.Reader) (io.ReadCloser, error) {
+	alg, ok := lookup(c)
+	if !ok {
+		return nil, fmt.Errorf("compression %q is not registered", c.ContentEncoding())
+	}
+
+	reader, err := alg.newReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create %s reader: %w", c.ContentEncoding(), err)
+	}
+
+	return reader, nil
+}
+
+func lookup(c Compression) (algorithm, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	alg, ok := algorithms[c]
+
+	return alg, ok
+}
@@ -0,0 +1,62 @@
+package compression_test
+
+import (
+	"bytes"
+	"compress/flate"
+	"io"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/compression"
+)
+
+func TestGzipRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	compressed, err := compression.Compress(compression.GZIP, []byte(`{"query":"phone"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	r, err := compression.NewReader(compression.FromContentEncoding("gzip"), bytes.NewReader(compressed))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out, err := io.ReadAll(r)
+	if err != nil || string(out) != `{"query":"phone"}` {
+		t.Errorf("unexpected content %q (%v)", out, err)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	t.Parallel()
+
+	if _, err := compression.Compress(compression.ZSTD, []byte("data")); err == nil {
+		t.Error("expected an error for an unregistered compression")
+	}
+
+	// Brotli stands for any algorithm registered by the application.
+	compression.Register(compression.BROTLI,
+		func(w io.Writer) (io.WriteCloser, error) { return flate.NewWriter(w, flate.BestSpeed) },
+		func(r io.Reader) (io.ReadCloser, error) { return flate.NewReader(r), nil },
+	)
+
+	if !compression.IsRegistered(compression.BROTLI) || compression.BROTLI.ContentEncoding() != "br" {
+		t.Fatal("expected brotli to be registered")
+	}
+
+	compressed, err := compression.Compress(compression.BROTLI, []byte("data"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	r, err := compression.NewReader(compression.BROTLI, bytes.NewReader(compressed))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out, err := io.ReadAll(r)
+	if err != nil || string(out) != "data" {
+		t.Errorf("unexpected content %q (%v)", out, err)
+	}
+}
@@ -2,7 +2,6 @@ package debug
 
 import (
 	"bytes"
-	"compress/gzip"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -27,15 +26,16 @@ func copyReadCloser(r io.ReadCloser) (io.ReadCloser, string) {
 	return io.NopCloser(bytes.NewReader(data)), string(data)
 }
 
-func decodeGzipContent(in string) (string, error) {
-	gr, err := gzip.NewReader(strings.NewReader(in))
+func decodeContent(in string, c compression.Compression) (string, error) {
+	r, err := compression.NewReader(c, strings.NewReader(in))
 	if err != nil {
-		return in, fmt.Errorf("cannot open content with gzip.Reader: %w", err)
+		return in, fmt.Errorf("cannot open content: %w", err)
 	}
+	defer r.Close()
 
-	out, err := io.ReadAll(gr)
+	out, err := io.ReadAll(r)
 	if err != nil {
-		return in, fmt.Errorf("cannot read content from gzip.Reader: %w", err)
+		return in, fmt.Errorf("cannot read content: %w", err)
 	}
 
 	return string(out), nil
@@ -48,14 +48,11 @@ func extractBody(body io.ReadCloser, c compression.Compression) (io.ReadCloser,
 
 	reader, content := copyReadCloser(body)
 
-	switch c {
-	case compression.GZIP:
-		decodedContent, err := decodeGzipContent(content)
+	if c != compression.NONE {
+		decodedContent, err := decodeContent(content, c)
 		if err == nil {
 			content = decodedContent
 		}
-	default:
-		// Do nothing
 	}
 
 	return reader, content
@@ -79,12 +76,7 @@ func debugRequest(req *http.Request) string {
 
 	var body string
 
-	switch req.Header.Get("Content-Encoding") {
-	case "gzip":
-		req.Body, body = extractBody(req.Body, compression.GZIP)
-	default:
-		req.Body, body = extractBody(req.Body, compression.NONE)
-	}
+	req.Body, body = extractBody(req.Body, compression.FromContentEncoding(req.Header.Get("Content-Encoding")))
 
 	msg := "> FLAPJACK DEBUG request:\n"
 	msg += fmt.Sprintf("\tmethod=%q\n", req.Method)
@@ -111,7 +103,7 @@ func debugResponse(res *http.Response) string {
 
 	var body string
 
-	res.Body, body = extractBody(res.Body, compression.NONE)
+	res.Body, body = extractBody(res.Body, compression.FromContentEncoding(res.Header.Get("Content-Encoding")))
 
 	msg := "> FLAPJACK DEBUG response:\n"
 	msg += fmt.Sprintf("\tbody=\n\t%s\n", prettyPrintJSON(body))
@@ -0,0 +1,10 @@
+package errs
+
+import "errors"
+
+// Sentinel errors matched by errors.Is against the API errors of the corresponding HTTP status.
+var (
+	ErrNotFound    = errors.New("not found")
+	ErrForbidden   = errors.New("forbidden")
+	ErrRateLimited = errors.New("rate limited")
+)
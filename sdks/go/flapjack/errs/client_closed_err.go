@@ -0,0 +1,6 @@
+package errs
+
+import "errors"
+
+// ErrClientClosed is returned by the calls of a client after it has been closed.
+var ErrClientClosed = errors.New("the client is closed")
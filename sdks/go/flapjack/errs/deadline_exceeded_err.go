@@ -0,0 +1,45 @@
+package errs
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// DeadlineExceededError is returned when the deadline of the context of a call leaves too little time for another
+// attempt, instead of starting an attempt that cannot finish. It matches context.DeadlineExceeded.
+type DeadlineExceededError struct {
+	// Hosts are the hosts tried before giving up, in order, empty when no attempt could start.
+	Hosts []string
+	// Remaining is the time left before the deadline when the call was given up.
+	Remaining time.Duration
+	// Err is the failure of the last attempt, nil when no attempt could start.
+	Err error
+}
+
+func NewDeadlineExceededError(hosts []string, remaining time.Duration, err error) *DeadlineExceededError {
+	return &DeadlineExceededError{
+		Hosts:     hosts,
+		Remaining: remaining,
+		Err:       err,
+	}
+}
+
+func (e *DeadlineExceededError) Error() string {
+	msg := fmt.Sprintf("deadline exceeded after %d attempts, %s left is too short for another one", len(e.Hosts), e.Remaining)
+
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %v", msg, e.Err)
+	}
+
+	return msg
+}
+
+// Unwrap returns context.DeadlineExceeded and the failure of the last attempt, if any.
+func (e *DeadlineExceededError) Unwrap() []error {
+	if e.Err == nil {
+		return []error{context.DeadlineExceeded}
+	}
+
+	return []error{context.DeadlineExceeded, e.Err}
+}
@@ -0,0 +1,90 @@
+package errs
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// HostError is the failure of a single attempt of a call on a host.
+type HostError struct {
+	Host string
+	// Attempt is 0 for the first attempt of the call, 1 for the first retry, and so on.
+	Attempt int
+	// Time is when the attempt started.
+	Time time.Time
+	// StatusCode is the HTTP status of the response, 0 when no response was received.
+	StatusCode int
+	Err        error
+}
+
+func (e *HostError) Error() string {
+	return fmt.Sprintf("attempt %d on %s at %s: %v", e.Attempt, e.Host, e.Time.Format(time.RFC3339Nano), e.Err)
+}
+
+func (e *HostError) Unwrap() error {
+	return e.Err
+}
+
+// MultiHostError is returned when all hosts have been contacted unsuccessfully and the client is configured with
+// `ExposeIntermediateNetworkErrors: true`. It lists the failure of every attempt, and matches ErrNoMoreHostToTry: errors.As
+// also finds a *NoMoreHostToTryError whose IntermediateNetworkErrors are the failures of the attempts.
+type MultiHostError struct {
+	Errors []*HostError
+}
+
+func NewMultiHostError(errs ...*HostError) *MultiHostError {
+	return &MultiHostError{
+		Errors: errs,
+	}
+}
+
+func (e *MultiHostError) Error() string {
+	var sb strings.Builder
+
+	sb.WriteString("all hosts have been contacted unsuccessfully:")
+
+	for _, err := range e.Errors {
+		sb.WriteString("\n\t")
+		sb.WriteString(err.Error())
+	}
+
+	return sb.String()
+}
+
+// Unwrap returns the failures of the attempts, so that errors.As and errors.Is look into each of them.
+func (e *MultiHostError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		errs = append(errs, err)
+	}
+
+	return errs
+}
+
+func (e MultiHostError) Is(target error) bool {
+	switch target.(type) {
+	case *MultiHostError, *NoMoreHostToTryError:
+		return true
+	default:
+		return false
+	}
+}
+
+// As sets a *NoMoreHostToTryError target to the error returned before MultiHostError was introduced, so that the callers
+// of IntermediateNetworkErrors keep working.
+func (e *MultiHostError) As(target any) bool {
+	noMoreHost, ok := target.(**NoMoreHostToTryError)
+	if !ok {
+		return false
+	}
+
+	errs := make([]error, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		errs = append(errs, err)
+	}
+
+	*noMoreHost = NewNoMoreHostToTryError(errs...)
+
+	return true
+}
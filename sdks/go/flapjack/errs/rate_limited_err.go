@@ -0,0 +1,40 @@
+package errs
+
+import (
+	"fmt"
+	"time"
+)
+
+// RateLimitedError is returned when a host answers with a `429 Too Many Requests` status and the client is
+// configured to surface it, or when it cannot wait as long as the host asked to.
+type RateLimitedError struct {
+	// Host is the host that rate limited the request.
+	Host string
+	// RetryAfter is the delay requested by the host through the `Retry-After` header, zero if there was none.
+	RetryAfter time.Duration
+}
+
+func NewRateLimitedError(host string, retryAfter time.Duration) *RateLimitedError {
+	return &RateLimitedError{
+		Host:       host,
+		RetryAfter: retryAfter,
+	}
+}
+
+func (e *RateLimitedError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("rate limited by %s, retry after %s", e.Host, e.RetryAfter)
+	}
+
+	return fmt.Sprintf("rate limited by %s", e.Host)
+}
+
+func (e RateLimitedError) Is(target error) bool {
+	if target == ErrRateLimited {
+		return true
+	}
+
+	_, ok := target.(*RateLimitedError)
+
+	return ok
+}
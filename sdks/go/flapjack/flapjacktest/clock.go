@@ -0,0 +1,76 @@
+package flapjacktest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+// Clock is a fake transport.Clock whose Sleep returns at once after moving the time forward, so that the retries,
+// backoffs and polling of the clients run instantly in tests:
+//
+//	clock := flapjacktest.NewClock(time.Time{})
+//	client, err := search.NewClient(appID, apiKey, transport.WithClock(clock))
+//	...
+//	clock.Slept() // the waits of the client
+type Clock struct {
+	mu    sync.Mutex
+	now   time.Time
+	slept []time.Duration
+}
+
+var _ transport.Clock = (*Clock)(nil)
+
+// NewClock returns a fake clock starting at now, or at an arbitrary fixed time when now is zero.
+func NewClock(now time.Time) *Clock {
+	if now.IsZero() {
+		now = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	return &Clock{now: now}
+}
+
+// Now returns the current time of the clock.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// Sleep moves the clock forward by d without waiting, unless ctx is done.
+func (c *Clock) Sleep(ctx context.Context, d time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if d <= 0 {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+	c.slept = append(c.slept, d)
+
+	return nil
+}
+
+// Advance moves the clock forward by d, for instance to expire a cache.
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+}
+
+// Slept returns the durations passed to Sleep, in order.
+func (c *Clock) Slept() []time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]time.Duration(nil), c.slept...)
+}
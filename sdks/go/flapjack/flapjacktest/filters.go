@@ -0,0 +1,442 @@
+package flapjacktest
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// filterExpr is a parsed `filters` expression, telling whether a record matches it.
+type filterExpr func(object map[string]any) bool
+
+// filterParser parses `filters` expressions with the grammar of the engine, so that the expressions the engine rejects
+// are rejected here too:
+//
+//	filter     = and ("OR" and)*
+//	and        = atom ("AND" atom)*
+//	atom       = "(" filter ")" | not | identifier operator number | comparison
+//	not        = "NOT" ("(" filter ")" | not | comparison)
+//	comparison = identifier ":" (number "TO" number | '"' non-quotes '"' | identifier)
+//
+// Identifiers are made of letters, digits and underscores, and the keywords are case-insensitive.
+type filterParser struct {
+	input string
+	pos   int
+}
+
+// parseFilters parses a `filters` expression.
+func parseFilters(input string) (filterExpr, error) {
+	p := &filterParser{input: strings.TrimSpace(input)}
+
+	expr, err := p.or()
+	if err != nil {
+		return nil, fmt.Errorf("Filter parse error: %w", err)
+	}
+
+	if p.pos < len(p.input) {
+		return nil, fmt.Errorf("Filter parse error: unexpected input after filter: '%s'", p.input[p.pos:])
+	}
+
+	return expr, nil
+}
+
+func (p *filterParser) or() (filterExpr, error) {
+	return p.list("OR", p.and, func(exprs []filterExpr, object map[string]any) bool {
+		for _, expr := range exprs {
+			if expr(object) {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+func (p *filterParser) and() (filterExpr, error) {
+	return p.list("AND", p.atom, func(exprs []filterExpr, object map[string]any) bool {
+		for _, expr := range exprs {
+			if !expr(object) {
+				return false
+			}
+		}
+
+		return true
+	})
+}
+
+// list parses operands separated by a keyword, an operand being required after each keyword.
+func (p *filterParser) list(keyword string, operand func() (filterExpr, error), combine func([]filterExpr, map[string]any) bool) (filterExpr, error) {
+	first, err := operand()
+	if err != nil {
+		return nil, err
+	}
+
+	exprs := []filterExpr{first}
+
+	for {
+		start := p.pos
+
+		p.spaces()
+
+		if !p.keyword(keyword) {
+			p.pos = start
+
+			break
+		}
+
+		p.spaces()
+
+		expr, err := operand()
+		if err != nil {
+			return nil, err
+		}
+
+		exprs = append(exprs, expr)
+	}
+
+	if len(exprs) == 1 {
+		return first, nil
+	}
+
+	return func(object map[string]any) bool { return combine(exprs, object) }, nil
+}
+
+func (p *filterParser) atom() (filterExpr, error) {
+	start := p.pos
+
+	if expr, ok, err := p.group(); ok || err != nil {
+		return expr, err
+	}
+
+	if expr, ok, err := p.not(); ok || err != nil {
+		return expr, err
+	}
+
+	if expr, ok := p.numericComparison(); ok {
+		return expr, nil
+	}
+
+	p.pos = start
+
+	if expr, ok := p.comparison(); ok {
+		return expr, nil
+	}
+
+	p.pos = start
+
+	return nil, fmt.Errorf("invalid condition at '%s'", p.input[start:])
+}
+
+// group parses a parenthesized expression, returning false when the input doesn't start with a parenthesis.
+func (p *filterParser) group() (filterExpr, bool, error) {
+	if !p.char('(') {
+		return nil, false, nil
+	}
+
+	p.spaces()
+
+	expr, err := p.or()
+	if err != nil {
+		return nil, true, err
+	}
+
+	p.spaces()
+
+	if !p.char(')') {
+		return nil, true, fmt.Errorf("expected ')' at '%s'", p.input[p.pos:])
+	}
+
+	return expr, true, nil
+}
+
+// not parses a negation, returning false when the input doesn't start with NOT. Numeric comparisons can only be negated
+// in parentheses, as with the engine.
+func (p *filterParser) not() (filterExpr, bool, error) {
+	start := p.pos
+
+	p.spaces()
+
+	if !p.keyword("NOT") {
+		p.pos = start
+
+		return nil, false, nil
+	}
+
+	if p.spaces() == 0 {
+		return nil, true, fmt.Errorf("expected a space after NOT at '%s'", p.input[p.pos:])
+	}
+
+	inner, ok, err := p.group()
+	if !ok && err == nil {
+		inner, ok, err = p.not()
+	}
+
+	if !ok && err == nil {
+		inner, ok = p.comparison()
+		if !ok {
+			err = fmt.Errorf("invalid negated condition at '%s'", p.input[p.pos:])
+		}
+	}
+
+	if err != nil {
+		return nil, true, err
+	}
+
+	return func(object map[string]any) bool { return !inner(object) }, true, nil
+}
+
+func (p *filterParser) numericComparison() (filterExpr, bool) {
+	p.spaces()
+
+	attribute, ok := p.identifier()
+	if !ok {
+		return nil, false
+	}
+
+	p.spaces()
+
+	var operator string
+
+	for _, candidate := range []string{">=", "<=", "!=", "=", ">", "<"} {
+		if strings.HasPrefix(p.input[p.pos:], candidate) {
+			operator = candidate
+			p.pos += len(candidate)
+
+			break
+		}
+	}
+
+	if operator == "" {
+		return nil, false
+	}
+
+	p.spaces()
+
+	value, ok := p.number()
+	if !ok {
+		return nil, false
+	}
+
+	p.spaces()
+
+	return func(object map[string]any) bool {
+		matched := false
+
+		for _, v := range numericValues(lookup(object, attribute)) {
+			switch operator {
+			case "=":
+				matched = matched || v == value
+			case "!=":
+				matched = matched || v != value
+			case ">":
+				matched = matched || v > value
+			case ">=":
+				matched = matched || v >= value
+			case "<":
+				matched = matched || v < value
+			case "<=":
+				matched = matched || v <= value
+			}
+		}
+
+		return matched
+	}, true
+}
+
+func (p *filterParser) comparison() (filterExpr, bool) {
+	p.spaces()
+
+	attribute, ok := p.identifier()
+	if !ok {
+		return nil, false
+	}
+
+	p.spaces()
+
+	if !p.char(':') {
+		return nil, false
+	}
+
+	p.spaces()
+
+	start := p.pos
+
+	if lower, ok := p.number(); ok && p.spaces() > 0 && p.keyword("TO") && p.spaces() > 0 {
+		if upper, ok := p.number(); ok {
+			return func(object map[string]any) bool {
+				for _, v := range numericValues(lookup(object, attribute)) {
+					if v >= lower && v <= upper {
+						return true
+					}
+				}
+
+				return false
+			}, true
+		}
+	}
+
+	p.pos = start
+
+	value, ok := p.quoted()
+	if !ok {
+		value, ok = p.identifier()
+	}
+
+	if !ok {
+		return nil, false
+	}
+
+	return func(object map[string]any) bool {
+		for _, v := range appendValues(nil, lookup(object, attribute)) {
+			if v == value {
+				return true
+			}
+		}
+
+		return false
+	}, true
+}
+
+// spaces skips the whitespace, returning how much was skipped.
+func (p *filterParser) spaces() int {
+	start := p.pos
+	for p.pos < len(p.input) && unicode.IsSpace(rune(p.input[p.pos])) {
+		p.pos++
+	}
+
+	return p.pos - start
+}
+
+func (p *filterParser) char(c byte) bool {
+	if p.pos < len(p.input) && p.input[p.pos] == c {
+		p.pos++
+
+		return true
+	}
+
+	return false
+}
+
+// keyword consumes a case-insensitive keyword not followed by a letter, digit or underscore.
+func (p *filterParser) keyword(keyword string) bool {
+	end := p.pos + len(keyword)
+	if end > len(p.input) || !strings.EqualFold(p.input[p.pos:end], keyword) {
+		return false
+	}
+
+	if end < len(p.input) && isIdentifierRune(rune(p.input[end])) {
+		return false
+	}
+
+	p.pos = end
+
+	return true
+}
+
+func (p *filterParser) identifier() (string, bool) {
+	start := p.pos
+
+	for i, r := range p.input[p.pos:] {
+		if !isIdentifierRune(r) {
+			p.pos = start + i
+
+			return p.input[start:p.pos], p.pos > start
+		}
+	}
+
+	p.pos = len(p.input)
+
+	return p.input[start:], p.pos > start
+}
+
+// quoted parses a non-empty string in double quotes, which cannot contain double quotes.
+func (p *filterParser) quoted() (string, bool) {
+	if p.pos >= len(p.input) || p.input[p.pos] != '"' {
+		return "", false
+	}
+
+	end := strings.IndexByte(p.input[p.pos+1:], '"')
+	if end <= 0 {
+		return "", false
+	}
+
+	value := p.input[p.pos+1 : p.pos+1+end]
+	p.pos += end + 2
+
+	return value, true
+}
+
+// number parses a decimal number with an optional sign, fraction and exponent.
+func (p *filterParser) number() (float64, bool) {
+	start := p.pos
+	end := p.pos
+
+	digits := func() int {
+		from := end
+		for end < len(p.input) && p.input[end] >= '0' && p.input[end] <= '9' {
+			end++
+		}
+
+		return end - from
+	}
+
+	if end < len(p.input) && (p.input[end] == '+' || p.input[end] == '-') {
+		end++
+	}
+
+	n := digits()
+
+	if end < len(p.input) && p.input[end] == '.' {
+		end++
+		n += digits()
+	}
+
+	if n == 0 {
+		return 0, false
+	}
+
+	if end < len(p.input) && (p.input[end] == 'e' || p.input[end] == 'E') {
+		exponent := end
+		end++
+
+		if end < len(p.input) && (p.input[end] == '+' || p.input[end] == '-') {
+			end++
+		}
+
+		if digits() == 0 {
+			end = exponent
+		}
+	}
+
+	value, err := strconv.ParseFloat(p.input[start:end], 64)
+	if err != nil || math.IsInf(value, 0) {
+		return 0, false
+	}
+
+	p.pos = end
+
+	return value, true
+}
+
+func isIdentifierRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsNumber(r) || r == '_'
+}
+
+// numericValues returns the numbers of an attribute, which may hold a list.
+func numericValues(value any) []float64 {
+	switch value := value.(type) {
+	case float64:
+		return []float64{value}
+	case []any:
+		var values []float64
+		for _, v := range value {
+			values = append(values, numericValues(v)...)
+		}
+
+		return values
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,570 @@
+package flapjacktest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+const defaultHitsPerPage = 20
+
+// collection is a set of items identified by their objectID, kept in insertion order.
+type collection struct {
+	items map[string]map[string]any
+	order []string
+}
+
+func newCollection() *collection {
+	return &collection{items: map[string]map[string]any{}}
+}
+
+func (c *collection) save(objectID string, item map[string]any) {
+	item = clone(item)
+	item["objectID"] = objectID
+
+	if _, ok := c.items[objectID]; !ok {
+		c.order = append(c.order, objectID)
+	}
+
+	c.items[objectID] = item
+}
+
+func (c *collection) remove(objectID string) {
+	if _, ok := c.items[objectID]; !ok {
+		return
+	}
+
+	delete(c.items, objectID)
+
+	for i, id := range c.order {
+		if id == objectID {
+			c.order = append(c.order[:i:i], c.order[i+1:]...)
+
+			break
+		}
+	}
+}
+
+func (c *collection) clear() {
+	c.items = map[string]map[string]any{}
+	c.order = nil
+}
+
+func (c *collection) copy() *collection {
+	copied := newCollection()
+	for _, objectID := range c.order {
+		copied.save(objectID, c.items[objectID])
+	}
+
+	return copied
+}
+
+// search returns the items whose JSON contains the query, for the synonyms and rules searches.
+func (c *collection) search(params map[string]any) map[string]any {
+	query, _ := params["query"].(string)
+	query = strings.ToLower(query)
+
+	var hits []any
+
+	for _, objectID := range c.order {
+		raw, _ := json.Marshal(c.items[objectID])
+		if strings.Contains(strings.ToLower(string(raw)), query) {
+			hits = append(hits, c.items[objectID])
+		}
+	}
+
+	page, hitsPerPage := pagination(params, defaultHitsPerPage)
+	pageHits, nbPages := paginate(hits, page, hitsPerPage)
+
+	return map[string]any{"hits": pageHits, "nbHits": len(hits), "page": page, "nbPages": nbPages}
+}
+
+type index struct {
+	*collection
+
+	settings  map[string]any
+	synonyms  *collection
+	rules     *collection
+	createdAt string
+}
+
+func newIndex() *index {
+	return &index{
+		collection: newCollection(),
+		settings:   map[string]any{},
+		synonyms:   newCollection(),
+		rules:      newCollection(),
+		createdAt:  now(),
+	}
+}
+
+// copy returns a copy of the index. With scopes, only the settings, synonyms and rules in scope are copied to the
+// destination, keeping its records.
+func (idx *index) copy(destination *index, scopes map[string]bool) *index {
+	if len(scopes) == 0 || destination == nil {
+		destination = newIndex()
+	}
+
+	if len(scopes) == 0 {
+		destination.collection = idx.collection.copy()
+	}
+
+	if len(scopes) == 0 || scopes["settings"] {
+		destination.settings = clone(idx.settings)
+	}
+
+	if len(scopes) == 0 || scopes["synonyms"] {
+		destination.synonyms = idx.synonyms.copy()
+	}
+
+	if len(scopes) == 0 || scopes["rules"] {
+		destination.rules = idx.rules.copy()
+	}
+
+	return destination
+}
+
+// partialUpdate updates the given attributes of a record, applying the built-in operations such as `Increment`.
+func (idx *index) partialUpdate(objectID string, attributes map[string]any, createIfNotExists bool) {
+	object, ok := idx.items[objectID]
+	if !ok && !createIfNotExists {
+		return
+	}
+
+	if !ok {
+		object = map[string]any{}
+	}
+
+	object = clone(object)
+
+	for attribute, value := range attributes {
+		if attribute == "objectID" {
+			continue
+		}
+
+		object[attribute] = applyOperation(object[attribute], value)
+	}
+
+	idx.save(objectID, object)
+}
+
+func applyOperation(current, value any) any {
+	operation, ok := value.(map[string]any)
+	if !ok {
+		return value
+	}
+
+	name, ok := operation["_operation"].(string)
+	if !ok {
+		return value
+	}
+
+	operand := operation["value"]
+
+	switch name {
+	case "Increment", "Decrement":
+		n, _ := current.(float64)
+		delta, _ := operand.(float64)
+
+		if name == "Decrement" {
+			delta = -delta
+		}
+
+		return n + delta
+	case "Add", "AddUnique", "Remove":
+		values, _ := current.([]any)
+
+		if name == "Remove" {
+			kept := []any{}
+			for _, v := range values {
+				if fmt.Sprint(v) != fmt.Sprint(operand) {
+					kept = append(kept, v)
+				}
+			}
+
+			return kept
+		}
+
+		if name == "AddUnique" {
+			for _, v := range values {
+				if fmt.Sprint(v) == fmt.Sprint(operand) {
+					return values
+				}
+			}
+		}
+
+		return append(values, operand)
+	default:
+		return value
+	}
+}
+
+// search runs a query on the records of the index.
+func (idx *index) search(indexName string, params map[string]any) (map[string]any, error) {
+	filters := func(map[string]any) bool { return true }
+
+	if expression, _ := params["filters"].(string); expression != "" {
+		parsed, err := parseFilters(expression)
+		if err != nil {
+			return nil, err
+		}
+
+		filters = parsed
+	}
+
+	query, _ := params["query"].(string)
+	words := strings.Fields(strings.ToLower(query))
+	searchable := searchableAttributes(idx.settings)
+	facetFilters := params["facetFilters"]
+
+	attributesToRetrieve := joinAttributes(params["attributesToRetrieve"])
+	if attributesToRetrieve == "" {
+		attributesToRetrieve = joinAttributes(idx.settings["attributesToRetrieve"])
+	}
+
+	getRankingInfo, _ := params["getRankingInfo"].(bool)
+
+	var hits []any
+
+	for _, objectID := range idx.order {
+		object := idx.items[objectID]
+
+		if matches(object, words, searchable) && matchesFacetFilters(object, facetFilters) && filters(object) {
+			hit := retrieve(object, attributesToRetrieve)
+			if getRankingInfo {
+				hit["_rankingInfo"] = rankingInfo(len(words))
+			}
+
+			hits = append(hits, hit)
+		}
+	}
+
+	defaultPerPage := defaultHitsPerPage
+	if n, ok := idx.settings["hitsPerPage"].(float64); ok {
+		defaultPerPage = int(n)
+	}
+
+	page, hitsPerPage := pagination(params, defaultPerPage)
+	pageHits, nbPages := paginate(hits, page, hitsPerPage)
+
+	return map[string]any{
+		"hits":             pageHits,
+		"nbHits":           len(hits),
+		"page":             page,
+		"nbPages":          nbPages,
+		"hitsPerPage":      hitsPerPage,
+		"processingTimeMS": 1,
+		"exhaustiveNbHits": true,
+		"query":            query,
+		"params":           "",
+		"index":            indexName,
+	}, nil
+}
+
+// rankingInfo returns the ranking information of a hit, as the engine reports it without typo tolerance or geo
+// search: every word of the query matched exactly.
+func rankingInfo(words int) map[string]any {
+	return map[string]any{
+		"nbTypos":           0,
+		"firstMatchedWord":  0,
+		"proximityDistance": 0,
+		"userScore":         0,
+		"geoDistance":       0,
+		"geoPrecision":      1,
+		"nbExactWords":      words,
+		"words":             words,
+		"filters":           0,
+	}
+}
+
+func searchableAttributes(settings map[string]any) []string {
+	var attributes []string
+
+	list, _ := settings["searchableAttributes"].([]any)
+	for _, attribute := range list {
+		attribute, _ := attribute.(string)
+
+		for _, name := range strings.Split(attribute, ",") {
+			name = strings.TrimSpace(name)
+			name = strings.TrimSuffix(strings.TrimPrefix(name, "unordered("), ")")
+			attributes = append(attributes, name)
+		}
+	}
+
+	return attributes
+}
+
+// matches tells whether every word of the query is found in a searchable attribute of the record.
+func matches(object map[string]any, words []string, searchable []string) bool {
+	if len(words) == 0 {
+		return true
+	}
+
+	var values []string
+
+	if len(searchable) == 0 {
+		for attribute, value := range object {
+			if attribute != "objectID" {
+				values = appendStrings(values, value)
+			}
+		}
+	} else {
+		for _, attribute := range searchable {
+			values = appendStrings(values, lookup(object, attribute))
+		}
+	}
+
+	for _, word := range words {
+		found := false
+
+		for _, value := range values {
+			if strings.Contains(strings.ToLower(value), word) {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchesFacetFilters applies facet filters such as `["brand:Apple", ["color:red", "color:blue"]]`: the elements of
+// the top level are combined with AND, the nested ones with OR.
+func matchesFacetFilters(object map[string]any, facetFilters any) bool {
+	switch filters := facetFilters.(type) {
+	case nil:
+		return true
+	case string:
+		return matchesFacetFilter(object, filters)
+	case []any:
+		for _, filter := range filters {
+			switch filter := filter.(type) {
+			case string:
+				if !matchesFacetFilter(object, filter) {
+					return false
+				}
+			case []any:
+				anyMatch := false
+
+				for _, alternative := range filter {
+					if alternative, ok := alternative.(string); ok && matchesFacetFilter(object, alternative) {
+						anyMatch = true
+
+						break
+					}
+				}
+
+				if !anyMatch {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// matchesFacetFilter applies a facet filter, negated by a `-` before the attribute as with the engine: a `-` before
+// the value is part of the value.
+func matchesFacetFilter(object map[string]any, filter string) bool {
+	negated := strings.HasPrefix(filter, "-")
+	filter = strings.TrimPrefix(filter, "-")
+
+	attribute, value, ok := strings.Cut(filter, ":")
+	if !ok {
+		return false
+	}
+
+	found := false
+
+	for _, v := range appendValues(nil, lookup(object, attribute)) {
+		if v == value {
+			found = true
+
+			break
+		}
+	}
+
+	return found != negated
+}
+
+// lookup returns the value of an attribute of the record, nested attributes being separated by dots.
+func lookup(object map[string]any, attribute string) any {
+	var value any = object
+
+	for _, name := range strings.Split(attribute, ".") {
+		m, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+
+		value = m[name]
+	}
+
+	return value
+}
+
+func appendStrings(values []string, value any) []string {
+	switch value := value.(type) {
+	case string:
+		return append(values, value)
+	case []any:
+		for _, v := range value {
+			values = appendStrings(values, v)
+		}
+	case map[string]any:
+		for _, v := range value {
+			values = appendStrings(values, v)
+		}
+	}
+
+	return values
+}
+
+// appendValues appends the scalar values of a facet, as strings.
+func appendValues(values []string, value any) []string {
+	switch value := value.(type) {
+	case nil:
+		return values
+	case []any:
+		for _, v := range value {
+			values = appendValues(values, v)
+		}
+
+		return values
+	default:
+		return append(values, fmt.Sprint(value))
+	}
+}
+
+func pagination(params map[string]any, defaultHitsPerPage int) (int, int) {
+	page, hitsPerPage := 0, defaultHitsPerPage
+
+	if n, ok := params["page"].(float64); ok && n > 0 {
+		page = int(n)
+	}
+
+	if n, ok := params["hitsPerPage"].(float64); ok && n > 0 {
+		hitsPerPage = int(n)
+	}
+
+	return page, hitsPerPage
+}
+
+func paginate(hits []any, page, hitsPerPage int) ([]any, int) {
+	nbPages := (len(hits) + hitsPerPage - 1) / hitsPerPage
+	start := min(page*hitsPerPage, len(hits))
+	end := min(start+hitsPerPage, len(hits))
+
+	return append([]any{}, hits[start:end]...), nbPages
+}
+
+// retrieve returns a copy of the record with the given comma-separated attributes only, all of them when empty or `*`.
+func retrieve(object map[string]any, attributes string) map[string]any {
+	if attributes == "" || attributes == "*" {
+		return clone(object)
+	}
+
+	retrieved := map[string]any{"objectID": object["objectID"]}
+
+	for _, attribute := range strings.Split(attributes, ",") {
+		attribute = strings.TrimSpace(attribute)
+		if value, ok := object[attribute]; ok {
+			retrieved[attribute] = value
+		}
+	}
+
+	return clone(retrieved)
+}
+
+func joinAttributes(attributes any) string {
+	switch attributes := attributes.(type) {
+	case string:
+		return attributes
+	case []any:
+		names := make([]string, 0, len(attributes))
+		for _, attribute := range attributes {
+			if attribute, ok := attribute.(string); ok {
+				names = append(names, attribute)
+			}
+		}
+
+		return strings.Join(names, ",")
+	default:
+		return ""
+	}
+}
+
+// withParams returns the search parameters with those of the legacy `params` query string merged in.
+func withParams(params map[string]any) map[string]any {
+	merged := make(map[string]any, len(params))
+	for k, v := range params {
+		merged[k] = v
+	}
+
+	encoded, _ := params["params"].(string)
+
+	values, err := url.ParseQuery(encoded)
+	if err != nil {
+		return merged
+	}
+
+	for k := range values {
+		if _, ok := merged[k]; ok {
+			continue
+		}
+
+		var value any
+		if json.Unmarshal([]byte(values.Get(k)), &value) != nil {
+			value = values.Get(k)
+		}
+
+		merged[k] = value
+	}
+
+	return merged
+}
+
+func clone(m map[string]any) map[string]any {
+	if m == nil {
+		return map[string]any{}
+	}
+
+	raw, _ := json.Marshal(m)
+
+	var cloned map[string]any
+	_ = json.Unmarshal(raw, &cloned)
+
+	return cloned
+}
+
+func sortedKeys(indices map[string]*index) []string {
+	keys := make([]string, 0, len(indices))
+	for k := range indices {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+type rawBodyKey struct{}
+
+func withRawBody(ctx context.Context, raw json.RawMessage) context.Context {
+	return context.WithValue(ctx, rawBodyKey{}, raw)
+}
+
+func rawBodyFromContext(ctx context.Context) json.RawMessage {
+	raw, _ := ctx.Value(rawBodyKey{}).(json.RawMessage)
+
+	return raw
+}
@@ -0,0 +1,641 @@
+// Package flapjacktest provides an in-process fake Flapjack server, to test code using the API clients without
+// running the engine.
+//
+// The fake keeps its indices in memory and implements the search, objects, batch, settings, synonyms and rules
+// endpoints of the Search API. Tasks are published immediately. Searches match the words of the query against the
+// searchable attributes of the records, and support pagination, filters, facet filters and getRankingInfo only:
+// ranking, typo tolerance and the other search parameters are ignored.
+//
+//	srv := flapjacktest.NewServer()
+//	defer srv.Close()
+//
+//	client, err := srv.NewClient()
+package flapjacktest
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/call"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+const (
+	AppID  = "flapjacktest"
+	ApiKey = "flapjacktest-api-key" //nolint:gosec
+)
+
+// Server is a fake Flapjack server listening on a local address.
+type Server struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	indices map[string]*index
+	taskID  int64
+}
+
+// NewServer starts a fake server without indices. It must be closed with Close.
+func NewServer() *Server {
+	s := &Server{
+		indices: map[string]*index{},
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// Host returns the host of the server, to be used in the configuration of the clients.
+func (s *Server) Host() transport.StatefulHost {
+	return transport.NewStatefulHost("http", strings.TrimPrefix(s.URL, "http://"), call.IsReadWrite)
+}
+
+// NewClient creates a search client sending its requests to the server, customized with the given options.
+func (s *Server) NewClient(opts ...transport.ClientOption) (*search.APIClient, error) {
+	return search.NewClient(AppID, ApiKey, append([]transport.ClientOption{transport.WithHosts(s.Host())}, opts...)...) //nolint:wrapcheck
+}
+
+// AddObjects adds records to an index, creating it if needed, as if they had been indexed with the API. Records without
+// objectID get a generated one.
+func (s *Server) AddObjects(indexName string, objects ...map[string]any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	idx := s.index(indexName, true)
+	for _, object := range objects {
+		idx.save(s.objectID(object), object)
+	}
+}
+
+// Objects returns the records of an index, in the order they were added.
+func (s *Server) Objects(indexName string) []map[string]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	idx := s.index(indexName, false)
+	if idx == nil {
+		return nil
+	}
+
+	objects := make([]map[string]any, 0, len(idx.order))
+	for _, objectID := range idx.order {
+		objects = append(objects, clone(idx.items[objectID]))
+	}
+
+	return objects
+}
+
+// Settings returns the settings of an index, nil if it doesn't exist.
+func (s *Server) Settings(indexName string) map[string]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	idx := s.index(indexName, false)
+	if idx == nil {
+		return nil
+	}
+
+	return clone(idx.settings)
+}
+
+// index returns the index of the given name, creating it when create is set, or nil.
+func (s *Server) index(indexName string, create bool) *index {
+	idx, ok := s.indices[indexName]
+	if !ok && create {
+		idx = newIndex()
+		s.indices[indexName] = idx
+	}
+
+	return idx
+}
+
+func (s *Server) objectID(object map[string]any) string {
+	if objectID, ok := object["objectID"].(string); ok && objectID != "" {
+		return objectID
+	}
+
+	s.taskID++
+
+	return "flapjacktest-" + strconv.FormatInt(s.taskID, 10)
+}
+
+// nextTaskID returns the ID of a new task, published immediately.
+func (s *Server) nextTaskID() int64 {
+	s.taskID++
+
+	return s.taskID
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	var segments []string
+
+	for _, segment := range strings.Split(strings.Trim(r.URL.EscapedPath(), "/"), "/") {
+		unescaped, err := url.PathUnescape(segment)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid path")
+
+			return
+		}
+
+		segments = append(segments, unescaped)
+	}
+
+	body := map[string]any{}
+	if r.Body != nil && r.ContentLength != 0 && r.Method != http.MethodGet {
+		raw := json.RawMessage{}
+
+		err := json.NewDecoder(r.Body).Decode(&raw)
+		if err != nil && !errors.Is(err, io.EOF) {
+			writeError(w, http.StatusBadRequest, "invalid JSON body: "+err.Error())
+
+			return
+		}
+
+		r = r.WithContext(withRawBody(r.Context(), raw))
+		_ = json.Unmarshal(raw, &body)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case len(segments) >= 2 && segments[0] == "1" && segments[1] == "indexes":
+		s.serveIndexes(w, r, segments[2:], body)
+	case len(segments) == 3 && segments[0] == "1" && segments[1] == "task" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]any{"status": "published"})
+	default:
+		writeError(w, http.StatusNotFound, "route not supported by flapjacktest")
+	}
+}
+
+func (s *Server) serveIndexes(w http.ResponseWriter, r *http.Request, segments []string, body map[string]any) {
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		s.listIndices(w)
+	case len(segments) == 2 && segments[0] == "*" && r.Method == http.MethodPost:
+		switch segments[1] {
+		case "queries":
+			s.searchMultipleIndices(w, body)
+		case "batch":
+			s.batchMultipleIndices(w, body)
+		case "objects":
+			s.getObjects(w, body)
+		default:
+			writeError(w, http.StatusNotFound, "route not supported by flapjacktest")
+		}
+	case len(segments) == 1:
+		s.serveIndex(w, r, segments[0], body)
+	case len(segments) == 2:
+		s.serveIndexAction(w, r, segments[0], segments[1], body)
+	case len(segments) == 3 && segments[1] == "task" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]any{"status": "published", "pendingTask": false})
+	case len(segments) == 3 && (segments[1] == "synonyms" || segments[1] == "rules"):
+		s.serveCollection(w, r, segments[0], segments[1], segments[2], body)
+	case len(segments) == 3 && segments[2] == "partial" && r.Method == http.MethodPost:
+		s.partialUpdateObject(w, r, segments[0], segments[1], body)
+	default:
+		writeError(w, http.StatusNotFound, "route not supported by flapjacktest")
+	}
+}
+
+func (s *Server) serveIndex(w http.ResponseWriter, r *http.Request, indexName string, body map[string]any) {
+	switch r.Method {
+	case http.MethodPost:
+		objectID := s.objectID(body)
+		s.index(indexName, true).save(objectID, body)
+		writeJSON(w, http.StatusCreated, map[string]any{"taskID": s.nextTaskID(), "objectID": objectID, "createdAt": now()})
+	case http.MethodDelete:
+		delete(s.indices, indexName)
+		writeJSON(w, http.StatusOK, map[string]any{"taskID": s.nextTaskID(), "deletedAt": now()})
+	default:
+		writeError(w, http.StatusNotFound, "route not supported by flapjacktest")
+	}
+}
+
+func (s *Server) serveIndexAction(w http.ResponseWriter, r *http.Request, indexName, action string, body map[string]any) {
+	switch {
+	case action == "query" && r.Method == http.MethodPost:
+		s.search(w, indexName, body)
+	case action == "browse" && r.Method == http.MethodPost:
+		s.browse(w, indexName, body)
+	case action == "batch" && r.Method == http.MethodPost:
+		s.batch(w, indexName, body)
+	case action == "clear" && r.Method == http.MethodPost:
+		if idx := s.index(indexName, false); idx != nil {
+			idx.clear()
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{"taskID": s.nextTaskID(), "updatedAt": now()})
+	case action == "operation" && r.Method == http.MethodPost:
+		s.operation(w, indexName, body)
+	case action == "settings" && r.Method == http.MethodGet:
+		idx := s.index(indexName, false)
+		if idx == nil {
+			writeError(w, http.StatusNotFound, "Index does not exist")
+
+			return
+		}
+
+		writeJSON(w, http.StatusOK, idx.settings)
+	case action == "settings" && r.Method == http.MethodPut:
+		idx := s.index(indexName, true)
+		for k, v := range body {
+			idx.settings[k] = v
+		}
+
+		s.createReplicas(indexName, body["replicas"])
+
+		writeJSON(w, http.StatusOK, map[string]any{"taskID": s.nextTaskID(), "updatedAt": now()})
+	default:
+		s.serveObject(w, r, indexName, action, body)
+	}
+}
+
+// createReplicas creates the replicas of a primary index missing, like the engine does when the replicas setting is set.
+func (s *Server) createReplicas(indexName string, replicas any) {
+	names, _ := replicas.([]any)
+
+	for _, name := range names {
+		replica, _ := name.(string)
+		replica = strings.TrimSuffix(strings.TrimPrefix(replica, "virtual("), ")")
+
+		if replica != "" {
+			s.index(replica, true).settings["primary"] = indexName
+		}
+	}
+}
+
+func (s *Server) serveObject(w http.ResponseWriter, r *http.Request, indexName, objectID string, body map[string]any) {
+	switch r.Method {
+	case http.MethodGet:
+		idx := s.index(indexName, false)
+		if idx == nil {
+			writeError(w, http.StatusNotFound, "Index does not exist")
+
+			return
+		}
+
+		object, ok := idx.items[objectID]
+		if !ok {
+			writeError(w, http.StatusNotFound, "ObjectID does not exist")
+
+			return
+		}
+
+		writeJSON(w, http.StatusOK, retrieve(object, r.URL.Query().Get("attributesToRetrieve")))
+	case http.MethodPut:
+		s.index(indexName, true).save(objectID, body)
+		writeJSON(w, http.StatusOK, map[string]any{"taskID": s.nextTaskID(), "objectID": objectID, "updatedAt": now()})
+	case http.MethodDelete:
+		if idx := s.index(indexName, false); idx != nil {
+			idx.remove(objectID)
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{"taskID": s.nextTaskID(), "deletedAt": now()})
+	default:
+		writeError(w, http.StatusNotFound, "route not supported by flapjacktest")
+	}
+}
+
+func (s *Server) partialUpdateObject(w http.ResponseWriter, r *http.Request, indexName, objectID string, body map[string]any) {
+	createIfNotExists := r.URL.Query().Get("createIfNotExists") != "false"
+
+	s.index(indexName, true).partialUpdate(objectID, body, createIfNotExists)
+	writeJSON(w, http.StatusOK, map[string]any{"taskID": s.nextTaskID(), "objectID": objectID, "updatedAt": now()})
+}
+
+func (s *Server) listIndices(w http.ResponseWriter) {
+	items := []map[string]any{}
+
+	for _, name := range sortedKeys(s.indices) {
+		idx := s.indices[name]
+		items = append(items, map[string]any{
+			"name":                 name,
+			"createdAt":            idx.createdAt,
+			"updatedAt":            now(),
+			"entries":              len(idx.order),
+			"dataSize":             0,
+			"fileSize":             0,
+			"lastBuildTimeS":       0,
+			"numberOfPendingTasks": 0,
+			"pendingTask":          false,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"items": items, "nbPages": 1})
+}
+
+func (s *Server) batch(w http.ResponseWriter, indexName string, body map[string]any) {
+	requests, _ := body["requests"].([]any)
+	objectIDs := []string{}
+
+	for _, request := range requests {
+		request, _ := request.(map[string]any)
+		action, _ := request["action"].(string)
+		object, _ := request["body"].(map[string]any)
+
+		objectID, ok := s.applyBatchAction(indexName, action, object)
+		if !ok {
+			writeError(w, http.StatusBadRequest, "unsupported batch action "+strconv.Quote(action))
+
+			return
+		}
+
+		objectIDs = append(objectIDs, objectID)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"taskID": s.nextTaskID(), "objectIDs": objectIDs})
+}
+
+func (s *Server) batchMultipleIndices(w http.ResponseWriter, body map[string]any) {
+	requests, _ := body["requests"].([]any)
+	objectIDs := []string{}
+	taskIDs := map[string]int64{}
+
+	for _, request := range requests {
+		request, _ := request.(map[string]any)
+		action, _ := request["action"].(string)
+		indexName, _ := request["indexName"].(string)
+		object, _ := request["body"].(map[string]any)
+
+		objectID, ok := s.applyBatchAction(indexName, action, object)
+		if !ok {
+			writeError(w, http.StatusBadRequest, "unsupported batch action "+strconv.Quote(action))
+
+			return
+		}
+
+		objectIDs = append(objectIDs, objectID)
+		taskIDs[indexName] = s.nextTaskID()
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"taskID": taskIDs, "objectIDs": objectIDs})
+}
+
+// applyBatchAction applies an action of a batch, returning the objectID of its record and false when the action isn't
+// supported.
+func (s *Server) applyBatchAction(indexName, action string, object map[string]any) (string, bool) {
+	idx := s.index(indexName, true)
+
+	switch action {
+	case "addObject":
+		objectID := s.objectID(object)
+		idx.save(objectID, object)
+
+		return objectID, true
+	case "updateObject":
+		objectID, _ := object["objectID"].(string)
+		idx.save(objectID, object)
+
+		return objectID, true
+	case "partialUpdateObject", "partialUpdateObjectNoCreate":
+		objectID, _ := object["objectID"].(string)
+		idx.partialUpdate(objectID, object, action == "partialUpdateObject")
+
+		return objectID, true
+	case "deleteObject":
+		objectID, _ := object["objectID"].(string)
+		idx.remove(objectID)
+
+		return objectID, true
+	case "clear":
+		idx.clear()
+
+		return "", true
+	case "delete":
+		delete(s.indices, indexName)
+
+		return "", true
+	default:
+		return "", false
+	}
+}
+
+func (s *Server) getObjects(w http.ResponseWriter, body map[string]any) {
+	requests, _ := body["requests"].([]any)
+	results := []any{}
+
+	for _, request := range requests {
+		request, _ := request.(map[string]any)
+		indexName, _ := request["indexName"].(string)
+		objectID, _ := request["objectID"].(string)
+
+		var result any
+
+		if idx := s.index(indexName, false); idx != nil {
+			if object, ok := idx.items[objectID]; ok {
+				result = retrieve(object, joinAttributes(request["attributesToRetrieve"]))
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"results": results})
+}
+
+func (s *Server) operation(w http.ResponseWriter, indexName string, body map[string]any) {
+	operation, _ := body["operation"].(string)
+	destination, _ := body["destination"].(string)
+
+	// Copying a missing index copies an empty one, so that ReplaceAllObjects works on new indices.
+	source := s.index(indexName, false)
+	if source == nil && operation == "copy" {
+		source = newIndex()
+	}
+
+	if source == nil {
+		writeError(w, http.StatusNotFound, "Index does not exist")
+
+		return
+	}
+
+	scopes := map[string]bool{}
+	if scope, ok := body["scope"].([]any); ok && operation == "copy" {
+		for _, s := range scope {
+			if s, ok := s.(string); ok {
+				scopes[s] = true
+			}
+		}
+	}
+
+	switch operation {
+	case "move":
+		s.indices[destination] = source
+		delete(s.indices, indexName)
+	case "copy":
+		s.indices[destination] = source.copy(s.index(destination, false), scopes)
+	default:
+		writeError(w, http.StatusBadRequest, "unsupported operation "+strconv.Quote(operation))
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"taskID": s.nextTaskID(), "updatedAt": now()})
+}
+
+func (s *Server) search(w http.ResponseWriter, indexName string, params map[string]any) {
+	res, err := s.index(indexName, true).search(indexName, withParams(params))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (s *Server) searchMultipleIndices(w http.ResponseWriter, body map[string]any) {
+	requests, _ := body["requests"].([]any)
+	results := []any{}
+
+	for _, request := range requests {
+		params, _ := request.(map[string]any)
+		params = withParams(params)
+		indexName, _ := params["indexName"].(string)
+
+		if kind, _ := params["type"].(string); kind == "facet" {
+			writeError(w, http.StatusBadRequest, "facet searches are not supported by flapjacktest")
+
+			return
+		}
+
+		res, err := s.index(indexName, true).search(indexName, params)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+
+			return
+		}
+
+		results = append(results, res)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"results": results})
+}
+
+func (s *Server) browse(w http.ResponseWriter, indexName string, params map[string]any) {
+	idx := s.index(indexName, false)
+	if idx == nil {
+		writeError(w, http.StatusNotFound, "Index does not exist")
+
+		return
+	}
+
+	params = withParams(params)
+
+	// The cursor is the position of the next page in the results.
+	if cursor, ok := params["cursor"].(string); ok {
+		page, err := strconv.Atoi(cursor)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid cursor")
+
+			return
+		}
+
+		params["page"] = float64(page)
+	}
+
+	res, err := idx.search(indexName, params)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	if page, nbPages := res["page"].(int), res["nbPages"].(int); page+1 < nbPages {
+		res["cursor"] = strconv.Itoa(page + 1)
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (s *Server) serveCollection(w http.ResponseWriter, r *http.Request, indexName, collection, id string, body map[string]any) {
+	idx := s.index(indexName, r.Method != http.MethodGet)
+	if idx == nil {
+		writeError(w, http.StatusNotFound, "Index does not exist")
+
+		return
+	}
+
+	items := idx.synonyms
+	if collection == "rules" {
+		items = idx.rules
+	}
+
+	updated := map[string]any{"taskID": s.nextTaskID(), "updatedAt": now()}
+
+	switch {
+	case id == "batch" && r.Method == http.MethodPost:
+		if r.URL.Query().Get("replaceExistingSynonyms") == "true" || r.URL.Query().Get("clearExistingRules") == "true" {
+			items.clear()
+		}
+
+		var batch []map[string]any
+		_ = json.Unmarshal(rawBodyFromContext(r.Context()), &batch)
+
+		for _, item := range batch {
+			objectID, _ := item["objectID"].(string)
+			items.save(objectID, item)
+		}
+
+		writeJSON(w, http.StatusOK, updated)
+	case id == "clear" && r.Method == http.MethodPost:
+		items.clear()
+		writeJSON(w, http.StatusOK, updated)
+	case id == "search" && r.Method == http.MethodPost:
+		writeJSON(w, http.StatusOK, items.search(body))
+	case r.Method == http.MethodPut:
+		body["objectID"] = id
+		items.save(id, body)
+
+		if collection == "synonyms" {
+			updated["id"] = id
+		}
+
+		writeJSON(w, http.StatusOK, updated)
+	case r.Method == http.MethodGet:
+		item, ok := items.items[id]
+		if !ok {
+			writeError(w, http.StatusNotFound, "ObjectID does not exist")
+
+			return
+		}
+
+		writeJSON(w, http.StatusOK, item)
+	case r.Method == http.MethodDelete:
+		items.remove(id)
+
+		if collection == "synonyms" {
+			writeJSON(w, http.StatusOK, map[string]any{"taskID": updated["taskID"], "deletedAt": now()})
+
+			return
+		}
+
+		writeJSON(w, http.StatusOK, updated)
+	default:
+		writeError(w, http.StatusNotFound, "route not supported by flapjacktest")
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]any{"message": message, "status": status})
+}
+
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
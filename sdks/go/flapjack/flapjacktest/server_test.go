@@ -0,0 +1,375 @@
+package flapjacktest_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/flapjacktest"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+func newServer(t *testing.T) (*flapjacktest.Server, *search.APIClient) {
+	t.Helper()
+
+	srv := flapjacktest.NewServer()
+	t.Cleanup(srv.Close)
+
+	client, err := srv.NewClient()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	srv.AddObjects("products",
+		map[string]any{"objectID": "1", "name": "Red phone", "brand": "Acme", "color": "red"},
+		map[string]any{"objectID": "2", "name": "Blue phone", "brand": "Acme", "color": "blue"},
+		map[string]any{"objectID": "3", "name": "Red shirt", "brand": "Wearit", "color": "red"},
+	)
+
+	return srv, client
+}
+
+func searchIDs(t *testing.T, client *search.APIClient, params *search.SearchParamsObject) []string {
+	t.Helper()
+
+	res, err := client.SearchSingleIndex(client.NewApiSearchSingleIndexRequest("products").
+		WithSearchParams(search.SearchParamsObjectAsSearchParams(params)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ids := []string{}
+	for _, hit := range res.Hits {
+		ids = append(ids, hit.ObjectID)
+	}
+
+	return ids
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestServerSearchSingleIndex(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		params *search.SearchParamsObject
+		want   []string
+	}{
+		{
+			name:   "empty query",
+			params: search.NewEmptySearchParamsObject(),
+			want:   []string{"1", "2", "3"},
+		},
+		{
+			name:   "all the words of the query",
+			params: search.NewEmptySearchParamsObject().SetQuery("RED pho"),
+			want:   []string{"1"},
+		},
+		{
+			name: "facet filters",
+			params: search.NewEmptySearchParamsObject().SetFacetFilters(search.ArrayOfFacetFiltersAsFacetFilters([]search.FacetFilters{
+				*search.StringAsFacetFilters("color:red"),
+				*search.StringAsFacetFilters("-brand:Wearit"),
+			})),
+			want: []string{"1"},
+		},
+		{
+			name:   "leading dash in the value",
+			params: search.NewEmptySearchParamsObject().SetFacetFilters(search.StringAsFacetFilters("brand:-Wearit")),
+			want:   []string{},
+		},
+		{
+			name: "negated attribute",
+			params: search.NewEmptySearchParamsObject().SetFacetFilters(search.AndFacetFilters(
+				search.NewFacetFilter("brand", "Wearit").Not(),
+			)),
+			want: []string{"1", "2"},
+		},
+		{
+			name:   "pagination",
+			params: search.NewEmptySearchParamsObject().SetHitsPerPage(2).SetPage(1),
+			want:   []string{"3"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, client := newServer(t)
+
+			if got := searchIDs(t, client, tt.params); !equal(got, tt.want) {
+				t.Errorf("expected hits %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestServerObjects(t *testing.T) {
+	t.Parallel()
+
+	srv, client := newServer(t)
+
+	res, err := client.SaveObjects("products", []map[string]any{{"objectID": "4", "name": "Green phone"}}, search.WithWaitForTasks(true))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(res) != 1 || len(res[0].ObjectIDs) != 1 || res[0].ObjectIDs[0] != "4" {
+		t.Errorf("unexpected batch response: %+v", res)
+	}
+
+	object, err := client.GetObject(client.NewApiGetObjectRequest("products", "4"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if (*object)["name"] != "Green phone" {
+		t.Errorf("unexpected object: %v", *object)
+	}
+
+	_, err = client.GetObject(client.NewApiGetObjectRequest("products", "missing"))
+	if !errors.Is(err, transport.ErrNotFound) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+
+	if got := len(srv.Objects("products")); got != 4 {
+		t.Errorf("expected 4 objects, got %d", got)
+	}
+}
+
+func TestServerBrowseAndReplaceAllObjects(t *testing.T) {
+	t.Parallel()
+
+	srv, client := newServer(t)
+
+	_, err := client.ReplaceAllObjects("products", []map[string]any{
+		{"objectID": "a", "name": "Mug"},
+		{"objectID": "b", "name": "Cup"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var ids []string
+
+	params := search.NewEmptyBrowseParamsObject().SetHitsPerPage(1)
+
+	err = client.BrowseObjects("products", *params, search.WithAggregator(func(res any, _ error) {
+		for _, hit := range res.(*search.BrowseResponse).Hits {
+			ids = append(ids, hit.ObjectID)
+		}
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !equal(ids, []string{"a", "b"}) {
+		t.Errorf("expected the replaced objects, got %v", ids)
+	}
+
+	if objects := srv.Objects("products"); len(objects) != 2 {
+		t.Errorf("expected 2 objects, got %v", objects)
+	}
+}
+
+func TestServerSettingsSynonymsAndRules(t *testing.T) {
+	t.Parallel()
+
+	srv, client := newServer(t)
+
+	_, err := client.SetSettings(client.NewApiSetSettingsRequest("products",
+		search.NewEmptyIndexSettings().SetSearchableAttributes([]string{"brand"})))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	settings, err := client.GetSettings(client.NewApiGetSettingsRequest("products"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !equal(settings.SearchableAttributes, []string{"brand"}) || srv.Settings("products") == nil {
+		t.Errorf("unexpected settings: %+v", settings)
+	}
+
+	if got := searchIDs(t, client, search.NewEmptySearchParamsObject().SetQuery("phone")); len(got) != 0 {
+		t.Errorf("expected the query to only match the searchable attributes, got %v", got)
+	}
+
+	_, err = client.SaveSynonym(client.NewApiSaveSynonymRequest("products", "phones",
+		search.NewSynonymHit("phones", search.SYNONYM_TYPE_SYNONYM, search.WithSynonymHitSynonyms([]string{"phone", "mobile"}))))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = client.SaveRule(client.NewApiSaveRuleRequest("products", "promo",
+		search.NewRule("promo", *search.NewEmptyConsequence(), search.WithRuleDescription("Promote phones"))))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	synonyms, err := client.SearchSynonyms(client.NewApiSearchSynonymsRequest("products"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if synonyms.NbHits != 1 || synonyms.Hits[0].ObjectID != "phones" {
+		t.Errorf("unexpected synonyms: %+v", synonyms)
+	}
+
+	rules, err := client.SearchRules(client.NewApiSearchRulesRequest("products"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if rules.NbHits != 1 || rules.Hits[0].ObjectID != "promo" {
+		t.Errorf("unexpected rules: %+v", rules)
+	}
+
+	_, err = client.DeleteSynonym(client.NewApiDeleteSynonymRequest("products", "phones"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	synonyms, err = client.SearchSynonyms(client.NewApiSearchSynonymsRequest("products"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if synonyms.NbHits != 0 {
+		t.Errorf("expected the synonym to be deleted, got %+v", synonyms)
+	}
+}
+
+func TestServerSearchMultipleIndices(t *testing.T) {
+	t.Parallel()
+
+	srv, client := newServer(t)
+	srv.AddObjects("articles", map[string]any{"title": "Choosing a phone"})
+
+	res, err := client.Search(client.NewApiSearchRequest(search.NewSearchMethodParams([]search.SearchQuery{
+		*search.SearchForHitsAsSearchQuery(search.NewEmptySearchForHits().SetIndexName("products").SetQuery("blue")),
+		*search.SearchForHitsAsSearchQuery(search.NewEmptySearchForHits().SetIndexName("articles").SetQuery("phone")),
+	})))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(res.Results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(res.Results))
+	}
+
+	for i, want := range []int32{1, 1} {
+		if r := res.Results[i].SearchResponse; r == nil || r.GetNbHits() != want {
+			t.Errorf("unexpected result %d: %+v", i, res.Results[i])
+		}
+	}
+}
+
+func TestServerRankingInfo(t *testing.T) {
+	t.Parallel()
+
+	_, client := newServer(t)
+
+	res, err := client.Search(client.NewApiSearchRequest(search.NewSearchMethodParams([]search.SearchQuery{
+		*search.SearchForHitsAsSearchQuery(search.NewSearchForHits("products",
+			search.WithSearchForHitsQuery("red phone"), search.WithSearchForHitsGetRankingInfo(true))),
+		*search.SearchForHitsAsSearchQuery(search.NewSearchForHits("products", search.WithSearchForHitsQuery("red phone"))),
+	})))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	hits := res.Results[0].SearchResponse.Hits
+	if len(hits) != 1 || hits[0].RankingInfo == nil {
+		t.Fatalf("expected a hit with its ranking info, got %+v", hits)
+	}
+
+	if info := hits[0].RankingInfo; info.NbTypos != 0 || info.GetWords() != 2 || info.NbExactWords != 2 || info.UserScore != 0 {
+		t.Errorf("unexpected ranking info %+v", info)
+	}
+
+	if _, ok := hits[0].AdditionalProperties["_rankingInfo"]; ok {
+		t.Errorf("expected the ranking info to be removed from the attributes of the hit")
+	}
+
+	if hit := res.Results[1].SearchResponse.Hits[0]; hit.RankingInfo != nil {
+		t.Errorf("expected no ranking info without getRankingInfo, got %+v", hit.RankingInfo)
+	}
+}
+
+func TestServerFilters(t *testing.T) {
+	t.Parallel()
+
+	srv, client := newServer(t)
+
+	srv.AddObjects("products", map[string]any{"objectID": "4", "name": "Green hat", "brand": "Wearit", "color": "green", "price": 12.5})
+
+	tests := []struct {
+		name    string
+		filters string
+		want    []string
+		wantErr bool
+	}{
+		{name: "quoted value", filters: `brand:"Acme"`, want: []string{"1", "2"}},
+		{name: "identifier value", filters: "color:red", want: []string{"1", "3"}},
+		{name: "numeric comparison", filters: "price >= 12.5", want: []string{"4"}},
+		{name: "range", filters: "price:10 TO 20", want: []string{"4"}},
+		{name: "precedence", filters: `color:red AND brand:"Acme" or price < 20`, want: []string{"1", "4"}},
+		{name: "negations", filters: `NOT color:red AND NOT (price > 20) AND NOT NOT brand:Acme`, want: []string{"2"}},
+		{name: "quoted attribute", filters: `"brand":"Acme"`, wantErr: true},
+		{name: "escaped quote", filters: `brand:"Ac\"me"`, wantErr: true},
+		{name: "empty value", filters: `brand:""`, wantErr: true},
+		{name: "dotted attribute", filters: `author.name:"Ann"`, wantErr: true},
+		{name: "negated numeric comparison", filters: "NOT price > 20", wantErr: true},
+		{name: "missing operand", filters: "color:red AND", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := client.SearchSingleIndex(client.NewApiSearchSingleIndexRequest("products").
+				WithSearchParams(search.SearchParamsObjectAsSearchParams(search.NewEmptySearchParamsObject().SetFilters(tt.filters))))
+			if tt.wantErr {
+				var apiErr *search.APIError
+				if !errors.As(err, &apiErr) || apiErr.Status != 400 {
+					t.Errorf("expected a 400 error for %s, got %v", tt.filters, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got := []string{}
+			for _, hit := range res.Hits {
+				got = append(got, hit.ObjectID)
+			}
+
+			if !equal(got, tt.want) {
+				t.Errorf("expected hits %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
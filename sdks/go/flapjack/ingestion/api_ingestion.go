@@ -29,9 +29,11 @@ type config struct {
 	batchSize    int
 
 	// -- Iterable options
-	maxRetries int
-	timeout    func(int) time.Duration
-	aggregator func(any, error)
+	maxRetries  int
+	timeout     func(int) time.Duration
+	maxDuration time.Duration
+	aggregator  func(any, error)
+	clock       transport.Clock
 }
 
 type RequestOption interface {
@@ -44,6 +46,10 @@ func (r requestOption) apply(c *config) {
 	r(c)
 }
 
+// WithContext sets the context used to perform the request, allowing callers to
+// propagate deadlines and cancellation. When given to helpers that issue several
+// calls (e.g. WaitForTask, ChunkedBatch or BrowseObjects), the context is shared
+// by every underlying request and also interrupts the wait between attempts.
 func WithContext(ctx context.Context) requestOption {
 	return requestOption(func(c *config) {
 		c.context = ctx
@@ -80,6 +86,40 @@ func WithConnectTimeout(timeout time.Duration) requestOption {
 	})
 }
 
+// WithExtraHeaders sets headers on the request of this call only, overriding the headers of the same name.
+func WithExtraHeaders(headers map[string]string) requestOption {
+	return requestOption(func(c *config) {
+		if c.timeouts.ExtraHeaders == nil {
+			c.timeouts.ExtraHeaders = make(map[string]string, len(headers))
+		}
+
+		for k, v := range headers {
+			c.timeouts.ExtraHeaders[k] = v
+		}
+	})
+}
+
+// WithExtraQueryParams appends query parameters to the request of this call only.
+func WithExtraQueryParams(params url.Values) requestOption {
+	return requestOption(func(c *config) {
+		if c.timeouts.ExtraQueryParams == nil {
+			c.timeouts.ExtraQueryParams = make(url.Values, len(params))
+		}
+
+		for k, v := range params {
+			c.timeouts.ExtraQueryParams[k] = append(c.timeouts.ExtraQueryParams[k], v...)
+		}
+	})
+}
+
+// WithResponseInfo fills info with the metadata of the response of this call, such as its status, headers, request ID
+// and duration, alongside the decoded body.
+func WithResponseInfo(info *transport.ResponseInfo) requestOption {
+	return requestOption(func(c *config) {
+		c.timeouts.ResponseInfo = info
+	})
+}
+
 // WithBodyParam adds a single custom parameter to the request body.
 // For write requests (POST, PUT, PATCH, DELETE), the param is merged into the body.
 // For read requests (GET), the param is converted to a query parameter.
@@ -189,7 +229,7 @@ func (c *APIClient) CreateAuthenticationWithHTTPInfo(r ApiCreateAuthenticationRe
 	// body params
 	postBody = r.authenticationCreate
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "createAuthentication"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -315,7 +355,7 @@ func (c *APIClient) CreateDestinationWithHTTPInfo(r ApiCreateDestinationRequest,
 	// body params
 	postBody = r.destinationCreate
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "createDestination"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -441,7 +481,7 @@ func (c *APIClient) CreateSourceWithHTTPInfo(r ApiCreateSourceRequest, opts ...R
 	// body params
 	postBody = r.sourceCreate
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "createSource"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -567,7 +607,7 @@ func (c *APIClient) CreateTaskWithHTTPInfo(r ApiCreateTaskRequest, opts ...Reque
 	// body params
 	postBody = r.taskCreate
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "createTask"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -696,7 +736,7 @@ func (c *APIClient) CreateTaskV1WithHTTPInfo(r ApiCreateTaskV1Request, opts ...R
 	// body params
 	postBody = r.taskCreate
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "createTaskV1"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -824,7 +864,7 @@ func (c *APIClient) CreateTransformationWithHTTPInfo(r ApiCreateTransformationRe
 	// body params
 	postBody = r.transformationCreate
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "createTransformation"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -964,7 +1004,7 @@ func (c *APIClient) CustomDeleteWithHTTPInfo(r ApiCustomDeleteRequest, opts ...R
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodDelete, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "customDelete"), requestPath, http.MethodDelete, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1100,7 +1140,7 @@ func (c *APIClient) CustomGetWithHTTPInfo(r ApiCustomGetRequest, opts ...Request
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "customGet"), requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1262,7 +1302,7 @@ func (c *APIClient) CustomPostWithHTTPInfo(r ApiCustomPostRequest, opts ...Reque
 		postBody = r.body
 	}
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "customPost"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1425,7 +1465,7 @@ func (c *APIClient) CustomPutWithHTTPInfo(r ApiCustomPutRequest, opts ...Request
 		postBody = r.body
 	}
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPut, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "customPut"), requestPath, http.MethodPut, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1541,7 +1581,7 @@ func (c *APIClient) DeleteAuthenticationWithHTTPInfo(r ApiDeleteAuthenticationRe
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodDelete, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "deleteAuthentication"), requestPath, http.MethodDelete, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1660,7 +1700,7 @@ func (c *APIClient) DeleteDestinationWithHTTPInfo(r ApiDeleteDestinationRequest,
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodDelete, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "deleteDestination"), requestPath, http.MethodDelete, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1779,7 +1819,7 @@ func (c *APIClient) DeleteSourceWithHTTPInfo(r ApiDeleteSourceRequest, opts ...R
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodDelete, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "deleteSource"), requestPath, http.MethodDelete, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1898,7 +1938,7 @@ func (c *APIClient) DeleteTaskWithHTTPInfo(r ApiDeleteTaskRequest, opts ...Reque
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodDelete, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "deleteTask"), requestPath, http.MethodDelete, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -2020,7 +2060,7 @@ func (c *APIClient) DeleteTaskV1WithHTTPInfo(r ApiDeleteTaskV1Request, opts ...R
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodDelete, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "deleteTaskV1"), requestPath, http.MethodDelete, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -2141,7 +2181,7 @@ func (c *APIClient) DeleteTransformationWithHTTPInfo(r ApiDeleteTransformationRe
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodDelete, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "deleteTransformation"), requestPath, http.MethodDelete, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -2260,7 +2300,7 @@ func (c *APIClient) DisableTaskWithHTTPInfo(r ApiDisableTaskRequest, opts ...Req
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPut, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "disableTask"), requestPath, http.MethodPut, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -2382,7 +2422,7 @@ func (c *APIClient) DisableTaskV1WithHTTPInfo(r ApiDisableTaskV1Request, opts ..
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPut, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "disableTaskV1"), requestPath, http.MethodPut, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -2503,7 +2543,7 @@ func (c *APIClient) EnableTaskWithHTTPInfo(r ApiEnableTaskRequest, opts ...Reque
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPut, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "enableTask"), requestPath, http.MethodPut, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -2625,7 +2665,7 @@ func (c *APIClient) EnableTaskV1WithHTTPInfo(r ApiEnableTaskV1Request, opts ...R
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPut, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "enableTaskV1"), requestPath, http.MethodPut, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -2746,7 +2786,7 @@ func (c *APIClient) GetAuthenticationWithHTTPInfo(r ApiGetAuthenticationRequest,
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "getAuthentication"), requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -2865,7 +2905,7 @@ func (c *APIClient) GetDestinationWithHTTPInfo(r ApiGetDestinationRequest, opts
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "getDestination"), requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -3002,7 +3042,7 @@ func (c *APIClient) GetEventWithHTTPInfo(r ApiGetEventRequest, opts ...RequestOp
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "getEvent"), requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -3122,7 +3162,7 @@ func (c *APIClient) GetRunWithHTTPInfo(r ApiGetRunRequest, opts ...RequestOption
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "getRun"), requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -3241,7 +3281,7 @@ func (c *APIClient) GetSourceWithHTTPInfo(r ApiGetSourceRequest, opts ...Request
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "getSource"), requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -3360,7 +3400,7 @@ func (c *APIClient) GetTaskWithHTTPInfo(r ApiGetTaskRequest, opts ...RequestOpti
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "getTask"), requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -3482,7 +3522,7 @@ func (c *APIClient) GetTaskV1WithHTTPInfo(r ApiGetTaskV1Request, opts ...Request
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "getTaskV1"), requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -3603,7 +3643,7 @@ func (c *APIClient) GetTransformationWithHTTPInfo(r ApiGetTransformationRequest,
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "getTransformation"), requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -3841,7 +3881,7 @@ func (c *APIClient) ListAuthenticationsWithHTTPInfo(r ApiListAuthenticationsRequ
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "listAuthentications"), requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -4107,7 +4147,7 @@ func (c *APIClient) ListDestinationsWithHTTPInfo(r ApiListDestinationsRequest, o
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "listDestinations"), requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -4418,7 +4458,7 @@ func (c *APIClient) ListEventsWithHTTPInfo(r ApiListEventsRequest, opts ...Reque
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "listEvents"), requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -4737,7 +4777,7 @@ func (c *APIClient) ListRunsWithHTTPInfo(r ApiListRunsRequest, opts ...RequestOp
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "listRuns"), requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -4985,7 +5025,7 @@ func (c *APIClient) ListSourcesWithHTTPInfo(r ApiListSourcesRequest, opts ...Req
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "listSources"), requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -5343,7 +5383,7 @@ func (c *APIClient) ListTasksWithHTTPInfo(r ApiListTasksRequest, opts ...Request
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "listTasks"), requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -5663,7 +5703,7 @@ func (c *APIClient) ListTasksV1WithHTTPInfo(r ApiListTasksV1Request, opts ...Req
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "listTasksV1"), requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -5888,7 +5928,7 @@ func (c *APIClient) ListTransformationsWithHTTPInfo(r ApiListTransformationsRequ
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "listTransformations"), requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -6094,7 +6134,7 @@ func (c *APIClient) PushWithHTTPInfo(r ApiPushRequest, opts ...RequestOption) (*
 	// body params
 	postBody = r.pushTaskPayload
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "push"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -6280,7 +6320,7 @@ func (c *APIClient) PushTaskWithHTTPInfo(r ApiPushTaskRequest, opts ...RequestOp
 	// body params
 	postBody = r.pushTaskPayload
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "pushTask"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -6430,7 +6470,7 @@ func (c *APIClient) ReplaceTaskWithHTTPInfo(r ApiReplaceTaskRequest, opts ...Req
 	// body params
 	postBody = r.taskReplace
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPut, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "replaceTask"), requestPath, http.MethodPut, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -6576,7 +6616,7 @@ func (c *APIClient) RunSourceWithHTTPInfo(r ApiRunSourceRequest, opts ...Request
 		postBody = r.runSourcePayload
 	}
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "runSource"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -6722,7 +6762,7 @@ func (c *APIClient) RunTaskWithHTTPInfo(r ApiRunTaskRequest, opts ...RequestOpti
 		postBody = r.runTaskPayload
 	}
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "runTask"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -6871,7 +6911,7 @@ func (c *APIClient) RunTaskV1WithHTTPInfo(r ApiRunTaskV1Request, opts ...Request
 		postBody = r.runTaskPayload
 	}
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "runTaskV1"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -7000,7 +7040,7 @@ func (c *APIClient) SearchAuthenticationsWithHTTPInfo(r ApiSearchAuthentications
 	// body params
 	postBody = r.authenticationSearch
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "searchAuthentications"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -7126,7 +7166,7 @@ func (c *APIClient) SearchDestinationsWithHTTPInfo(r ApiSearchDestinationsReques
 	// body params
 	postBody = r.destinationSearch
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "searchDestinations"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -7252,7 +7292,7 @@ func (c *APIClient) SearchSourcesWithHTTPInfo(r ApiSearchSourcesRequest, opts ..
 	// body params
 	postBody = r.sourceSearch
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "searchSources"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -7378,7 +7418,7 @@ func (c *APIClient) SearchTasksWithHTTPInfo(r ApiSearchTasksRequest, opts ...Req
 	// body params
 	postBody = r.taskSearch
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "searchTasks"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -7507,7 +7547,7 @@ func (c *APIClient) SearchTasksV1WithHTTPInfo(r ApiSearchTasksV1Request, opts ..
 	// body params
 	postBody = r.taskSearch
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "searchTasksV1"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -7635,7 +7675,7 @@ func (c *APIClient) SearchTransformationsWithHTTPInfo(r ApiSearchTransformations
 	// body params
 	postBody = r.transformationSearch
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "searchTransformations"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -7764,7 +7804,7 @@ func (c *APIClient) TriggerDockerSourceDiscoverWithHTTPInfo(
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "triggerDockerSourceDiscover"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -7891,7 +7931,7 @@ func (c *APIClient) TryTransformationWithHTTPInfo(r ApiTryTransformationRequest,
 	// body params
 	postBody = r.transformationTry
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "tryTransformation"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -8041,7 +8081,7 @@ func (c *APIClient) TryTransformationBeforeUpdateWithHTTPInfo(
 	// body params
 	postBody = r.transformationTry
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "tryTransformationBeforeUpdate"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -8192,7 +8232,7 @@ func (c *APIClient) UpdateAuthenticationWithHTTPInfo(r ApiUpdateAuthenticationRe
 	// body params
 	postBody = r.authenticationUpdate
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPatch, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "updateAuthentication"), requestPath, http.MethodPatch, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -8337,7 +8377,7 @@ func (c *APIClient) UpdateDestinationWithHTTPInfo(r ApiUpdateDestinationRequest,
 	// body params
 	postBody = r.destinationUpdate
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPatch, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "updateDestination"), requestPath, http.MethodPatch, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -8482,7 +8522,7 @@ func (c *APIClient) UpdateSourceWithHTTPInfo(r ApiUpdateSourceRequest, opts ...R
 	// body params
 	postBody = r.sourceUpdate
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPatch, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "updateSource"), requestPath, http.MethodPatch, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -8627,7 +8667,7 @@ func (c *APIClient) UpdateTaskWithHTTPInfo(r ApiUpdateTaskRequest, opts ...Reque
 	// body params
 	postBody = r.taskUpdate
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPatch, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "updateTask"), requestPath, http.MethodPatch, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -8775,7 +8815,7 @@ func (c *APIClient) UpdateTaskV1WithHTTPInfo(r ApiUpdateTaskV1Request, opts ...R
 	// body params
 	postBody = r.taskUpdate
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPatch, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "updateTaskV1"), requestPath, http.MethodPatch, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -8925,7 +8965,7 @@ func (c *APIClient) UpdateTransformationWithHTTPInfo(r ApiUpdateTransformationRe
 	// body params
 	postBody = r.transformationCreate
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPut, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "updateTransformation"), requestPath, http.MethodPut, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -9058,7 +9098,7 @@ func (c *APIClient) ValidateSourceWithHTTPInfo(r ApiValidateSourceRequest, opts
 		postBody = r.sourceCreate
 	}
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "validateSource"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -9211,7 +9251,7 @@ func (c *APIClient) ValidateSourceBeforeUpdateWithHTTPInfo(
 	// body params
 	postBody = r.sourceUpdate
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "validateSourceBeforeUpdate"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -9339,6 +9379,35 @@ func WithAggregator(aggregator func(any, error)) iterableOption {
 	})
 }
 
+// WithExponentialBackoff waits `initial` before the first retry and doubles the delay on every subsequent retry, capped at `maxDelay`. It replaces the function given to WithTimeout.
+func WithExponentialBackoff(initial time.Duration, maxDelay time.Duration) iterableOption {
+	return iterableOption(func(c *config) {
+		c.timeout = func(count int) time.Duration {
+			delay := initial
+
+			for i := 1; i < count && delay < maxDelay; i++ {
+				delay *= 2
+			}
+
+			return min(delay, maxDelay)
+		}
+	})
+}
+
+// WithMaxDuration the maximum total duration of the iterable, across all retries. Default to no limit.
+func WithMaxDuration(maxDuration time.Duration) iterableOption {
+	return iterableOption(func(c *config) {
+		c.maxDuration = maxDuration
+	})
+}
+
+// WithClock the clock measuring the duration of the iterable and waiting between retries. Default to the clock of the client for its helpers, transport.SystemClock otherwise.
+func WithClock(clock transport.Clock) iterableOption {
+	return iterableOption(func(c *config) {
+		c.clock = clock
+	})
+}
+
 func CreateIterable[T any](execute func(*T, error) (*T, error), validate func(*T, error) (bool, error), opts ...IterableOption) (*T, error) {
 	conf := config{
 		headerParams: map[string]string{},
@@ -9346,15 +9415,22 @@ func CreateIterable[T any](execute func(*T, error) (*T, error), validate func(*T
 		timeout: func(count int) time.Duration {
 			return 0 * time.Millisecond
 		},
+		clock: transport.SystemClock{},
 	}
 
 	for _, opt := range opts {
 		opt.apply(&conf)
 	}
 
+	ctx := conf.context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
 	var executor func(*T, error) (*T, error)
 
 	retryCount := 0
+	start := conf.clock.Now()
 
 	executor = func(previousResponse *T, previousError error) (*T, error) {
 		response, responseErr := execute(previousResponse, previousError)
@@ -9374,7 +9450,18 @@ func CreateIterable[T any](execute func(*T, error) (*T, error), validate func(*T
 			return nil, errs.NewWaitError(fmt.Sprintf("The maximum number of retries exceeded. (%d/%d)", retryCount, conf.maxRetries))
 		}
 
-		time.Sleep(conf.timeout(retryCount))
+		delay := conf.timeout(retryCount)
+
+		if conf.maxDuration > 0 && conf.clock.Now().Sub(start)+delay > conf.maxDuration {
+			return nil, errs.NewWaitError(fmt.Sprintf("The maximum duration exceeded. (%s)", conf.maxDuration))
+		}
+
+		// Wait before the next attempt, unless the caller's context is done,
+		// in which case there is no point in polling any further.
+		err = conf.clock.Sleep(ctx, delay)
+		if err != nil {
+			return nil, fmt.Errorf("iterable interrupted after %d attempt(s): %w", retryCount, err)
+		}
 
 		return executor(response, responseErr)
 	}
@@ -9484,7 +9571,7 @@ func (c *APIClient) ChunkedPush(
 
 						return true, err
 					},
-					WithTimeout(func(count int) time.Duration { return time.Duration(min(500*count, 5000)) * time.Millisecond }), WithMaxRetries(50),
+					WithTimeout(func(count int) time.Duration { return time.Duration(min(500*count, 5000)) * time.Millisecond }), WithMaxRetries(50), WithClock(transport.ClockOf(c.cfg.Configuration)),
 				)
 				if err != nil {
 					return nil, err
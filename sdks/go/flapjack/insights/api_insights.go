@@ -0,0 +1,238 @@
+package insights
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/utils"
+)
+
+type config struct {
+	// -- Request options for API calls
+	context      context.Context
+	queryParams  url.Values
+	headerParams map[string]string
+	bodyParams   map[string]any
+	timeouts     transport.RequestConfiguration
+}
+
+type RequestOption interface {
+	apply(*config)
+}
+
+type requestOption func(*config)
+
+func (r requestOption) apply(c *config) {
+	r(c)
+}
+
+// WithContext sets the context used to perform the request, allowing callers to
+// propagate deadlines and cancellation.
+func WithContext(ctx context.Context) requestOption {
+	return requestOption(func(c *config) {
+		c.context = ctx
+	})
+}
+
+func WithHeaderParam(key string, value any) requestOption {
+	return requestOption(func(c *config) {
+		c.headerParams[key] = utils.ParameterToString(value)
+	})
+}
+
+func WithQueryParam(key string, value any) requestOption {
+	return requestOption(func(c *config) {
+		c.queryParams.Set(utils.QueryParameterToString(key), utils.QueryParameterToString(value))
+	})
+}
+
+func WithReadTimeout(timeout time.Duration) requestOption {
+	return requestOption(func(c *config) {
+		c.timeouts.ReadTimeout = &timeout
+	})
+}
+
+func WithWriteTimeout(timeout time.Duration) requestOption {
+	return requestOption(func(c *config) {
+		c.timeouts.WriteTimeout = &timeout
+	})
+}
+
+func WithConnectTimeout(timeout time.Duration) requestOption {
+	return requestOption(func(c *config) {
+		c.timeouts.ConnectTimeout = &timeout
+	})
+}
+
+// WithExtraHeaders sets headers on the request of this call only, overriding the headers of the same name.
+func WithExtraHeaders(headers map[string]string) requestOption {
+	return requestOption(func(c *config) {
+		if c.timeouts.ExtraHeaders == nil {
+			c.timeouts.ExtraHeaders = make(map[string]string, len(headers))
+		}
+
+		for k, v := range headers {
+			c.timeouts.ExtraHeaders[k] = v
+		}
+	})
+}
+
+// WithExtraQueryParams appends query parameters to the request of this call only.
+func WithExtraQueryParams(params url.Values) requestOption {
+	return requestOption(func(c *config) {
+		if c.timeouts.ExtraQueryParams == nil {
+			c.timeouts.ExtraQueryParams = make(url.Values, len(params))
+		}
+
+		for k, v := range params {
+			c.timeouts.ExtraQueryParams[k] = append(c.timeouts.ExtraQueryParams[k], v...)
+		}
+	})
+}
+
+// WithResponseInfo fills info with the metadata of the response of this call, such as its status, headers, request ID
+// and duration, alongside the decoded body.
+func WithResponseInfo(info *transport.ResponseInfo) requestOption {
+	return requestOption(func(c *config) {
+		c.timeouts.ResponseInfo = info
+	})
+}
+
+// WithBodyParam adds a single custom parameter to the request body.
+// For write requests (POST, PUT, PATCH, DELETE), the param is merged into the body.
+// For read requests (GET), the param is converted to a query parameter.
+// Custom values override typed values when keys conflict.
+func WithBodyParam(key string, value any) requestOption {
+	return requestOption(func(c *config) {
+		if c.bodyParams == nil {
+			c.bodyParams = make(map[string]any)
+		}
+
+		c.bodyParams[key] = value
+	})
+}
+
+// WithBodyParams adds multiple custom parameters to the request body.
+// Parameters are deep-merged into the typed request body.
+// For write requests, params are merged into the body.
+// For read requests, params are converted to query parameters.
+// Custom values override typed values when keys conflict.
+func WithBodyParams(params map[string]any) requestOption {
+	return requestOption(func(c *config) {
+		if c.bodyParams == nil {
+			c.bodyParams = make(map[string]any)
+		}
+
+		for k, v := range params {
+			c.bodyParams[k] = v
+		}
+	})
+}
+
+// ApiPushEventsRequest represents the request with all the parameters for the API call.
+type ApiPushEventsRequest struct {
+	insightsEvents *InsightsEvents
+}
+
+// NewApiPushEventsRequest creates an instance of the ApiPushEventsRequest to be used for the API call.
+func (c *APIClient) NewApiPushEventsRequest(insightsEvents *InsightsEvents) ApiPushEventsRequest {
+	return ApiPushEventsRequest{
+		insightsEvents: insightsEvents,
+	}
+}
+
+/*
+PushEvents calls the API and returns the raw response from it.
+
+	Sends a list of events to the Insights API.
+
+You can include up to 1,000 events in a single request, but the request body must be smaller than 2&nbsp;MB.
+Every event is validated before being sent: the request is not performed if one of them is invalid, or if the body is too large.
+
+	Request can be constructed by NewApiPushEventsRequest with parameters below.
+	  @param insightsEvents InsightsEvents
+	@param opts ...RequestOption - Optional parameters for the API call
+	@return *http.Response - The raw response from the API
+	@return []byte - The raw response body from the API
+	@return error - An error if the API call fails
+*/
+func (c *APIClient) PushEventsWithHTTPInfo(r ApiPushEventsRequest, opts ...RequestOption) (*http.Response, []byte, error) {
+	requestPath := "/1/events"
+
+	if r.insightsEvents == nil {
+		return nil, nil, reportError("Parameter `insightsEvents` is required when calling `PushEvents`.")
+	}
+
+	err := r.insightsEvents.ValidateAt(c.clock().Now())
+	if err != nil {
+		return nil, nil, reportError("Parameter `insightsEvents` is invalid when calling `PushEvents`: %w", err)
+	}
+
+	conf := config{
+		context:      context.Background(),
+		queryParams:  url.Values{},
+		headerParams: map[string]string{},
+	}
+
+	// optional params if any
+	for _, opt := range opts {
+		opt.apply(&conf)
+	}
+
+	var postBody any
+
+	// body params
+	postBody = r.insightsEvents
+
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "pushEvents"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if req.ContentLength >= MaxEventsRequestBytes {
+		return nil, nil, reportError("Parameter `insightsEvents` is invalid when calling `PushEvents`: the body is %d bytes, it must be smaller than %d bytes", req.ContentLength, MaxEventsRequestBytes)
+	}
+
+	return c.callAPI(req, false, conf.timeouts)
+}
+
+/*
+PushEvents casts the HTTP response body to a defined struct.
+
+Sends a list of events to the Insights API.
+
+You can include up to 1,000 events in a single request, but the request body must be smaller than 2&nbsp;MB.
+Every event is validated before being sent: the request is not performed if one of them is invalid, or if the body is too large.
+
+Request can be constructed by NewApiPushEventsRequest with parameters below.
+
+	@param insightsEvents InsightsEvents
+	@return EventsResponse
+*/
+func (c *APIClient) PushEvents(r ApiPushEventsRequest, opts ...RequestOption) (*EventsResponse, error) {
+	var returnValue *EventsResponse
+
+	res, resBody, err := c.PushEventsWithHTTPInfo(r, opts...)
+	if err != nil {
+		return returnValue, err
+	}
+
+	if res == nil {
+		return returnValue, reportError("res is nil")
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 300 {
+		return returnValue, c.decodeError(res, resBody)
+	}
+
+	err = c.decode(&returnValue, resBody)
+	if err != nil {
+		return returnValue, reportError("cannot decode result: %w", err)
+	}
+
+	return returnValue, nil
+}
@@ -0,0 +1,96 @@
+package insights_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/call"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/flapjacktest"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/insights"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+// newTestClient returns a client of a server answering with handler, telling the time with clock unless nil.
+func newTestClient(t *testing.T, handler http.HandlerFunc, clock transport.Clock) *insights.APIClient {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	client, err := insights.NewClientWithConfig(insights.InsightsConfiguration{
+		Configuration: transport.Configuration{
+			AppID:  "test-app",
+			ApiKey: "test-api-key",
+			Hosts: []transport.StatefulHost{
+				transport.NewStatefulHost("http", strings.TrimPrefix(srv.URL, "http://"), call.IsReadWrite),
+			},
+			DefaultHeader: make(map[string]string),
+			Clock:         clock,
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	return client
+}
+
+func TestPushEventsRejectsLargeBodies(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		t.Error("unexpected request for a body over the size limit")
+		w.WriteHeader(http.StatusOK)
+	}, nil)
+
+	objectIDs := make([]string, insights.MaxObjectIDsPerEvent)
+	for i := range objectIDs {
+		objectIDs[i] = strings.Repeat("x", 120)
+	}
+
+	events := make([]insights.EventsItems, insights.MaxEventsPerRequest)
+	for i := range events {
+		events[i] = *insights.NewViewedObjectIDs("Viewed", "products", "user-1", objectIDs)
+	}
+
+	_, err := client.PushEvents(client.NewApiPushEventsRequest(insights.NewInsightsEvents(events)))
+	if err == nil || !strings.Contains(err.Error(), "must be smaller than") {
+		t.Errorf("expected an error for a body over %d bytes, got %v", insights.MaxEventsRequestBytes, err)
+	}
+}
+
+func TestPushEventsChecksTheAgeOnTheClock(t *testing.T) {
+	t.Parallel()
+
+	clock := flapjacktest.NewClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	requests := 0
+
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"message":"OK","status":200}`))
+	}, clock)
+
+	push := func(timestamp time.Time) error {
+		event := insights.NewViewedObjectIDs("Viewed", "products", "user-1", []string{"1"}, insights.WithEventsItemsTimestamp(timestamp.UnixMilli()))
+		_, err := client.PushEvents(client.NewApiPushEventsRequest(insights.NewInsightsEvents([]insights.EventsItems{*event})))
+
+		return err
+	}
+
+	if err := push(clock.Now().Add(-3 * 24 * time.Hour)); err != nil {
+		t.Errorf("unexpected error for an event of 3 days ago: %v", err)
+	}
+
+	if err := push(clock.Now().Add(-5 * 24 * time.Hour)); err == nil || !strings.Contains(err.Error(), "timestamp") {
+		t.Errorf("expected an error for an event of 5 days ago, got %v", err)
+	}
+
+	if requests != 1 {
+		t.Errorf("expected only the recent event to be sent, got %d requests", requests)
+	}
+}
@@ -0,0 +1,406 @@
+package insights
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/call"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/utils"
+)
+
+// APIClient manages communication with the Insights API API v1.0.0
+// In most cases there should be only one, shared, APIClient.
+type APIClient struct {
+	appID     string
+	cfg       *InsightsConfiguration
+	transport *transport.Transport
+	// timeouts are the default timeouts of the calls of a derived client.
+	timeouts transport.RequestConfiguration
+	apiKey   *transport.APIKey
+}
+
+// NewClient creates a new API client with appID and apiKey, customized with the given options.
+func NewClient(appID, apiKey string, opts ...transport.ClientOption) (*APIClient, error) {
+	cfg := InsightsConfiguration{
+		Configuration: transport.Configuration{
+			AppID:         appID,
+			ApiKey:        apiKey,
+			DefaultHeader: make(map[string]string),
+			UserAgent:     getUserAgent(),
+		},
+	}
+
+	for _, opt := range opts {
+		opt(&cfg.Configuration)
+	}
+
+	return NewClientWithConfig(cfg)
+}
+
+// NewClientWithConfig creates a new API client with the given configuration to fully customize the client behaviour.
+func NewClientWithConfig(cfg InsightsConfiguration) (*APIClient, error) {
+	if cfg.AppID == "" {
+		return nil, errors.New("`appId` is missing.")
+	}
+
+	if cfg.ApiKey == "" {
+		return nil, errors.New("`apiKey` is missing.")
+	}
+
+	if len(cfg.Hosts) == 0 {
+		cfg.Hosts = getDefaultHosts(cfg.AppID)
+	}
+
+	if cfg.Failover != nil && len(cfg.Failover.Hosts) == 0 {
+		failover := *cfg.Failover
+		failover.Hosts = getDefaultHosts(failover.AppID)
+		cfg.Failover = &failover
+	}
+
+	if cfg.UserAgent == "" {
+		cfg.UserAgent = getUserAgent()
+	}
+
+	if cfg.ReadTimeout == 0 {
+		cfg.ReadTimeout = 5000 * time.Millisecond
+	}
+
+	if cfg.ConnectTimeout == 0 {
+		cfg.ConnectTimeout = 2000 * time.Millisecond
+	}
+
+	if cfg.WriteTimeout == 0 {
+		cfg.WriteTimeout = 30000 * time.Millisecond
+	}
+
+	if cfg.Codec == nil {
+		cfg.Codec = transport.JSONCodec{}
+	}
+
+	err := cfg.Validate()
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	apiClient := APIClient{
+		appID: cfg.AppID,
+		cfg:   &cfg,
+		transport: transport.New(
+			cfg.Configuration,
+		),
+		apiKey: transport.NewAPIKey(cfg.ApiKey),
+	}
+
+	return &apiClient, nil
+}
+
+func getDefaultHosts(appID string) []transport.StatefulHost {
+	hosts := []transport.StatefulHost{
+		transport.NewStatefulHost("https", appID+".flapjack.io", call.IsReadWrite),
+	}
+	hosts = append(hosts, transport.Shuffle(
+		[]transport.StatefulHost{
+			transport.NewStatefulHost("https", fmt.Sprintf("%s-1.flapjack.io", appID), call.IsReadWrite),
+			transport.NewStatefulHost("https", fmt.Sprintf("%s-2.flapjack.io", appID), call.IsReadWrite),
+			transport.NewStatefulHost("https", fmt.Sprintf("%s-3.flapjack.io", appID), call.IsReadWrite),
+		},
+	)...)
+
+	return hosts
+}
+
+func getUserAgent() string {
+	return fmt.Sprintf("Flapjack for Go (4.36.0); Go (%s); Insights (4.36.0)", runtime.Version())
+}
+
+// AddDefaultHeader adds a new HTTP header to the default header in the request.
+func (c *APIClient) AddDefaultHeader(key string, value string) {
+	c.cfg.DefaultHeader[key] = value
+}
+
+// AddUserAgentSegment appends the segment of an integration built on top of the client, such as `Laravel Scout (10.0.0)`,
+// to the user agent of the requests. A segment already present is not added again. Like AddDefaultHeader, it must be
+// called before sending requests.
+func (c *APIClient) AddUserAgentSegment(name, version string) {
+	c.cfg.UserAgent = transport.AppendUserAgentSegment(c.cfg.UserAgent, transport.UserAgentSegment(name, version))
+}
+
+// Allow modification of underlying config for alternate implementations and testing.
+// Caution: modifying the configuration while live can cause data races and potentially unwanted behavior.
+func (c *APIClient) GetConfiguration() *InsightsConfiguration {
+	return c.cfg
+}
+
+// clock returns the clock of the client, telling the age of the events.
+func (c *APIClient) clock() transport.Clock {
+	return transport.ClockOf(c.cfg.Configuration)
+}
+
+// GetHostStatuses returns the current health of the hosts of the client, for observability.
+func (c *APIClient) GetHostStatuses() []transport.HostStatus {
+	return c.transport.HostStatuses()
+}
+
+// LastRateLimit returns the rate limit reported by the last response with rate limit headers, so that batch jobs can
+// slow down before being rate limited. Derived clients share it, as they share the transport.
+func (c *APIClient) LastRateLimit() (transport.RateLimitInfo, bool) {
+	return c.transport.LastRateLimit()
+}
+
+// Close releases the resources of the client for a clean shutdown: it stops the background probing of the hosts and
+// closes the idle connections. The calls started afterwards fail with transport.ErrClientClosed. Derived clients share
+// the transport of the client, and are closed with it.
+func (c *APIClient) Close() error {
+	return c.transport.Close()
+}
+
+// WithConfiguration returns a client derived from this one with the given settings overridden, such as the API key of
+// a tenant in multi-tenant applications. The derived client is cheap to create: it shares the transport, connection pool
+// and host health of this client.
+func (c *APIClient) WithConfiguration(overrides transport.ConfigurationOverrides) *APIClient {
+	cfg := *c.cfg
+
+	var timeouts transport.RequestConfiguration
+	cfg.Configuration, timeouts = overrides.Apply(c.cfg.Configuration)
+
+	derived := *c
+	derived.cfg = &cfg
+	derived.timeouts = timeouts.InheritTimeouts(c.timeouts)
+
+	if overrides.ApiKey != "" {
+		derived.apiKey = transport.NewAPIKey(overrides.ApiKey)
+	}
+
+	return &derived
+}
+
+// Allow update of stored API key used to authenticate requests. Like SetApiKey, it is safe for concurrent use: the key
+// is swapped atomically, and the configuration returned by GetConfiguration keeps the key the client was created with.
+//
+// Deprecated: use SetApiKey.
+func (c *APIClient) SetClientApiKey(apiKey string) error {
+	if c.cfg == nil {
+		return errors.New("client config is not set")
+	}
+
+	return c.SetApiKey(apiKey)
+}
+
+// SetApiKey replaces the API key used to authenticate the requests. It is safe to call while requests are in flight, so
+// that long-lived services can rotate their credentials without recreating the client and losing its connection pool
+// and host health. Derived clients without an API key of their own use the new key too.
+func (c *APIClient) SetApiKey(apiKey string) error {
+	if apiKey == "" {
+		return errors.New("`apiKey` is missing.")
+	}
+
+	c.apiKey.Set(apiKey)
+
+	return nil
+}
+
+// callAPI do the request.
+func (c *APIClient) callAPI(
+	request *http.Request,
+	useReadTransporter bool,
+	requestConfiguration transport.RequestConfiguration,
+) (*http.Response, []byte, error) {
+	callKind := call.Write
+	if useReadTransporter || request.Method == http.MethodGet {
+		callKind = call.Read
+	}
+
+	resp, body, err := c.transport.Request(request.Context(), request, callKind, requestConfiguration.InheritTimeouts(c.timeouts))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to do request: %w", err)
+	}
+
+	return resp, body, nil
+}
+
+// prepareRequest build the request.
+func (c *APIClient) prepareRequest(
+	ctx context.Context,
+	path string, method string,
+	postBody any,
+	bodyParams map[string]any,
+	headerParams map[string]string,
+	queryParams url.Values,
+) (req *http.Request, err error) {
+	var finalBody any
+
+	if method == http.MethodGet {
+		finalBody = nil
+
+		for k, v := range bodyParams {
+			queryParams.Set(k, utils.QueryParameterToString(v))
+		}
+	} else {
+		if len(bodyParams) > 0 {
+			finalBody, err = utils.MergeBodyParams(postBody, bodyParams)
+			if err != nil {
+				return nil, fmt.Errorf("failed to merge body params: %w", err)
+			}
+		} else {
+			finalBody = postBody
+		}
+	}
+
+	body, err := setBody(finalBody, c.cfg.Codec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set the body: %w", err)
+	}
+
+	// Setup path and query parameters
+	url, err := url.Parse(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the path: %w", err)
+	}
+
+	var queryString []string
+
+	for k, v := range queryParams {
+		for _, value := range v {
+			queryString = append(queryString, k+"="+value)
+		}
+	}
+
+	url.RawQuery = strings.Join(queryString, "&")
+
+	// Generate a new request
+
+	// weird nil typing
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = body
+	}
+
+	req, err = http.NewRequestWithContext(ctx, method, url.String(), bodyReader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create http request: %w", err)
+	}
+
+	// add header parameters, if any
+	if len(headerParams) > 0 {
+		for h, v := range headerParams {
+			req.Header.Add(h, v)
+		}
+	}
+
+	contentType := "application/json"
+
+	// Add the user agent to the request.
+	req.Header.Add("User-Agent", c.cfg.UserAgent)
+	req.Header.Add("X-Algolia-Application-Id", c.cfg.AppID)
+	req.Header.Add("X-Algolia-API-Key", c.apiKey.Get())
+	req.Header.Add("Content-Type", contentType)
+	req.Header.Add("Accept", contentType)
+
+	if ctx != nil {
+		// add context to the request
+		req = req.WithContext(ctx)
+	}
+
+	for header, value := range c.cfg.DefaultHeader {
+		req.Header.Add(header, value)
+	}
+
+	return req, nil
+}
+
+func (c *APIClient) decode(v any, b []byte) error {
+	if len(b) == 0 {
+		return nil
+	}
+
+	if s, ok := v.(*string); ok {
+		*s = string(b)
+
+		return nil
+	}
+
+	if actualObj, ok := v.(interface{ GetActualInstance() any }); ok { // oneOf schemas
+		if unmarshalObj, ok := actualObj.(interface{ UnmarshalJSON([]byte) error }); ok { // make sure it has UnmarshalJSON defined
+			err := unmarshalObj.UnmarshalJSON(b)
+			if err != nil {
+				return fmt.Errorf("failed to unmarshal one of in response body: %w", err)
+			}
+		} else {
+			return errors.New("unknown type with GetActualInstance but no unmarshalObj.UnmarshalJSON defined")
+		}
+	} else { // simple model
+		err := c.cfg.Codec.Unmarshal(b, v)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal response body: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (c *APIClient) decodeError(res *http.Response, body []byte) error {
+	return transport.NewAPIError(res, body)
+}
+
+// Prevent trying to import "fmt".
+func reportError(format string, a ...any) error {
+	return fmt.Errorf(format, a...)
+}
+
+// Set request body from an any.
+// The body is compressed by the transport, according to the configuration.
+func setBody(body any, codec transport.Codec) (*bytes.Buffer, error) {
+	if body == nil {
+		return nil, nil
+	}
+
+	bodyBuf := &bytes.Buffer{}
+
+	var err error
+
+	if reader, ok := body.(io.Reader); ok {
+		_, err = bodyBuf.ReadFrom(reader)
+	} else if b, ok := body.([]byte); ok {
+		_, err = bodyBuf.Write(b)
+	} else if s, ok := body.(string); ok {
+		_, err = bodyBuf.WriteString(s)
+	} else if s, ok := body.(*string); ok {
+		_, err = bodyBuf.WriteString(*s)
+	} else {
+		err = encodeBody(bodyBuf, body, codec)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode body: %w", err)
+	}
+
+	if bodyBuf.Len() == 0 {
+		return nil, errors.New("invalid body type, or empty body")
+	}
+
+	return bodyBuf, nil
+}
+
+// encodeBody writes the body encoded with the given codec.
+func encodeBody(w io.Writer, body any, codec transport.Codec) error {
+	b, err := codec.Marshal(body)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	_, err = w.Write(b)
+
+	return err //nolint:wrapcheck
+}
+
+// APIError is the error returned for responses with a non-2xx status.
+type APIError = transport.APIError
@@ -0,0 +1,10 @@
+package insights
+
+import (
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+// InsightsConfiguration stores the configuration of the API client.
+type InsightsConfiguration struct {
+	transport.Configuration
+}
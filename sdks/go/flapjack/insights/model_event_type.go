@@ -0,0 +1,70 @@
+package insights
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// EventType The kind of user interaction described by the event.
+type EventType string
+
+// List of eventType.
+const (
+	EVENT_TYPE_CLICK      EventType = "click"
+	EVENT_TYPE_CONVERSION EventType = "conversion"
+	EVENT_TYPE_VIEW       EventType = "view"
+)
+
+// All allowed values of EventType enum.
+var AllowedEventTypeEnumValues = []EventType{
+	"click",
+	"conversion",
+	"view",
+}
+
+// NewEventTypeFromValue returns a pointer to a valid EventType.
+// for the value passed as argument, or an error if the value passed is not allowed by the enum.
+func NewEventTypeFromValue(v string) (*EventType, error) {
+	ev := EventType(v)
+	if ev.IsValid() {
+		return &ev, nil
+	} else {
+		return nil, fmt.Errorf("invalid value '%v' for EventType: valid values are %v", v, AllowedEventTypeEnumValues)
+	}
+}
+
+func (v *EventType) UnmarshalJSON(src []byte) error {
+	var value string
+
+	err := json.Unmarshal(src, &value)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal value '%s' for enum 'EventType': %w", string(src), err)
+	}
+
+	enumTypeValue := EventType(value)
+	for _, existing := range AllowedEventTypeEnumValues {
+		if existing == enumTypeValue {
+			*v = enumTypeValue
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%+v is not a valid EventType", value)
+}
+
+// IsValid return true if the value is valid for the enum, false otherwise.
+func (v EventType) IsValid() bool {
+	for _, existing := range AllowedEventTypeEnumValues {
+		if existing == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Ptr returns reference to eventType value.
+func (v EventType) Ptr() *EventType {
+	return &v
+}
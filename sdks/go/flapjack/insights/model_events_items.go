@@ -0,0 +1,252 @@
+package insights
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+const (
+	// MaxEventsPerRequest is the maximum number of events accepted by a single `pushEvents` call.
+	MaxEventsPerRequest = 1000
+	// MaxEventsRequestBytes is the size the serialized body of a `pushEvents` call must stay under.
+	MaxEventsRequestBytes = 2 * 1024 * 1024
+	// MaxObjectIDsPerEvent is the maximum number of objectIDs an event can reference.
+	MaxObjectIDsPerEvent = 20
+	// MaxEventNameLength is the maximum length of an event name.
+	MaxEventNameLength = 64
+	// MaxUserTokenLength is the maximum length of a user token.
+	MaxUserTokenLength = 129
+	// MaxEventAge is the maximum age of an event's timestamp.
+	MaxEventAge = 4 * 24 * time.Hour
+)
+
+var (
+	userTokenRegexp = regexp.MustCompile(`^[a-zA-Z0-9_=/+\-]+$`)
+	queryIDRegexp   = regexp.MustCompile(`^[0-9a-fA-F]{32}$`)
+)
+
+// EventsItems A click, conversion or view event, related or not to a previous search.
+type EventsItems struct {
+	// Event name, up to 64 ASCII characters.  Consider naming events consistently—for example, by adopting Segment's [object-action](https://segment.com/academy/collecting-data/naming-conventions-for-clean-data/#the-object-action-framework) framework.
+	EventName string    `json:"eventName"`
+	EventType EventType `json:"eventType"`
+	// Index name (case-sensitive) to which the event's items belong.
+	Index string `json:"index"`
+	// Anonymous or pseudonymous user identifier.  Don't use personally identifiable information in user tokens.
+	UserToken string `json:"userToken"`
+	// Identifier for authenticated users.  When the user signs in, you can get an identifier from your system and send it as `authenticatedUserToken`.
+	AuthenticatedUserToken *string `json:"authenticatedUserToken,omitempty"`
+	// Object IDs of the records that are part of the event.
+	ObjectIDs []string `json:"objectIDs,omitempty"`
+	// Position of the clicked item in the search results, starting at 1. It is required for click events related to a search, and must have the same length as `objectIDs`.
+	Positions []int32 `json:"positions,omitempty"`
+	// Unique identifier for a search query, as returned in search responses when `clickAnalytics` is enabled.
+	QueryID *string `json:"queryID,omitempty"`
+	// Timestamp of the event, measured in milliseconds since the Unix epoch. By default, the server uses the time it receives the event.
+	Timestamp *int64 `json:"timestamp,omitempty"`
+	// Total monetary value of this event, for conversion events.
+	Value *float64 `json:"value,omitempty"`
+	// Three-letter currency code (ISO 4217) of the `value`.
+	Currency *string `json:"currency,omitempty"`
+}
+
+type EventsItemsOption func(f *EventsItems)
+
+func WithEventsItemsAuthenticatedUserToken(val string) EventsItemsOption {
+	return func(f *EventsItems) {
+		f.AuthenticatedUserToken = &val
+	}
+}
+
+func WithEventsItemsObjectIDs(val []string) EventsItemsOption {
+	return func(f *EventsItems) {
+		f.ObjectIDs = val
+	}
+}
+
+func WithEventsItemsPositions(val []int32) EventsItemsOption {
+	return func(f *EventsItems) {
+		f.Positions = val
+	}
+}
+
+func WithEventsItemsQueryID(val string) EventsItemsOption {
+	return func(f *EventsItems) {
+		f.QueryID = &val
+	}
+}
+
+func WithEventsItemsTimestamp(val int64) EventsItemsOption {
+	return func(f *EventsItems) {
+		f.Timestamp = &val
+	}
+}
+
+func WithEventsItemsValue(val float64) EventsItemsOption {
+	return func(f *EventsItems) {
+		f.Value = &val
+	}
+}
+
+func WithEventsItemsCurrency(val string) EventsItemsOption {
+	return func(f *EventsItems) {
+		f.Currency = &val
+	}
+}
+
+// NewEventsItems instantiates a new EventsItems object
+// This constructor will assign default values to properties that have it defined,
+// and makes sure properties required by API are set, but the set of arguments
+// will change when the set of required properties is changed.
+func NewEventsItems(eventName string, eventType EventType, index string, userToken string, opts ...EventsItemsOption) *EventsItems {
+	this := &EventsItems{}
+	this.EventName = eventName
+	this.EventType = eventType
+	this.Index = index
+	this.UserToken = userToken
+
+	for _, opt := range opts {
+		opt(this)
+	}
+
+	return this
+}
+
+// NewEmptyEventsItems return a pointer to an empty EventsItems object.
+func NewEmptyEventsItems() *EventsItems {
+	return &EventsItems{}
+}
+
+// NewClickedObjectIDsAfterSearch creates a click event on records at the given positions of the results of the search identified by `queryID`.
+func NewClickedObjectIDsAfterSearch(
+	eventName string, index string, userToken string, queryID string, objectIDs []string, positions []int32, opts ...EventsItemsOption,
+) *EventsItems {
+	opts = append([]EventsItemsOption{
+		WithEventsItemsQueryID(queryID), WithEventsItemsObjectIDs(objectIDs), WithEventsItemsPositions(positions),
+	}, opts...)
+
+	return NewEventsItems(eventName, EVENT_TYPE_CLICK, index, userToken, opts...)
+}
+
+// NewClickedObjectIDs creates a click event on records, unrelated to a search.
+func NewClickedObjectIDs(eventName string, index string, userToken string, objectIDs []string, opts ...EventsItemsOption) *EventsItems {
+	opts = append([]EventsItemsOption{WithEventsItemsObjectIDs(objectIDs)}, opts...)
+
+	return NewEventsItems(eventName, EVENT_TYPE_CLICK, index, userToken, opts...)
+}
+
+// NewConvertedObjectIDsAfterSearch creates a conversion event on records found by the search identified by `queryID`.
+func NewConvertedObjectIDsAfterSearch(
+	eventName string, index string, userToken string, queryID string, objectIDs []string, opts ...EventsItemsOption,
+) *EventsItems {
+	opts = append([]EventsItemsOption{WithEventsItemsQueryID(queryID), WithEventsItemsObjectIDs(objectIDs)}, opts...)
+
+	return NewEventsItems(eventName, EVENT_TYPE_CONVERSION, index, userToken, opts...)
+}
+
+// NewConvertedObjectIDs creates a conversion event on records, unrelated to a search.
+func NewConvertedObjectIDs(eventName string, index string, userToken string, objectIDs []string, opts ...EventsItemsOption) *EventsItems {
+	opts = append([]EventsItemsOption{WithEventsItemsObjectIDs(objectIDs)}, opts...)
+
+	return NewEventsItems(eventName, EVENT_TYPE_CONVERSION, index, userToken, opts...)
+}
+
+// NewViewedObjectIDs creates a view event on records.
+func NewViewedObjectIDs(eventName string, index string, userToken string, objectIDs []string, opts ...EventsItemsOption) *EventsItems {
+	opts = append([]EventsItemsOption{WithEventsItemsObjectIDs(objectIDs)}, opts...)
+
+	return NewEventsItems(eventName, EVENT_TYPE_VIEW, index, userToken, opts...)
+}
+
+// Validate checks the event against the constraints enforced by the API, so invalid events are reported before being sent.
+func (o *EventsItems) Validate() error {
+	return o.ValidateAt(time.Now())
+}
+
+// ValidateAt is Validate with `now` as the current time, telling the age of the timestamp of the event.
+func (o *EventsItems) ValidateAt(now time.Time) error {
+	if o == nil {
+		return fmt.Errorf("event is nil")
+	}
+
+	if !o.EventType.IsValid() {
+		return fmt.Errorf("invalid `eventType` %q: valid values are %v", o.EventType, AllowedEventTypeEnumValues)
+	}
+
+	if len(o.EventName) == 0 || len(o.EventName) > MaxEventNameLength {
+		return fmt.Errorf("`eventName` must be 1-%d characters, got %d", MaxEventNameLength, len(o.EventName))
+	}
+
+	if o.Index == "" {
+		return fmt.Errorf("`index` is required")
+	}
+
+	if len(o.UserToken) == 0 || len(o.UserToken) > MaxUserTokenLength || !userTokenRegexp.MatchString(o.UserToken) {
+		return fmt.Errorf("`userToken` must be 1-%d characters among [a-zA-Z0-9_=/+-], got %q", MaxUserTokenLength, o.UserToken)
+	}
+
+	if o.AuthenticatedUserToken != nil && (len(*o.AuthenticatedUserToken) == 0 || len(*o.AuthenticatedUserToken) > MaxUserTokenLength) {
+		return fmt.Errorf("`authenticatedUserToken` must be 1-%d characters", MaxUserTokenLength)
+	}
+
+	switch {
+	case len(o.ObjectIDs) == 0:
+		return fmt.Errorf("`objectIDs` is required")
+	case len(o.ObjectIDs) > MaxObjectIDsPerEvent:
+		return fmt.Errorf("`objectIDs` must have at most %d items, got %d", MaxObjectIDsPerEvent, len(o.ObjectIDs))
+	}
+
+	if o.QueryID != nil && !queryIDRegexp.MatchString(*o.QueryID) {
+		return fmt.Errorf("`queryID` must be a 32 characters hexadecimal string, got %q", *o.QueryID)
+	}
+
+	if o.EventType == EVENT_TYPE_CLICK && o.QueryID != nil && len(o.ObjectIDs) > 0 && len(o.Positions) != len(o.ObjectIDs) {
+		return fmt.Errorf("`positions` must have the same length as `objectIDs` for click events related to a search")
+	}
+
+	for _, p := range o.Positions {
+		if p < 1 {
+			return fmt.Errorf("`positions` start at 1, got %d", p)
+		}
+	}
+
+	if o.Timestamp != nil && now.Sub(time.UnixMilli(*o.Timestamp)) > MaxEventAge {
+		return fmt.Errorf("`timestamp` must be within the last %s", MaxEventAge)
+	}
+
+	if o.Currency != nil && len(*o.Currency) != 3 {
+		return fmt.Errorf("`currency` must be a three-letter ISO 4217 code, got %q", *o.Currency)
+	}
+
+	return nil
+}
+
+func (o EventsItems) MarshalJSON() ([]byte, error) {
+	type _EventsItems EventsItems
+
+	serialized, err := json.Marshal(_EventsItems(o))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal EventsItems: %w", err)
+	}
+
+	return serialized, nil
+}
+
+func (o EventsItems) String() string {
+	out := ""
+	out += fmt.Sprintf("  eventName=%v\n", o.EventName)
+	out += fmt.Sprintf("  eventType=%v\n", o.EventType)
+	out += fmt.Sprintf("  index=%v\n", o.Index)
+	out += fmt.Sprintf("  userToken=%v\n", o.UserToken)
+	out += fmt.Sprintf("  authenticatedUserToken=%v\n", o.AuthenticatedUserToken)
+	out += fmt.Sprintf("  objectIDs=%v\n", o.ObjectIDs)
+	out += fmt.Sprintf("  positions=%v\n", o.Positions)
+	out += fmt.Sprintf("  queryID=%v\n", o.QueryID)
+	out += fmt.Sprintf("  timestamp=%v\n", o.Timestamp)
+	out += fmt.Sprintf("  value=%v\n", o.Value)
+	out += fmt.Sprintf("  currency=%v\n", o.Currency)
+
+	return fmt.Sprintf("EventsItems {\n%s}", out)
+}
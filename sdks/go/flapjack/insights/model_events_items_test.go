@@ -0,0 +1,116 @@
+package insights_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/insights"
+)
+
+func TestEventsItemsValidate(t *testing.T) {
+	t.Parallel()
+
+	queryID := strings.Repeat("a1", 16)
+
+	tests := []struct {
+		name    string
+		event   *insights.EventsItems
+		wantErr string
+	}{
+		{
+			name:  "valid click after search",
+			event: insights.NewClickedObjectIDsAfterSearch("Product Clicked", "products", "user-1", queryID, []string{"1", "2"}, []int32{1, 2}),
+		},
+		{
+			name:  "valid conversion",
+			event: insights.NewConvertedObjectIDs("Product Purchased", "products", "user-1", []string{"1"}, insights.WithEventsItemsValue(9.99), insights.WithEventsItemsCurrency("USD")),
+		},
+		{
+			name:  "valid uppercase query ID",
+			event: insights.NewConvertedObjectIDsAfterSearch("Product Purchased", "products", "user-1", strings.ToUpper(queryID), []string{"1"}),
+		},
+		{
+			name:  "valid view",
+			event: insights.NewViewedObjectIDs("Product Viewed", "products", "user-1", []string{"1"}),
+		},
+		{
+			name:    "invalid event type",
+			event:   insights.NewEventsItems("Hovered", "hover", "products", "user-1", insights.WithEventsItemsObjectIDs([]string{"1"})),
+			wantErr: "eventType",
+		},
+		{
+			name:    "event name too long",
+			event:   insights.NewViewedObjectIDs(strings.Repeat("e", 65), "products", "user-1", []string{"1"}),
+			wantErr: "eventName",
+		},
+		{
+			name:    "invalid user token",
+			event:   insights.NewViewedObjectIDs("Viewed", "products", "user token", []string{"1"}),
+			wantErr: "userToken",
+		},
+		{
+			name:    "no object",
+			event:   insights.NewViewedObjectIDs("Viewed", "products", "user-1", nil),
+			wantErr: "objectIDs",
+		},
+		{
+			name:    "too many objects",
+			event:   insights.NewViewedObjectIDs("Viewed", "products", "user-1", make([]string, 21)),
+			wantErr: "at most 20",
+		},
+		{
+			name:    "positions mismatch",
+			event:   insights.NewClickedObjectIDsAfterSearch("Clicked", "products", "user-1", queryID, []string{"1", "2"}, []int32{1}),
+			wantErr: "positions",
+		},
+		{
+			name:    "malformed query ID",
+			event:   insights.NewConvertedObjectIDsAfterSearch("Purchased", "products", "user-1", "not-a-query-id", []string{"1"}),
+			wantErr: "queryID",
+		},
+		{
+			name:    "timestamp too old",
+			event:   insights.NewViewedObjectIDs("Viewed", "products", "user-1", []string{"1"}, insights.WithEventsItemsTimestamp(time.Now().Add(-5*24*time.Hour).UnixMilli())),
+			wantErr: "timestamp",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.event.Validate()
+
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case tt.wantErr != "" && err == nil:
+				t.Errorf("expected an error mentioning %q", tt.wantErr)
+			case tt.wantErr != "" && !strings.Contains(err.Error(), tt.wantErr):
+				t.Errorf("expected an error mentioning %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestInsightsEventsValidateBatchSize(t *testing.T) {
+	t.Parallel()
+
+	event := insights.NewViewedObjectIDs("Viewed", "products", "user-1", []string{"1"})
+
+	events := make([]insights.EventsItems, insights.MaxEventsPerRequest+1)
+	for i := range events {
+		events[i] = *event
+	}
+
+	if err := insights.NewInsightsEvents(events).Validate(); err == nil {
+		t.Error("expected an error for too many events")
+	}
+
+	if err := insights.NewInsightsEvents(events[:1]).Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+package insights
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// EventsResponse The response of the Insights API.
+type EventsResponse struct {
+	// Details about the response, such as error messages.
+	Message *string `json:"message,omitempty"`
+	// The HTTP status code of the response.
+	Status *int32 `json:"status,omitempty"`
+}
+
+// NewEmptyEventsResponse return a pointer to an empty EventsResponse object.
+func NewEmptyEventsResponse() *EventsResponse {
+	return &EventsResponse{}
+}
+
+// GetMessage returns the Message field value if set, zero value otherwise.
+func (o *EventsResponse) GetMessage() string {
+	if o == nil || o.Message == nil {
+		var ret string
+
+		return ret
+	}
+
+	return *o.Message
+}
+
+// GetStatus returns the Status field value if set, zero value otherwise.
+func (o *EventsResponse) GetStatus() int32 {
+	if o == nil || o.Status == nil {
+		var ret int32
+
+		return ret
+	}
+
+	return *o.Status
+}
+
+func (o EventsResponse) MarshalJSON() ([]byte, error) {
+	toSerialize := map[string]any{}
+	if o.Message != nil {
+		toSerialize["message"] = o.Message
+	}
+
+	if o.Status != nil {
+		toSerialize["status"] = o.Status
+	}
+
+	serialized, err := json.Marshal(toSerialize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal EventsResponse: %w", err)
+	}
+
+	return serialized, nil
+}
+
+func (o EventsResponse) String() string {
+	out := ""
+	out += fmt.Sprintf("  message=%v\n", o.Message)
+	out += fmt.Sprintf("  status=%v\n", o.Status)
+
+	return fmt.Sprintf("EventsResponse {\n%s}", out)
+}
@@ -0,0 +1,91 @@
+package insights
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// InsightsEvents struct for InsightsEvents.
+type InsightsEvents struct {
+	// Click and conversion events.  **All** events must be valid, otherwise the API returns an error.
+	Events []EventsItems `json:"events"`
+}
+
+// NewInsightsEvents instantiates a new InsightsEvents object
+// This constructor will assign default values to properties that have it defined,
+// and makes sure properties required by API are set, but the set of arguments
+// will change when the set of required properties is changed.
+func NewInsightsEvents(events []EventsItems) *InsightsEvents {
+	this := &InsightsEvents{}
+	this.Events = events
+
+	return this
+}
+
+// NewEmptyInsightsEvents return a pointer to an empty InsightsEvents object.
+func NewEmptyInsightsEvents() *InsightsEvents {
+	return &InsightsEvents{}
+}
+
+// GetEvents returns the Events field value.
+func (o *InsightsEvents) GetEvents() []EventsItems {
+	if o == nil {
+		var ret []EventsItems
+
+		return ret
+	}
+
+	return o.Events
+}
+
+// SetEvents sets field value.
+func (o *InsightsEvents) SetEvents(v []EventsItems) *InsightsEvents {
+	o.Events = v
+
+	return o
+}
+
+// Validate checks the number of events and every event, returning the first violation found.
+func (o *InsightsEvents) Validate() error {
+	return o.ValidateAt(time.Now())
+}
+
+// ValidateAt is Validate with `now` as the current time, telling the age of the timestamps of the events.
+func (o *InsightsEvents) ValidateAt(now time.Time) error {
+	if o == nil || len(o.Events) == 0 {
+		return fmt.Errorf("at least one event is required")
+	}
+
+	if len(o.Events) > MaxEventsPerRequest {
+		return fmt.Errorf("at most %d events can be sent at once, got %d", MaxEventsPerRequest, len(o.Events))
+	}
+
+	for i := range o.Events {
+		err := o.Events[i].ValidateAt(now)
+		if err != nil {
+			return fmt.Errorf("invalid event at position %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+func (o InsightsEvents) MarshalJSON() ([]byte, error) {
+	toSerialize := map[string]any{}
+	toSerialize["events"] = o.Events
+
+	serialized, err := json.Marshal(toSerialize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal InsightsEvents: %w", err)
+	}
+
+	return serialized, nil
+}
+
+func (o InsightsEvents) String() string {
+	out := ""
+	out += fmt.Sprintf("  events=%v\n", o.Events)
+
+	return fmt.Sprintf("InsightsEvents {\n%s}", out)
+}
@@ -0,0 +1,166 @@
+package insights
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+)
+
+// AnonymousUserTokenPrefix is the prefix of the user tokens generated by NewAnonymousUserToken.
+const AnonymousUserTokenPrefix = "anonymous-"
+
+// UserTokens identifies the user of searches and events.
+type UserTokens struct {
+	// UserToken is the anonymous or pseudonymous identifier of the user, stable across sessions when persisted.
+	UserToken string
+	// AuthenticatedUserToken is the identifier of the signed in user, if any.
+	AuthenticatedUserToken string
+}
+
+// SearchUserToken returns the user token to send as the `userToken` search parameter: the authenticated one for signed in users, so their searches are personalized across devices, the anonymous one otherwise.
+func (t UserTokens) SearchUserToken() string {
+	if t.AuthenticatedUserToken != "" {
+		return t.AuthenticatedUserToken
+	}
+
+	return t.UserToken
+}
+
+// EventsItemsOptions returns the options of the event constructors setting the authenticated user token, if any. The user token is given to the constructors.
+func (t UserTokens) EventsItemsOptions() []EventsItemsOption {
+	if t.AuthenticatedUserToken == "" {
+		return nil
+	}
+
+	return []EventsItemsOption{WithEventsItemsAuthenticatedUserToken(t.AuthenticatedUserToken)}
+}
+
+// Validate checks the user tokens against the constraints of the events.
+func (t UserTokens) Validate() error {
+	if !validUserToken(t.UserToken) {
+		return fmt.Errorf("`userToken` must be 1-%d characters among [a-zA-Z0-9_=/+-], got %q", MaxUserTokenLength, t.UserToken)
+	}
+
+	if len(t.AuthenticatedUserToken) > MaxUserTokenLength {
+		return fmt.Errorf("`authenticatedUserToken` must be 1-%d characters", MaxUserTokenLength)
+	}
+
+	return nil
+}
+
+// NewAnonymousUserToken generates a random anonymous user token, such as `anonymous-3f2c...`.
+func NewAnonymousUserToken() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+
+	return AnonymousUserTokenPrefix + hex.EncodeToString(b)
+}
+
+type authenticatedUserTokenKey struct{}
+
+// WithAuthenticatedUserToken returns a copy of ctx carrying the identifier of the signed in user, returned by UserTokenProvider.UserTokens.
+func WithAuthenticatedUserToken(ctx context.Context, authenticatedUserToken string) context.Context {
+	return context.WithValue(ctx, authenticatedUserTokenKey{}, authenticatedUserToken)
+}
+
+// AuthenticatedUserTokenFromContext returns the identifier of the signed in user of a context, if any.
+func AuthenticatedUserTokenFromContext(ctx context.Context) (string, bool) {
+	authenticatedUserToken, ok := ctx.Value(authenticatedUserTokenKey{}).(string)
+
+	return authenticatedUserToken, ok && authenticatedUserToken != ""
+}
+
+// UserTokenStore persists the anonymous user token of a user, for instance in a cookie or a session reached through the context of the request.
+type UserTokenStore interface {
+	// LoadUserToken returns the user token of the user, or an empty string if there is none yet.
+	LoadUserToken(ctx context.Context) (string, error)
+	// SaveUserToken persists the user token generated for the user.
+	SaveUserToken(ctx context.Context, userToken string) error
+}
+
+// UserTokenStoreFuncs is a UserTokenStore made of functions. A nil Load finds no user token and a nil Save persists nothing, so a new user token is generated every time.
+type UserTokenStoreFuncs struct {
+	Load func(ctx context.Context) (string, error)
+	Save func(ctx context.Context, userToken string) error
+}
+
+// LoadUserToken calls Load, if any.
+func (f UserTokenStoreFuncs) LoadUserToken(ctx context.Context) (string, error) {
+	if f.Load == nil {
+		return "", nil
+	}
+
+	return f.Load(ctx)
+}
+
+// SaveUserToken calls Save, if any.
+func (f UserTokenStoreFuncs) SaveUserToken(ctx context.Context, userToken string) error {
+	if f.Save == nil {
+		return nil
+	}
+
+	return f.Save(ctx, userToken)
+}
+
+/*
+UserTokenProvider provides the user tokens of the users, shared by their searches and events so they are attributed to the same user.
+Users without a valid persisted user token get an anonymous one, which is saved in the store:
+
+	provider := insights.NewUserTokenProvider(insights.UserTokenStoreFuncs{
+		Load: func(ctx context.Context) (string, error) { return sessionFrom(ctx).UserToken, nil },
+		Save: func(ctx context.Context, userToken string) error { return sessionFrom(ctx).SetUserToken(userToken) },
+	})
+
+	tokens, err := provider.UserTokens(insights.WithAuthenticatedUserToken(ctx, user.ID))
+*/
+type UserTokenProvider struct {
+	store UserTokenStore
+}
+
+// NewUserTokenProvider returns a provider persisting the user tokens in store.
+func NewUserTokenProvider(store UserTokenStore) *UserTokenProvider {
+	if store == nil {
+		store = UserTokenStoreFuncs{}
+	}
+
+	return &UserTokenProvider{store: store}
+}
+
+/*
+UserTokens returns the user tokens of the user of the context: the persisted user token, or a new anonymous one, and the authenticated user token given to WithAuthenticatedUserToken.
+
+	@param ctx context.Context - Context of the request of the user, given to the store.
+	@return UserTokens - The user tokens.
+	@return error - Error if the store fails, or if the authenticated user token is invalid.
+*/
+func (p *UserTokenProvider) UserTokens(ctx context.Context) (UserTokens, error) {
+	userToken, err := p.store.LoadUserToken(ctx)
+	if err != nil {
+		return UserTokens{}, fmt.Errorf("cannot load the user token: %w", err)
+	}
+
+	// A missing or altered user token is replaced.
+	if !validUserToken(userToken) {
+		userToken = NewAnonymousUserToken()
+
+		err = p.store.SaveUserToken(ctx, userToken)
+		if err != nil {
+			return UserTokens{}, fmt.Errorf("cannot save the user token: %w", err)
+		}
+	}
+
+	tokens := UserTokens{UserToken: userToken}
+	tokens.AuthenticatedUserToken, _ = AuthenticatedUserTokenFromContext(ctx)
+
+	err = tokens.Validate()
+	if err != nil {
+		return UserTokens{}, err
+	}
+
+	return tokens, nil
+}
+
+func validUserToken(userToken string) bool {
+	return len(userToken) > 0 && len(userToken) <= MaxUserTokenLength && userTokenRegexp.MatchString(userToken)
+}
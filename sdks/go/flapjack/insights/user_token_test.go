@@ -0,0 +1,113 @@
+package insights_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/insights"
+)
+
+func TestUserTokenProvider(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		stored    string
+		wantSaved bool
+	}{
+		{name: "persisted user token", stored: "user-42"},
+		{name: "no user token", wantSaved: true},
+		{name: "altered user token", stored: "user 42", wantSaved: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			stored := tt.stored
+			saved := false
+
+			provider := insights.NewUserTokenProvider(insights.UserTokenStoreFuncs{
+				Load: func(ctx context.Context) (string, error) { return stored, nil },
+				Save: func(ctx context.Context, userToken string) error {
+					stored, saved = userToken, true
+
+					return nil
+				},
+			})
+
+			tokens, err := provider.UserTokens(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if saved != tt.wantSaved || tokens.UserToken != stored {
+				t.Errorf("expected the user token %q to be saved: %v, got %q saved: %v", stored, tt.wantSaved, tokens.UserToken, saved)
+			}
+
+			if tt.wantSaved && !strings.HasPrefix(tokens.UserToken, insights.AnonymousUserTokenPrefix) {
+				t.Errorf("expected an anonymous user token, got %q", tokens.UserToken)
+			}
+
+			if tokens.AuthenticatedUserToken != "" || tokens.SearchUserToken() != tokens.UserToken || tokens.EventsItemsOptions() != nil {
+				t.Errorf("expected no authenticated user token, got %+v", tokens)
+			}
+		})
+	}
+}
+
+func TestUserTokenProviderAuthenticatedUser(t *testing.T) {
+	t.Parallel()
+
+	provider := insights.NewUserTokenProvider(insights.UserTokenStoreFuncs{
+		Load: func(ctx context.Context) (string, error) { return "user-42", nil },
+	})
+
+	tokens, err := provider.UserTokens(insights.WithAuthenticatedUserToken(context.Background(), "account-7"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if tokens != (insights.UserTokens{UserToken: "user-42", AuthenticatedUserToken: "account-7"}) || tokens.SearchUserToken() != "account-7" {
+		t.Errorf("unexpected user tokens %+v", tokens)
+	}
+
+	event := insights.NewViewedObjectIDs("Viewed", "products", tokens.UserToken, []string{"1"}, tokens.EventsItemsOptions()...)
+	if event.AuthenticatedUserToken == nil || *event.AuthenticatedUserToken != "account-7" {
+		t.Errorf("expected the event to carry the authenticated user token, got %+v", event)
+	}
+
+	_, err = provider.UserTokens(insights.WithAuthenticatedUserToken(context.Background(), strings.Repeat("a", 130)))
+	if err == nil || !strings.Contains(err.Error(), "authenticatedUserToken") {
+		t.Errorf("expected an error for the authenticated user token, got %v", err)
+	}
+}
+
+func TestUserTokenProviderStoreErrors(t *testing.T) {
+	t.Parallel()
+
+	errStore := errors.New("session unavailable")
+
+	_, err := insights.NewUserTokenProvider(insights.UserTokenStoreFuncs{
+		Load: func(ctx context.Context) (string, error) { return "", errStore },
+	}).UserTokens(context.Background())
+	if !errors.Is(err, errStore) {
+		t.Errorf("expected the load error, got %v", err)
+	}
+
+	_, err = insights.NewUserTokenProvider(insights.UserTokenStoreFuncs{
+		Save: func(ctx context.Context, userToken string) error { return errStore },
+	}).UserTokens(context.Background())
+	if !errors.Is(err, errStore) {
+		t.Errorf("expected the save error, got %v", err)
+	}
+
+	tokens, err := insights.NewUserTokenProvider(nil).UserTokens(context.Background())
+	if err != nil || tokens.Validate() != nil {
+		t.Errorf("expected a valid anonymous user token without store, got %+v: %v", tokens, err)
+	}
+}
@@ -0,0 +1,190 @@
+// Package logging routes the logs of the API clients into the logging library of the application.
+//
+// The clients log through the *slog.Logger of their configuration, so a slog handler is used as is:
+//
+//	cfg.Logger = slog.New(myHandler)
+//
+// This package builds that *slog.Logger on top of other libraries without depending on them. Their loggers
+// implement the small interfaces below:
+//
+//	cfg.Logger = logging.FromKeyValueLogger(zapLogger.Sugar(), nil)          // *zap.SugaredLogger
+//	cfg.Logger = logging.FromLeveledLogger(logrus.StandardLogger(), nil)      // *logrus.Logger or *logrus.Entry
+//
+// To keep the attributes of the logs as structured logrus fields, use FromFunc:
+//
+//	cfg.Logger = logging.FromFunc(func(_ context.Context, level slog.Level, msg string, fields map[string]any) {
+//		logrus.WithFields(fields).Log(logrusLevel(level), msg)
+//	}, nil)
+//
+// Attributes in groups are flattened, their keys joined with dots, such as `http.status`.
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+)
+
+// KeyValueLogger logs messages with alternating keys and values, like *zap.SugaredLogger.
+type KeyValueLogger interface {
+	Debugw(msg string, keysAndValues ...any)
+	Infow(msg string, keysAndValues ...any)
+	Warnw(msg string, keysAndValues ...any)
+	Errorw(msg string, keysAndValues ...any)
+}
+
+// LeveledLogger logs formatted messages, like *logrus.Logger and *logrus.Entry. The attributes are appended to the
+// message as `key=value` pairs.
+type LeveledLogger interface {
+	Debugf(format string, args ...any)
+	Infof(format string, args ...any)
+	Warnf(format string, args ...any)
+	Errorf(format string, args ...any)
+}
+
+// Options configures the loggers of the package.
+type Options struct {
+	// Level is the minimum level of the logs passed on, slog.LevelDebug when nil, as the clients only log at the
+	// debug level. The underlying logger may still filter them out.
+	Level slog.Leveler
+}
+
+// FromKeyValueLogger returns a *slog.Logger passing the logs on to a KeyValueLogger.
+func FromKeyValueLogger(logger KeyValueLogger, opts *Options) *slog.Logger {
+	return slog.New(newHandler(opts, func(_ context.Context, level slog.Level, msg string, attrs []slog.Attr) {
+		keysAndValues := make([]any, 0, 2*len(attrs))
+		for _, attr := range attrs {
+			keysAndValues = append(keysAndValues, attr.Key, attr.Value.Any())
+		}
+
+		switch {
+		case level < slog.LevelInfo:
+			logger.Debugw(msg, keysAndValues...)
+		case level < slog.LevelWarn:
+			logger.Infow(msg, keysAndValues...)
+		case level < slog.LevelError:
+			logger.Warnw(msg, keysAndValues...)
+		default:
+			logger.Errorw(msg, keysAndValues...)
+		}
+	}))
+}
+
+// FromLeveledLogger returns a *slog.Logger passing the logs on to a LeveledLogger.
+func FromLeveledLogger(logger LeveledLogger, opts *Options) *slog.Logger {
+	return slog.New(newHandler(opts, func(_ context.Context, level slog.Level, msg string, attrs []slog.Attr) {
+		var sb strings.Builder
+
+		sb.WriteString(msg)
+
+		for _, attr := range attrs {
+			sb.WriteByte(' ')
+			sb.WriteString(attr.String())
+		}
+
+		switch {
+		case level < slog.LevelInfo:
+			logger.Debugf("%s", sb.String())
+		case level < slog.LevelWarn:
+			logger.Infof("%s", sb.String())
+		case level < slog.LevelError:
+			logger.Warnf("%s", sb.String())
+		default:
+			logger.Errorf("%s", sb.String())
+		}
+	}))
+}
+
+// FromFunc returns a *slog.Logger passing the logs on to a function, with their attributes as fields.
+func FromFunc(fn func(ctx context.Context, level slog.Level, msg string, fields map[string]any), opts *Options) *slog.Logger {
+	return slog.New(newHandler(opts, func(ctx context.Context, level slog.Level, msg string, attrs []slog.Attr) {
+		fields := make(map[string]any, len(attrs))
+		for _, attr := range attrs {
+			fields[attr.Key] = attr.Value.Any()
+		}
+
+		fn(ctx, level, msg, fields)
+	}))
+}
+
+// handler is a slog.Handler giving the logs, with their flattened attributes, to an emit function.
+type handler struct {
+	level  slog.Leveler
+	attrs  []slog.Attr
+	prefix string
+	emit   func(ctx context.Context, level slog.Level, msg string, attrs []slog.Attr)
+}
+
+func newHandler(opts *Options, emit func(ctx context.Context, level slog.Level, msg string, attrs []slog.Attr)) *handler {
+	h := &handler{level: slog.LevelDebug, emit: emit}
+
+	if opts != nil && opts.Level != nil {
+		h.level = opts.Level
+	}
+
+	return h
+}
+
+func (h *handler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+func (h *handler) Handle(ctx context.Context, record slog.Record) error {
+	attrs := make([]slog.Attr, len(h.attrs), len(h.attrs)+record.NumAttrs())
+	copy(attrs, h.attrs)
+
+	record.Attrs(func(attr slog.Attr) bool {
+		attrs = flatten(attrs, h.prefix, attr)
+
+		return true
+	})
+
+	h.emit(ctx, record.Level, record.Message, attrs)
+
+	return nil
+}
+
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	derived := *h
+	derived.attrs = append([]slog.Attr{}, h.attrs...)
+
+	for _, attr := range attrs {
+		derived.attrs = flatten(derived.attrs, h.prefix, attr)
+	}
+
+	return &derived
+}
+
+func (h *handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	derived := *h
+	derived.prefix = h.prefix + name + "."
+
+	return &derived
+}
+
+// flatten appends the attribute to attrs, with the attributes of groups flattened and their keys prefixed.
+func flatten(attrs []slog.Attr, prefix string, attr slog.Attr) []slog.Attr {
+	attr.Value = attr.Value.Resolve()
+
+	if attr.Equal(slog.Attr{}) {
+		return attrs
+	}
+
+	if attr.Value.Kind() != slog.KindGroup {
+		return append(attrs, slog.Attr{Key: prefix + attr.Key, Value: attr.Value})
+	}
+
+	if attr.Key != "" {
+		prefix += attr.Key + "."
+	}
+
+	for _, member := range attr.Value.Group() {
+		attrs = flatten(attrs, prefix, member)
+	}
+
+	return attrs
+}
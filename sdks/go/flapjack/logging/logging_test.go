@@ -0,0 +1,101 @@
+package logging_test
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/logging"
+)
+
+// keyValueLogger records the logs like a *zap.SugaredLogger would receive them.
+type keyValueLogger struct {
+	logs []string
+}
+
+func (l *keyValueLogger) log(level, msg string, keysAndValues []any) {
+	l.logs = append(l.logs, fmt.Sprintf("%s %s %v", level, msg, keysAndValues))
+}
+
+func (l *keyValueLogger) Debugw(msg string, kv ...any) { l.log("debug", msg, kv) }
+func (l *keyValueLogger) Infow(msg string, kv ...any)  { l.log("info", msg, kv) }
+func (l *keyValueLogger) Warnw(msg string, kv ...any)  { l.log("warn", msg, kv) }
+func (l *keyValueLogger) Errorw(msg string, kv ...any) { l.log("error", msg, kv) }
+
+// leveledLogger records the logs like a *logrus.Logger would receive them.
+type leveledLogger struct {
+	logs []string
+}
+
+func (l *leveledLogger) log(level, format string, args []any) {
+	l.logs = append(l.logs, level+" "+fmt.Sprintf(format, args...))
+}
+
+func (l *leveledLogger) Debugf(format string, args ...any) { l.log("debug", format, args) }
+func (l *leveledLogger) Infof(format string, args ...any)  { l.log("info", format, args) }
+func (l *leveledLogger) Warnf(format string, args ...any)  { l.log("warn", format, args) }
+func (l *leveledLogger) Errorf(format string, args ...any) { l.log("error", format, args) }
+
+func logSome(logger *slog.Logger) {
+	logger = logger.With(slog.String("kind", "read"))
+
+	logger.Debug("flapjack: sending request", slog.String("host", "localhost:7700"), slog.Group("http", slog.Int("status", 200)))
+	logger.WithGroup("retry").Warn("flapjack: retrying request", slog.Int("attempt", 1))
+	logger.Error("flapjack: no more host to try", slog.String("percent", "100%"))
+}
+
+func TestFromKeyValueLogger(t *testing.T) {
+	t.Parallel()
+
+	l := &keyValueLogger{}
+	logSome(logging.FromKeyValueLogger(l, nil))
+
+	want := []string{
+		"debug flapjack: sending request [kind read host localhost:7700 http.status 200]",
+		"warn flapjack: retrying request [kind read retry.attempt 1]",
+		"error flapjack: no more host to try [kind read percent 100%]",
+	}
+	if !reflect.DeepEqual(l.logs, want) {
+		t.Errorf("expected %q, got %q", want, l.logs)
+	}
+}
+
+func TestFromLeveledLogger(t *testing.T) {
+	t.Parallel()
+
+	l := &leveledLogger{}
+	logSome(logging.FromLeveledLogger(l, &logging.Options{Level: slog.LevelWarn}))
+
+	want := []string{
+		"warn flapjack: retrying request kind=read retry.attempt=1",
+		"error flapjack: no more host to try kind=read percent=100%",
+	}
+	if !reflect.DeepEqual(l.logs, want) {
+		t.Errorf("expected %q, got %q", want, l.logs)
+	}
+}
+
+func TestFromFunc(t *testing.T) {
+	t.Parallel()
+
+	var got []map[string]any
+
+	logSome(logging.FromFunc(func(_ context.Context, level slog.Level, msg string, fields map[string]any) {
+		got = append(got, map[string]any{"level": level, "msg": msg, "fields": fields})
+	}, nil))
+
+	if len(got) != 3 {
+		t.Fatalf("expected 3 logs, got %v", got)
+	}
+
+	want := map[string]any{
+		"level":  slog.LevelDebug,
+		"msg":    "flapjack: sending request",
+		"fields": map[string]any{"kind": "read", "host": "localhost:7700", "http.status": int64(200)},
+	}
+	if !reflect.DeepEqual(got[0], want) {
+		t.Errorf("expected %v, got %v", want, got[0])
+	}
+}
@@ -0,0 +1,215 @@
+// Package prometheus provides a transport.MetricsCollector exposing the client metrics in the Prometheus text
+// exposition format, without depending on the Prometheus client library.
+//
+//	collector := prometheus.NewCollector()
+//	cfg.MetricsCollector = collector
+//	http.Handle("/metrics/flapjack", collector)
+//
+// When metrics are already served by another handler, WriteTo appends them to its output.
+package prometheus
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/call"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+// DefaultBuckets are the upper bounds, in seconds, of the request duration histogram.
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+type CollectorOption func(*Collector)
+
+// WithNamespace sets the prefix of the metric names, `flapjack` by default.
+func WithNamespace(namespace string) CollectorOption {
+	return func(c *Collector) {
+		c.namespace = namespace
+	}
+}
+
+// WithBuckets sets the upper bounds, in seconds, of the request duration histogram.
+func WithBuckets(buckets []float64) CollectorOption {
+	return func(c *Collector) {
+		c.buckets = append([]float64(nil), buckets...)
+		sort.Float64s(c.buckets)
+	}
+}
+
+// Collector aggregates the measurements of the transport. It is safe for concurrent use.
+type Collector struct {
+	mu        sync.Mutex
+	namespace string
+	buckets   []float64
+
+	requests  map[labels]float64
+	attempts  map[labels]float64
+	retries   map[labels]float64
+	failovers map[labels]float64
+	durations map[labels]*histogram
+}
+
+var _ transport.MetricsCollector = (*Collector)(nil)
+
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// labels is a serialized set of label pairs, usable as a map key.
+type labels string
+
+type histogram struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// NewCollector creates an empty collector.
+func NewCollector(opts ...CollectorOption) *Collector {
+	c := &Collector{
+		namespace: "flapjack",
+		buckets:   DefaultBuckets,
+		requests:  map[labels]float64{},
+		attempts:  map[labels]float64{},
+		retries:   map[labels]float64{},
+		failovers: map[labels]float64{},
+		durations: map[labels]*histogram{},
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// ObserveAttempt implements transport.MetricsCollector.
+func (c *Collector) ObserveAttempt(m transport.AttemptMetrics) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.attempts[newLabels("host", m.Host, "kind", kindName(m.Kind), "status_class", transport.StatusClass(m.StatusCode))]++
+}
+
+// ObserveRequest implements transport.MetricsCollector.
+func (c *Collector) ObserveRequest(m transport.RequestMetrics) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	operation := newLabels("operation", m.OperationName, "kind", kindName(m.Kind))
+
+	c.requests[newLabels("operation", m.OperationName, "kind", kindName(m.Kind), "status_class", transport.StatusClass(m.StatusCode))]++
+
+	if m.Attempts > 1 {
+		c.retries[operation] += float64(m.Attempts - 1)
+	}
+
+	if m.Failovers > 0 {
+		c.failovers[operation] += float64(m.Failovers)
+	}
+
+	h, ok := c.durations[operation]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(c.buckets))}
+		c.durations[operation] = h
+	}
+
+	seconds := m.Duration.Seconds()
+	for i, bound := range c.buckets {
+		if seconds <= bound {
+			h.counts[i]++
+		}
+	}
+
+	h.count++
+	h.sum += seconds
+}
+
+// ServeHTTP writes the metrics in the Prometheus text exposition format.
+func (c *Collector) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+
+	_, _ = c.WriteTo(w)
+}
+
+// WriteTo writes the metrics in the Prometheus text exposition format.
+func (c *Collector) WriteTo(w io.Writer) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var sb strings.Builder
+
+	c.writeCounter(&sb, "requests_total", "Calls performed by the client, by final status class.", c.requests)
+	c.writeCounter(&sb, "attempts_total", "HTTP attempts performed by the client, by host and status class.", c.attempts)
+	c.writeCounter(&sb, "retries_total", "HTTP attempts beyond the first one of each call.", c.retries)
+	c.writeCounter(&sb, "host_failovers_total", "Times a call moved on to another host.", c.failovers)
+	c.writeHistogram(&sb, "request_duration_seconds", "Duration of the calls, including retries.", c.durations)
+
+	n, err := io.WriteString(w, sb.String())
+
+	return int64(n), err
+}
+
+func (c *Collector) writeCounter(sb *strings.Builder, name string, help string, values map[labels]float64) {
+	name = c.namespace + "_" + name
+
+	fmt.Fprintf(sb, "# HELP %s %s\n# TYPE %s counter\n", name, help, name)
+
+	for _, l := range sortedKeys(values) {
+		fmt.Fprintf(sb, "%s{%s} %s\n", name, l, formatFloat(values[l]))
+	}
+}
+
+func (c *Collector) writeHistogram(sb *strings.Builder, name string, help string, values map[labels]*histogram) {
+	name = c.namespace + "_" + name
+
+	fmt.Fprintf(sb, "# HELP %s %s\n# TYPE %s histogram\n", name, help, name)
+
+	for _, l := range sortedKeys(values) {
+		h := values[l]
+
+		for i, bound := range c.buckets {
+			fmt.Fprintf(sb, "%s_bucket{%s,le=\"%s\"} %d\n", name, l, formatFloat(bound), h.counts[i])
+		}
+
+		fmt.Fprintf(sb, "%s_bucket{%s,le=\"+Inf\"} %d\n", name, l, h.count)
+		fmt.Fprintf(sb, "%s_sum{%s} %s\n", name, l, formatFloat(h.sum))
+		fmt.Fprintf(sb, "%s_count{%s} %d\n", name, l, h.count)
+	}
+}
+
+func newLabels(pairs ...string) labels {
+	parts := make([]string, 0, len(pairs)/2)
+
+	for i := 0; i+1 < len(pairs); i += 2 {
+		parts = append(parts, pairs[i]+"=\""+labelValueReplacer.Replace(pairs[i+1])+"\"")
+	}
+
+	return labels(strings.Join(parts, ","))
+}
+
+func sortedKeys[V any](m map[labels]V) []labels {
+	keys := make([]labels, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	return keys
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func kindName(k call.Kind) string {
+	if k == call.Read {
+		return "read"
+	}
+
+	return "write"
+}
@@ -0,0 +1,95 @@
+package prometheus_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/call"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/prometheus"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+func TestCollectorFormat(t *testing.T) {
+	t.Parallel()
+
+	collector := prometheus.NewCollector(prometheus.WithBuckets([]float64{0.1, 1}))
+
+	collector.ObserveAttempt(transport.AttemptMetrics{OperationName: "search", Kind: call.Read, Host: "a", StatusCode: 503})
+	collector.ObserveAttempt(transport.AttemptMetrics{OperationName: "search", Kind: call.Read, Host: "b", StatusCode: 200})
+	collector.ObserveRequest(transport.RequestMetrics{
+		OperationName: "search", Kind: call.Read, Attempts: 2, Failovers: 1, StatusCode: 200, Duration: 500 * time.Millisecond,
+	})
+
+	rec := httptest.NewRecorder()
+	collector.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	for _, want := range []string{
+		"# TYPE flapjack_requests_total counter",
+		`flapjack_requests_total{operation="search",kind="read",status_class="2xx"} 1`,
+		`flapjack_attempts_total{host="a",kind="read",status_class="5xx"} 1`,
+		`flapjack_attempts_total{host="b",kind="read",status_class="2xx"} 1`,
+		`flapjack_retries_total{operation="search",kind="read"} 1`,
+		`flapjack_host_failovers_total{operation="search",kind="read"} 1`,
+		"# TYPE flapjack_request_duration_seconds histogram",
+		`flapjack_request_duration_seconds_bucket{operation="search",kind="read",le="0.1"} 0`,
+		`flapjack_request_duration_seconds_bucket{operation="search",kind="read",le="1"} 1`,
+		`flapjack_request_duration_seconds_bucket{operation="search",kind="read",le="+Inf"} 1`,
+		`flapjack_request_duration_seconds_sum{operation="search",kind="read"} 0.5`,
+		`flapjack_request_duration_seconds_count{operation="search",kind="read"} 1`,
+	} {
+		if !strings.Contains(rec.Body.String(), want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, rec.Body.String())
+		}
+	}
+}
+
+func TestCollectorWithTransport(t *testing.T) {
+	t.Parallel()
+
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer down.Close()
+
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer up.Close()
+
+	collector := prometheus.NewCollector()
+
+	tr := transport.New(transport.Configuration{
+		Hosts: []transport.StatefulHost{
+			transport.NewStatefulHost("http", strings.TrimPrefix(down.URL, "http://"), call.IsReadWrite),
+			transport.NewStatefulHost("http", strings.TrimPrefix(up.URL, "http://"), call.IsReadWrite),
+		},
+		MetricsCollector: collector,
+	})
+
+	ctx := transport.WithOperationName(context.Background(), "getSettings")
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://placeholder/1/indexes/products/settings", nil)
+
+	_, _, err := tr.Request(ctx, req, call.Read, transport.RequestConfiguration{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var sb strings.Builder
+
+	_, _ = collector.WriteTo(&sb)
+
+	for _, want := range []string{
+		`flapjack_requests_total{operation="getSettings",kind="read",status_class="2xx"} 1`,
+		`flapjack_retries_total{operation="getSettings",kind="read"} 1`,
+		`flapjack_host_failovers_total{operation="getSettings",kind="read"} 1`,
+	} {
+		if !strings.Contains(sb.String(), want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, sb.String())
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package search
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// errCopyStopped stops browsing the source index once the copy failed.
+var errCopyStopped = errors.New("copy stopped")
+
+/*
+AccountCopyIndex copies an index, with its settings, synonyms, rules and records, to another application, such as from staging to production.
+The records are browsed from the source index and sent by chunks of `addObject` batches as they are received. The destination index must not exist.
+Within an application, prefer OperationIndex, which copies indices server-side.
+
+	@param sourceIndexName string - Index to copy.
+	@param destination *APIClient - Client of the destination application.
+	@param destinationIndexName string - Index to create in the destination application.
+	@param opts ...ChunkedBatchOption - Optional parameters for the requests.
+	@return error - Error if any.
+*/
+func (c *APIClient) AccountCopyIndex(sourceIndexName string, destination *APIClient, destinationIndexName string, opts ...ChunkedBatchOption) error {
+	conf := config{}
+
+	for _, opt := range opts {
+		opt.apply(&conf)
+	}
+
+	exists, err := destination.IndexExists(destinationIndexName)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		return reportError("destination index %s already exists", destinationIndexName)
+	}
+
+	var taskIDs []int64
+
+	settings, err := c.GetSettings(c.NewApiGetSettingsRequest(sourceIndexName), toRequestOptions(opts)...)
+	if err != nil {
+		return err
+	}
+
+	values, err := jsonValues(settings)
+	if err != nil {
+		return err
+	}
+
+	// Replicas belong to the source index.
+	delete(values, "replicas")
+	delete(values, "primary")
+
+	raw, err := json.Marshal(values)
+	if err != nil {
+		return fmt.Errorf("cannot encode the settings: %w", err)
+	}
+
+	var indexSettings IndexSettings
+
+	err = json.Unmarshal(raw, &indexSettings)
+	if err != nil {
+		return fmt.Errorf("cannot decode the settings: %w", err)
+	}
+
+	res, err := destination.SetSettings(destination.NewApiSetSettingsRequest(destinationIndexName, &indexSettings), toRequestOptions(opts)...)
+	if err != nil {
+		return err
+	}
+
+	taskIDs = append(taskIDs, res.TaskID)
+
+	// Synonyms and rules are saved page by page, the first error stopping the next saves.
+	var saveErr error
+
+	err = c.BrowseSynonyms(sourceIndexName, SearchSynonymsParams{}, append(toIterableOptions(opts), WithAggregator(func(page any, _ error) {
+		if page, ok := page.(*SearchSynonymsResponse); ok && saveErr == nil && len(page.Hits) > 0 {
+			var res *UpdatedAtResponse
+
+			res, saveErr = destination.SaveSynonyms(destination.NewApiSaveSynonymsRequest(destinationIndexName, page.Hits), toRequestOptions(opts)...)
+			if saveErr == nil {
+				taskIDs = append(taskIDs, res.TaskID)
+			}
+		}
+	}))...)
+	if err = errors.Join(err, saveErr); err != nil {
+		return err
+	}
+
+	err = c.BrowseRules(sourceIndexName, SearchRulesParams{}, append(toIterableOptions(opts), WithAggregator(func(page any, _ error) {
+		if page, ok := page.(*SearchRulesResponse); ok && saveErr == nil && len(page.Hits) > 0 {
+			var res *UpdatedAtResponse
+
+			res, saveErr = destination.SaveRules(destination.NewApiSaveRulesRequest(destinationIndexName, page.Hits), toRequestOptions(opts)...)
+			if saveErr == nil {
+				taskIDs = append(taskIDs, res.TaskID)
+			}
+		}
+	}))...)
+	if err = errors.Join(err, saveErr); err != nil {
+		return err
+	}
+
+	err = c.copyRecords(sourceIndexName, destination, destinationIndexName, opts)
+	if err != nil {
+		return err
+	}
+
+	if conf.waitForTasks {
+		return <-destination.WaitForTasks(destinationIndexName, taskIDs, toIterableOptions(opts)...)
+	}
+
+	return nil
+}
+
+// copyRecords browses the records of the source index in the background, while importing them in the destination.
+func (c *APIClient) copyRecords(sourceIndexName string, destination *APIClient, destinationIndexName string, opts []ChunkedBatchOption) error {
+	records := make(chan map[string]any)
+	browseErr := make(chan error, 1)
+	stop := make(chan struct{})
+
+	defer close(stop)
+
+	go func() {
+		defer close(records)
+
+		browseErr <- c.BrowseObjectsStream(sourceIndexName, BrowseParamsObject{}, func(hit json.RawMessage) error {
+			var record map[string]any
+
+			err := json.Unmarshal(hit, &record)
+			if err != nil {
+				return fmt.Errorf("cannot decode record: %w", err)
+			}
+
+			select {
+			case records <- record:
+				return nil
+			case <-stop:
+				return errCopyStopped
+			}
+		}, toRequestOptions(withoutProgress(opts))...)
+	}()
+
+	_, err := destination.importObjects(destinationIndexName, func() (map[string]any, error) {
+		record, ok := <-records
+		if ok {
+			return record, nil
+		}
+
+		err := <-browseErr
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, io.EOF
+	}, opts...)
+
+	return err
+}
+
+// withoutProgress removes the progress function from options, so that only the batches report their progress.
+func withoutProgress(opts []ChunkedBatchOption) []ChunkedBatchOption {
+	return append(append([]ChunkedBatchOption{}, opts...), WithProgress(nil))
+}
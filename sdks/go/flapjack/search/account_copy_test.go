@@ -0,0 +1,86 @@
+package search_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/flapjacktest"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+)
+
+func TestAccountCopyIndex(t *testing.T) {
+	t.Parallel()
+
+	newServer := func() (*flapjacktest.Server, *search.APIClient) {
+		srv := flapjacktest.NewServer()
+		t.Cleanup(srv.Close)
+
+		client, err := srv.NewClient()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		return srv, client
+	}
+
+	staging, stagingClient := newServer()
+	production, productionClient := newServer()
+
+	staging.AddObjects("products", parallelObjects(5)...)
+
+	_, err := stagingClient.SetSettings(stagingClient.NewApiSetSettingsRequest("products", search.NewEmptyIndexSettings().
+		SetSearchableAttributes([]string{"name"}).
+		SetReplicas([]string{"products_by_price"})))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = stagingClient.SaveSynonyms(stagingClient.NewApiSaveSynonymsRequest("products", []search.SynonymHit{
+		*search.NewSynonymHit("crepe", search.SYNONYM_TYPE_SYNONYM, search.WithSynonymHitSynonyms([]string{"crepe", "galette"})),
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = stagingClient.SaveRules(stagingClient.NewApiSaveRulesRequest("products", []search.Rule{
+		*search.NewRule("promote-waffles", *search.NewEmptyConsequence()),
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var calls []progressCall
+
+	err = stagingClient.AccountCopyIndex("products", productionClient, "products",
+		search.WithBatchSize(2), search.WithWaitForTasks(true), search.WithProgress(recordProgress(&calls)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, want := production.Objects("products"), staging.Objects("products"); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected copied objects\n got: %v\nwant: %v", got, want)
+	}
+
+	checkProgress(t, calls, []int{2, 4, 5}, -1)
+
+	want := map[string]any{"searchableAttributes": []any{"name"}}
+	if got := production.Settings("products"); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected copied settings %v", got)
+	}
+
+	synonyms, err := productionClient.SearchSynonyms(productionClient.NewApiSearchSynonymsRequest("products"))
+	if err != nil || len(synonyms.Hits) != 1 {
+		t.Errorf("unexpected copied synonyms %+v: %v", synonyms, err)
+	}
+
+	rules, err := productionClient.SearchRules(productionClient.NewApiSearchRulesRequest("products"))
+	if err != nil || len(rules.Hits) != 1 {
+		t.Errorf("unexpected copied rules %+v: %v", rules, err)
+	}
+
+	err = stagingClient.AccountCopyIndex("products", productionClient, "products")
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("expected an error for the existing destination, got %v", err)
+	}
+}
@@ -0,0 +1,273 @@
+package search
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+var (
+	// ErrAliasNotFound is returned for the aliases missing from an AliasRegistry.
+	ErrAliasNotFound = errors.New("alias not found")
+	// ErrAliasExists is returned by AliasRegistry.CreateAlias for the aliases already registered.
+	ErrAliasExists = errors.New("alias already exists")
+)
+
+const (
+	// DefaultAliasIndex is the default index where an AliasRegistry stores its aliases.
+	DefaultAliasIndex = "flapjack_aliases"
+	// DefaultAliasCacheTTL is the default duration an AliasRegistry caches its aliases for.
+	DefaultAliasCacheTTL = 10 * time.Second
+)
+
+// Alias is an alternative name of an index.
+type Alias struct {
+	Name      string    `json:"objectID"`
+	IndexName string    `json:"indexName"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// AliasRegistryConfig configures an AliasRegistry.
+type AliasRegistryConfig struct {
+	// IndexName is the index storing the aliases, one record per alias,
+	// DefaultAliasIndex when empty.
+	IndexName string
+	// CacheTTL is the duration the aliases are cached for by Resolve,
+	// DefaultAliasCacheTTL when zero. A negative TTL disables the cache.
+	CacheTTL time.Duration
+}
+
+// AliasRegistry maps aliases to indices, so that applications can search "products" while it points to the physical
+// index of the day, and flip it to a new index atomically once a reindex is done. The server has no aliases: they
+// are stored as records of an index of the application, which makes them shared by every client of the application.
+type AliasRegistry struct {
+	client    *APIClient
+	indexName string
+	ttl       time.Duration
+
+	mu       sync.Mutex
+	aliases  map[string]Alias
+	loadedAt time.Time
+}
+
+/*
+NewAliasRegistry creates an alias registry storing its aliases with the given client.
+
+	@param client *APIClient - Client of the application.
+	@param cfg AliasRegistryConfig - Configuration of the registry.
+	@return *AliasRegistry - The alias registry.
+*/
+func NewAliasRegistry(client *APIClient, cfg AliasRegistryConfig) *AliasRegistry {
+	r := &AliasRegistry{client: client, indexName: cfg.IndexName, ttl: cfg.CacheTTL}
+
+	if r.indexName == "" {
+		r.indexName = DefaultAliasIndex
+	}
+
+	if r.ttl == 0 {
+		r.ttl = DefaultAliasCacheTTL
+	}
+
+	return r
+}
+
+/*
+CreateAlias registers a new alias of an index. It fails with ErrAliasExists when the alias is already registered, use MoveAlias to point it to another index.
+
+The existence check and the write are separate calls, as the server has no conditional writes: two clients creating
+the same alias at the same time can both succeed, the last write winning. Create the aliases from a single process when
+this matters.
+
+	@param alias string - The alias.
+	@param indexName string - The index the alias points to.
+	@param opts ...IterableOption - Optional parameters for the requests.
+	@return error - Error if any.
+*/
+func (r *AliasRegistry) CreateAlias(alias string, indexName string, opts ...IterableOption) error {
+	_, err := r.GetAlias(alias, opts...)
+	if err == nil {
+		return fmt.Errorf("%w: %s", ErrAliasExists, alias)
+	}
+
+	if !errors.Is(err, ErrAliasNotFound) {
+		return err
+	}
+
+	return r.MoveAlias(alias, indexName, opts...)
+}
+
+/*
+MoveAlias points an alias to an index, registering it if needed. The alias is switched at once, and MoveAlias returns
+once the change is published on the server, and visible to this registry. Other registries keep resolving the alias
+from their cache until it expires, for up to their CacheTTL (DefaultAliasCacheTTL by default).
+
+	@param alias string - The alias.
+	@param indexName string - The index the alias points to.
+	@param opts ...IterableOption - Optional parameters for the requests.
+	@return error - Error if any.
+*/
+func (r *AliasRegistry) MoveAlias(alias string, indexName string, opts ...IterableOption) error {
+	if alias == "" || indexName == "" {
+		return reportError("`alias` and `indexName` are required to move an alias.")
+	}
+
+	a := Alias{Name: alias, IndexName: indexName, UpdatedAt: r.client.clock().Now().UTC()}
+
+	res, err := r.client.AddOrUpdateObject(r.client.NewApiAddOrUpdateObjectRequest(r.indexName, alias, map[string]any{
+		"indexName": a.IndexName,
+		"updatedAt": a.UpdatedAt,
+	}), toRequestOptions(opts)...)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.client.WaitForTask(r.indexName, res.GetTaskID(), opts...)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	if r.aliases != nil {
+		r.aliases[alias] = a
+	}
+	r.mu.Unlock()
+
+	return nil
+}
+
+/*
+DeleteAlias removes an alias, leaving the index it points to as it is.
+
+	@param alias string - The alias.
+	@param opts ...IterableOption - Optional parameters for the requests.
+	@return error - Error if any.
+*/
+func (r *AliasRegistry) DeleteAlias(alias string, opts ...IterableOption) error {
+	res, err := r.client.DeleteObject(r.client.NewApiDeleteObjectRequest(r.indexName, alias), toRequestOptions(opts)...)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.client.WaitForTask(r.indexName, res.TaskID, opts...)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	delete(r.aliases, alias)
+	r.mu.Unlock()
+
+	return nil
+}
+
+/*
+ListAliases returns all the aliases, sorted by name, and refreshes the cache of Resolve.
+
+	@param opts ...IterableOption - Optional parameters for the requests.
+	@return []Alias - The aliases.
+	@return error - Error if any.
+*/
+func (r *AliasRegistry) ListAliases(opts ...IterableOption) ([]Alias, error) {
+	aliases := map[string]Alias{}
+
+	err := r.client.BrowseObjectsStream(r.indexName, BrowseParamsObject{}, func(hit json.RawMessage) error {
+		var a Alias
+
+		err := json.Unmarshal(hit, &a)
+		if err != nil {
+			return fmt.Errorf("cannot decode alias: %w", err)
+		}
+
+		aliases[a.Name] = a
+
+		return nil
+	}, toRequestOptions(opts)...)
+	if err != nil && !errors.Is(err, transport.ErrNotFound) {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	r.aliases = aliases
+	r.loadedAt = r.client.clock().Now()
+	r.mu.Unlock()
+
+	list := make([]Alias, 0, len(aliases))
+	for _, a := range aliases {
+		list = append(list, a)
+	}
+
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+
+	return list, nil
+}
+
+/*
+Resolve returns the index an alias points to, and the name itself when it is not an alias, so that it can be called on every index name.
+The aliases are cached for the TTL of the registry, the aliases moved by other clients being seen once it expires.
+
+	@param name string - An alias or index name.
+	@param opts ...IterableOption - Optional parameters for the requests.
+	@return string - The index name.
+	@return error - Error if any.
+*/
+func (r *AliasRegistry) Resolve(name string, opts ...IterableOption) (string, error) {
+	r.mu.Lock()
+	fresh := r.aliases != nil && r.ttl > 0 && r.client.clock().Now().Sub(r.loadedAt) < r.ttl
+	a, ok := r.aliases[name]
+	r.mu.Unlock()
+
+	if !fresh {
+		_, err := r.ListAliases(opts...)
+		if err != nil {
+			return "", err
+		}
+
+		r.mu.Lock()
+		a, ok = r.aliases[name]
+		r.mu.Unlock()
+	}
+
+	if !ok {
+		return name, nil
+	}
+
+	return a.IndexName, nil
+}
+
+/*
+GetAlias returns an alias, read from the server. It fails with ErrAliasNotFound when the alias is not registered.
+
+	@param alias string - The alias.
+	@param opts ...IterableOption - Optional parameters for the requests.
+	@return *Alias - The alias.
+	@return error - Error if any.
+*/
+func (r *AliasRegistry) GetAlias(alias string, opts ...IterableOption) (*Alias, error) {
+	obj, err := r.client.GetObject(r.client.NewApiGetObjectRequest(r.indexName, alias), toRequestOptions(opts)...)
+	if errors.Is(err, transport.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %s", ErrAliasNotFound, alias)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("cannot encode alias: %w", err)
+	}
+
+	var a Alias
+
+	err = json.Unmarshal(raw, &a)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode alias: %w", err)
+	}
+
+	return &a, nil
+}
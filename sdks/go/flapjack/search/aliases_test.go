@@ -0,0 +1,139 @@
+package search_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/flapjacktest"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+func TestAliasRegistry(t *testing.T) {
+	t.Parallel()
+
+	srv := flapjacktest.NewServer()
+	t.Cleanup(srv.Close)
+
+	client, err := srv.NewClient()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	registry := search.NewAliasRegistry(client, search.AliasRegistryConfig{})
+
+	// Without aliases, names resolve to themselves.
+	indexName, err := registry.Resolve("products")
+	if err != nil || indexName != "products" {
+		t.Fatalf("expected products, got %q: %v", indexName, err)
+	}
+
+	err = registry.CreateAlias("products", "products_v1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = registry.CreateAlias("products", "products_v2")
+	if !errors.Is(err, search.ErrAliasExists) {
+		t.Errorf("expected ErrAliasExists, got %v", err)
+	}
+
+	indexName, err = registry.Resolve("products")
+	if err != nil || indexName != "products_v1" {
+		t.Fatalf("expected products_v1, got %q: %v", indexName, err)
+	}
+
+	err = registry.MoveAlias("products", "products_v2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The move is seen right away by the registry which made it, and by the other ones once their cache expires.
+	indexName, err = registry.Resolve("products")
+	if err != nil || indexName != "products_v2" {
+		t.Errorf("expected products_v2, got %q: %v", indexName, err)
+	}
+
+	other := search.NewAliasRegistry(client, search.AliasRegistryConfig{CacheTTL: -1})
+
+	alias, err := other.GetAlias("products")
+	if err != nil || alias.IndexName != "products_v2" || time.Since(alias.UpdatedAt) > time.Minute {
+		t.Errorf("unexpected alias %+v: %v", alias, err)
+	}
+
+	err = other.MoveAlias("articles", "articles_v1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	aliases, err := registry.ListAliases()
+	if err != nil || len(aliases) != 2 || aliases[0].Name != "articles" || aliases[1].IndexName != "products_v2" {
+		t.Errorf("unexpected aliases %+v: %v", aliases, err)
+	}
+
+	err = registry.DeleteAlias("products")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = other.GetAlias("products")
+	if !errors.Is(err, search.ErrAliasNotFound) {
+		t.Errorf("expected ErrAliasNotFound, got %v", err)
+	}
+
+	indexName, err = other.Resolve("products")
+	if err != nil || indexName != "products" {
+		t.Errorf("expected products, got %q: %v", indexName, err)
+	}
+}
+
+func TestAliasRegistryCacheExpiresWithClock(t *testing.T) {
+	t.Parallel()
+
+	srv := flapjacktest.NewServer()
+	t.Cleanup(srv.Close)
+
+	clock := flapjacktest.NewClock(time.Time{})
+
+	client, err := srv.NewClient(transport.WithClock(clock))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	registry := search.NewAliasRegistry(client, search.AliasRegistryConfig{})
+	other := search.NewAliasRegistry(client, search.AliasRegistryConfig{})
+
+	err = registry.MoveAlias("products", "products_v1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if alias, err := registry.GetAlias("products"); err != nil || !alias.UpdatedAt.Equal(clock.Now()) {
+		t.Errorf("expected the alias to be updated at %s, got %+v: %v", clock.Now(), alias, err)
+	}
+
+	indexName, err := other.Resolve("products")
+	if err != nil || indexName != "products_v1" {
+		t.Fatalf("expected products_v1, got %q: %v", indexName, err)
+	}
+
+	err = registry.MoveAlias("products", "products_v2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	clock.Advance(search.DefaultAliasCacheTTL - time.Second)
+
+	indexName, err = other.Resolve("products")
+	if err != nil || indexName != "products_v1" {
+		t.Errorf("expected the cached products_v1, got %q: %v", indexName, err)
+	}
+
+	clock.Advance(time.Second)
+
+	indexName, err = other.Resolve("products")
+	if err != nil || indexName != "products_v2" {
+		t.Errorf("expected products_v2 once the cache expired, got %q: %v", indexName, err)
+	}
+}
@@ -0,0 +1,122 @@
+package search_test
+
+import (
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+func TestSetApiKey(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu      sync.Mutex
+		lastKey string
+	)
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		lastKey = r.Header.Get("X-Algolia-API-Key")
+		mu.Unlock()
+
+		_, _ = w.Write([]byte(`{"items":[],"nbPages":0}`))
+	})
+
+	tenant := client.WithConfiguration(transport.ConfigurationOverrides{ApiKey: "tenant-key"})
+	follower := client.WithConfiguration(transport.ConfigurationOverrides{})
+
+	// Rotate the key while requests are in flight.
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_, _ = client.ListIndices(client.NewApiListIndicesRequest())
+		}()
+	}
+
+	err := client.SetApiKey("rotated-key")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wg.Wait()
+
+	tests := []struct {
+		name   string
+		client *search.APIClient
+		want   string
+	}{
+		{name: "client", client: client, want: "rotated-key"},
+		{name: "derived client", client: follower, want: "rotated-key"},
+		{name: "derived client with its own key", client: tenant, want: "tenant-key"},
+	}
+
+	for _, tt := range tests {
+		_, err = tt.client.ListIndices(tt.client.NewApiListIndicesRequest())
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+
+		mu.Lock()
+		got := lastKey
+		mu.Unlock()
+
+		if got != tt.want {
+			t.Errorf("%s: expected the API key %q, got %q", tt.name, tt.want, got)
+		}
+	}
+
+	if err := client.SetApiKey(""); err == nil {
+		t.Error("expected an empty API key to be rejected")
+	}
+}
+
+func TestSetClientApiKey(t *testing.T) {
+	t.Parallel()
+
+	var lastKey atomic.Value
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		lastKey.Store(r.Header.Get("X-Algolia-API-Key"))
+
+		_, _ = w.Write([]byte(`{"items":[],"nbPages":0}`))
+	})
+
+	// Rotate the key while requests are in flight and clients are derived, which the race detector checks.
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			derived := client.WithConfiguration(transport.ConfigurationOverrides{})
+			_, _ = derived.ListIndices(derived.NewApiListIndicesRequest())
+		}()
+	}
+
+	err := client.SetClientApiKey("rotated-key")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wg.Wait()
+
+	_, err = client.ListIndices(client.NewApiListIndicesRequest())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := lastKey.Load(); got != "rotated-key" {
+		t.Errorf("expected the API key %q, got %q", "rotated-key", got)
+	}
+}
@@ -36,19 +36,43 @@ type config struct {
 	timeouts     transport.RequestConfiguration
 
 	// -- ChunkedBatch options
-	waitForTasks bool
-	batchSize    int
+	waitForTasks    bool
+	batchSize       int
+	maxBatchBytes   int
+	maxRecordBytes  int
+	workers         int
+	maxBatchRetries int
+	progress        ProgressFunc
 
 	// -- Partial update options
 	createIfNotExists bool
 
+	// -- SaveStructs options
+	autoGenerateObjectID bool
+
 	// -- ReplaceAllObjects options
 	scopes []ScopeType
 
+	// -- SearchAll options
+	maxHits int
+
+	// -- Browse options
+	cursorStore CursorStore
+	cursorKey   string
+
+	// -- Parallel options
+	maxConcurrentOperations int
+	failFast                bool
+
 	// -- Iterable options
-	maxRetries int
-	timeout    func(int) time.Duration
-	aggregator func(any, error)
+	maxRetries  int
+	timeout     func(int) time.Duration
+	maxDuration time.Duration
+	aggregator  func(any, error)
+	clock       transport.Clock
+
+	// -- WaitForTasks options
+	maxConcurrency int
 
 	// -- WaitForApiKey options
 	apiKey *ApiKey
@@ -64,6 +88,10 @@ func (r requestOption) apply(c *config) {
 	r(c)
 }
 
+// WithContext sets the context used to perform the request, allowing callers to
+// propagate deadlines and cancellation. When given to helpers that issue several
+// calls (e.g. WaitForTask, ChunkedBatch or BrowseObjects), the context is shared
+// by every underlying request and also interrupts the wait between attempts.
 func WithContext(ctx context.Context) requestOption {
 	return requestOption(func(c *config) {
 		c.context = ctx
@@ -100,6 +128,40 @@ func WithConnectTimeout(timeout time.Duration) requestOption {
 	})
 }
 
+// WithExtraHeaders sets headers on the request of this call only, overriding the headers of the same name.
+func WithExtraHeaders(headers map[string]string) requestOption {
+	return requestOption(func(c *config) {
+		if c.timeouts.ExtraHeaders == nil {
+			c.timeouts.ExtraHeaders = make(map[string]string, len(headers))
+		}
+
+		for k, v := range headers {
+			c.timeouts.ExtraHeaders[k] = v
+		}
+	})
+}
+
+// WithExtraQueryParams appends query parameters to the request of this call only.
+func WithExtraQueryParams(params url.Values) requestOption {
+	return requestOption(func(c *config) {
+		if c.timeouts.ExtraQueryParams == nil {
+			c.timeouts.ExtraQueryParams = make(url.Values, len(params))
+		}
+
+		for k, v := range params {
+			c.timeouts.ExtraQueryParams[k] = append(c.timeouts.ExtraQueryParams[k], v...)
+		}
+	})
+}
+
+// WithResponseInfo fills info with the metadata of the response of this call, such as its status, headers, request ID
+// and duration, alongside the decoded body. Helpers issuing several calls fill it with their last call.
+func WithResponseInfo(info *transport.ResponseInfo) requestOption {
+	return requestOption(func(c *config) {
+		c.timeouts.ResponseInfo = info
+	})
+}
+
 // WithBodyParam adds a single custom parameter to the request body.
 // For write requests (POST, PUT, PATCH, DELETE), the param is merged into the body.
 // For read requests (GET), the param is converted to a query parameter.
@@ -400,7 +462,7 @@ func (c *APIClient) AddApiKeyWithHTTPInfo(r ApiAddApiKeyRequest, opts ...Request
 	// body params
 	postBody = r.apiKey
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "addApiKey"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -564,7 +626,7 @@ func (c *APIClient) AddOrUpdateObjectWithHTTPInfo(r ApiAddOrUpdateObjectRequest,
 	// body params
 	postBody = r.body
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPut, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "addOrUpdateObject"), requestPath, http.MethodPut, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -693,7 +755,7 @@ func (c *APIClient) AppendSourceWithHTTPInfo(r ApiAppendSourceRequest, opts ...R
 	// body params
 	postBody = r.source
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "appendSource"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -839,7 +901,7 @@ func (c *APIClient) AssignUserIdWithHTTPInfo(r ApiAssignUserIdRequest, opts ...R
 	// body params
 	postBody = r.assignUserIdParams
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "assignUserId"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -991,7 +1053,7 @@ func (c *APIClient) BatchWithHTTPInfo(r ApiBatchRequest, opts ...RequestOption)
 	// body params
 	postBody = r.batchWriteParams
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "batch"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1148,7 +1210,7 @@ func (c *APIClient) BatchAssignUserIdsWithHTTPInfo(r ApiBatchAssignUserIdsReques
 	// body params
 	postBody = r.batchAssignUserIdsParams
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "batchAssignUserIds"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1292,7 +1354,7 @@ func (c *APIClient) BatchDictionaryEntriesWithHTTPInfo(r ApiBatchDictionaryEntri
 	// body params
 	postBody = r.batchDictionaryEntriesParams
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "batchDictionaryEntries"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1458,7 +1520,7 @@ func (c *APIClient) BrowseWithHTTPInfo(r ApiBrowseRequest, opts ...RequestOption
 		postBody = r.browseParams
 	}
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "browse"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1600,7 +1662,7 @@ func (c *APIClient) ClearObjectsWithHTTPInfo(r ApiClearObjectsRequest, opts ...R
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "clearObjects"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1739,7 +1801,7 @@ func (c *APIClient) ClearRulesWithHTTPInfo(r ApiClearRulesRequest, opts ...Reque
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "clearRules"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1878,7 +1940,7 @@ func (c *APIClient) ClearSynonymsWithHTTPInfo(r ApiClearSynonymsRequest, opts ..
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "clearSynonyms"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -2017,7 +2079,7 @@ func (c *APIClient) CustomDeleteWithHTTPInfo(r ApiCustomDeleteRequest, opts ...R
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodDelete, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "customDelete"), requestPath, http.MethodDelete, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -2153,7 +2215,7 @@ func (c *APIClient) CustomGetWithHTTPInfo(r ApiCustomGetRequest, opts ...Request
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "customGet"), requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -2315,7 +2377,7 @@ func (c *APIClient) CustomPostWithHTTPInfo(r ApiCustomPostRequest, opts ...Reque
 		postBody = r.body
 	}
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "customPost"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -2478,7 +2540,7 @@ func (c *APIClient) CustomPutWithHTTPInfo(r ApiCustomPutRequest, opts ...Request
 		postBody = r.body
 	}
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPut, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "customPut"), requestPath, http.MethodPut, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -2592,7 +2654,7 @@ func (c *APIClient) DeleteApiKeyWithHTTPInfo(r ApiDeleteApiKeyRequest, opts ...R
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodDelete, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "deleteApiKey"), requestPath, http.MethodDelete, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -2739,7 +2801,7 @@ func (c *APIClient) DeleteByWithHTTPInfo(r ApiDeleteByRequest, opts ...RequestOp
 	// body params
 	postBody = r.deleteByParams
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "deleteBy"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -2874,7 +2936,7 @@ func (c *APIClient) DeleteIndexWithHTTPInfo(r ApiDeleteIndexRequest, opts ...Req
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodDelete, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "deleteIndex"), requestPath, http.MethodDelete, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -3020,7 +3082,7 @@ func (c *APIClient) DeleteObjectWithHTTPInfo(r ApiDeleteObjectRequest, opts ...R
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodDelete, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "deleteObject"), requestPath, http.MethodDelete, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -3183,7 +3245,7 @@ func (c *APIClient) DeleteRuleWithHTTPInfo(r ApiDeleteRuleRequest, opts ...Reque
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodDelete, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "deleteRule"), requestPath, http.MethodDelete, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -3302,7 +3364,7 @@ func (c *APIClient) DeleteSourceWithHTTPInfo(r ApiDeleteSourceRequest, opts ...R
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodDelete, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "deleteSource"), requestPath, http.MethodDelete, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -3460,7 +3522,7 @@ func (c *APIClient) DeleteSynonymWithHTTPInfo(r ApiDeleteSynonymRequest, opts ..
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodDelete, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "deleteSynonym"), requestPath, http.MethodDelete, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -3582,7 +3644,7 @@ func (c *APIClient) GetApiKeyWithHTTPInfo(r ApiGetApiKeyRequest, opts ...Request
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "getApiKey"), requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -3698,7 +3760,7 @@ func (c *APIClient) GetAppTaskWithHTTPInfo(r ApiGetAppTaskRequest, opts ...Reque
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "getAppTask"), requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -3775,7 +3837,7 @@ func (c *APIClient) GetDictionaryLanguagesWithHTTPInfo(opts ...RequestOption) (*
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "getDictionaryLanguages"), requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -3850,7 +3912,7 @@ func (c *APIClient) GetDictionarySettingsWithHTTPInfo(opts ...RequestOption) (*h
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "getDictionarySettings"), requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -4041,7 +4103,7 @@ func (c *APIClient) GetLogsWithHTTPInfo(r ApiGetLogsRequest, opts ...RequestOpti
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "getLogs"), requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -4208,7 +4270,7 @@ func (c *APIClient) GetObjectWithHTTPInfo(r ApiGetObjectRequest, opts ...Request
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "getObject"), requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -4338,7 +4400,7 @@ func (c *APIClient) GetObjectsWithHTTPInfo(r ApiGetObjectsRequest, opts ...Reque
 	// body params
 	postBody = r.getObjectsParams
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "getObjects"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -4475,7 +4537,7 @@ func (c *APIClient) GetRuleWithHTTPInfo(r ApiGetRuleRequest, opts ...RequestOpti
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "getRule"), requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -4617,7 +4679,7 @@ func (c *APIClient) GetSettingsWithHTTPInfo(r ApiGetSettingsRequest, opts ...Req
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "getSettings"), requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -4696,7 +4758,7 @@ func (c *APIClient) GetSourcesWithHTTPInfo(opts ...RequestOption) (*http.Respons
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "getSources"), requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -4831,7 +4893,7 @@ func (c *APIClient) GetSynonymWithHTTPInfo(r ApiGetSynonymRequest, opts ...Reque
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "getSynonym"), requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -4969,7 +5031,7 @@ func (c *APIClient) GetTaskWithHTTPInfo(r ApiGetTaskRequest, opts ...RequestOpti
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "getTask"), requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -5057,7 +5119,7 @@ func (c *APIClient) GetTopUserIdsWithHTTPInfo(opts ...RequestOption) (*http.Resp
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "getTopUserIds"), requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -5182,7 +5244,7 @@ func (c *APIClient) GetUserIdWithHTTPInfo(r ApiGetUserIdRequest, opts ...Request
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "getUserId"), requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -5310,7 +5372,7 @@ func (c *APIClient) HasPendingMappingsWithHTTPInfo(r ApiHasPendingMappingsReques
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "hasPendingMappings"), requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -5388,7 +5450,7 @@ func (c *APIClient) ListApiKeysWithHTTPInfo(opts ...RequestOption) (*http.Respon
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "listApiKeys"), requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -5465,7 +5527,7 @@ func (c *APIClient) ListClustersWithHTTPInfo(opts ...RequestOption) (*http.Respo
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "listClusters"), requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -5610,7 +5672,7 @@ func (c *APIClient) ListIndicesWithHTTPInfo(r ApiListIndicesRequest, opts ...Req
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "listIndices"), requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -5761,7 +5823,7 @@ func (c *APIClient) ListUserIdsWithHTTPInfo(r ApiListUserIdsRequest, opts ...Req
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "listUserIds"), requestPath, http.MethodGet, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -5894,7 +5956,7 @@ func (c *APIClient) MultipleBatchWithHTTPInfo(r ApiMultipleBatchRequest, opts ..
 	// body params
 	postBody = r.batchParams
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "multipleBatch"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -6066,7 +6128,7 @@ func (c *APIClient) OperationIndexWithHTTPInfo(r ApiOperationIndexRequest, opts
 	// body params
 	postBody = r.operationIndexParams
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "operationIndex"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -6315,7 +6377,7 @@ func (c *APIClient) PartialUpdateObjectWithHTTPInfo(r ApiPartialUpdateObjectRequ
 	// body params
 	postBody = r.attributesToUpdate
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "partialUpdateObject"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -6472,7 +6534,7 @@ func (c *APIClient) RemoveUserIdWithHTTPInfo(r ApiRemoveUserIdRequest, opts ...R
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodDelete, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "removeUserId"), requestPath, http.MethodDelete, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -6596,7 +6658,7 @@ func (c *APIClient) ReplaceSourcesWithHTTPInfo(r ApiReplaceSourcesRequest, opts
 	// body params
 	postBody = r.source
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPut, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "replaceSources"), requestPath, http.MethodPut, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -6716,7 +6778,7 @@ func (c *APIClient) RestoreApiKeyWithHTTPInfo(r ApiRestoreApiKeyRequest, opts ..
 
 	var postBody any
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "restoreApiKey"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -6871,7 +6933,7 @@ func (c *APIClient) SaveObjectWithHTTPInfo(r ApiSaveObjectRequest, opts ...Reque
 	// body params
 	postBody = r.body
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "saveObject"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -7067,7 +7129,7 @@ func (c *APIClient) SaveRuleWithHTTPInfo(r ApiSaveRuleRequest, opts ...RequestOp
 	// body params
 	postBody = r.rule
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPut, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "saveRule"), requestPath, http.MethodPut, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -7264,7 +7326,7 @@ func (c *APIClient) SaveRulesWithHTTPInfo(r ApiSaveRulesRequest, opts ...Request
 	// body params
 	postBody = r.rules
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "saveRules"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -7456,7 +7518,7 @@ func (c *APIClient) SaveSynonymWithHTTPInfo(r ApiSaveSynonymRequest, opts ...Req
 	// body params
 	postBody = r.synonymHit
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPut, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "saveSynonym"), requestPath, http.MethodPut, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -7651,7 +7713,7 @@ func (c *APIClient) SaveSynonymsWithHTTPInfo(r ApiSaveSynonymsRequest, opts ...R
 	// body params
 	postBody = r.synonymHit
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "saveSynonyms"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -7786,7 +7848,7 @@ func (c *APIClient) SearchWithHTTPInfo(r ApiSearchRequest, opts ...RequestOption
 	// body params
 	postBody = r.searchMethodParams
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "search"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -7932,7 +7994,7 @@ func (c *APIClient) SearchDictionaryEntriesWithHTTPInfo(r ApiSearchDictionaryEnt
 	// body params
 	postBody = r.searchDictionaryEntriesParams
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "searchDictionaryEntries"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -8102,7 +8164,7 @@ func (c *APIClient) SearchForFacetValuesWithHTTPInfo(r ApiSearchForFacetValuesRe
 		postBody = r.searchForFacetValuesRequest
 	}
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "searchForFacetValues"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -8251,7 +8313,7 @@ func (c *APIClient) SearchRulesWithHTTPInfo(r ApiSearchRulesRequest, opts ...Req
 		postBody = r.searchRulesParams
 	}
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "searchRules"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -8396,7 +8458,7 @@ func (c *APIClient) SearchSingleIndexWithHTTPInfo(r ApiSearchSingleIndexRequest,
 		postBody = r.searchParams
 	}
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "searchSingleIndex"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -8541,7 +8603,7 @@ func (c *APIClient) SearchSynonymsWithHTTPInfo(r ApiSearchSynonymsRequest, opts
 		postBody = r.searchSynonymsParams
 	}
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "searchSynonyms"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -8671,7 +8733,7 @@ func (c *APIClient) SearchUserIdsWithHTTPInfo(r ApiSearchUserIdsRequest, opts ..
 	// body params
 	postBody = r.searchUserIdsParams
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "searchUserIds"), requestPath, http.MethodPost, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -8798,7 +8860,7 @@ func (c *APIClient) SetDictionarySettingsWithHTTPInfo(r ApiSetDictionarySettings
 	// body params
 	postBody = r.dictionarySettingsParams
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPut, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "setDictionarySettings"), requestPath, http.MethodPut, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -8966,7 +9028,7 @@ func (c *APIClient) SetSettingsWithHTTPInfo(r ApiSetSettingsRequest, opts ...Req
 	// body params
 	postBody = r.indexSettings
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPut, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "setSettings"), requestPath, http.MethodPut, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -9115,7 +9177,7 @@ func (c *APIClient) UpdateApiKeyWithHTTPInfo(r ApiUpdateApiKeyRequest, opts ...R
 	// body params
 	postBody = r.apiKey
 
-	req, err := c.prepareRequest(conf.context, requestPath, http.MethodPut, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "updateApiKey"), requestPath, http.MethodPut, postBody, conf.bodyParams, conf.headerParams, conf.queryParams)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -9200,6 +9262,13 @@ func WithBatchSize(batchSize int) chunkedBatchOption {
 	})
 }
 
+// WithMaxBatchBytes the maximum size, in bytes, of the JSON-encoded `objects` sent in a single `batch` call. A chunk is sent as soon as adding the next object would exceed this limit, even if it holds less than `batchSize` objects. Defaults to 0 (no limit).
+func WithMaxBatchBytes(maxBatchBytes int) chunkedBatchOption {
+	return chunkedBatchOption(func(c *config) {
+		c.maxBatchBytes = maxBatchBytes
+	})
+}
+
 // --------- Iterable options ---------.
 
 type IterableOption interface {
@@ -9243,6 +9312,35 @@ func WithAggregator(aggregator func(any, error)) iterableOption {
 	})
 }
 
+// WithExponentialBackoff waits `initial` before the first retry and doubles the delay on every subsequent retry, capped at `maxDelay`. It replaces the function given to WithTimeout.
+func WithExponentialBackoff(initial time.Duration, maxDelay time.Duration) iterableOption {
+	return iterableOption(func(c *config) {
+		c.timeout = func(count int) time.Duration {
+			delay := initial
+
+			for i := 1; i < count && delay < maxDelay; i++ {
+				delay *= 2
+			}
+
+			return min(delay, maxDelay)
+		}
+	})
+}
+
+// WithMaxDuration the maximum total duration of the iterable, across all retries. Default to no limit.
+func WithMaxDuration(maxDuration time.Duration) iterableOption {
+	return iterableOption(func(c *config) {
+		c.maxDuration = maxDuration
+	})
+}
+
+// WithClock the clock measuring the duration of the iterable and waiting between retries. Default to the clock of the client for its helpers, transport.SystemClock otherwise.
+func WithClock(clock transport.Clock) iterableOption {
+	return iterableOption(func(c *config) {
+		c.clock = clock
+	})
+}
+
 func CreateIterable[T any](execute func(*T, error) (*T, error), validate func(*T, error) (bool, error), opts ...IterableOption) (*T, error) {
 	conf := config{
 		headerParams: map[string]string{},
@@ -9250,15 +9348,22 @@ func CreateIterable[T any](execute func(*T, error) (*T, error), validate func(*T
 		timeout: func(count int) time.Duration {
 			return 0 * time.Millisecond
 		},
+		clock: transport.SystemClock{},
 	}
 
 	for _, opt := range opts {
 		opt.apply(&conf)
 	}
 
+	ctx := conf.context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
 	var executor func(*T, error) (*T, error)
 
 	retryCount := 0
+	start := conf.clock.Now()
 
 	executor = func(previousResponse *T, previousError error) (*T, error) {
 		response, responseErr := execute(previousResponse, previousError)
@@ -9278,7 +9383,18 @@ func CreateIterable[T any](execute func(*T, error) (*T, error), validate func(*T
 			return nil, errs.NewWaitError(fmt.Sprintf("The maximum number of retries exceeded. (%d/%d)", retryCount, conf.maxRetries))
 		}
 
-		time.Sleep(conf.timeout(retryCount))
+		delay := conf.timeout(retryCount)
+
+		if conf.maxDuration > 0 && conf.clock.Now().Sub(start)+delay > conf.maxDuration {
+			return nil, errs.NewWaitError(fmt.Sprintf("The maximum duration exceeded. (%s)", conf.maxDuration))
+		}
+
+		// Wait before the next attempt, unless the caller's context is done,
+		// in which case there is no point in polling any further.
+		err = conf.clock.Sleep(ctx, delay)
+		if err != nil {
+			return nil, fmt.Errorf("iterable interrupted after %d attempt(s): %w", retryCount, err)
+		}
 
 		return executor(response, responseErr)
 	}
@@ -9354,24 +9470,7 @@ func (c *APIClient) WaitForTask(
 	taskID int64,
 	opts ...IterableOption,
 ) (*GetTaskResponse, error) {
-	// provide a default timeout function
-	opts = append([]IterableOption{WithTimeout(func(count int) time.Duration {
-		return time.Duration(min(200*count, 5000)) * time.Millisecond
-	}), WithMaxRetries(50)}, opts...)
-
-	return CreateIterable(
-		func(*GetTaskResponse, error) (*GetTaskResponse, error) {
-			return c.GetTask(c.NewApiGetTaskRequest(indexName, taskID), toRequestOptions(opts)...)
-		},
-		func(response *GetTaskResponse, err error) (bool, error) {
-			if err != nil || response == nil {
-				return false, err
-			}
-
-			return response.Status == TASK_STATUS_PUBLISHED, nil
-		},
-		opts...,
-	)
+	return c.waitForTask(context.Background(), indexName, taskID, nil, opts...)
 }
 
 /*
@@ -9391,7 +9490,7 @@ func (c *APIClient) WaitForAppTask(
 	// provide a default timeout function
 	opts = append([]IterableOption{WithTimeout(func(count int) time.Duration {
 		return time.Duration(min(200*count, 5000)) * time.Millisecond
-	}), WithMaxRetries(50)}, opts...)
+	}), WithMaxRetries(50), WithClock(c.clock())}, opts...)
 
 	return CreateIterable(
 		func(*GetTaskResponse, error) (*GetTaskResponse, error) {
@@ -9524,7 +9623,7 @@ func (c *APIClient) WaitForApiKey(
 	// provide a default timeout function
 	opts = append([]WaitForApiKeyOption{WithTimeout(func(count int) time.Duration {
 		return time.Duration(min(200*count, 5000)) * time.Millisecond
-	}), WithMaxRetries(50)}, opts...)
+	}), WithMaxRetries(50), WithClock(c.clock())}, opts...)
 
 	return CreateIterable(
 		func(*GetApiKeyResponse, error) (*GetApiKeyResponse, error) {
@@ -9537,7 +9636,7 @@ func (c *APIClient) WaitForApiKey(
 
 /*
 BrowseObjects allows to aggregate all the hits returned by the API calls.
-Use the `WithAggregator` option to collect all the responses.
+Use the `WithAggregator` option to collect all the responses, `WithProgress` to follow the browse, and `WithCursorCheckpoint` to resume it once interrupted.
 
 	@param indexName string - Index name.
 	@param browseParams BrowseParamsObject - Browse parameters.
@@ -9554,24 +9653,47 @@ func (c *APIClient) BrowseObjects(
 		browseParams.HitsPerPage = utils.ToPtr(int32(1000))
 	}
 
-	_, err := CreateIterable(
+	conf := config{}
+
+	for _, opt := range opts {
+		opt.apply(&conf)
+	}
+
+	progress := progressTracker{fn: conf.progress, total: -1}
+	checkpoint := newCursorCheckpoint(conf)
+
+	err := checkpoint.resume(&browseParams)
+	if err != nil {
+		return err
+	}
+
+	_, err = CreateIterable(
 		func(previousResponse *BrowseResponse, previousErr error) (*BrowseResponse, error) {
 			if previousResponse != nil {
 				browseParams.Cursor = previousResponse.Cursor
 			}
 
-			return c.Browse(
+			res, err := c.Browse(
 				c.NewApiBrowseRequest(indexName).WithBrowseParams(BrowseParamsObjectAsBrowseParams(&browseParams)),
 				toRequestOptions(opts)...,
 			)
+			if err == nil && progress.fn != nil {
+				progress.addHits(res.Hits, res.NbHits)
+			}
+
+			return res, err
 		},
 		func(response *BrowseResponse, err error) (bool, error) {
+			if err == nil && response != nil {
+				err = checkpoint.save(response.Cursor)
+			}
+
 			return err != nil || response != nil && response.Cursor == nil, err
 		},
 		opts...,
 	)
 
-	return err
+	return checkpoint.invalidated(err)
 }
 
 /*
@@ -9782,6 +9904,7 @@ func (c *APIClient) GetSecuredApiKeyRemainingValidity(securedApiKey string) (tim
 
 /*
 Helper: Saves the given array of objects in the given index. The `chunkedBatch` helper is used under the hood, which creates a `batch` requests with at most 1000 objects in it.
+Use `ToObjects` to convert a slice of structs to the expected format.
 
 	@param indexName string - the index name to save objects into.
 	@param objects []map[string]any - List of objects to save.
@@ -9812,8 +9935,150 @@ func (c *APIClient) DeleteObjects(indexName string, objectIDs []string, opts ...
 	return c.ChunkedBatch(indexName, objects, ACTION_DELETE_OBJECT, opts...)
 }
 
+/*
+Helper: Retrieves the records with the given objectIDs from the given index in a single `getObjects` call. Records are returned in the same order as `objectIDs`, with a nil entry for every objectID that doesn't exist.
+
+	@param indexName string - the index name to retrieve the records from.
+	@param objectIDs []string - the objectIDs of the records to retrieve.
+	@param attributesToRetrieve []string - Attributes to retrieve, all retrievable attributes when empty.
+	@param opts ...RequestOption - Optional parameters for the request.
+	@return []map[string]any - The retrieved records.
+	@return error - Error if any.
+*/
+func (c *APIClient) GetObjectsByIDs(indexName string, objectIDs []string, attributesToRetrieve []string, opts ...RequestOption) ([]map[string]any, error) {
+	if len(objectIDs) == 0 {
+		return []map[string]any{}, nil
+	}
+
+	requests := make([]GetObjectsRequest, 0, len(objectIDs))
+
+	for _, objectID := range objectIDs {
+		request := NewGetObjectsRequest(objectID, indexName)
+		if len(attributesToRetrieve) > 0 {
+			request.SetAttributesToRetrieve(attributesToRetrieve)
+		}
+
+		requests = append(requests, *request)
+	}
+
+	resp, err := c.GetObjects(c.NewApiGetObjectsRequest(NewGetObjectsParams(requests)), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.Results) != len(objectIDs) {
+		return nil, reportError("expected %d records from `getObjects`, got %d", len(objectIDs), len(resp.Results))
+	}
+
+	return resp.Results, nil
+}
+
+/*
+Helper: Copies the `source` index to `destination`, overwriting it. When `scopes` is empty, records, settings, synonyms and rules are all copied, otherwise only the given scopes are, leaving the destination records unchanged. Waits for the operation to be processed when `WithWaitForTasks(true)` is given.
+
+	@param source string - the index to copy.
+	@param destination string - the index to copy to.
+	@param scopes []ScopeType - the scopes to copy, everything when empty.
+	@param opts ...ChunkedBatchOption - Optional parameters for the request.
+	@return *UpdatedAtResponse - The response of the operationIndex call.
+	@return error - Error if any.
+*/
+func (c *APIClient) CopyIndex(source string, destination string, scopes []ScopeType, opts ...ChunkedBatchOption) (*UpdatedAtResponse, error) {
+	params := NewOperationIndexParams(OPERATION_TYPE_COPY, destination)
+	if len(scopes) > 0 {
+		params.SetScope(scopes)
+	}
+
+	return c.operationIndex(source, params, opts...)
+}
+
+/*
+Helper: Copies the settings of the `source` index to `destination`. See CopyIndex.
+
+	@param source string - the index to copy the settings from.
+	@param destination string - the index to copy the settings to.
+	@param opts ...ChunkedBatchOption - Optional parameters for the request.
+	@return *UpdatedAtResponse - The response of the operationIndex call.
+	@return error - Error if any.
+*/
+func (c *APIClient) CopySettings(source string, destination string, opts ...ChunkedBatchOption) (*UpdatedAtResponse, error) {
+	return c.CopyIndex(source, destination, []ScopeType{SCOPE_TYPE_SETTINGS}, opts...)
+}
+
+/*
+Helper: Copies the synonyms of the `source` index to `destination`. See CopyIndex.
+
+	@param source string - the index to copy the synonyms from.
+	@param destination string - the index to copy the synonyms to.
+	@param opts ...ChunkedBatchOption - Optional parameters for the request.
+	@return *UpdatedAtResponse - The response of the operationIndex call.
+	@return error - Error if any.
+*/
+func (c *APIClient) CopySynonyms(source string, destination string, opts ...ChunkedBatchOption) (*UpdatedAtResponse, error) {
+	return c.CopyIndex(source, destination, []ScopeType{SCOPE_TYPE_SYNONYMS}, opts...)
+}
+
+/*
+Helper: Copies the rules of the `source` index to `destination`. See CopyIndex.
+
+	@param source string - the index to copy the rules from.
+	@param destination string - the index to copy the rules to.
+	@param opts ...ChunkedBatchOption - Optional parameters for the request.
+	@return *UpdatedAtResponse - The response of the operationIndex call.
+	@return error - Error if any.
+*/
+func (c *APIClient) CopyRules(source string, destination string, opts ...ChunkedBatchOption) (*UpdatedAtResponse, error) {
+	return c.CopyIndex(source, destination, []ScopeType{SCOPE_TYPE_RULES}, opts...)
+}
+
+/*
+Helper: Renames the `source` index to `destination`, replacing it if it exists. Waits for the operation to be processed when `WithWaitForTasks(true)` is given.
+
+	@param source string - the index to move.
+	@param destination string - the new name of the index.
+	@param opts ...ChunkedBatchOption - Optional parameters for the request.
+	@return *UpdatedAtResponse - The response of the operationIndex call.
+	@return error - Error if any.
+*/
+func (c *APIClient) MoveIndex(source string, destination string, opts ...ChunkedBatchOption) (*UpdatedAtResponse, error) {
+	return c.operationIndex(source, NewOperationIndexParams(OPERATION_TYPE_MOVE, destination), opts...)
+}
+
+func (c *APIClient) operationIndex(source string, params *OperationIndexParams, opts ...ChunkedBatchOption) (*UpdatedAtResponse, error) {
+	if params.Destination == "" {
+		return nil, reportError("Parameter `destination` is required when calling `OperationIndex`.")
+	}
+
+	if params.Destination == source {
+		return nil, reportError("Parameter `destination` must differ from the source index when calling `OperationIndex`.")
+	}
+
+	conf := config{
+		headerParams: map[string]string{},
+	}
+
+	for _, opt := range opts {
+		opt.apply(&conf)
+	}
+
+	resp, err := c.OperationIndex(c.NewApiOperationIndexRequest(source, params), toRequestOptions(opts)...)
+	if err != nil {
+		return nil, err
+	}
+
+	if conf.waitForTasks {
+		_, err = c.WaitForTask(source, resp.TaskID, toIterableOptions(opts)...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
+
 /*
 Helper: Replaces object content of all the given objects according to their respective `objectID` field. The `chunkedBatch` helper is used under the hood, which creates a `batch` requests with at most 1000 objects in it.
+An error is returned before any request is sent if one of the objects has no `objectID`.
 
 	@param indexName string - the index name to save objects into.
 	@param objects []map[string]any - List of objects to save.
@@ -9844,6 +10109,7 @@ func (c *APIClient) PartialUpdateObjects(indexName string, objects []map[string]
 
 /*
 ChunkedBatch chunks the given `objects` list in subset of 1000 elements max in order to make it fit in `batch` requests.
+Use `WithMaxBatchBytes` to also bound the payload size of each `batch` request, and `WithProgress` to follow the upload.
 
 	@param indexName string - the index name to save objects into.
 	@param objects []map[string]any - List of objects to save.
@@ -9863,20 +10129,71 @@ func (c *APIClient) ChunkedBatch(indexName string, objects []map[string]any, act
 		opt.apply(&conf)
 	}
 
-	requests := make([]BatchRequest, 0, len(objects)%conf.batchSize)
-	responses := make([]BatchResponse, 0, len(objects)%conf.batchSize)
+	switch action {
+	case ACTION_UPDATE_OBJECT, ACTION_PARTIAL_UPDATE_OBJECT, ACTION_PARTIAL_UPDATE_OBJECT_NO_CREATE, ACTION_DELETE_OBJECT:
+		err := requireObjectIDs(objects)
+		if err != nil {
+			return nil, err
+		}
+	default:
+	}
+
+	if conf.maxRecordBytes > 0 {
+		err := validateRecordSizes(objects, conf.maxRecordBytes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	requests := make([]BatchRequest, 0, min(len(objects), conf.batchSize))
+	responses := make([]BatchResponse, 0, len(objects)/max(conf.batchSize, 1)+1)
+	requestsBytes := 0
+	done, doneBytes := 0, int64(0)
+
+	flush := func() error {
+		resp, err := c.Batch(c.NewApiBatchRequest(indexName, NewBatchWriteParams(requests)), toRequestOptions(opts)...)
+		if err != nil {
+			return err
+		}
+
+		if conf.progress != nil {
+			done += len(requests)
+			doneBytes += int64(requestsBytes)
+			conf.progress(done, len(objects), doneBytes)
+		}
+
+		responses = append(responses, *resp)
+		requests = make([]BatchRequest, 0, min(len(objects), conf.batchSize))
+		requestsBytes = 0
+
+		return nil
+	}
 
 	for i, obj := range objects {
+		objBytes := 0
+
+		if conf.maxBatchBytes > 0 || conf.progress != nil {
+			raw, err := json.Marshal(obj)
+			if err != nil {
+				return nil, fmt.Errorf("cannot compute the size of object at position %d: %w", i, err)
+			}
+
+			objBytes = len(raw)
+
+			if conf.maxBatchBytes > 0 && len(requests) > 0 && requestsBytes+objBytes > conf.maxBatchBytes {
+				if err := flush(); err != nil {
+					return nil, err
+				}
+			}
+		}
+
 		requests = append(requests, *NewBatchRequest(action, obj))
+		requestsBytes += objBytes
 
 		if len(requests) == conf.batchSize || i == len(objects)-1 {
-			resp, err := c.Batch(c.NewApiBatchRequest(indexName, NewBatchWriteParams(requests)), toRequestOptions(opts)...)
-			if err != nil {
+			if err := flush(); err != nil {
 				return nil, err
 			}
-
-			responses = append(responses, *resp)
-			requests = make([]BatchRequest, 0, len(objects)%conf.batchSize)
 		}
 	}
 
@@ -0,0 +1,260 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/utils"
+)
+
+// ErrCursorInvalidated is returned by the browses whose cursor the engine rejects because the index changed since the
+// cursor was returned, by a write or by a merge of its segments. The saved cursor is cleared, so the browse starts over
+// when retried.
+var ErrCursorInvalidated = errors.New("browse cursor invalidated by a change of the index")
+
+// CursorStore persists the cursors of browses under a key, so that an interrupted export resumes from its last page
+// instead of starting over.
+type CursorStore interface {
+	// LoadCursor returns the cursor saved under key, empty when there is none.
+	LoadCursor(ctx context.Context, key string) (string, error)
+	// SaveCursor saves the cursor of the next page to browse under key, empty once the browse is complete.
+	SaveCursor(ctx context.Context, key string, cursor string) error
+}
+
+// WithCursorCheckpoint sets the store where BrowseObjects, BrowseObjectsStream, ExportNDJSON and the iterator of BrowseAll save, under `key`, the cursor of the next page once all the records of a page were handled. A browse without a cursor in its parameters resumes from the saved cursor. The engine invalidates the cursors on any write or merge of the index, so a checkpoint only resumes a browse of an index left unchanged, and ErrCursorInvalidated is returned otherwise. Defaults to no checkpoint.
+func WithCursorCheckpoint(store CursorStore, key string) requestOption {
+	return requestOption(func(c *config) {
+		c.cursorStore = store
+		c.cursorKey = key
+	})
+}
+
+// cursorCheckpoint saves the cursors of a browse in its CursorStore, it does nothing when nil.
+type cursorCheckpoint struct {
+	ctx   context.Context
+	store CursorStore
+	key   string
+}
+
+func newCursorCheckpoint(conf config) *cursorCheckpoint {
+	if conf.cursorStore == nil {
+		return nil
+	}
+
+	ctx := conf.context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return &cursorCheckpoint{ctx: ctx, store: conf.cursorStore, key: conf.cursorKey}
+}
+
+// resume sets the saved cursor on the browse parameters, unless they already have one.
+func (c *cursorCheckpoint) resume(params *BrowseParamsObject) error {
+	if c == nil || params.Cursor != nil {
+		return nil
+	}
+
+	cursor, err := c.store.LoadCursor(c.ctx, c.key)
+	if err != nil {
+		return reportError("cannot load the cursor %q: %w", c.key, err)
+	}
+
+	if cursor != "" {
+		params.Cursor = utils.ToPtr(cursor)
+	}
+
+	return nil
+}
+
+// save saves the cursor of the next page, nil after the last page.
+func (c *cursorCheckpoint) save(cursor *string) error {
+	if c == nil {
+		return nil
+	}
+
+	var value string
+	if cursor != nil {
+		value = *cursor
+	}
+
+	err := c.store.SaveCursor(c.ctx, c.key, value)
+	if err != nil {
+		return reportError("cannot save the cursor %q: %w", c.key, err)
+	}
+
+	return nil
+}
+
+// invalidated clears the saved cursor and returns ErrCursorInvalidated when the engine rejected the cursor of the browse, or returns err as is.
+func (c *cursorCheckpoint) invalidated(err error) error {
+	var apiErr *transport.APIError
+	if !errors.As(err, &apiErr) || apiErr.Status != http.StatusBadRequest || !strings.Contains(apiErr.Message, "Cursor is not valid anymore") {
+		return err
+	}
+
+	return errors.Join(fmt.Errorf("%w: %w", ErrCursorInvalidated, err), c.save(nil))
+}
+
+/*
+BrowseIterator walks all the records of an index page after page, fetching each page when the records of the previous one are consumed:
+
+	it := client.BrowseAll("products", search.BrowseParamsObject{})
+	for it.Next() {
+		fmt.Println(it.Hit().ObjectID)
+	}
+
+	if err := it.Err(); err != nil {
+		log.Printf("interrupted, resume from %q", it.Cursor())
+	}
+
+It is not safe for concurrent use.
+*/
+type BrowseIterator struct {
+	client     *APIClient
+	indexName  string
+	params     BrowseParamsObject
+	opts       []RequestOption
+	checkpoint *cursorCheckpoint
+
+	res        *BrowseResponse
+	pageCursor *string
+	pending    []Hit
+	hit        Hit
+	started    bool
+	done       bool
+	finished   bool
+	err        error
+}
+
+/*
+BrowseAll returns an iterator over all the records of an index, from the cursor of `params` on, or the cursor saved by WithCursorCheckpoint when it has none.
+
+	@param indexName string - Index name.
+	@param params BrowseParamsObject - Browse parameters, with the cursor to resume from if any.
+	@param opts ...RequestOption - Optional parameters for the requests, such as WithCursorCheckpoint.
+	@return *BrowseIterator - The iterator, which performs no request until Next is called.
+*/
+func (c *APIClient) BrowseAll(indexName string, params BrowseParamsObject, opts ...RequestOption) *BrowseIterator {
+	conf := config{}
+
+	for _, opt := range opts {
+		opt.apply(&conf)
+	}
+
+	if params.HitsPerPage == nil {
+		params.HitsPerPage = utils.ToPtr(int32(1000))
+	}
+
+	return &BrowseIterator{
+		client:     c,
+		indexName:  indexName,
+		params:     params,
+		opts:       opts,
+		checkpoint: newCursorCheckpoint(conf),
+	}
+}
+
+// Next advances to the next record, fetching the next page if needed, and returns false at the end of the records or on error.
+func (it *BrowseIterator) Next() bool {
+	for {
+		if it.err != nil {
+			return false
+		}
+
+		if len(it.pending) > 0 {
+			it.hit, it.pending = it.pending[0], it.pending[1:]
+
+			return true
+		}
+
+		if it.done {
+			it.finish()
+
+			return false
+		}
+
+		it.fetch()
+	}
+}
+
+// Hit returns the current record.
+func (it *BrowseIterator) Hit() Hit {
+	return it.hit
+}
+
+// Response returns the response of the last fetched page, or nil before the first page.
+func (it *BrowseIterator) Response() *BrowseResponse {
+	return it.res
+}
+
+/*
+Cursor returns the cursor to set in the browse parameters to resume the iteration, empty to start over or once the last page was consumed.
+It is the cursor of the current page while some of its records were not returned by Next yet, so resuming may return the start of that page again but never skips a record.
+*/
+func (it *BrowseIterator) Cursor() string {
+	cursor := it.params.Cursor
+	if len(it.pending) > 0 {
+		cursor = it.pageCursor
+	}
+
+	if cursor == nil {
+		return ""
+	}
+
+	return *cursor
+}
+
+// Err returns the error which stopped the iteration, if any.
+func (it *BrowseIterator) Err() error {
+	return it.err
+}
+
+// finish saves that the browse is complete, once all the records of the last page were returned.
+func (it *BrowseIterator) finish() {
+	if it.finished {
+		return
+	}
+
+	it.finished = true
+	it.err = it.checkpoint.save(nil)
+}
+
+// fetch saves the cursor of the page to fetch, fetches it, and marks the iteration as done after the last one.
+func (it *BrowseIterator) fetch() {
+	if it.started {
+		it.err = it.checkpoint.save(it.params.Cursor)
+	} else {
+		it.started = true
+		it.err = it.checkpoint.resume(&it.params)
+	}
+
+	if it.err != nil {
+		return
+	}
+
+	params := it.params
+
+	res, err := it.client.Browse(it.client.NewApiBrowseRequest(it.indexName).WithBrowseParams(BrowseParamsObjectAsBrowseParams(&params)), it.opts...)
+	if err != nil {
+		it.err = it.checkpoint.invalidated(err)
+		if errors.Is(it.err, ErrCursorInvalidated) {
+			it.params.Cursor = nil
+		}
+
+		return
+	}
+
+	it.res = res
+	it.pageCursor = it.params.Cursor
+	it.pending = res.Hits
+	it.params.Cursor = res.Cursor
+
+	if res.Cursor == nil {
+		it.done = true
+	}
+}
@@ -0,0 +1,225 @@
+package search_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/flapjacktest"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/utils"
+)
+
+// memoryCursorStore keeps the cursors in memory and records every save.
+type memoryCursorStore struct {
+	cursors map[string]string
+	saves   []string
+	err     error
+}
+
+func (s *memoryCursorStore) LoadCursor(_ context.Context, key string) (string, error) {
+	return s.cursors[key], nil
+}
+
+func (s *memoryCursorStore) SaveCursor(_ context.Context, key string, cursor string) error {
+	if s.err != nil {
+		return s.err
+	}
+
+	if s.cursors == nil {
+		s.cursors = map[string]string{}
+	}
+
+	s.cursors[key] = cursor
+	s.saves = append(s.saves, cursor)
+
+	return nil
+}
+
+// newBrowseServer returns a client of a fake server with 5 records, browsed 2 per page.
+func newBrowseServer(t *testing.T) (*search.APIClient, search.BrowseParamsObject) {
+	t.Helper()
+
+	srv := flapjacktest.NewServer()
+	t.Cleanup(srv.Close)
+
+	for i := 1; i <= 5; i++ {
+		srv.AddObjects("products", map[string]any{"objectID": strconv.Itoa(i)})
+	}
+
+	client, err := srv.NewClient()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return client, search.BrowseParamsObject{HitsPerPage: utils.ToPtr(int32(2))}
+}
+
+func TestBrowseAll(t *testing.T) {
+	t.Parallel()
+
+	client, params := newBrowseServer(t)
+	store := &memoryCursorStore{}
+
+	it := client.BrowseAll("products", params, search.WithCursorCheckpoint(store, "export"))
+
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Hit().ObjectID)
+	}
+
+	if err := it.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := []string{"1", "2", "3", "4", "5"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("expected %v, got %v", want, ids)
+	}
+
+	if want := []string{"1", "2", ""}; !reflect.DeepEqual(store.saves, want) {
+		t.Errorf("expected the cursors %q to be saved, got %q", want, store.saves)
+	}
+
+	if it.Cursor() != "" {
+		t.Errorf("expected no cursor once complete, got %q", it.Cursor())
+	}
+}
+
+func TestBrowseAllResume(t *testing.T) {
+	t.Parallel()
+
+	client, params := newBrowseServer(t)
+
+	it := client.BrowseAll("products", params)
+	for i := 0; i < 3; i++ {
+		if !it.Next() {
+			t.Fatalf("expected a record, got %v", it.Err())
+		}
+	}
+
+	// The 4th record is left on the current page, which is browsed again.
+	cursor := it.Cursor()
+	if cursor != "1" {
+		t.Fatalf("expected the cursor of the second page, got %q", cursor)
+	}
+
+	params.Cursor = &cursor
+	it = client.BrowseAll("products", params)
+
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Hit().ObjectID)
+	}
+
+	if want := []string{"3", "4", "5"}; it.Err() != nil || !reflect.DeepEqual(ids, want) {
+		t.Errorf("expected %v, got %v: %v", want, ids, it.Err())
+	}
+}
+
+func TestBrowseObjectsStreamResumesFromCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	client, params := newBrowseServer(t)
+	store := &memoryCursorStore{cursors: map[string]string{"export": "2"}}
+
+	var ids []string
+
+	err := client.BrowseObjectsStream("products", params, func(hit json.RawMessage) error {
+		var record struct {
+			ObjectID string `json:"objectID"`
+		}
+
+		err := json.Unmarshal(hit, &record)
+		ids = append(ids, record.ObjectID)
+
+		return err
+	}, search.WithCursorCheckpoint(store, "export"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(ids, []string{"5"}) || store.cursors["export"] != "" {
+		t.Errorf("expected to resume from the last page, got %v and the cursor %q", ids, store.cursors["export"])
+	}
+}
+
+func TestBrowseObjectsCheckpointError(t *testing.T) {
+	t.Parallel()
+
+	client, params := newBrowseServer(t)
+	errStore := errors.New("store unavailable")
+	pages := 0
+
+	err := client.BrowseObjects("products", params,
+		search.WithCursorCheckpoint(&memoryCursorStore{err: errStore}, "export"),
+		search.WithAggregator(func(any, error) { pages++ }),
+	)
+	if !errors.Is(err, errStore) {
+		t.Fatalf("expected the error of the store, got %v", err)
+	}
+
+	if pages != 1 {
+		t.Errorf("expected the browse to stop after the first page, got %d pages", pages)
+	}
+}
+
+func TestBrowseInvalidatedCursor(t *testing.T) {
+	t.Parallel()
+
+	// The engine rejects the cursors once the index changed, here after the first page.
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var params map[string]any
+
+		_ = json.NewDecoder(r.Body).Decode(&params)
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if params["cursor"] != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"Cursor is not valid anymore (index modified)","status":400}`))
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"hits":[{"objectID":"1"}],"cursor":"next","page":0,"nbHits":2,"nbPages":2,"hitsPerPage":1,"processingTimeMS":1,"query":"","params":""}`))
+	})
+
+	browses := map[string]func(store search.CursorStore) error{
+		"BrowseAll": func(store search.CursorStore) error {
+			it := client.BrowseAll("products", search.BrowseParamsObject{}, search.WithCursorCheckpoint(store, "export"))
+			for it.Next() {
+				// The records of the first page are consumed before the cursor is rejected.
+			}
+
+			if it.Cursor() != "" {
+				t.Errorf("expected to start over, got the cursor %q", it.Cursor())
+			}
+
+			return it.Err()
+		},
+		"BrowseObjects": func(store search.CursorStore) error {
+			return client.BrowseObjects("products", search.BrowseParamsObject{}, search.WithCursorCheckpoint(store, "export"))
+		},
+		"BrowseObjectsStream": func(store search.CursorStore) error {
+			return client.BrowseObjectsStream("products", search.BrowseParamsObject{}, func(json.RawMessage) error { return nil },
+				search.WithCursorCheckpoint(store, "export"))
+		},
+	}
+
+	for name, browse := range browses {
+		store := &memoryCursorStore{}
+
+		err := browse(store)
+		if !errors.Is(err, search.ErrCursorInvalidated) {
+			t.Errorf("%s: expected the cursor to be invalidated, got %v", name, err)
+		}
+
+		if want := []string{"next", ""}; !reflect.DeepEqual(store.saves, want) {
+			t.Errorf("%s: expected the saved cursor to be cleared, got the saves %q", name, store.saves)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package search
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/utils"
+)
+
+// withStream makes the transport return the body of a successful response unread.
+func withStream() requestOption {
+	return requestOption(func(c *config) {
+		c.timeouts.Stream = true
+	})
+}
+
+/*
+BrowseObjectsStream browses all the records of an index, like BrowseObjects, but decodes every page from the response
+stream and gives the records to `fn` one at a time, as raw JSON, instead of buffering whole pages. The memory used is
+bounded by the largest record, which suits exports of large indices.
+
+Browsing stops at the first error returned by `fn`, which is then returned. Use `WithProgress` to follow the export, and
+`WithCursorCheckpoint` to resume it from its last complete page once interrupted.
+
+	@param indexName string - Index name.
+	@param browseParams BrowseParamsObject - Browse parameters.
+	@param fn func(json.RawMessage) error - Function called for every record.
+	@param opts ...RequestOption - Optional parameters for the requests.
+	@return error - Error if any.
+*/
+func (c *APIClient) BrowseObjectsStream(
+	indexName string,
+	browseParams BrowseParamsObject,
+	fn func(hit json.RawMessage) error,
+	opts ...RequestOption,
+) error {
+	if browseParams.HitsPerPage == nil {
+		browseParams.HitsPerPage = utils.ToPtr(int32(1000))
+	}
+
+	conf := config{}
+
+	for _, opt := range opts {
+		opt.apply(&conf)
+	}
+
+	opts = append(opts, withStream())
+	progress := progressTracker{fn: conf.progress, total: -1}
+	checkpoint := newCursorCheckpoint(conf)
+
+	err := checkpoint.resume(&browseParams)
+	if err != nil {
+		return err
+	}
+
+	for {
+		records, bytes := 0, int64(0)
+
+		cursor, nbHits, err := c.browsePageStream(indexName, browseParams, func(hit json.RawMessage) error {
+			records++
+			bytes += int64(len(hit))
+
+			return fn(hit)
+		}, opts...)
+		if err != nil {
+			return checkpoint.invalidated(err)
+		}
+
+		if progress.fn != nil {
+			progress.add(records, bytes, nbHits)
+		}
+
+		err = checkpoint.save(cursor)
+		if err != nil {
+			return err
+		}
+
+		if cursor == nil {
+			return nil
+		}
+
+		browseParams.Cursor = cursor
+	}
+}
+
+// browsePageStream browses a single page and returns its cursor, nil on the last page, and the number of hits, if any.
+func (c *APIClient) browsePageStream(indexName string, browseParams BrowseParamsObject, fn func(hit json.RawMessage) error, opts ...RequestOption) (*string, *int32, error) {
+	res, resBody, err := c.BrowseWithHTTPInfo(
+		c.NewApiBrowseRequest(indexName).WithBrowseParams(BrowseParamsObjectAsBrowseParams(&browseParams)),
+		opts...,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if res == nil {
+		return nil, nil, reportError("res is nil")
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 300 {
+		return nil, nil, c.decodeError(res, resBody)
+	}
+
+	cursor, nbHits, err := decodeBrowseStream(res.Body, fn)
+	if err != nil {
+		var fnErr *browseFnError
+		if errors.As(err, &fnErr) {
+			return nil, nil, fnErr.err
+		}
+
+		return nil, nil, reportError("cannot decode result: %w", err)
+	}
+
+	return cursor, nbHits, nil
+}
+
+// decodeBrowseStream reads a browse response, calling fn for each of its hits, and returns its cursor and number of
+// hits.
+func decodeBrowseStream(r io.Reader, fn func(hit json.RawMessage) error) (*string, *int32, error) {
+	dec := json.NewDecoder(r)
+
+	err := expectDelim(dec, '{')
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		cursor *string
+		nbHits *int32
+	)
+
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot read key: %w", err)
+		}
+
+		switch token {
+		case "hits":
+			err = decodeHitsStream(dec, fn)
+		case "cursor":
+			err = dec.Decode(&cursor)
+		case "nbHits":
+			err = dec.Decode(&nbHits)
+		default:
+			var skipped json.RawMessage
+			err = dec.Decode(&skipped)
+		}
+
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return cursor, nbHits, expectDelim(dec, '}')
+}
+
+func decodeHitsStream(dec *json.Decoder, fn func(hit json.RawMessage) error) error {
+	err := expectDelim(dec, '[')
+	if err != nil {
+		return err
+	}
+
+	for dec.More() {
+		var hit json.RawMessage
+
+		err = dec.Decode(&hit)
+		if err != nil {
+			return fmt.Errorf("cannot decode hit: %w", err)
+		}
+
+		err = fn(hit)
+		if err != nil {
+			return &browseFnError{err: err}
+		}
+	}
+
+	return expectDelim(dec, ']')
+}
+
+// browseFnError is an error returned by the function given to BrowseObjectsStream, returned as is.
+type browseFnError struct {
+	err error
+}
+
+func (e *browseFnError) Error() string {
+	return e.err.Error()
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("cannot read %q: %w", delim, err)
+	}
+
+	if token != delim {
+		return fmt.Errorf("unexpected token %v, expected %s", token, delim)
+	}
+
+	return nil
+}
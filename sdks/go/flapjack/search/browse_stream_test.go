@@ -0,0 +1,102 @@
+package search_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+)
+
+func TestBrowseObjectsStream(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		var params map[string]any
+		_ = json.Unmarshal(body, &params)
+
+		switch calls.Add(1) {
+		case 1:
+			if params["cursor"] != nil || params["hitsPerPage"] != float64(1000) {
+				t.Errorf("unexpected first page params %v", params)
+			}
+
+			_, _ = w.Write([]byte(`{"page":0,"hits":[{"objectID":"1","tags":["a",{"b":[1]}]},{"objectID":"2"}],"nbHits":3,"cursor":"next"}`))
+		case 2:
+			if params["cursor"] != "next" {
+				t.Errorf("unexpected second page params %v", params)
+			}
+
+			_, _ = w.Write([]byte(`{"cursor":null,"hits":[{"objectID":"3"}],"processingTimeMS":1}`))
+		default:
+			t.Errorf("unexpected call %d", calls.Load())
+		}
+	})
+
+	var ids []string
+
+	err := client.BrowseObjectsStream("products", search.BrowseParamsObject{}, func(hit json.RawMessage) error {
+		var record struct {
+			ObjectID string `json:"objectID"`
+		}
+
+		err := json.Unmarshal(hit, &record)
+		ids = append(ids, record.ObjectID)
+
+		return err
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(ids) != 3 || ids[0] != "1" || ids[1] != "2" || ids[2] != "3" {
+		t.Errorf("unexpected records %v", ids)
+	}
+}
+
+func TestBrowseObjectsStreamStopsOnError(t *testing.T) {
+	t.Parallel()
+
+	errStop := errors.New("stop")
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"hits":[{"objectID":"1"},{"objectID":"2"}],"cursor":"next"}`))
+	})
+
+	count := 0
+
+	err := client.BrowseObjectsStream("products", search.BrowseParamsObject{}, func(hit json.RawMessage) error {
+		count++
+
+		return errStop
+	})
+	if !errors.Is(err, errStop) || count != 1 {
+		t.Errorf("expected to stop after the first record, got %v after %d records", err, count)
+	}
+}
+
+func TestBrowseObjectsStreamError(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Index products does not exist"}`))
+	})
+
+	err := client.BrowseObjectsStream("products", search.BrowseParamsObject{}, func(hit json.RawMessage) error {
+		return nil
+	})
+
+	var apiErr *search.APIError
+	if !errors.As(err, &apiErr) || apiErr.Message != "Index products does not exist" {
+		t.Errorf("expected an API error, got %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+package search_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+)
+
+func TestChunkedBatch(t *testing.T) {
+	t.Parallel()
+
+	objects := make([]map[string]any, 0, 10)
+	for i := 0; i < 10; i++ {
+		objects = append(objects, map[string]any{"objectID": string(rune('a' + i)), "text": strings.Repeat("x", 100)})
+	}
+
+	tests := []struct {
+		name     string
+		opts     []search.ChunkedBatchOption
+		expected []int
+	}{
+		{
+			name:     "default batch size",
+			expected: []int{10},
+		},
+		{
+			name:     "batch size",
+			opts:     []search.ChunkedBatchOption{search.WithBatchSize(4)},
+			expected: []int{4, 4, 2},
+		},
+		{
+			name:     "max batch bytes",
+			opts:     []search.ChunkedBatchOption{search.WithMaxBatchBytes(350)},
+			expected: []int{2, 2, 2, 2, 2},
+		},
+		{
+			name:     "object larger than max batch bytes is sent alone",
+			opts:     []search.ChunkedBatchOption{search.WithMaxBatchBytes(10), search.WithBatchSize(3)},
+			expected: []int{1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				mu    sync.Mutex
+				sizes []int
+			)
+
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				var body search.BatchWriteParams
+
+				_ = json.NewDecoder(r.Body).Decode(&body)
+
+				mu.Lock()
+				sizes = append(sizes, len(body.Requests))
+				mu.Unlock()
+
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"taskID":1,"objectIDs":[]}`))
+			})
+
+			responses, err := client.ChunkedBatch("products", objects, search.ACTION_ADD_OBJECT, tt.opts...)
+			if err != nil {
+				t.Fatalf("ChunkedBatch failed: %v", err)
+			}
+
+			if len(responses) != len(tt.expected) {
+				t.Fatalf("expected %d responses, got %d", len(tt.expected), len(responses))
+			}
+
+			for i, want := range tt.expected {
+				if sizes[i] != want {
+					t.Errorf("batch %d: expected %d requests, got %d (all: %v)", i, want, sizes[i], sizes)
+				}
+			}
+		})
+	}
+}
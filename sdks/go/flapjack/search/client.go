@@ -3,9 +3,7 @@ package search
 
 import (
 	"bytes"
-	"compress/gzip"
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -16,7 +14,6 @@ import (
 	"time"
 
 	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/call"
-	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/compression"
 	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/ingestion"
 	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
 	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/utils"
@@ -29,19 +26,27 @@ type APIClient struct {
 	cfg                  *SearchConfiguration
 	transport            *transport.Transport
 	ingestionTransporter *ingestion.APIClient
+	// timeouts are the default timeouts of the calls of a derived client.
+	timeouts transport.RequestConfiguration
+	apiKey   *transport.APIKey
 }
 
-// NewClient creates a new API client with appID and apiKey.
-func NewClient(appID, apiKey string) (*APIClient, error) {
-	return NewClientWithConfig(SearchConfiguration{
+// NewClient creates a new API client with appID and apiKey, customized with the given options.
+func NewClient(appID, apiKey string, opts ...transport.ClientOption) (*APIClient, error) {
+	cfg := SearchConfiguration{
 		Configuration: transport.Configuration{
 			AppID:         appID,
 			ApiKey:        apiKey,
 			DefaultHeader: make(map[string]string),
 			UserAgent:     getUserAgent(),
-			Requester:     transport.NewDefaultRequester(nil),
 		},
-	})
+	}
+
+	for _, opt := range opts {
+		opt(&cfg.Configuration)
+	}
+
+	return NewClientWithConfig(cfg)
 }
 
 // NewClientWithConfig creates a new API client with the given configuration to fully customize the client behaviour.
@@ -58,6 +63,12 @@ func NewClientWithConfig(cfg SearchConfiguration) (*APIClient, error) {
 		cfg.Hosts = getDefaultHosts(cfg.AppID)
 	}
 
+	if cfg.Failover != nil && len(cfg.Failover.Hosts) == 0 {
+		failover := *cfg.Failover
+		failover.Hosts = getDefaultHosts(failover.AppID)
+		cfg.Failover = &failover
+	}
+
 	if cfg.UserAgent == "" {
 		cfg.UserAgent = getUserAgent()
 	}
@@ -74,12 +85,22 @@ func NewClientWithConfig(cfg SearchConfiguration) (*APIClient, error) {
 		cfg.WriteTimeout = 30000 * time.Millisecond
 	}
 
+	if cfg.Codec == nil {
+		cfg.Codec = transport.JSONCodec{}
+	}
+
+	err := cfg.Validate()
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
 	apiClient := APIClient{
 		appID: cfg.AppID,
 		cfg:   &cfg,
 		transport: transport.New(
 			cfg.Configuration,
 		),
+		apiKey: transport.NewAPIKey(cfg.ApiKey),
 	}
 
 	if cfg.Transformation != nil && cfg.Transformation.Region != "" {
@@ -87,6 +108,7 @@ func NewClientWithConfig(cfg SearchConfiguration) (*APIClient, error) {
 			Configuration: transport.Configuration{
 				AppID:  cfg.AppID,
 				ApiKey: cfg.ApiKey,
+				Codec:  cfg.Codec,
 			},
 			Region: cfg.Transformation.Region,
 		}
@@ -131,19 +153,99 @@ func (c *APIClient) AddDefaultHeader(key string, value string) {
 	c.cfg.DefaultHeader[key] = value
 }
 
+// AddUserAgentSegment appends the segment of an integration built on top of the client, such as `Laravel Scout (10.0.0)`,
+// to the user agent of the requests. A segment already present is not added again. Like AddDefaultHeader, it must be
+// called before sending requests.
+func (c *APIClient) AddUserAgentSegment(name, version string) {
+	c.cfg.UserAgent = transport.AppendUserAgentSegment(c.cfg.UserAgent, transport.UserAgentSegment(name, version))
+
+	if c.ingestionTransporter != nil {
+		c.ingestionTransporter.AddUserAgentSegment(name, version)
+	}
+}
+
 // Allow modification of underlying config for alternate implementations and testing.
 // Caution: modifying the configuration while live can cause data races and potentially unwanted behavior.
 func (c *APIClient) GetConfiguration() *SearchConfiguration {
 	return c.cfg
 }
 
-// Allow update of stored API key used to authenticate requests.
+// clock returns the clock of the client, timing the retries of its helpers and its caches.
+func (c *APIClient) clock() transport.Clock {
+	return transport.ClockOf(c.cfg.Configuration)
+}
+
+// GetHostStatuses returns the current health of the hosts of the client, for observability.
+func (c *APIClient) GetHostStatuses() []transport.HostStatus {
+	return c.transport.HostStatuses()
+}
+
+// LastRateLimit returns the rate limit reported by the last response with rate limit headers, so that batch jobs can
+// slow down before being rate limited. Derived clients share it, as they share the transport.
+func (c *APIClient) LastRateLimit() (transport.RateLimitInfo, bool) {
+	return c.transport.LastRateLimit()
+}
+
+// Close releases the resources of the client for a clean shutdown: it stops the background probing of the hosts and
+// closes the idle connections. The calls started afterwards fail with transport.ErrClientClosed. Derived clients share
+// the transport of the client, and are closed with it.
+func (c *APIClient) Close() error {
+	if c.ingestionTransporter != nil {
+		_ = c.ingestionTransporter.Close()
+	}
+
+	return c.transport.Close()
+}
+
+// WithConfiguration returns a client derived from this one with the given settings overridden, such as the API key of
+// a tenant in multi-tenant applications. The derived client is cheap to create: it shares the transport, connection pool
+// and host health of this client.
+func (c *APIClient) WithConfiguration(overrides transport.ConfigurationOverrides) *APIClient {
+	cfg := *c.cfg
+
+	var timeouts transport.RequestConfiguration
+	cfg.Configuration, timeouts = overrides.Apply(c.cfg.Configuration)
+
+	derived := *c
+	derived.cfg = &cfg
+	derived.timeouts = timeouts.InheritTimeouts(c.timeouts)
+
+	if overrides.ApiKey != "" {
+		derived.apiKey = transport.NewAPIKey(overrides.ApiKey)
+	}
+
+	if c.ingestionTransporter != nil {
+		derived.ingestionTransporter = c.ingestionTransporter.WithConfiguration(overrides)
+	}
+
+	return &derived
+}
+
+// Allow update of stored API key used to authenticate requests. Like SetApiKey, it is safe for concurrent use: the key
+// is swapped atomically, and the configuration returned by GetConfiguration keeps the key the client was created with.
+//
+// Deprecated: use SetApiKey.
 func (c *APIClient) SetClientApiKey(apiKey string) error {
 	if c.cfg == nil {
 		return errors.New("client config is not set")
 	}
 
-	c.cfg.ApiKey = apiKey
+	return c.SetApiKey(apiKey)
+}
+
+// SetApiKey replaces the API key used to authenticate the requests. It is safe to call while requests are in flight, so
+// that long-lived services can rotate their credentials without recreating the client and losing its connection pool
+// and host health. Derived clients without an API key of their own use the new key too.
+func (c *APIClient) SetApiKey(apiKey string) error {
+	if apiKey == "" {
+		return errors.New("`apiKey` is missing.")
+	}
+
+	c.apiKey.Set(apiKey)
+
+	if c.ingestionTransporter != nil {
+		return c.ingestionTransporter.SetApiKey(apiKey) //nolint:wrapcheck
+	}
 
 	return nil
 }
@@ -159,7 +261,7 @@ func (c *APIClient) callAPI(
 		callKind = call.Read
 	}
 
-	resp, body, err := c.transport.Request(request.Context(), request, callKind, requestConfiguration)
+	resp, body, err := c.transport.Request(request.Context(), request, callKind, requestConfiguration.InheritTimeouts(c.timeouts))
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to do request: %w", err)
 	}
@@ -195,7 +297,7 @@ func (c *APIClient) prepareRequest(
 		}
 	}
 
-	body, err := setBody(finalBody, c.cfg.Compression)
+	body, err := setBody(finalBody, c.cfg.Codec)
 	if err != nil {
 		return nil, fmt.Errorf("failed to set the body: %w", err)
 	}
@@ -241,7 +343,7 @@ func (c *APIClient) prepareRequest(
 	// Add the user agent to the request.
 	req.Header.Add("User-Agent", c.cfg.UserAgent)
 	req.Header.Add("X-Algolia-Application-Id", c.cfg.AppID)
-	req.Header.Add("X-Algolia-API-Key", c.cfg.ApiKey)
+	req.Header.Add("X-Algolia-API-Key", c.apiKey.Get())
 	req.Header.Add("Content-Type", contentType)
 	req.Header.Add("Accept", contentType)
 
@@ -278,7 +380,7 @@ func (c *APIClient) decode(v any, b []byte) error {
 			return errors.New("unknown type with GetActualInstance but no unmarshalObj.UnmarshalJSON defined")
 		}
 	} else { // simple model
-		err := json.Unmarshal(b, v)
+		err := c.cfg.Codec.Unmarshal(b, v)
 		if err != nil {
 			return fmt.Errorf("failed to unmarshal response body: %w", err)
 		}
@@ -288,31 +390,7 @@ func (c *APIClient) decode(v any, b []byte) error {
 }
 
 func (c *APIClient) decodeError(res *http.Response, body []byte) error {
-	apiErr := &APIError{
-		Message: string(body), // default to the full body if we cannot guess the type of the error.
-		Status:  res.StatusCode,
-	}
-
-	if strings.Contains(res.Header.Get("Content-Type"), "application/json") {
-		var errBase ErrorBase
-
-		err := c.decode(&errBase, body)
-		if err != nil {
-			apiErr.Message = err.Error()
-
-			return apiErr
-		}
-
-		if errBase.Message != nil {
-			apiErr.Message = *errBase.Message
-		}
-
-		apiErr.AdditionalProperties = errBase.AdditionalProperties
-	} else if strings.Contains(res.Header.Get("Content-Type"), "text/html") {
-		apiErr.Message = http.StatusText(res.StatusCode)
-	}
-
-	return apiErr
+	return transport.NewAPIError(res, body)
 }
 
 // Prevent trying to import "fmt".
@@ -321,7 +399,8 @@ func reportError(format string, a ...any) error {
 }
 
 // Set request body from an any.
-func setBody(body any, c compression.Compression) (*bytes.Buffer, error) {
+// The body is compressed by the transport, according to the configuration.
+func setBody(body any, codec transport.Codec) (*bytes.Buffer, error) {
 	if body == nil {
 		return nil, nil
 	}
@@ -330,24 +409,16 @@ func setBody(body any, c compression.Compression) (*bytes.Buffer, error) {
 
 	var err error
 
-	switch c {
-	case compression.GZIP:
-		gzipWriter := gzip.NewWriter(bodyBuf)
-		defer gzipWriter.Close()
-
-		err = json.NewEncoder(gzipWriter).Encode(body)
-	default:
-		if reader, ok := body.(io.Reader); ok {
-			_, err = bodyBuf.ReadFrom(reader)
-		} else if b, ok := body.([]byte); ok {
-			_, err = bodyBuf.Write(b)
-		} else if s, ok := body.(string); ok {
-			_, err = bodyBuf.WriteString(s)
-		} else if s, ok := body.(*string); ok {
-			_, err = bodyBuf.WriteString(*s)
-		} else {
-			err = json.NewEncoder(bodyBuf).Encode(body)
-		}
+	if reader, ok := body.(io.Reader); ok {
+		_, err = bodyBuf.ReadFrom(reader)
+	} else if b, ok := body.([]byte); ok {
+		_, err = bodyBuf.Write(b)
+	} else if s, ok := body.(string); ok {
+		_, err = bodyBuf.WriteString(s)
+	} else if s, ok := body.(*string); ok {
+		_, err = bodyBuf.WriteString(*s)
+	} else {
+		err = encodeBody(bodyBuf, body, codec)
 	}
 
 	if err != nil {
@@ -361,60 +432,17 @@ func setBody(body any, c compression.Compression) (*bytes.Buffer, error) {
 	return bodyBuf, nil
 }
 
-type APIError struct {
-	Message              string         `json:"message"`
-	Status               int            `json:"status"`
-	AdditionalProperties map[string]any `json:"-"`
-}
-
-func (e APIError) Error() string {
-	return fmt.Sprintf("API error [%d] %s", e.Status, e.Message)
-}
-
-func (o APIError) MarshalJSON() ([]byte, error) {
-	toSerialize := map[string]any{
-		"message": o.Message,
-	}
-
-	for key, value := range o.AdditionalProperties {
-		toSerialize[key] = value
-	}
-
-	serialized, err := json.Marshal(toSerialize)
+// encodeBody writes the body encoded with the given codec.
+func encodeBody(w io.Writer, body any, codec transport.Codec) error {
+	b, err := codec.Marshal(body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal APIError: %w", err)
+		return err //nolint:wrapcheck
 	}
 
-	return serialized, nil
-}
-
-func (o *APIError) UnmarshalJSON(bytes []byte) error {
-	type _APIError APIError
-
-	apiErr := _APIError{}
-
-	err := json.Unmarshal(bytes, &apiErr)
-	if err != nil {
-		return fmt.Errorf("failed to unmarshal APIError: %w", err)
-	}
+	_, err = w.Write(b)
 
-	*o = APIError(apiErr)
-
-	additionalProperties := make(map[string]any)
-
-	err = json.Unmarshal(bytes, &additionalProperties)
-	if err != nil {
-		return fmt.Errorf("failed to unmarshal additionalProperties in APIError: %w", err)
-	}
-
-	delete(additionalProperties, "message")
-	o.AdditionalProperties = additionalProperties
-
-	return nil
+	return err //nolint:wrapcheck
 }
 
-func (a APIError) Is(target error) bool {
-	_, ok := target.(*APIError)
-
-	return ok
-}
+// APIError is the error returned for responses with a non-2xx status.
+type APIError = transport.APIError
@@ -0,0 +1,126 @@
+package search_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/call"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+func TestNewClientWithOptions(t *testing.T) {
+	t.Parallel()
+
+	var userAgent, customHeader string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		customHeader = r.Header.Get("X-Custom")
+
+		_, _ = w.Write([]byte(`{"items":[],"nbPages":0}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := search.NewClient("test-app", "test-api-key",
+		transport.WithHosts(transport.NewStatefulHost("http", strings.TrimPrefix(srv.URL, "http://"), call.IsReadWrite)),
+		transport.WithReadTimeout(time.Second),
+		transport.WithUserAgentSegment("MyApp (1.2.0)"),
+		transport.WithDefaultHeader("X-Custom", "value"),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	if got := client.GetConfiguration().ReadTimeout; got != time.Second {
+		t.Errorf("expected a read timeout of 1s, got %s", got)
+	}
+
+	if got := client.GetConfiguration().WriteTimeout; got != 30*time.Second {
+		t.Errorf("expected the default write timeout, got %s", got)
+	}
+
+	_, err = client.ListIndices(client.NewApiListIndicesRequest())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.HasPrefix(userAgent, "Flapjack for Go") || !strings.HasSuffix(userAgent, "; MyApp (1.2.0)") {
+		t.Errorf("expected the user agent segment to be appended, got %q", userAgent)
+	}
+
+	if customHeader != "value" {
+		t.Errorf("expected the default header to be sent, got %q", customHeader)
+	}
+}
+
+func TestNewClientWithConfigValidatesConfiguration(t *testing.T) {
+	t.Parallel()
+
+	_, err := search.NewClientWithConfig(search.SearchConfiguration{
+		Configuration: transport.Configuration{
+			AppID:        "test-app",
+			ApiKey:       "test-api-key",
+			Hosts:        []transport.StatefulHost{transport.NewStatefulHost("http", "localhost:7700/api", call.IsReadWrite)},
+			WriteTimeout: -time.Second,
+		},
+	})
+	if err == nil {
+		t.Fatal("expected the configuration to be rejected")
+	}
+
+	for _, want := range []string{"without scheme or path", "`WriteTimeout` must not be negative"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected the error to contain %q, got %v", want, err)
+		}
+	}
+}
+
+func TestAddUserAgentSegment(t *testing.T) {
+	t.Parallel()
+
+	var userAgent string
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+
+		_, _ = w.Write([]byte(`{"items":[],"nbPages":0}`))
+	})
+
+	client.AddUserAgentSegment("Scout", "10.0.0")
+	client.AddUserAgentSegment("Scout", "10.0.0")
+
+	_, err := client.ListIndices(client.NewApiListIndicesRequest())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.HasSuffix(userAgent, "Search (4.36.0); Scout (10.0.0)") {
+		t.Errorf("expected the segment to be appended once, got %q", userAgent)
+	}
+}
+
+func TestClose(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	})
+
+	derived := client.WithConfiguration(transport.ConfigurationOverrides{ApiKey: "tenant-key"})
+
+	if err := client.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, c := range []*search.APIClient{client, derived} {
+		_, err := c.GetSettings(c.NewApiGetSettingsRequest("products"))
+		if !errors.Is(err, transport.ErrClientClosed) {
+			t.Errorf("expected ErrClientClosed, got %v", err)
+		}
+	}
+}
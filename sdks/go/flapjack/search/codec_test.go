@@ -0,0 +1,73 @@
+package search_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/call"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+// countingCodec counts the calls to the default codec.
+type countingCodec struct {
+	transport.JSONCodec
+
+	marshals   atomic.Int32
+	unmarshals atomic.Int32
+}
+
+func (c *countingCodec) Marshal(v any) ([]byte, error) {
+	c.marshals.Add(1)
+
+	return c.JSONCodec.Marshal(v)
+}
+
+func (c *countingCodec) Unmarshal(data []byte, v any) error {
+	c.unmarshals.Add(1)
+
+	return c.JSONCodec.Unmarshal(data, v)
+}
+
+func TestCustomCodec(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"hits":[{"objectID":"1"}],"nbHits":1}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	codec := &countingCodec{}
+
+	client, err := search.NewClientWithConfig(search.SearchConfiguration{
+		Configuration: transport.Configuration{
+			AppID:  "test-app",
+			ApiKey: "test-api-key",
+			Hosts: []transport.StatefulHost{
+				transport.NewStatefulHost("http", strings.TrimPrefix(srv.URL, "http://"), call.IsReadWrite),
+			},
+			DefaultHeader: make(map[string]string),
+			Codec:         codec,
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	res, err := client.SearchSingleIndex(client.NewApiSearchSingleIndexRequest("products").
+		WithSearchParams(search.SearchParamsObjectAsSearchParams(search.NewEmptySearchParamsObject().SetQuery("phone"))))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(res.Hits) != 1 || res.Hits[0].ObjectID != "1" {
+		t.Errorf("unexpected hits %v", res.Hits)
+	}
+
+	if codec.marshals.Load() != 1 || codec.unmarshals.Load() != 1 {
+		t.Errorf("expected the codec to encode and decode once, got %d and %d", codec.marshals.Load(), codec.unmarshals.Load())
+	}
+}
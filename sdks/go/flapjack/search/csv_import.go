@@ -0,0 +1,313 @@
+package search
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// DefaultCSVInferenceRows is the default number of rows ImportCSV reads to infer the types of the columns.
+const DefaultCSVInferenceRows = 100
+
+// CSVColumnType is the type the values of a CSV column are converted to by ImportCSV.
+type CSVColumnType string
+
+const (
+	CSVColumnString  CSVColumnType = "string"
+	CSVColumnNumber  CSVColumnType = "number"
+	CSVColumnBoolean CSVColumnType = "boolean"
+)
+
+// CSVImportConfig configures ImportCSV.
+type CSVImportConfig struct {
+	// Comma is the field delimiter, ',' when zero.
+	Comma rune
+	// ObjectIDColumn is the column whose values are the objectIDs of the
+	// records, instead of being an attribute. When empty, a column named
+	// `objectID` is used, if any.
+	ObjectIDColumn string
+	// Schema sets the type of some columns. The type of the other ones is
+	// inferred from their first InferenceRows values: number when they are
+	// all numbers, boolean when they are all `true` or `false`, and string
+	// otherwise.
+	Schema map[string]CSVColumnType
+	// InferenceRows is the number of rows read to infer the types of the
+	// columns, DefaultCSVInferenceRows when zero.
+	InferenceRows int
+}
+
+/*
+ImportCSV streams a CSV file, whose first row is the header, into `addObject` batches of the given index, chunked like ChunkedBatch.
+The values of the number and boolean columns are converted, and their empty values omitted.
+
+	@param indexName string - the index name to save objects into.
+	@param r io.Reader - The CSV file.
+	@param cfg CSVImportConfig - The configuration of the import.
+	@param opts ...ChunkedBatchOption - Optional parameters for the request.
+	@return []BatchResponse - List of batch responses.
+	@return error - Error if any.
+*/
+func (c *APIClient) ImportCSV(indexName string, r io.Reader, cfg CSVImportConfig, opts ...ChunkedBatchOption) ([]BatchResponse, error) {
+	reader := csv.NewReader(r)
+
+	if cfg.Comma != 0 {
+		reader.Comma = cfg.Comma
+	}
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the CSV header: %w", err)
+	}
+
+	objectIDColumn := cfg.ObjectIDColumn
+	if objectIDColumn == "" {
+		objectIDColumn = "objectID"
+	}
+
+	inferenceRows := cfg.InferenceRows
+	if inferenceRows <= 0 {
+		inferenceRows = DefaultCSVInferenceRows
+	}
+
+	// Read the rows used to infer the types of the columns ahead.
+	var rows [][]string
+
+	for len(rows) < inferenceRows {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("cannot read the CSV file: %w", err)
+		}
+
+		rows = append(rows, row)
+	}
+
+	types := csvColumnTypes(header, rows, cfg.Schema, objectIDColumn)
+	line := 1
+
+	return c.importObjects(indexName, func() (map[string]any, error) {
+		var row []string
+
+		if len(rows) > 0 {
+			row, rows = rows[0], rows[1:]
+		} else {
+			var err error
+
+			row, err = reader.Read()
+			if err != nil {
+				return nil, err //nolint:wrapcheck
+			}
+		}
+
+		line++
+
+		return csvRecord(header, row, types, objectIDColumn, line)
+	}, opts...)
+}
+
+// importObjects sends the records returned by `next`, until io.EOF, by chunks of `addObject` batches. It waits for
+// the tasks at the end and reports the progress of the whole import.
+func (c *APIClient) importObjects(indexName string, next func() (map[string]any, error), opts ...ChunkedBatchOption) ([]BatchResponse, error) {
+	conf := config{batchSize: 1000}
+
+	for _, opt := range opts {
+		opt.apply(&conf)
+	}
+
+	responses := []BatchResponse{}
+	objects := make([]map[string]any, 0, conf.batchSize)
+	done, doneBytes := 0, int64(0)
+
+	flush := func() error {
+		chunkOpts := append(append([]ChunkedBatchOption{}, opts...), WithWaitForTasks(false))
+		chunkBytes := int64(0)
+
+		if conf.progress != nil {
+			chunkOpts = append(chunkOpts, WithProgress(func(chunkDone, _ int, bytes int64) {
+				chunkBytes = bytes
+				conf.progress(done+chunkDone, -1, doneBytes+bytes)
+			}))
+		}
+
+		res, err := c.ChunkedBatch(indexName, objects, ACTION_ADD_OBJECT, chunkOpts...)
+		if err != nil {
+			return err
+		}
+
+		done += len(objects)
+		doneBytes += chunkBytes
+		responses = append(responses, res...)
+		objects = make([]map[string]any, 0, conf.batchSize)
+
+		return nil
+	}
+
+	for {
+		obj, err := next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return responses, fmt.Errorf("cannot import the records: %w", err)
+		}
+
+		objects = append(objects, obj)
+
+		if len(objects) == max(conf.batchSize, 1) {
+			err = flush()
+			if err != nil {
+				return responses, err
+			}
+		}
+	}
+
+	if len(objects) > 0 {
+		err := flush()
+		if err != nil {
+			return responses, err
+		}
+	}
+
+	if conf.waitForTasks {
+		taskIDs := make([]int64, 0, len(responses))
+		for _, res := range responses {
+			taskIDs = append(taskIDs, res.TaskID)
+		}
+
+		err := <-c.WaitForTasks(indexName, taskIDs, toIterableOptions(opts)...)
+		if err != nil {
+			return responses, err
+		}
+	}
+
+	return responses, nil
+}
+
+// csvColumnTypes returns the type of every column, from the schema or inferred from the given rows.
+func csvColumnTypes(header []string, rows [][]string, schema map[string]CSVColumnType, objectIDColumn string) []CSVColumnType {
+	types := make([]CSVColumnType, len(header))
+
+	for i, name := range header {
+		if t, ok := schema[name]; ok {
+			types[i] = t
+
+			continue
+		}
+
+		if name == objectIDColumn {
+			types[i] = CSVColumnString
+
+			continue
+		}
+
+		numbers, booleans, values := true, true, 0
+
+		for _, row := range rows {
+			if i >= len(row) || row[i] == "" {
+				continue
+			}
+
+			values++
+
+			if _, ok := parseCSVNumber(row[i]); !ok {
+				numbers = false
+			}
+
+			if !isCSVBoolean(row[i]) {
+				booleans = false
+			}
+		}
+
+		switch {
+		case values > 0 && numbers:
+			types[i] = CSVColumnNumber
+		case values > 0 && booleans:
+			types[i] = CSVColumnBoolean
+		default:
+			types[i] = CSVColumnString
+		}
+	}
+
+	return types
+}
+
+// csvRecord converts a row to a record.
+func csvRecord(header []string, row []string, types []CSVColumnType, objectIDColumn string, line int) (map[string]any, error) {
+	record := make(map[string]any, len(header))
+
+	for i, name := range header {
+		if i >= len(row) {
+			break
+		}
+
+		value := row[i]
+
+		if name == objectIDColumn {
+			if value == "" {
+				return nil, fmt.Errorf("line %d: the `%s` column is empty", line, objectIDColumn)
+			}
+
+			record["objectID"] = value
+
+			continue
+		}
+
+		switch types[i] {
+		case CSVColumnNumber:
+			if value == "" {
+				continue
+			}
+
+			n, ok := parseCSVNumber(value)
+			if !ok {
+				return nil, fmt.Errorf("line %d: %q of column `%s` is not a number", line, value, name)
+			}
+
+			record[name] = n
+		case CSVColumnBoolean:
+			if value == "" {
+				continue
+			}
+
+			if !isCSVBoolean(value) {
+				return nil, fmt.Errorf("line %d: %q of column `%s` is not a boolean", line, value, name)
+			}
+
+			record[name] = strings.EqualFold(value, "true")
+		default:
+			record[name] = value
+		}
+	}
+
+	return record, nil
+}
+
+// parseCSVNumber parses integers as int64 and other numbers as float64. Numbers with leading zeros, such as zip
+// codes, are not numbers.
+func parseCSVNumber(value string) (any, bool) {
+	digits := strings.TrimPrefix(value, "-")
+	if len(digits) > 1 && digits[0] == '0' && digits[1] != '.' {
+		return nil, false
+	}
+
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return n, true
+	}
+
+	if f, err := strconv.ParseFloat(value, 64); err == nil && !strings.ContainsAny(value, "xXpP_") && !strings.EqualFold(strings.Trim(value, "+-"), "inf") && !strings.EqualFold(value, "nan") {
+		return f, true
+	}
+
+	return nil, false
+}
+
+func isCSVBoolean(value string) bool {
+	return strings.EqualFold(value, "true") || strings.EqualFold(value, "false")
+}
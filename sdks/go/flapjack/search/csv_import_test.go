@@ -0,0 +1,118 @@
+package search_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/flapjacktest"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+)
+
+func TestImportCSV(t *testing.T) {
+	t.Parallel()
+
+	csv := `sku,name,price,stock,available,zip,rating
+a1,Pancake,4.5,10,true,01234,
+a2,"Waffle, large",6,0,FALSE,75001,3
+a3,Crepe,,2,,10001,x
+`
+
+	tests := []struct {
+		name    string
+		cfg     search.CSVImportConfig
+		want    []map[string]any
+		wantErr string
+	}{
+		{
+			name: "inferred types",
+			cfg:  search.CSVImportConfig{ObjectIDColumn: "sku"},
+			want: []map[string]any{
+				{"objectID": "a1", "name": "Pancake", "price": 4.5, "stock": float64(10), "available": true, "zip": "01234", "rating": ""},
+				{"objectID": "a2", "name": "Waffle, large", "price": float64(6), "stock": float64(0), "available": false, "zip": "75001", "rating": "3"},
+				{"objectID": "a3", "name": "Crepe", "stock": float64(2), "zip": "10001", "rating": "x"},
+			},
+		},
+		{
+			name: "schema",
+			cfg: search.CSVImportConfig{ObjectIDColumn: "sku", Schema: map[string]search.CSVColumnType{
+				"stock": search.CSVColumnString,
+				"zip":   search.CSVColumnNumber,
+			}},
+			wantErr: `line 2: "01234" of column ` + "`zip`" + ` is not a number`,
+		},
+		{
+			name:    "values not matching the inferred type",
+			cfg:     search.CSVImportConfig{ObjectIDColumn: "sku", InferenceRows: 2},
+			wantErr: `line 4: "x" of column ` + "`rating`" + ` is not a number`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := flapjacktest.NewServer()
+			t.Cleanup(srv.Close)
+
+			client, err := srv.NewClient()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			_, err = client.ImportCSV("products", strings.NewReader(csv), tt.cfg, search.WithWaitForTasks(true))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error %q, got %v", tt.wantErr, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := srv.Objects("products"); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("unexpected objects\n got: %v\nwant: %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestImportCSVBatches(t *testing.T) {
+	t.Parallel()
+
+	srv := flapjacktest.NewServer()
+	t.Cleanup(srv.Close)
+
+	client, err := srv.NewClient()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var calls []progressCall
+
+	res, err := client.ImportCSV("products", strings.NewReader("objectID;count\n1;1\n2;2\n3;3\n4;4\n5;5\n"),
+		search.CSVImportConfig{Comma: ';'}, search.WithBatchSize(2), search.WithProgress(recordProgress(&calls)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(res) != 3 {
+		t.Errorf("expected 3 batches, got %d", len(res))
+	}
+
+	checkProgress(t, calls, []int{2, 4, 5}, -1)
+
+	if got := srv.Objects("products"); len(got) != 5 || got[4]["objectID"] != "5" || got[4]["count"] != float64(5) {
+		t.Errorf("unexpected objects %v", got)
+	}
+
+	_, err = client.ImportCSV("products", strings.NewReader("objectID,count\n,1\n"), search.CSVImportConfig{})
+	if err == nil || !strings.Contains(err.Error(), "line 2: the `objectID` column is empty") {
+		t.Errorf("expected an error for the empty objectID, got %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+package search
+
+import (
+	"slices"
+	"strings"
+)
+
+// Asc returns the ranking criterion sorting by the values of the attribute in ascending order, `asc(attribute)`.
+func Asc(attribute string) string {
+	return "asc(" + attribute + ")"
+}
+
+// Desc returns the ranking criterion sorting by the values of the attribute in descending order, `desc(attribute)`.
+func Desc(attribute string) string {
+	return "desc(" + attribute + ")"
+}
+
+/*
+CustomRanking builds the `customRanking` setting, the attributes ordering the records tied by the other ranking criteria:
+
+	opts, err := search.NewCustomRanking(search.Desc("popularity")).
+		Asc("price").
+		IndexSettingsOptions()
+
+Each criterion must be `asc(attribute)` or `desc(attribute)`, an attribute being ranked once. The criteria are validated when the setting is built.
+*/
+type CustomRanking struct {
+	criteria   []string
+	attributes []string
+	err        error
+}
+
+// NewCustomRanking returns a CustomRanking with the criteria, built with Asc and Desc, in order of precedence.
+func NewCustomRanking(criteria ...string) *CustomRanking {
+	r := &CustomRanking{criteria: []string{}}
+
+	for _, criterion := range criteria {
+		r.add(criterion)
+	}
+
+	return r
+}
+
+// Asc adds a criterion sorting by the values of the attribute in ascending order, after the other criteria.
+func (r *CustomRanking) Asc(attribute string) *CustomRanking {
+	r.add(Asc(attribute))
+
+	return r
+}
+
+// Desc adds a criterion sorting by the values of the attribute in descending order, after the other criteria.
+func (r *CustomRanking) Desc(attribute string) *CustomRanking {
+	r.add(Desc(attribute))
+
+	return r
+}
+
+// add appends the criterion, and records an error if it is invalid or its attribute already ranked.
+func (r *CustomRanking) add(criterion string) {
+	attribute, err := customRankingAttribute(criterion)
+	if err != nil {
+		r.fail(err)
+
+		return
+	}
+
+	if slices.Contains(r.attributes, attribute) {
+		r.fail(reportError("the attribute `%s` is ranked more than once", attribute))
+
+		return
+	}
+
+	r.criteria = append(r.criteria, criterion)
+	r.attributes = append(r.attributes, attribute)
+}
+
+// fail records the first error, returned when the setting is built.
+func (r *CustomRanking) fail(err error) {
+	if r.err == nil {
+		r.err = err
+	}
+}
+
+// customRankingAttribute returns the attribute of an `asc(attribute)` or `desc(attribute)` criterion.
+func customRankingAttribute(criterion string) (string, error) {
+	modifier, rest, ok := strings.Cut(criterion, "(")
+	attribute, closed := strings.CutSuffix(rest, ")")
+
+	if !ok || !closed || (modifier != "asc" && modifier != "desc") {
+		return "", reportError("invalid custom ranking criterion %q: expected asc(attribute) or desc(attribute)", criterion)
+	}
+
+	if strings.TrimSpace(attribute) != attribute || attribute == "" || strings.ContainsAny(attribute, "(),") {
+		return "", reportError("invalid attribute %q in the custom ranking criterion %q", attribute, criterion)
+	}
+
+	return attribute, nil
+}
+
+/*
+Criteria validates the criteria and returns them as the value of the `customRanking` setting.
+
+	@return []string - The criteria, in order of precedence.
+	@return error - The first invalid criterion.
+*/
+func (r *CustomRanking) Criteria() ([]string, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	return r.criteria, nil
+}
+
+/*
+IndexSettingsOptions validates the criteria and returns them as options of NewIndexSettings.
+
+	@return []IndexSettingsOption - The options.
+	@return error - The first invalid criterion.
+*/
+func (r *CustomRanking) IndexSettingsOptions() ([]IndexSettingsOption, error) {
+	criteria, err := r.Criteria()
+	if err != nil {
+		return nil, err
+	}
+
+	return []IndexSettingsOption{WithIndexSettingsCustomRanking(criteria)}, nil
+}
@@ -0,0 +1,54 @@
+package search_test
+
+import (
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+)
+
+func TestCustomRanking(t *testing.T) {
+	t.Parallel()
+
+	opts, err := search.NewCustomRanking(search.Desc("popularity")).Asc("price").Desc("stats.rating").IndexSettingsOptions()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sameJSON(t, search.NewIndexSettings(opts...), `{"customRanking": ["desc(popularity)", "asc(price)", "desc(stats.rating)"]}`)
+
+	criteria, err := search.NewCustomRanking().Criteria()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sameJSON(t, criteria, `[]`)
+}
+
+func TestCustomRankingValidation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		ranking *search.CustomRanking
+	}{
+		{name: "no modifier", ranking: search.NewCustomRanking("price")},
+		{name: "unknown modifier", ranking: search.NewCustomRanking("up(price)")},
+		{name: "unclosed", ranking: search.NewCustomRanking("asc(price")},
+		{name: "blank attribute", ranking: search.NewCustomRanking().Asc("")},
+		{name: "padded attribute", ranking: search.NewCustomRanking().Desc(" price")},
+		{name: "nested modifier", ranking: search.NewCustomRanking().Asc("desc(price)")},
+		{name: "ranked twice", ranking: search.NewCustomRanking(search.Asc("price")).Desc("price")},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := tt.ranking.IndexSettingsOptions(); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/utils"
+)
+
+/*
+CustomRequest sends a request to any endpoint of the API and returns its raw JSON response, to call the new or experimental endpoints before the client supports them.
+Unlike CustomGet, CustomPost, CustomPut and CustomDelete, which send and decode JSON objects, the body can be any value encoded to JSON, such as an array, and the response is left for the caller to decode into its own types:
+
+	raw, err := client.CustomRequest(http.MethodPost, "1/indexes/products/newFeature", map[string]any{"dryRun": true}, []string{"a", "b"})
+
+	var res NewFeatureResponse
+	err = json.Unmarshal(raw, &res)
+
+	@param method string - HTTP method of the request, such as http.MethodGet. GET requests are sent as reads, with their retries and timeouts, the others as writes.
+	@param path string - Path of the endpoint, for example `1/newFeature`.
+	@param parameters map[string]any - Query parameters of the request. Can be nil.
+	@param body any - Body of the request, encoded to JSON. Ignored for GET requests. Can be nil.
+	@param opts ...RequestOption - Optional parameters for the request.
+	@return json.RawMessage - The raw JSON response, nil when the response has no body.
+	@return error - Error if any, a *transport.APIError when the response has a non-2xx status.
+*/
+func (c *APIClient) CustomRequest(method, path string, parameters map[string]any, body any, opts ...RequestOption) (json.RawMessage, error) {
+	path = strings.TrimPrefix(path, "/")
+	if path == "" {
+		return nil, reportError("Parameter `path` is required when calling `CustomRequest`.")
+	}
+
+	if method == "" {
+		return nil, reportError("Parameter `method` is required when calling `CustomRequest`.")
+	}
+
+	conf := config{
+		context:      context.Background(),
+		queryParams:  url.Values{},
+		headerParams: map[string]string{},
+	}
+
+	for k, v := range parameters {
+		conf.queryParams.Set(k, utils.QueryParameterToString(v))
+	}
+
+	for _, opt := range opts {
+		opt.apply(&conf)
+	}
+
+	method = strings.ToUpper(method)
+	if method == http.MethodGet {
+		body = nil
+	}
+
+	req, err := c.prepareRequest(transport.WithOperationName(conf.context, "customRequest"), "/"+path, method, body, conf.bodyParams, conf.headerParams, conf.queryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	res, resBody, err := c.callAPI(req, false, conf.timeouts)
+	if err != nil {
+		return nil, err
+	}
+
+	if res == nil {
+		return nil, reportError("res is nil")
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 300 {
+		return nil, c.decodeError(res, resBody)
+	}
+
+	if len(resBody) == 0 {
+		return nil, nil
+	}
+
+	return json.RawMessage(resBody), nil
+}
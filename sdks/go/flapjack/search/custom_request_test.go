@@ -0,0 +1,104 @@
+package search_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+func TestCustomRequest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		parameters map[string]any
+		body       any
+		wantTarget string
+		wantBody   string
+	}{
+		{
+			name:       "get",
+			method:     http.MethodGet,
+			path:       "1/newFeature",
+			parameters: map[string]any{"page": 2},
+			body:       []string{"ignored"},
+			wantTarget: "GET /1/newFeature?page=2",
+		},
+		{
+			name:       "post array",
+			method:     "post",
+			path:       "/1/indexes/products/newFeature",
+			body:       []string{"a", "b"},
+			wantTarget: "POST /1/indexes/products/newFeature",
+			wantBody:   `["a","b"]`,
+		},
+		{
+			name:       "delete",
+			method:     http.MethodDelete,
+			path:       "1/newFeature/42",
+			wantTarget: "DELETE /1/newFeature/42",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+
+				if target := r.Method + " " + r.URL.RequestURI(); target != tt.wantTarget {
+					t.Errorf("expected the request %s, got %s", tt.wantTarget, target)
+				}
+
+				if string(body) != tt.wantBody {
+					t.Errorf("expected the body %s, got %s", tt.wantBody, body)
+				}
+
+				_, _ = w.Write([]byte(`[{"id": 1}, {"id": 2}]`))
+			})
+
+			raw, err := client.CustomRequest(tt.method, tt.path, tt.parameters, tt.body)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if string(raw) != `[{"id": 1}, {"id": 2}]` {
+				t.Errorf("expected the raw response, got %s", raw)
+			}
+		})
+	}
+}
+
+func TestCustomRequestErrors(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "Not found", "status": 404}`))
+	})
+
+	raw, err := client.CustomRequest(http.MethodGet, "1/unknown", nil, nil)
+
+	var apiErr *transport.APIError
+	if !errors.As(err, &apiErr) || apiErr.Status != http.StatusNotFound || apiErr.Message != "Not found" {
+		t.Errorf("expected an *APIError with the status of the response, got %v", err)
+	}
+
+	if raw != nil {
+		t.Errorf("expected no response, got %s", raw)
+	}
+
+	if _, err := client.CustomRequest(http.MethodGet, "", nil, nil); err == nil ||
+		err.Error() != "Parameter `path` is required when calling `CustomRequest`." {
+		t.Errorf("expected an error for the missing path, got %v", err)
+	}
+}
@@ -0,0 +1,125 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrQuerySuperseded is returned by the SearchDebouncer for the queries replaced by a later query of the same session,
+// either while waiting for the quiet period or while being sent.
+var ErrQuerySuperseded = errors.New("query superseded by a later one")
+
+// DefaultQuietPeriod is the default time a SearchDebouncer waits for a later query before sending one.
+const DefaultQuietPeriod = 150 * time.Millisecond
+
+// SearchDebouncerConfig configures a SearchDebouncer.
+type SearchDebouncerConfig struct {
+	// QuietPeriod is the time without a later query of the same session after
+	// which a query is sent, DefaultQuietPeriod when zero. A negative period
+	// sends the queries at once, only cancelling the superseded ones in flight.
+	QuietPeriod time.Duration
+}
+
+// SearchDebouncer coalesces the rapid successive queries of a user or session, such as the keystrokes of an
+// autocomplete proxy: a query is only sent once no later query of its session came during the quiet period, and a
+// query still in flight is cancelled by the next one. The sessions are independent, and are forgotten once their
+// last query is answered.
+type SearchDebouncer struct {
+	client *APIClient
+	cfg    SearchDebouncerConfig
+
+	mu       sync.Mutex
+	sessions map[string]*debouncedQuery
+}
+
+// debouncedQuery is the latest query of a session.
+type debouncedQuery struct {
+	cancel context.CancelCauseFunc
+}
+
+/*
+NewSearchDebouncer creates a debouncer sending the queries with the given client, the clock of the client timing the quiet periods.
+
+	@param client *APIClient - Client sending the queries.
+	@param cfg SearchDebouncerConfig - Configuration of the debouncer.
+	@return *SearchDebouncer - The debouncer.
+*/
+func NewSearchDebouncer(client *APIClient, cfg SearchDebouncerConfig) *SearchDebouncer {
+	if cfg.QuietPeriod == 0 {
+		cfg.QuietPeriod = DefaultQuietPeriod
+	}
+
+	return &SearchDebouncer{client: client, cfg: cfg, sessions: map[string]*debouncedQuery{}}
+}
+
+/*
+Do calls fn with the latest query of the session once the quiet period is over, cancelling the previous query of the session.
+The context given to fn is cancelled when a later query of the session comes, to abort the requests of fn.
+
+	@param ctx context.Context - Context of the query.
+	@param session string - User or session of the query, such as the ID of a user or of a connection.
+	@param fn func(ctx context.Context) error - The query.
+	@return error - ErrQuerySuperseded when a later query of the session came first, or the error of fn.
+*/
+func (d *SearchDebouncer) Do(ctx context.Context, session string, fn func(ctx context.Context) error) error {
+	ctx, cancel := context.WithCancelCause(ctx)
+	query := &debouncedQuery{cancel: cancel}
+
+	d.mu.Lock()
+	if previous, ok := d.sessions[session]; ok {
+		previous.cancel(ErrQuerySuperseded)
+	}
+
+	d.sessions[session] = query
+	d.mu.Unlock()
+
+	defer func() {
+		d.mu.Lock()
+		if d.sessions[session] == query {
+			delete(d.sessions, session)
+		}
+		d.mu.Unlock()
+
+		cancel(nil)
+	}()
+
+	err := d.client.clock().Sleep(ctx, d.cfg.QuietPeriod)
+	if err == nil {
+		err = fn(ctx)
+	}
+
+	if err != nil && errors.Is(context.Cause(ctx), ErrQuerySuperseded) {
+		return ErrQuerySuperseded
+	}
+
+	return err
+}
+
+/*
+Search sends the queries with SearchForHits once the quiet period is over, unless a later Search or Do of the same session supersedes them.
+
+	@param ctx context.Context - Context of the queries.
+	@param session string - User or session of the queries, such as the ID of a user or of a connection.
+	@param params *SearchMethodParams - The queries.
+	@param opts ...RequestOption - Optional parameters for the request.
+	@return []SearchResponse - The results of the queries.
+	@return error - ErrQuerySuperseded when a later query of the session came first, or the error of the request.
+*/
+func (d *SearchDebouncer) Search(ctx context.Context, session string, params *SearchMethodParams, opts ...RequestOption) ([]SearchResponse, error) {
+	var results []SearchResponse
+
+	err := d.Do(ctx, session, func(ctx context.Context) error {
+		var err error
+
+		results, err = d.client.SearchForHits(d.client.NewApiSearchRequest(params), append(append([]RequestOption{}, opts...), WithContext(ctx))...)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
@@ -0,0 +1,180 @@
+package search_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/flapjacktest"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+const debouncedResults = `{"results":[{"hits":[],"nbHits":0,"page":0,"nbPages":0,"hitsPerPage":20,"processingTimeMS":1,"query":"","params":""}]}`
+
+// debouncedQuery returns the parameters of a search of products.
+func debouncedQuery(query string) *search.SearchMethodParams {
+	return search.NewSearchMethodParams([]search.SearchQuery{
+		*search.SearchForHitsAsSearchQuery(search.NewSearchForHits("products", search.WithSearchForHitsQuery(query))),
+	})
+}
+
+// heldClock is a fake clock whose first Sleep is held until its context is done, so that the query waiting for the
+// quiet period is superseded before the period is over.
+type heldClock struct {
+	*flapjacktest.Clock
+
+	hold chan struct{}
+	held chan struct{}
+}
+
+func newHeldClock() *heldClock {
+	c := &heldClock{Clock: flapjacktest.NewClock(time.Time{}), hold: make(chan struct{}, 1), held: make(chan struct{})}
+	c.hold <- struct{}{}
+
+	return c
+}
+
+func (c *heldClock) Sleep(ctx context.Context, d time.Duration) error {
+	select {
+	case <-c.hold:
+		close(c.held)
+		<-ctx.Done()
+
+		return ctx.Err()
+	default:
+		return c.Clock.Sleep(ctx, d)
+	}
+}
+
+func TestSearchDebouncerQuietPeriod(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+
+	clock := newHeldClock()
+
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+
+		_, _ = w.Write([]byte(debouncedResults))
+	}, transport.WithClock(clock))
+
+	debouncer := search.NewSearchDebouncer(client, search.SearchDebouncerConfig{QuietPeriod: 200 * time.Millisecond})
+
+	superseded := make(chan error, 1)
+
+	go func() {
+		_, err := debouncer.Search(context.Background(), "user-1", debouncedQuery("cr"))
+		superseded <- err
+	}()
+
+	<-clock.held
+
+	// Another session is not affected by the queries of user-1.
+	if _, err := debouncer.Search(context.Background(), "user-2", debouncedQuery("waffle")); err != nil {
+		t.Errorf("unexpected error for another session: %v", err)
+	}
+
+	results, err := debouncer.Search(context.Background(), "user-1", debouncedQuery("crepe"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(results) != 1 {
+		t.Errorf("unexpected results %+v", results)
+	}
+
+	if err := <-superseded; !errors.Is(err, search.ErrQuerySuperseded) {
+		t.Errorf("expected the first query to be superseded, got %v", err)
+	}
+
+	if got := requests.Load(); got != 2 {
+		t.Errorf("expected 2 requests, got %d", got)
+	}
+
+	if want := []time.Duration{200 * time.Millisecond, 200 * time.Millisecond}; !slices.Equal(clock.Slept(), want) {
+		t.Errorf("expected the sent queries to wait %v, got %v", want, clock.Slept())
+	}
+}
+
+func TestSearchDebouncerCancelsInFlightQuery(t *testing.T) {
+	t.Parallel()
+
+	received := make(chan string, 2)
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Requests []map[string]any `json:"requests"`
+		}
+
+		raw, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(raw, &body)
+
+		query, _ := body.Requests[0]["query"].(string)
+		received <- query
+
+		if query == "slow" {
+			<-r.Context().Done()
+
+			return
+		}
+
+		_, _ = w.Write([]byte(debouncedResults))
+	})
+
+	debouncer := search.NewSearchDebouncer(client, search.SearchDebouncerConfig{QuietPeriod: -1})
+
+	superseded := make(chan error, 1)
+
+	go func() {
+		_, err := debouncer.Search(context.Background(), "user-1", debouncedQuery("slow"))
+		superseded <- err
+	}()
+
+	if query := <-received; query != "slow" {
+		t.Fatalf("unexpected query %q", query)
+	}
+
+	_, err := debouncer.Search(context.Background(), "user-1", debouncedQuery("fast"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	select {
+	case err := <-superseded:
+		if !errors.Is(err, search.ErrQuerySuperseded) {
+			t.Errorf("expected the in-flight query to be superseded, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the in-flight query was not cancelled")
+	}
+}
+
+func TestSearchDebouncerContextCanceled(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		t.Error("unexpected request")
+	})
+
+	debouncer := search.NewSearchDebouncer(client, search.SearchDebouncerConfig{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := debouncer.Do(ctx, "user-1", func(context.Context) error {
+		t.Error("unexpected call")
+
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
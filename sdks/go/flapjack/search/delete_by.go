@@ -0,0 +1,229 @@
+package search
+
+import (
+	"strconv"
+	"strings"
+)
+
+/*
+DeleteByFilters deletes every record matching the `filters`, `facetFilters` and `numericFilters` of the parameters, and waits for the task to be processed when `WithWaitForTasks(true)` is given.
+The server only reads the `filters` expression, so the facet and numeric filters are converted to conditions of the expression, combined with AND:
+
+	res, err := client.DeleteByFilters("products", search.NewDeleteByParams(
+		search.WithDeleteByParamsFilters("price < 10"),
+		search.WithDeleteByParamsFacetFilters(*search.StringAsFacetFilters("brand:Acme")),
+	))
+
+	// Deletes the records matching: (price < 10) AND brand:"Acme"
+
+The parameters the server ignores, such as `tagFilters` or the geo parameters, return an error rather than deleting more records than requested, as do parameters without any filter.
+
+	@param indexName string - the index name to delete records from.
+	@param params *DeleteByParams - Filters selecting the records to delete.
+	@param opts ...ChunkedBatchOption - Optional parameters for the request.
+	@return *UpdatedAtResponse - The response of the deleteBy operation.
+	@return error - Error if any.
+*/
+func (c *APIClient) DeleteByFilters(indexName string, params *DeleteByParams, opts ...ChunkedBatchOption) (*UpdatedAtResponse, error) {
+	filters, err := deleteByExpression(params)
+	if err != nil {
+		return nil, err
+	}
+
+	conf := config{
+		headerParams: map[string]string{},
+	}
+
+	for _, opt := range opts {
+		opt.apply(&conf)
+	}
+
+	resp, err := c.DeleteBy(
+		c.NewApiDeleteByRequest(indexName, NewDeleteByParams(WithDeleteByParamsFilters(filters))),
+		toRequestOptions(opts)...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if conf.waitForTasks {
+		_, err = c.WaitForTask(indexName, resp.TaskID, toIterableOptions(opts)...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
+
+// deleteByExpression returns the `filters` expression selecting the same records as the parameters.
+func deleteByExpression(params *DeleteByParams) (string, error) {
+	if params == nil {
+		return "", reportError("Parameter `params` is required when calling `DeleteByFilters`.")
+	}
+
+	switch {
+	case params.TagFilters != nil:
+		return "", reportError("`tagFilters` is not supported by `DeleteByFilters`: use `filters` with `_tags:value` conditions")
+	case params.AroundLatLng != nil, params.AroundRadius != nil, params.InsideBoundingBox.IsSet(), params.InsidePolygon != nil:
+		return "", reportError("the geo parameters are not supported by `DeleteByFilters`")
+	}
+
+	converted := NewFilters()
+
+	if params.FacetFilters != nil {
+		converted.And(facetFiltersExpression(*params.FacetFilters, 0))
+	}
+
+	if params.NumericFilters != nil {
+		converted.And(numericFiltersExpression(*params.NumericFilters, 0))
+	}
+
+	expression, err := converted.Build()
+	if err != nil {
+		return "", err
+	}
+
+	filters := strings.TrimSpace(params.GetFilters())
+
+	switch {
+	case filters == "" && expression == "":
+		return "", reportError("Parameter `filters`, `facetFilters` or `numericFilters` is required when calling `DeleteByFilters`.")
+	case filters == "":
+		return expression, nil
+	case expression == "":
+		return filters, nil
+	default:
+		return "(" + filters + ") AND " + expression, nil
+	}
+}
+
+// facetFiltersExpression converts facet filters such as `["brand:Acme", ["color:red", "-color:blue"]]`: the elements of
+// the top level are combined with AND, the nested ones with OR.
+func facetFiltersExpression(filters FacetFilters, depth int) *Filters {
+	if filters.String != nil {
+		filter := *filters.String
+		negated := strings.HasPrefix(filter, "-")
+
+		attribute, value, ok := strings.Cut(strings.TrimPrefix(filter, "-"), ":")
+		if !ok {
+			return failedFilters(reportError("invalid facet filter %q: expected `attribute:value`", filter))
+		}
+
+		condition := NewFilters().Eq(attribute, value)
+		if negated {
+			return NewFilters().Not(condition)
+		}
+
+		return condition
+	}
+
+	elements := filters.ArrayOfFacetFilters
+	if elements == nil || len(*elements) == 0 || depth > 1 {
+		return failedFilters(reportError("facet filters must be a non-empty list of conditions, or of non-empty lists of conditions"))
+	}
+
+	converted := make([]*Filters, 0, len(*elements))
+	for _, element := range *elements {
+		converted = append(converted, facetFiltersExpression(element, depth+1))
+	}
+
+	if depth == 0 {
+		return NewFilters().And(converted...)
+	}
+
+	return NewFilters().Or(converted...)
+}
+
+// numericFiltersExpression converts numeric filters such as `["price < 10", ["stock = 0", "rating:1 TO 2"]]`: the
+// elements of the top level are combined with AND, the nested ones with OR.
+func numericFiltersExpression(filters NumericFilters, depth int) *Filters {
+	if filters.String != nil {
+		return numericFilterExpression(*filters.String)
+	}
+
+	elements := filters.ArrayOfNumericFilters
+	if elements == nil || len(*elements) == 0 || depth > 1 {
+		return failedFilters(reportError("numeric filters must be a non-empty list of conditions, or of non-empty lists of conditions"))
+	}
+
+	converted := make([]*Filters, 0, len(*elements))
+	for _, element := range *elements {
+		converted = append(converted, numericFiltersExpression(element, depth+1))
+	}
+
+	if depth == 0 {
+		return NewFilters().And(converted...)
+	}
+
+	return NewFilters().Or(converted...)
+}
+
+// numericFilterExpression converts a numeric filter, either a comparison such as `price <= 10` or a range such as
+// `price:10 TO 20`.
+func numericFilterExpression(filter string) *Filters {
+	invalid := func() *Filters {
+		return failedFilters(reportError("invalid numeric filter %q: expected `attribute operator number` or `attribute:lower TO upper`", filter))
+	}
+
+	if attribute, bounds, ok := strings.Cut(filter, ":"); ok {
+		lower, upper, ok := strings.Cut(bounds, " TO ")
+		if !ok {
+			return invalid()
+		}
+
+		lowerValue, err := strconv.ParseFloat(strings.TrimSpace(lower), 64)
+		if err != nil {
+			return invalid()
+		}
+
+		upperValue, err := strconv.ParseFloat(strings.TrimSpace(upper), 64)
+		if err != nil {
+			return invalid()
+		}
+
+		return NewFilters().Range(strings.TrimSpace(attribute), lowerValue, upperValue)
+	}
+
+	i := strings.IndexAny(filter, "<>=!")
+	if i < 0 {
+		return invalid()
+	}
+
+	operator := filter[i : i+1]
+	if strings.HasPrefix(filter[i+1:], "=") {
+		operator += "="
+	}
+
+	value, err := strconv.ParseFloat(strings.TrimSpace(filter[i+len(operator):]), 64)
+	if err != nil {
+		return invalid()
+	}
+
+	attribute := strings.TrimSpace(filter[:i])
+
+	switch operator {
+	case "=":
+		return NewFilters().Eq(attribute, value)
+	case "!=":
+		return NewFilters().Neq(attribute, value)
+	case "<":
+		return NewFilters().Lt(attribute, value)
+	case "<=":
+		return NewFilters().Lte(attribute, value)
+	case ">":
+		return NewFilters().Gt(attribute, value)
+	case ">=":
+		return NewFilters().Gte(attribute, value)
+	default:
+		return invalid()
+	}
+}
+
+// failedFilters returns an expression failing with the error when it is built.
+func failedFilters(err error) *Filters {
+	filters := NewFilters()
+	filters.fail(err)
+
+	return filters
+}
@@ -0,0 +1,125 @@
+package search_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/utils"
+)
+
+func TestDeleteByFilters(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		params *search.DeleteByParams
+		want   string
+	}{
+		{
+			name:   "filters",
+			params: search.NewDeleteByParams(search.WithDeleteByParamsFilters(` brand:Acme OR price > 100 `)),
+			want:   `brand:Acme OR price > 100`,
+		},
+		{
+			name: "facet filters",
+			params: search.NewDeleteByParams(search.WithDeleteByParamsFacetFilters(*search.ArrayOfFacetFiltersAsFacetFilters([]search.FacetFilters{
+				*search.StringAsFacetFilters("brand:Acme"),
+				*search.ArrayOfFacetFiltersAsFacetFilters([]search.FacetFilters{
+					*search.StringAsFacetFilters("color:red"),
+					*search.StringAsFacetFilters("-color:Dark blue"),
+				}),
+			}))),
+			want: `brand:"Acme" AND (color:"red" OR NOT color:"Dark blue")`,
+		},
+		{
+			name: "numeric filters",
+			params: search.NewDeleteByParams(search.WithDeleteByParamsNumericFilters(*search.ArrayOfNumericFiltersAsNumericFilters([]search.NumericFilters{
+				*search.StringAsNumericFilters("price>=20"),
+				*search.ArrayOfNumericFiltersAsNumericFilters([]search.NumericFilters{
+					*search.StringAsNumericFilters("stock = 0"),
+					*search.StringAsNumericFilters("price:100 TO 200.5"),
+				}),
+			}))),
+			want: `price >= 20 AND (stock = 0 OR price:100 TO 200.5)`,
+		},
+		{
+			name: "all filters",
+			params: search.NewDeleteByParams(
+				search.WithDeleteByParamsFilters("stock > 0 OR price < 15"),
+				search.WithDeleteByParamsFacetFilters(*search.StringAsFacetFilters("-brand:Wearit")),
+				search.WithDeleteByParamsNumericFilters(*search.StringAsNumericFilters("price < 100")),
+			),
+			want: `(stock > 0 OR price < 15) AND NOT brand:"Wearit" AND price < 100`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got map[string]any
+
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/1/indexes/products/deleteByQuery" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+
+				if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+					t.Errorf("failed to decode the body: %v", err)
+				}
+
+				_, _ = w.Write([]byte(`{"taskID":1,"updatedAt":"2024-01-01T00:00:00Z"}`))
+			})
+
+			_, err := client.DeleteByFilters("products", tt.params)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(got) != 1 || got["filters"] != tt.want {
+				t.Errorf("expected the body to only hold the filters %q, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDeleteByFiltersValidation(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(http.ResponseWriter, *http.Request) {
+		t.Error("unexpected request")
+	})
+
+	tests := []struct {
+		name   string
+		params *search.DeleteByParams
+	}{
+		{name: "nil", params: nil},
+		{name: "no filters", params: search.NewDeleteByParams(search.WithDeleteByParamsFilters(" "))},
+		{name: "empty facet filters", params: search.NewDeleteByParams(search.WithDeleteByParamsFacetFilters(*search.ArrayOfFacetFiltersAsFacetFilters([]search.FacetFilters{})))},
+		{name: "invalid facet filter", params: search.NewDeleteByParams(search.WithDeleteByParamsFacetFilters(*search.StringAsFacetFilters("brand")))},
+		{name: "quoted facet value", params: search.NewDeleteByParams(search.WithDeleteByParamsFacetFilters(*search.StringAsFacetFilters(`brand:"Acme"`)))},
+		{name: "invalid numeric filter", params: search.NewDeleteByParams(search.WithDeleteByParamsNumericFilters(*search.StringAsNumericFilters("price ~ 10")))},
+		{name: "non-finite number", params: search.NewDeleteByParams(search.WithDeleteByParamsNumericFilters(*search.StringAsNumericFilters("price < NaN")))},
+		{name: "invalid attribute", params: search.NewDeleteByParams(search.WithDeleteByParamsNumericFilters(*search.StringAsNumericFilters("author.age < 10")))},
+		{name: "tag filters", params: search.NewDeleteByParams(search.WithDeleteByParamsFilters("price < 10"), search.WithDeleteByParamsTagFilters(*search.StringAsTagFilters("sale")))},
+		{name: "geo", params: search.NewDeleteByParams(search.WithDeleteByParamsFilters("price < 10"), search.WithDeleteByParamsAroundLatLng("40.71, -74.01"))},
+		{name: "bounding box", params: search.NewDeleteByParams(search.WithDeleteByParamsFilters("price < 10"), search.WithDeleteByParamsInsideBoundingBox(*utils.NewNullable(search.ArrayOfArrayOfFloat64AsInsideBoundingBox([][]float64{{1, 2, 3, 4}}))))},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := client.DeleteByFilters("products", tt.params); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
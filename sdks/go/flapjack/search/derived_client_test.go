@@ -0,0 +1,86 @@
+package search_test
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+func TestWithConfigurationDerivesClient(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu      sync.Mutex
+		apiKeys []string
+		tenants []string
+	)
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		apiKeys = append(apiKeys, r.Header.Get("X-Algolia-API-Key"))
+		tenants = append(tenants, r.Header.Get("X-Tenant"))
+		mu.Unlock()
+
+		_, _ = w.Write([]byte(`{"items":[],"nbPages":0}`))
+	})
+
+	derived := client.WithConfiguration(transport.ConfigurationOverrides{
+		ApiKey:        "tenant-key",
+		DefaultHeader: map[string]string{"X-Tenant": "acme"},
+	})
+
+	for _, c := range []interface {
+		GetHostStatuses() []transport.HostStatus
+	}{derived, client} {
+		if len(c.GetHostStatuses()) != 1 {
+			t.Fatalf("expected the hosts of the original client, got %+v", c.GetHostStatuses())
+		}
+	}
+
+	_, err := derived.ListIndices(derived.NewApiListIndicesRequest())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = client.ListIndices(client.NewApiListIndicesRequest())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if apiKeys[0] != "tenant-key" || tenants[0] != "acme" {
+		t.Errorf("expected the derived client to use its overrides, got %q and %q", apiKeys[0], tenants[0])
+	}
+
+	if apiKeys[1] != "test-api-key" || tenants[1] != "" {
+		t.Errorf("expected the original client to be unchanged, got %q and %q", apiKeys[1], tenants[1])
+	}
+
+	if client.GetConfiguration().ApiKey != "test-api-key" || derived.GetConfiguration().ApiKey != "tenant-key" {
+		t.Error("expected the configurations to be distinct")
+	}
+}
+
+func TestWithConfigurationOverridesTimeouts(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+
+		_, _ = w.Write([]byte(`{"items":[],"nbPages":0}`))
+	})
+
+	derived := client.WithConfiguration(transport.ConfigurationOverrides{ReadTimeout: 10 * time.Millisecond})
+
+	_, err := derived.ListIndices(derived.NewApiListIndicesRequest())
+	if err == nil {
+		t.Fatal("expected the derived client to time out")
+	}
+
+	_, err = client.ListIndices(client.NewApiListIndicesRequest())
+	if err != nil {
+		t.Fatalf("expected the original client to keep its timeout, got %v", err)
+	}
+}
@@ -0,0 +1,194 @@
+package search
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+)
+
+/*
+DistinctQuery deduplicates the hits of the records sharing the value of the `attributeForDistinct` of the index, such as the variants of a
+product, keeping the best ones of each group:
+
+	opts, err := search.NewDistinct(1).FacetingAfterDistinct(true).SearchParamsObjectOptions()
+	if err != nil {
+		return err
+	}
+
+	params := search.NewSearchParamsObject(opts...)
+
+The same query sets the default of the index, with its attribute:
+
+	opts, err := search.NewDistinct(1).IndexSettingsOptions("product_id")
+
+The parameters are validated when the options are built.
+*/
+type DistinctQuery struct {
+	hitsPerGroup          int32
+	facetingAfterDistinct *bool
+	err                   error
+}
+
+/*
+NewDistinct returns a DistinctQuery keeping `hitsPerGroup` hits of each group, 0 disabling the deduplication.
+
+	@param hitsPerGroup int - Number of hits kept per group, the `distinct` parameter.
+	@return *DistinctQuery - The query.
+*/
+func NewDistinct(hitsPerGroup int) *DistinctQuery {
+	d := &DistinctQuery{hitsPerGroup: int32(min(max(hitsPerGroup, 0), math.MaxInt32))}
+
+	if hitsPerGroup < 0 || hitsPerGroup > math.MaxInt32 {
+		d.err = reportError("the number of hits per distinct group must be between 0 and %d, got %d", math.MaxInt32, hitsPerGroup)
+	}
+
+	return d
+}
+
+// FacetingAfterDistinct computes the facet counts on the deduplicated hits, which is only accurate when all the records of a group have the same facet values.
+func (d *DistinctQuery) FacetingAfterDistinct(enabled bool) *DistinctQuery {
+	d.facetingAfterDistinct = &enabled
+
+	return d
+}
+
+// distinct returns the `distinct` parameter.
+func (d *DistinctQuery) distinct() Distinct {
+	return *Int32AsDistinct(d.hitsPerGroup)
+}
+
+/*
+SearchForHitsOptions validates the parameters and returns them as options of NewSearchForHits.
+
+	@return []SearchForHitsOption - The options.
+	@return error - The first invalid parameter.
+*/
+func (d *DistinctQuery) SearchForHitsOptions() ([]SearchForHitsOption, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	opts := []SearchForHitsOption{WithSearchForHitsDistinct(d.distinct())}
+
+	if d.facetingAfterDistinct != nil {
+		opts = append(opts, WithSearchForHitsFacetingAfterDistinct(*d.facetingAfterDistinct))
+	}
+
+	return opts, nil
+}
+
+/*
+SearchParamsObjectOptions validates the parameters and returns them as options of NewSearchParamsObject.
+
+	@return []SearchParamsObjectOption - The options.
+	@return error - The first invalid parameter.
+*/
+func (d *DistinctQuery) SearchParamsObjectOptions() ([]SearchParamsObjectOption, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	opts := []SearchParamsObjectOption{WithSearchParamsObjectDistinct(d.distinct())}
+
+	if d.facetingAfterDistinct != nil {
+		opts = append(opts, WithSearchParamsObjectFacetingAfterDistinct(*d.facetingAfterDistinct))
+	}
+
+	return opts, nil
+}
+
+/*
+IndexSettingsOptions validates the parameters and returns them as options of NewIndexSettings, making the deduplication the default of the searches.
+FacetingAfterDistinct is a search parameter only, use the `afterDistinct` modifier of `attributesForFaceting` in the settings.
+
+	@param attributeForDistinct string - Attribute whose value identifies the group of a record, such as `product_id`.
+	@return []IndexSettingsOption - The options.
+	@return error - The first invalid parameter.
+*/
+func (d *DistinctQuery) IndexSettingsOptions(attributeForDistinct string) ([]IndexSettingsOption, error) {
+	switch {
+	case d.err != nil:
+		return nil, d.err
+	case attributeForDistinct == "" || strings.TrimSpace(attributeForDistinct) != attributeForDistinct:
+		return nil, reportError("the attribute for distinct %q must be an attribute name", attributeForDistinct)
+	case d.facetingAfterDistinct != nil:
+		return nil, reportError("facetingAfterDistinct is not a setting, use the `afterDistinct` modifier of attributesForFaceting")
+	}
+
+	return []IndexSettingsOption{
+		WithIndexSettingsAttributeForDistinct(attributeForDistinct),
+		WithIndexSettingsDistinct(d.distinct()),
+	}, nil
+}
+
+// HitGroup is a group of hits sharing the value of an attribute, in the order of the results.
+type HitGroup struct {
+	// Value is the value of the attribute in the hits of the group, nil for a hit without it.
+	Value any
+	Hits  []Hit
+}
+
+/*
+GroupHitsBy buckets the hits of the response by the value of an attribute, such as the variants of a product, for the listings showing a
+group per item. The groups are in the order of their best hit, and the hits of a group in the order of the results.
+A hit without the attribute is a group of its own, so that it keeps its place in the listing.
+
+	@param attribute string - Attribute of the hits, nested attributes being separated by dots, such as `product.id`.
+	@return []HitGroup - The groups.
+*/
+func (o *SearchResponse) GroupHitsBy(attribute string) []HitGroup {
+	var groups []HitGroup
+
+	positions := map[string]int{}
+
+	for _, hit := range o.Hits {
+		value, ok := hitAttribute(hit, attribute)
+		if !ok {
+			groups = append(groups, HitGroup{Hits: []Hit{hit}})
+
+			continue
+		}
+
+		key, err := json.Marshal(value)
+		if err != nil {
+			groups = append(groups, HitGroup{Value: value, Hits: []Hit{hit}})
+
+			continue
+		}
+
+		position, ok := positions[string(key)]
+		if !ok {
+			position = len(groups)
+			positions[string(key)] = position
+
+			groups = append(groups, HitGroup{Value: value})
+		}
+
+		groups[position].Hits = append(groups[position].Hits, hit)
+	}
+
+	return groups
+}
+
+// hitAttribute returns the value of a possibly nested attribute of the hit.
+func hitAttribute(hit Hit, attribute string) (any, bool) {
+	if attribute == "objectID" {
+		return hit.ObjectID, true
+	}
+
+	var value any = hit.AdditionalProperties
+
+	for _, name := range strings.Split(attribute, ".") {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+
+		value, ok = object[name]
+		if !ok {
+			return nil, false
+		}
+	}
+
+	return value, value != nil
+}
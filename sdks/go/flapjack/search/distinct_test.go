@@ -0,0 +1,87 @@
+package search_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+)
+
+func TestDistinctQuery(t *testing.T) {
+	t.Parallel()
+
+	opts, err := search.NewDistinct(2).FacetingAfterDistinct(true).SearchParamsObjectOptions()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sameJSON(t, search.NewSearchParamsObject(opts...), `{"distinct":2,"facetingAfterDistinct":true}`)
+
+	hitsOpts, err := search.NewDistinct(0).SearchForHitsOptions()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sameJSON(t, search.NewSearchForHits("products", hitsOpts...), `{"indexName":"products","distinct":0}`)
+
+	settingsOpts, err := search.NewDistinct(1).IndexSettingsOptions("product_id")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sameJSON(t, search.NewIndexSettings(settingsOpts...), `{"attributeForDistinct":"product_id","distinct":1}`)
+}
+
+func TestDistinctQueryValidation(t *testing.T) {
+	t.Parallel()
+
+	if _, err := search.NewDistinct(-1).SearchParamsObjectOptions(); err == nil {
+		t.Error("expected an error for a negative number of hits per group")
+	}
+
+	if _, err := search.NewDistinct(1).IndexSettingsOptions(""); err == nil {
+		t.Error("expected an error for an empty attribute")
+	}
+
+	if _, err := search.NewDistinct(1).FacetingAfterDistinct(true).IndexSettingsOptions("product_id"); err == nil {
+		t.Error("expected an error for facetingAfterDistinct in the settings")
+	}
+}
+
+func TestGroupHitsBy(t *testing.T) {
+	t.Parallel()
+
+	var res search.SearchResponse
+
+	err := json.Unmarshal([]byte(`{"hits": [
+		{"objectID": "1", "product": {"id": "shirt"}},
+		{"objectID": "2", "product": {"id": "shoe"}},
+		{"objectID": "3"},
+		{"objectID": "4", "product": {"id": "shirt"}},
+		{"objectID": "5", "product": {"id": 7}}
+	]}`), &res)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	groups := res.GroupHitsBy("product.id")
+
+	var got []string
+
+	for _, group := range groups {
+		ids := ""
+		for _, hit := range group.Hits {
+			ids += hit.ObjectID
+		}
+
+		got = append(got, ids)
+	}
+
+	if len(got) != 4 || got[0] != "14" || got[1] != "2" || got[2] != "3" || got[3] != "5" {
+		t.Errorf("unexpected groups %v", got)
+	}
+
+	if groups[0].Value != "shirt" || groups[2].Value != nil || groups[3].Value != float64(7) {
+		t.Errorf("unexpected group values %v, %v, %v", groups[0].Value, groups[2].Value, groups[3].Value)
+	}
+}
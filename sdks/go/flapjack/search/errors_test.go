@@ -0,0 +1,34 @@
+package search_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+func TestAPIErrorFromClient(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":"index_not_found","message":"Index products does not exist","request_id":"req_fj_1"}`))
+	})
+
+	_, err := client.GetSettings(client.NewApiGetSettingsRequest("products"))
+
+	if !errors.Is(err, transport.ErrNotFound) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+
+	var apiErr *transport.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected an APIError, got %T", err)
+	}
+
+	if apiErr.Message != "Index products does not exist" || apiErr.RequestID != "req_fj_1" || len(apiErr.Hosts) != 1 {
+		t.Errorf("unexpected error %+v", apiErr)
+	}
+}
@@ -0,0 +1,174 @@
+package search
+
+import "strconv"
+
+// FacetFilter matches the records with a facet value, or without it once negated.
+type FacetFilter struct {
+	Attribute string
+	Value     string
+	Negated   bool
+}
+
+// NewFacetFilter returns a FacetFilter matching the records whose attribute has the value.
+func NewFacetFilter(attribute string, value string) FacetFilter {
+	return FacetFilter{Attribute: attribute, Value: value}
+}
+
+// Not returns the negation of the filter, matching the records which do not have the facet value.
+func (f FacetFilter) Not() FacetFilter {
+	f.Negated = !f.Negated
+
+	return f
+}
+
+// String renders the filter as `attribute:value`, prefixed with `-` once negated.
+func (f FacetFilter) String() string {
+	s := f.Attribute + ":" + f.Value
+	if f.Negated {
+		return "-" + s
+	}
+
+	return s
+}
+
+// FacetFilterGroup is a group of facet filters combined with OR, returned by OrFacetFilters.
+type FacetFilterGroup []FacetFilter
+
+// FacetFilterCondition is a condition of AndFacetFilters, either a FacetFilter or a FacetFilterGroup.
+type FacetFilterCondition interface {
+	facetFilters() FacetFilters
+}
+
+func (f FacetFilter) facetFilters() FacetFilters {
+	return *StringAsFacetFilters(f.String())
+}
+
+func (g FacetFilterGroup) facetFilters() FacetFilters {
+	filters := make([]FacetFilters, 0, len(g))
+	for _, filter := range g {
+		filters = append(filters, filter.facetFilters())
+	}
+
+	return *ArrayOfFacetFiltersAsFacetFilters(filters)
+}
+
+// OrFacetFilters returns a group matching the records matching any of the filters, to be used in AndFacetFilters.
+func OrFacetFilters(filters ...FacetFilter) FacetFilterGroup {
+	return FacetFilterGroup(filters)
+}
+
+/*
+AndFacetFilters returns the `facetFilters` parameter matching the records matching all the conditions.
+As the API only supports ANDs of ORs, the nesting of the conditions is checked at compile time:
+
+	search.AndFacetFilters(
+		search.NewFacetFilter("category", "phones"),
+		search.OrFacetFilters(search.NewFacetFilter("brand", "Apple"), search.NewFacetFilter("brand", "Samsung")),
+		search.NewFacetFilter("condition", "refurbished").Not(),
+	)
+
+	// ["category:phones", ["brand:Apple", "brand:Samsung"], "-condition:refurbished"]
+
+Empty groups are left out.
+
+	@param conditions ...FacetFilterCondition - Facet filters and groups of facet filters.
+	@return *FacetFilters - The `facetFilters` parameter.
+*/
+func AndFacetFilters(conditions ...FacetFilterCondition) *FacetFilters {
+	filters := make([]FacetFilters, 0, len(conditions))
+
+	for _, condition := range conditions {
+		if group, ok := condition.(FacetFilterGroup); ok && len(group) == 0 {
+			continue
+		}
+
+		filters = append(filters, condition.facetFilters())
+	}
+
+	return ArrayOfFacetFiltersAsFacetFilters(filters)
+}
+
+// OptionalFilter boosts the records with a facet value, by its score relatively to the other optional filters.
+type OptionalFilter struct {
+	Attribute string
+	Value     string
+	// Score is the weight of the filter, the server default of 1 when zero.
+	Score int
+}
+
+// NewOptionalFilter returns an OptionalFilter boosting the records whose attribute has the value.
+func NewOptionalFilter(attribute string, value string) OptionalFilter {
+	return OptionalFilter{Attribute: attribute, Value: value}
+}
+
+// WithScore returns the filter with the given score.
+func (f OptionalFilter) WithScore(score int) OptionalFilter {
+	f.Score = score
+
+	return f
+}
+
+// String renders the filter as `attribute:value`, followed by `<score=N>` when it has a score.
+func (f OptionalFilter) String() string {
+	s := f.Attribute + ":" + f.Value
+	if f.Score != 0 {
+		return s + "<score=" + strconv.Itoa(f.Score) + ">"
+	}
+
+	return s
+}
+
+// OptionalFilterGroup is a group of optional filters combined with OR, returned by OrOptionalFilters.
+type OptionalFilterGroup []OptionalFilter
+
+// OptionalFilterCondition is a condition of AndOptionalFilters, either an OptionalFilter or an OptionalFilterGroup.
+type OptionalFilterCondition interface {
+	optionalFilters() OptionalFilters
+}
+
+func (f OptionalFilter) optionalFilters() OptionalFilters {
+	return *StringAsOptionalFilters(f.String())
+}
+
+func (g OptionalFilterGroup) optionalFilters() OptionalFilters {
+	filters := make([]OptionalFilters, 0, len(g))
+	for _, filter := range g {
+		filters = append(filters, filter.optionalFilters())
+	}
+
+	return *ArrayOfOptionalFiltersAsOptionalFilters(filters)
+}
+
+// OrOptionalFilters returns a group of optional filters of which only the best matching one counts, to be used in AndOptionalFilters.
+func OrOptionalFilters(filters ...OptionalFilter) OptionalFilterGroup {
+	return OptionalFilterGroup(filters)
+}
+
+/*
+AndOptionalFilters returns the `optionalFilters` parameter boosting the records by the scores of the conditions they match.
+
+	search.AndOptionalFilters(
+		search.NewOptionalFilter("brand", "Apple").WithScore(3),
+		search.OrOptionalFilters(search.NewOptionalFilter("color", "red"), search.NewOptionalFilter("color", "blue")),
+	)
+
+	// ["brand:Apple<score=3>", ["color:red", "color:blue"]]
+
+Empty groups are left out.
+
+	@param conditions ...OptionalFilterCondition - Optional filters and groups of optional filters.
+	@return *OptionalFilters - The `optionalFilters` parameter.
+*/
+func AndOptionalFilters(conditions ...OptionalFilterCondition) *OptionalFilters {
+	filters := make([]OptionalFilters, 0, len(conditions))
+
+	for _, condition := range conditions {
+		if group, ok := condition.(OptionalFilterGroup); ok && len(group) == 0 {
+			continue
+		}
+
+		filters = append(filters, condition.optionalFilters())
+	}
+
+	return ArrayOfOptionalFiltersAsOptionalFilters(filters)
+}
@@ -0,0 +1,86 @@
+package search_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+)
+
+func TestAndFacetFilters(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		filters *search.FacetFilters
+		want    string
+	}{
+		{
+			name:    "empty",
+			filters: search.AndFacetFilters(),
+			want:    `[]`,
+		},
+		{
+			name: "and of or",
+			filters: search.AndFacetFilters(
+				search.NewFacetFilter("category", "phones"),
+				search.OrFacetFilters(search.NewFacetFilter("brand", "Apple"), search.NewFacetFilter("brand", "Samsung Electronics")),
+				search.OrFacetFilters(),
+			),
+			want: `["category:phones",["brand:Apple","brand:Samsung Electronics"]]`,
+		},
+		{
+			name: "negations",
+			filters: search.AndFacetFilters(
+				search.NewFacetFilter("condition", "refurbished").Not(),
+				search.OrFacetFilters(search.NewFacetFilter("color", "red").Not(), search.NewFacetFilter("size", "XL").Not().Not()),
+			),
+			want: `["-condition:refurbished",["-color:red","size:XL"]]`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := json.Marshal(tt.filters)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if string(got) != tt.want {
+				t.Errorf("unexpected facet filters\n got: %s\nwant: %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAndOptionalFilters(t *testing.T) {
+	t.Parallel()
+
+	filters := search.AndOptionalFilters(
+		search.NewOptionalFilter("brand", "Apple").WithScore(3),
+		search.OrOptionalFilters(search.NewOptionalFilter("color", "red"), search.NewOptionalFilter("color", "blue").WithScore(2)),
+		search.OrOptionalFilters(),
+	)
+
+	raw, err := json.Marshal(search.NewSearchParamsObject().SetOptionalFilters(filters))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got map[string]any
+
+	err = json.Unmarshal(raw, &got)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]any{"optionalFilters": []any{"brand:Apple<score=3>", []any{"color:red", "color:blue<score=2>"}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected optional filters\n got: %v\nwant: %v", got, want)
+	}
+}
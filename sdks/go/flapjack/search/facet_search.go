@@ -0,0 +1,42 @@
+package search
+
+/*
+NewFacetValuesQuery returns the query of a multi-query `search` request searching for the values of a facet matching `facetQuery`, the equivalent of SearchForFacetValues.
+Use SearchForFacets to read the results of requests made only of such queries.
+
+	@param indexName string - Index name.
+	@param facet string - Facet attribute, which must be declared as `searchable()` in `attributesForFaceting`.
+	@param facetQuery string - Text to search inside the facet's values.
+	@param opts ...SearchForFacetsOption - Other parameters of the query, such as WithSearchForFacetsMaxFacetHits.
+	@return *SearchQuery - The query.
+*/
+func NewFacetValuesQuery(indexName string, facet string, facetQuery string, opts ...SearchForFacetsOption) *SearchQuery {
+	opts = append([]SearchForFacetsOption{WithSearchForFacetsFacetQuery(facetQuery)}, opts...)
+
+	return SearchForFacetsAsSearchQuery(NewSearchForFacets(facet, indexName, SEARCH_TYPE_FACET_FACET, opts...))
+}
+
+/*
+SearchFacetValues searches for the values of a facet matching `facetQuery`, like SearchForFacetValues without building the request.
+
+	@param indexName string - Index name.
+	@param facetName string - Facet attribute, which must be declared as `searchable()` in `attributesForFaceting`.
+	@param facetQuery string - Text to search inside the facet's values.
+	@param maxFacetHits int32 - Maximum number of facet values to return, the server default when zero.
+	@param opts ...RequestOption - Optional parameters for the request.
+	@return []FacetHits - The matching facet values.
+	@return error - Error if any.
+*/
+func (c *APIClient) SearchFacetValues(indexName string, facetName string, facetQuery string, maxFacetHits int32, opts ...RequestOption) ([]FacetHits, error) {
+	params := NewSearchForFacetValuesRequest(WithSearchForFacetValuesRequestFacetQuery(facetQuery))
+	if maxFacetHits > 0 {
+		params.SetMaxFacetHits(maxFacetHits)
+	}
+
+	res, err := c.SearchForFacetValues(c.NewApiSearchForFacetValuesRequest(indexName, facetName).WithSearchForFacetValuesRequest(params), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.FacetHits, nil
+}
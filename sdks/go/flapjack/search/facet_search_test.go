@@ -0,0 +1,76 @@
+package search_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+)
+
+func TestSearchFacetValues(t *testing.T) {
+	t.Parallel()
+
+	var (
+		path string
+		body map[string]any
+	)
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		raw, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(raw, &body)
+
+		_, _ = w.Write([]byte(`{"facetHits":[{"value":"Apple","highlighted":"<em>App</em>le","count":12}],"exhaustiveFacetsCount":true}`))
+	})
+
+	hits, err := client.SearchFacetValues("products", "brand", "app", 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if path != "/1/indexes/products/facets/brand/query" || !reflect.DeepEqual(body, map[string]any{"facetQuery": "app", "maxFacetHits": float64(5)}) {
+		t.Errorf("unexpected request %s %v", path, body)
+	}
+
+	if len(hits) != 1 || hits[0].Value != "Apple" || hits[0].Count != 12 {
+		t.Errorf("unexpected facet hits %+v", hits)
+	}
+}
+
+func TestNewFacetValuesQuery(t *testing.T) {
+	t.Parallel()
+
+	var body map[string]any
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(raw, &body)
+
+		_, _ = w.Write([]byte(`{"results":[
+			{"hits":[],"nbHits":0,"page":0,"nbPages":0,"hitsPerPage":20,"processingTimeMS":1,"query":"","params":""},
+			{"facetHits":[{"value":"Apple","highlighted":"<em>App</em>le","count":12}],"exhaustiveFacetsCount":true}
+		]}`))
+	})
+
+	res, err := client.SearchForFacets(client.NewApiSearchRequest(search.NewSearchMethodParams([]search.SearchQuery{
+		*search.SearchForHitsAsSearchQuery(search.NewSearchForHits("products", search.WithSearchForHitsQuery("app"))),
+		*search.NewFacetValuesQuery("products", "brand", "app", search.WithSearchForFacetsMaxFacetHits(5)),
+	})))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	requests, _ := body["requests"].([]any)
+	if len(requests) != 2 || !reflect.DeepEqual(requests[1], map[string]any{
+		"indexName": "products", "type": "facet", "facet": "brand", "facetQuery": "app", "maxFacetHits": float64(5),
+	}) {
+		t.Errorf("unexpected requests %v", requests)
+	}
+
+	if len(res) != 1 || len(res[0].FacetHits) != 1 || res[0].FacetHits[0].Value != "Apple" {
+		t.Errorf("unexpected facet results %+v", res)
+	}
+}
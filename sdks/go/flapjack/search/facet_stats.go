@@ -0,0 +1,88 @@
+package search
+
+import (
+	"math"
+	"strconv"
+)
+
+// NumericFacetStats are the statistics of the values of a numeric facet in the results, to bound a price slider for instance.
+type NumericFacetStats struct {
+	Min float64
+	Max float64
+	Avg float64
+	Sum float64
+	// Approximate is true when the statistics are computed from the counts of the facet values in `facets`, which only
+	// hold the `maxValuesPerFacet` most frequent values, because the response has no `facets_stats` for the facet.
+	Approximate bool
+}
+
+/*
+FacetsStatsMap returns the statistics of the numeric facets of the response, by facet name, from its `facets_stats`.
+The facets missing from `facets_stats` whose values are all numbers get statistics computed from the counts of their values in `facets`, marked as approximate.
+
+	@return map[string]NumericFacetStats - The statistics by facet name, empty when no facet is numeric.
+*/
+func (o *SearchResponse) FacetsStatsMap() map[string]NumericFacetStats {
+	stats := map[string]NumericFacetStats{}
+
+	for facet, counts := range o.GetFacets() {
+		if s, ok := computeFacetStats(counts); ok {
+			stats[facet] = s
+		}
+	}
+
+	for facet, s := range o.GetFacetsStats() {
+		stats[facet] = s.numeric()
+	}
+
+	return stats
+}
+
+/*
+FacetStatsOf returns the statistics of a numeric facet of the response, as FacetsStatsMap does.
+
+	@param facet string - Facet name, such as `price`.
+	@return NumericFacetStats - The statistics of the facet.
+	@return bool - Whether the facet is in the response and numeric.
+*/
+func (o *SearchResponse) FacetStatsOf(facet string) (NumericFacetStats, bool) {
+	if s, ok := o.GetFacetsStats()[facet]; ok {
+		return s.numeric(), true
+	}
+
+	return computeFacetStats(o.GetFacets()[facet])
+}
+
+// computeFacetStats computes the statistics of the values of a facet weighted by their counts, when they are all numbers.
+func computeFacetStats(counts map[string]int32) (NumericFacetStats, bool) {
+	if len(counts) == 0 {
+		return NumericFacetStats{}, false
+	}
+
+	stats := NumericFacetStats{Min: math.Inf(1), Max: math.Inf(-1), Approximate: true}
+
+	var total int64
+
+	for raw, count := range counts {
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+			return NumericFacetStats{}, false
+		}
+
+		stats.Min = min(stats.Min, value)
+		stats.Max = max(stats.Max, value)
+		stats.Sum += value * float64(count)
+		total += int64(count)
+	}
+
+	if total > 0 {
+		stats.Avg = stats.Sum / float64(total)
+	}
+
+	return stats, true
+}
+
+// numeric returns the statistics reported by the engine, the missing ones being zero.
+func (s FacetStats) numeric() NumericFacetStats {
+	return NumericFacetStats{Min: s.GetMin(), Max: s.GetMax(), Avg: s.GetAvg(), Sum: s.GetSum()}
+}
@@ -0,0 +1,44 @@
+package search_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+)
+
+func TestFacetsStats(t *testing.T) {
+	t.Parallel()
+
+	var res search.SearchResponse
+
+	err := json.Unmarshal([]byte(`{
+		"hits": [],
+		"facets": {"price": {"10": 2, "30": 1}, "rating": {"1.5": 1, "4": 3}, "brand": {"acme": 3}},
+		"facets_stats": {"price": {"min": 5, "max": 50, "avg": 20, "sum": 200}}
+	}`), &res)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]search.NumericFacetStats{
+		"price":  {Min: 5, Max: 50, Avg: 20, Sum: 200},
+		"rating": {Min: 1.5, Max: 4, Avg: 3.375, Sum: 13.5, Approximate: true},
+	}
+	if got := res.FacetsStatsMap(); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+
+	if stats, ok := res.FacetStatsOf("price"); !ok || stats.Max != 50 {
+		t.Errorf("expected the reported stats of price, got %+v (%v)", stats, ok)
+	}
+
+	if _, ok := res.FacetStatsOf("brand"); ok {
+		t.Error("expected no stats for a facet with text values")
+	}
+
+	if _, ok := res.FacetStatsOf("color"); ok {
+		t.Error("expected no stats for a missing facet")
+	}
+}
@@ -0,0 +1,145 @@
+package search
+
+import (
+	"sort"
+)
+
+// FederatedHit is a hit of a federated search, with the query it was found by.
+type FederatedHit struct {
+	Hit
+	// IndexName is the index of the query.
+	IndexName string
+	// QueryPosition is the position of the query in the federated search, starting at 0.
+	QueryPosition int
+	// Position is the position of the hit in the results of its query, starting at 0.
+	Position int
+	// Score is the score given by the FederatedScorer, the hits being merged by decreasing score.
+	Score float64
+}
+
+// FederatedScorer scores the hits of a federated search, which are merged by decreasing score. Hits with the same score are interleaved by position, then by query.
+type FederatedScorer func(hit FederatedHit) float64
+
+// InterleaveByPosition is the default FederatedScorer: it interleaves the results of the queries, the first hit of each query, then the second one, and so on.
+func InterleaveByPosition(hit FederatedHit) float64 {
+	return -float64(hit.Position)
+}
+
+// WeightedByIndex returns a FederatedScorer favoring the indices with a higher weight, the score of a hit being the weight of its index divided by its position plus one. Indices without a weight have a weight of 1.
+func WeightedByIndex(weights map[string]float64) FederatedScorer {
+	return func(hit FederatedHit) float64 {
+		weight, ok := weights[hit.IndexName]
+		if !ok {
+			weight = 1
+		}
+
+		return weight / float64(hit.Position+1)
+	}
+}
+
+// FederatedSearchConfig configures FederatedSearch.
+type FederatedSearchConfig struct {
+	// Scorer scores the hits to merge them. Defaults to InterleaveByPosition.
+	Scorer FederatedScorer
+	// DedupeKey returns the key of the hits considered the same, only the best scored one being kept. Defaults to the index and objectID of the hit, use the objectID alone to dedupe the records shared by several indices.
+	DedupeKey func(hit FederatedHit) string
+	// MaxHits is the maximum number of merged hits. Defaults to 0 (no maximum).
+	MaxHits int
+}
+
+// FederatedSearchResponse is the response of FederatedSearch.
+type FederatedSearchResponse struct {
+	// Hits are the merged hits.
+	Hits []FederatedHit
+	// Results are the responses of the queries, in order, for their facets and number of hits.
+	Results []SearchResponse
+}
+
+/*
+FederatedSearch searches several indices with a single `search` call, then merges their hits in a single list for "search everything" interfaces.
+The hits are scored by `cfg.Scorer`, interleaving the results of the queries by default, deduplicated by `cfg.DedupeKey` and sorted by decreasing score:
+
+	res, err := client.FederatedSearch([]search.SearchForHits{
+		*search.NewSearchForHits("products", search.WithSearchForHitsQuery("phone")),
+		*search.NewSearchForHits("articles", search.WithSearchForHitsQuery("phone")),
+	}, search.FederatedSearchConfig{Scorer: search.WeightedByIndex(map[string]float64{"products": 2}), MaxHits: 20})
+
+	@param queries []SearchForHits - The queries, one per index or variant of the search.
+	@param cfg FederatedSearchConfig - Configuration of the merge.
+	@param opts ...RequestOption - Optional parameters for the request.
+	@return *FederatedSearchResponse - The merged hits and the responses of the queries.
+	@return error - Error if any.
+*/
+func (c *APIClient) FederatedSearch(queries []SearchForHits, cfg FederatedSearchConfig, opts ...RequestOption) (*FederatedSearchResponse, error) {
+	if len(queries) == 0 {
+		return nil, reportError("a federated search needs at least one query")
+	}
+
+	if cfg.Scorer == nil {
+		cfg.Scorer = InterleaveByPosition
+	}
+
+	if cfg.DedupeKey == nil {
+		cfg.DedupeKey = func(hit FederatedHit) string {
+			return hit.IndexName + "\x00" + hit.ObjectID
+		}
+	}
+
+	requests := make([]SearchQuery, 0, len(queries))
+	for i := range queries {
+		requests = append(requests, *SearchForHitsAsSearchQuery(&queries[i]))
+	}
+
+	results, err := c.SearchForHits(c.NewApiSearchRequest(NewSearchMethodParams(requests)), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(results) != len(queries) {
+		return nil, reportError("expected %d search results for the federated search, got %d", len(queries), len(results))
+	}
+
+	var hits []FederatedHit
+
+	for i, res := range results {
+		for position, hit := range res.Hits {
+			hits = append(hits, FederatedHit{Hit: hit, IndexName: queries[i].IndexName, QueryPosition: i, Position: position})
+		}
+	}
+
+	// Before scoring, the hits are ordered by position then query, for the hits with the same score to be interleaved.
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].Position != hits[j].Position {
+			return hits[i].Position < hits[j].Position
+		}
+
+		return hits[i].QueryPosition < hits[j].QueryPosition
+	})
+
+	for i := range hits {
+		hits[i].Score = cfg.Scorer(hits[i])
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		return hits[i].Score > hits[j].Score
+	})
+
+	merged := make([]FederatedHit, 0, len(hits))
+	seen := make(map[string]bool, len(hits))
+
+	for _, hit := range hits {
+		if cfg.MaxHits > 0 && len(merged) >= cfg.MaxHits {
+			break
+		}
+
+		key := cfg.DedupeKey(hit)
+		if seen[key] {
+			continue
+		}
+
+		seen[key] = true
+		merged = append(merged, hit)
+	}
+
+	return &FederatedSearchResponse{Hits: merged, Results: results}, nil
+}
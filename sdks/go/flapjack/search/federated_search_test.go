@@ -0,0 +1,129 @@
+package search_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+)
+
+// federatedHits are the objectIDs of the hits of each index of the federated search tests.
+var federatedHits = map[string][]string{
+	"products":     {"p1", "p2", "p3"},
+	"articles":     {"a1", "shared"},
+	"products_new": {"p2", "shared"},
+}
+
+func newFederatedTestClient(t *testing.T) *search.APIClient {
+	t.Helper()
+
+	return newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Requests []struct {
+				IndexName string `json:"indexName"`
+			} `json:"requests"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		results := make([]string, 0, len(body.Requests))
+
+		for _, request := range body.Requests {
+			hits := make([]string, 0, len(federatedHits[request.IndexName]))
+			for _, objectID := range federatedHits[request.IndexName] {
+				hits = append(hits, fmt.Sprintf(`{"objectID":%q}`, objectID))
+			}
+
+			results = append(results, fmt.Sprintf(`{"index":%q,"hits":[%s],"nbHits":%d,"page":0,"hitsPerPage":20,"processingTimeMS":1,"query":"","params":""}`,
+				request.IndexName, strings.Join(hits, ","), len(hits)))
+		}
+
+		_, _ = w.Write([]byte(`{"results":[` + strings.Join(results, ",") + `]}`))
+	})
+}
+
+func TestFederatedSearch(t *testing.T) {
+	t.Parallel()
+
+	client := newFederatedTestClient(t)
+
+	tests := []struct {
+		name    string
+		indices []string
+		cfg     search.FederatedSearchConfig
+		want    []string
+	}{
+		{
+			name:    "interleaved",
+			indices: []string{"products", "articles"},
+			want:    []string{"products/p1", "articles/a1", "products/p2", "articles/shared", "products/p3"},
+		},
+		{
+			name:    "weighted",
+			indices: []string{"articles", "products"},
+			cfg:     search.FederatedSearchConfig{Scorer: search.WeightedByIndex(map[string]float64{"products": 3})},
+			want:    []string{"products/p1", "products/p2", "articles/a1", "products/p3", "articles/shared"},
+		},
+		{
+			name:    "deduped within an index",
+			indices: []string{"products", "products"},
+			want:    []string{"products/p1", "products/p2", "products/p3"},
+		},
+		{
+			name:    "deduped across indices",
+			indices: []string{"products", "products_new", "articles"},
+			cfg: search.FederatedSearchConfig{
+				DedupeKey: func(hit search.FederatedHit) string { return hit.ObjectID },
+				MaxHits:   4,
+			},
+			want: []string{"products/p1", "products_new/p2", "articles/a1", "products_new/shared"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			queries := make([]search.SearchForHits, 0, len(tt.indices))
+			for _, indexName := range tt.indices {
+				queries = append(queries, *search.NewSearchForHits(indexName, search.WithSearchForHitsQuery("phone")))
+			}
+
+			res, err := client.FederatedSearch(queries, tt.cfg)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got := make([]string, 0, len(res.Hits))
+			for _, hit := range res.Hits {
+				got = append(got, hit.IndexName+"/"+hit.ObjectID)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("unexpected hits\n got: %v\nwant: %v", got, tt.want)
+			}
+
+			if len(res.Results) != len(tt.indices) {
+				t.Errorf("expected %d results, got %d", len(tt.indices), len(res.Results))
+			}
+		})
+	}
+}
+
+func TestFederatedSearchWithoutQueries(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("no request should be sent, got %s %s", r.Method, r.URL.Path)
+	})
+
+	_, err := client.FederatedSearch(nil, search.FederatedSearchConfig{})
+	if err == nil || !strings.Contains(err.Error(), "at least one query") {
+		t.Errorf("expected an error for the missing queries, got %v", err)
+	}
+}
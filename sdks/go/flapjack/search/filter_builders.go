@@ -0,0 +1,239 @@
+package search
+
+import (
+	"math"
+	"strings"
+)
+
+// numericCondition is a comparison of a NumericFilterBuilder, such as `price>=10`.
+type numericCondition struct {
+	attribute string
+	operator  string
+	value     float64
+}
+
+func (c numericCondition) String() string {
+	return c.attribute + c.operator + formatFilterNumber(c.value)
+}
+
+/*
+NumericFilterBuilder builds the `numericFilters` parameter. Each call adds a condition, the conditions being combined with AND,
+and Or adds a group of conditions combined with OR, as the API only supports ANDs of ORs:
+
+	numericFilters, err := search.NewNumericFilterBuilder().
+		Range("price", 10, 100).
+		Or(search.NewNumericFilterBuilder().Gte("rating", 4), search.NewNumericFilterBuilder().Eq("featured", 1)).
+		Build()
+
+	// ["price>=10", "price<=100", ["rating>=4", "featured=1"]]
+
+The conditions are validated by Build, before being sent.
+*/
+type NumericFilterBuilder struct {
+	groups [][]numericCondition
+	err    error
+}
+
+// NewNumericFilterBuilder returns an empty NumericFilterBuilder.
+func NewNumericFilterBuilder() *NumericFilterBuilder {
+	return &NumericFilterBuilder{}
+}
+
+// Eq adds a condition matching the records whose numeric attribute equals the value.
+func (b *NumericFilterBuilder) Eq(attribute string, value float64) *NumericFilterBuilder {
+	return b.add(attribute, "=", value)
+}
+
+// Neq adds a condition matching the records whose numeric attribute differs from the value.
+func (b *NumericFilterBuilder) Neq(attribute string, value float64) *NumericFilterBuilder {
+	return b.add(attribute, "!=", value)
+}
+
+// Gt adds a condition matching the records whose numeric attribute is greater than the value.
+func (b *NumericFilterBuilder) Gt(attribute string, value float64) *NumericFilterBuilder {
+	return b.add(attribute, ">", value)
+}
+
+// Gte adds a condition matching the records whose numeric attribute is greater than or equal to the value.
+func (b *NumericFilterBuilder) Gte(attribute string, value float64) *NumericFilterBuilder {
+	return b.add(attribute, ">=", value)
+}
+
+// Lt adds a condition matching the records whose numeric attribute is less than the value.
+func (b *NumericFilterBuilder) Lt(attribute string, value float64) *NumericFilterBuilder {
+	return b.add(attribute, "<", value)
+}
+
+// Lte adds a condition matching the records whose numeric attribute is less than or equal to the value.
+func (b *NumericFilterBuilder) Lte(attribute string, value float64) *NumericFilterBuilder {
+	return b.add(attribute, "<=", value)
+}
+
+// Range adds the conditions matching the records whose numeric attribute is between lower and upper, both included.
+func (b *NumericFilterBuilder) Range(attribute string, lower float64, upper float64) *NumericFilterBuilder {
+	if lower > upper {
+		b.fail(reportError("the range of `%s` is empty: %s > %s", attribute, formatFilterNumber(lower), formatFilterNumber(upper)))
+	}
+
+	return b.add(attribute, ">=", lower).add(attribute, "<=", upper)
+}
+
+/*
+Or adds a group matching the records matching any of the builders' conditions.
+Each builder must hold a single condition or OR group, as the API cannot nest an AND inside an OR. Empty builders are ignored.
+
+	@param builders ...*NumericFilterBuilder - Builders of the alternatives.
+	@return *NumericFilterBuilder - The builder, for chaining.
+*/
+func (b *NumericFilterBuilder) Or(builders ...*NumericFilterBuilder) *NumericFilterBuilder {
+	var group []numericCondition
+
+	for _, builder := range builders {
+		if builder == nil {
+			continue
+		}
+
+		if builder.err != nil {
+			b.fail(builder.err)
+		}
+
+		// A builder holding a single OR group is flattened into this one.
+		if len(builder.groups) > 1 {
+			b.fail(reportError("an OR group of numeric filters cannot contain a builder with %d conditions", len(builder.groups)))
+		}
+
+		for _, conditions := range builder.groups {
+			group = append(group, conditions...)
+		}
+	}
+
+	if len(group) > 0 {
+		b.groups = append(b.groups, group)
+	}
+
+	return b
+}
+
+/*
+Build validates the conditions and returns the `numericFilters` parameter.
+
+	@return *NumericFilters - The `numericFilters` parameter.
+	@return error - The first invalid condition, such as an attribute name containing an operator or a value which is not finite.
+*/
+func (b *NumericFilterBuilder) Build() (*NumericFilters, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	filters := make([]NumericFilters, 0, len(b.groups))
+
+	for _, group := range b.groups {
+		if len(group) == 1 {
+			filters = append(filters, *StringAsNumericFilters(group[0].String()))
+
+			continue
+		}
+
+		alternatives := make([]NumericFilters, 0, len(group))
+		for _, condition := range group {
+			alternatives = append(alternatives, *StringAsNumericFilters(condition.String()))
+		}
+
+		filters = append(filters, *ArrayOfNumericFiltersAsNumericFilters(alternatives))
+	}
+
+	return ArrayOfNumericFiltersAsNumericFilters(filters), nil
+}
+
+func (b *NumericFilterBuilder) add(attribute string, operator string, value float64) *NumericFilterBuilder {
+	switch {
+	case attribute == "":
+		b.fail(reportError("the attribute of a numeric filter is empty"))
+	case strings.ContainsAny(attribute, "<>=! "):
+		b.fail(reportError("the attribute `%s` of a numeric filter cannot contain spaces or operators", attribute))
+	case math.IsNaN(value) || math.IsInf(value, 0):
+		b.fail(reportError("the value of the numeric filter on `%s` is not finite", attribute))
+	}
+
+	b.groups = append(b.groups, []numericCondition{{attribute: attribute, operator: operator, value: value}})
+
+	return b
+}
+
+// fail records the first error, returned by Build.
+func (b *NumericFilterBuilder) fail(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+/*
+TagFilterBuilder builds the `tagFilters` parameter, filtering on the `_tags` attribute of the records.
+Tag adds a tag which records must have, and Or a group of tags of which records must have at least one:
+
+	tagFilters, err := search.NewTagFilterBuilder().Tag("published").Or("news", "sports").Build()
+
+	// ["published", ["news", "sports"]]
+
+The tags are validated by Build, before being sent.
+*/
+type TagFilterBuilder struct {
+	groups [][]string
+	err    error
+}
+
+// NewTagFilterBuilder returns an empty TagFilterBuilder.
+func NewTagFilterBuilder() *TagFilterBuilder {
+	return &TagFilterBuilder{}
+}
+
+// Tag adds a condition matching the records with the tag.
+func (b *TagFilterBuilder) Tag(tag string) *TagFilterBuilder {
+	return b.Or(tag)
+}
+
+// Or adds a condition matching the records with any of the tags. Without tags, no condition is added.
+func (b *TagFilterBuilder) Or(tags ...string) *TagFilterBuilder {
+	for _, tag := range tags {
+		if tag == "" && b.err == nil {
+			b.err = reportError("tag filters cannot contain an empty tag")
+		}
+	}
+
+	if len(tags) > 0 {
+		b.groups = append(b.groups, append([]string{}, tags...))
+	}
+
+	return b
+}
+
+/*
+Build validates the tags and returns the `tagFilters` parameter.
+
+	@return *TagFilters - The `tagFilters` parameter.
+	@return error - Error if a tag is empty.
+*/
+func (b *TagFilterBuilder) Build() (*TagFilters, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	filters := make([]TagFilters, 0, len(b.groups))
+
+	for _, group := range b.groups {
+		if len(group) == 1 {
+			filters = append(filters, *StringAsTagFilters(group[0]))
+
+			continue
+		}
+
+		alternatives := make([]TagFilters, 0, len(group))
+		for _, tag := range group {
+			alternatives = append(alternatives, *StringAsTagFilters(tag))
+		}
+
+		filters = append(filters, *ArrayOfTagFiltersAsTagFilters(alternatives))
+	}
+
+	return ArrayOfTagFiltersAsTagFilters(filters), nil
+}
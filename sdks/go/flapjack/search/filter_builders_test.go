@@ -0,0 +1,109 @@
+package search_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+)
+
+func TestNumericFilterBuilder(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		builder *search.NumericFilterBuilder
+		want    string
+		wantErr string
+	}{
+		{
+			name:    "empty",
+			builder: search.NewNumericFilterBuilder(),
+			want:    `[]`,
+		},
+		{
+			name:    "comparisons",
+			builder: search.NewNumericFilterBuilder().Eq("stock", 0).Neq("rating", 1).Gt("price", 9.99).Gte("price", 5).Lt("weight", 1e6).Lte("weight", -2.5),
+			want:    `["stock=0","rating!=1","price>9.99","price>=5","weight<1000000","weight<=-2.5"]`,
+		},
+		{
+			name: "range and or",
+			builder: search.NewNumericFilterBuilder().
+				Range("price", 10, 100).
+				Or(search.NewNumericFilterBuilder().Gte("rating", 4), nil, search.NewNumericFilterBuilder().Eq("featured", 1)).
+				Or(search.NewNumericFilterBuilder().Or(search.NewNumericFilterBuilder().Lt("a", 1), search.NewNumericFilterBuilder().Gt("b", 2))).
+				Or(),
+			want: `["price>=10","price<=100",["rating>=4","featured=1"],["a<1","b>2"]]`,
+		},
+		{
+			name:    "empty attribute",
+			builder: search.NewNumericFilterBuilder().Gt("", 1),
+			wantErr: "attribute of a numeric filter is empty",
+		},
+		{
+			name:    "attribute with an operator",
+			builder: search.NewNumericFilterBuilder().Gt("price>0 OR price", 1),
+			wantErr: "cannot contain spaces or operators",
+		},
+		{
+			name:    "not finite",
+			builder: search.NewNumericFilterBuilder().Lt("price", math.Inf(1)),
+			wantErr: "is not finite",
+		},
+		{
+			name:    "empty range",
+			builder: search.NewNumericFilterBuilder().Range("price", 100, 10),
+			wantErr: "the range of `price` is empty",
+		},
+		{
+			name:    "and inside or",
+			builder: search.NewNumericFilterBuilder().Or(search.NewNumericFilterBuilder().Range("price", 10, 100)),
+			wantErr: "cannot contain a builder with 2 conditions",
+		},
+		{
+			name:    "invalid alternative",
+			builder: search.NewNumericFilterBuilder().Or(search.NewNumericFilterBuilder().Gt("", 1)),
+			wantErr: "attribute of a numeric filter is empty",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			filters, err := tt.builder.Build()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected an error containing %q, got %v", tt.wantErr, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			sameJSON(t, filters, tt.want)
+		})
+	}
+}
+
+func TestTagFilterBuilder(t *testing.T) {
+	t.Parallel()
+
+	filters, err := search.NewTagFilterBuilder().Tag("published").Or("news", "sports").Or().Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sameJSON(t, filters, `["published",["news","sports"]]`)
+
+	_, err = search.NewTagFilterBuilder().Or("news", "").Build()
+	if err == nil || !strings.Contains(err.Error(), "empty tag") {
+		t.Errorf("expected an error for the empty tag, got %v", err)
+	}
+}
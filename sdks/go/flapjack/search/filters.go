@@ -0,0 +1,323 @@
+package search
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// filterCondition is a condition of a Filters expression, rendered so that it can be joined with AND.
+type filterCondition struct {
+	expr string
+	// negatable is set for `attribute:value` conditions and parenthesized groups, which can be negated without adding parentheses.
+	negatable bool
+}
+
+/*
+Filters builds a `filters` expression, quoting values so that values containing spaces, colons or keywords cannot change the meaning of the expression.
+Each call adds a condition, the conditions being combined with AND, and Build validates and renders the expression:
+
+	filters, err := search.NewFilters().
+		Eq("brand", "Black & Decker").
+		Range("price", 10, 100).
+		Or(search.NewFilters().Eq("color", "red"), search.NewFilters().Eq("color", "blue")).
+		Build()
+
+	// brand:"Black & Decker" AND price:10 TO 100 AND (color:"red" OR color:"blue")
+	search.NewSearchParamsObject().SetFilters(filters)
+
+The engine only parses attribute names made of ASCII letters, digits and underscores, and quoted values which are not empty and contain no double quote:
+Build returns an error for the conditions which can't be written in its syntax, rather than an expression matching other records.
+*/
+type Filters struct {
+	conditions []filterCondition
+	err        error
+}
+
+// NewFilters returns an empty Filters expression, which matches every record.
+func NewFilters() *Filters {
+	return &Filters{}
+}
+
+/*
+Eq adds a condition matching the records whose attribute equals the value.
+Strings and booleans are matched as facet values, which requires the attribute to be declared in `attributesForFaceting`, and numbers with a numeric comparison.
+
+	@param attribute string - Attribute name.
+	@param value any - String, boolean or number to match, other values being matched as the facet value of their default format.
+	@return *Filters - The expression, for chaining.
+*/
+func (f *Filters) Eq(attribute string, value any) *Filters {
+	if number, ok := filterNumber(value); ok {
+		return f.compare(attribute, "=", number)
+	}
+
+	f.conditions = append(f.conditions, f.facetCondition(attribute, value))
+
+	return f
+}
+
+// Neq adds a condition matching the records whose numeric attribute differs from the value.
+func (f *Filters) Neq(attribute string, value float64) *Filters {
+	return f.compare(attribute, "!=", value)
+}
+
+// Gt adds a condition matching the records whose numeric attribute is greater than the value.
+func (f *Filters) Gt(attribute string, value float64) *Filters {
+	return f.compare(attribute, ">", value)
+}
+
+// Gte adds a condition matching the records whose numeric attribute is greater than or equal to the value.
+func (f *Filters) Gte(attribute string, value float64) *Filters {
+	return f.compare(attribute, ">=", value)
+}
+
+// Lt adds a condition matching the records whose numeric attribute is less than the value.
+func (f *Filters) Lt(attribute string, value float64) *Filters {
+	return f.compare(attribute, "<", value)
+}
+
+// Lte adds a condition matching the records whose numeric attribute is less than or equal to the value.
+func (f *Filters) Lte(attribute string, value float64) *Filters {
+	return f.compare(attribute, "<=", value)
+}
+
+// Range adds a condition matching the records whose numeric attribute is between lower and upper, both included.
+func (f *Filters) Range(attribute string, lower float64, upper float64) *Filters {
+	f.conditions = append(f.conditions, filterCondition{
+		expr: fmt.Sprintf("%s:%s TO %s", f.attribute(attribute), f.number(attribute, lower), f.number(attribute, upper)),
+	})
+
+	return f
+}
+
+/*
+In adds a condition matching the records whose attribute equals any of the values, as an OR of Eq conditions.
+Without values, the condition would match every record instead of none: Build returns an error.
+
+	@param attribute string - Attribute name.
+	@param values ...any - Values to match, as for Eq.
+	@return *Filters - The expression, for chaining.
+*/
+func (f *Filters) In(attribute string, values ...any) *Filters {
+	if len(values) == 0 {
+		f.fail(reportError("the list of values of `%s` is empty, which would match every record", attribute))
+
+		return f
+	}
+
+	alternatives := make([]*Filters, 0, len(values))
+	for _, value := range values {
+		alternatives = append(alternatives, NewFilters().Eq(attribute, value))
+	}
+
+	return f.Or(alternatives...)
+}
+
+// And adds a condition matching the records matching all the expressions, which is useful inside Or.
+func (f *Filters) And(filters ...*Filters) *Filters {
+	for _, filter := range filters {
+		if filter != nil {
+			f.conditions = append(f.conditions, filter.conditions...)
+			f.fail(filter.err)
+		}
+	}
+
+	return f
+}
+
+// Or adds a condition matching the records matching any of the expressions. Empty expressions are ignored.
+func (f *Filters) Or(filters ...*Filters) *Filters {
+	var alternatives []filterCondition
+
+	for _, filter := range filters {
+		if filter != nil {
+			f.fail(filter.err)
+		}
+
+		switch {
+		case filter == nil || len(filter.conditions) == 0:
+		case len(filter.conditions) == 1:
+			alternatives = append(alternatives, filter.conditions[0])
+		default:
+			alternatives = append(alternatives, filterCondition{expr: "(" + filter.String() + ")", negatable: true})
+		}
+	}
+
+	switch len(alternatives) {
+	case 0:
+	case 1:
+		f.conditions = append(f.conditions, alternatives[0])
+	default:
+		exprs := make([]string, 0, len(alternatives))
+		for _, alternative := range alternatives {
+			exprs = append(exprs, alternative.expr)
+		}
+
+		f.conditions = append(f.conditions, filterCondition{expr: "(" + strings.Join(exprs, " OR ") + ")", negatable: true})
+	}
+
+	return f
+}
+
+// Not adds a condition matching the records which do not match the expression. An empty expression is ignored.
+func (f *Filters) Not(filter *Filters) *Filters {
+	if filter != nil {
+		f.fail(filter.err)
+	}
+
+	switch {
+	case filter == nil || len(filter.conditions) == 0:
+	case len(filter.conditions) == 1 && filter.conditions[0].negatable:
+		f.conditions = append(f.conditions, filterCondition{expr: "NOT " + filter.conditions[0].expr})
+	default:
+		f.conditions = append(f.conditions, filterCondition{expr: "NOT (" + filter.String() + ")"})
+	}
+
+	return f
+}
+
+/*
+Build validates the conditions and renders the expression to the syntax of the `filters` parameter, an empty expression rendering to an empty string.
+
+	@return string - The `filters` parameter.
+	@return error - The first condition which can't be written in the syntax of the engine, such as an attribute name with a dot or a value with a double quote.
+*/
+func (f *Filters) Build() (string, error) {
+	if f == nil {
+		return "", nil
+	}
+
+	if f.err != nil {
+		return "", f.err
+	}
+
+	return f.String(), nil
+}
+
+// String renders the expression without validating it, for logging: use Build to get the `filters` parameter.
+func (f *Filters) String() string {
+	if f == nil {
+		return ""
+	}
+
+	exprs := make([]string, 0, len(f.conditions))
+	for _, condition := range f.conditions {
+		exprs = append(exprs, condition.expr)
+	}
+
+	return strings.Join(exprs, " AND ")
+}
+
+func (f *Filters) compare(attribute string, operator string, value float64) *Filters {
+	f.conditions = append(f.conditions, filterCondition{
+		expr: fmt.Sprintf("%s %s %s", f.attribute(attribute), operator, f.number(attribute, value)),
+	})
+
+	return f
+}
+
+// facetCondition renders an `attribute:value` condition, booleans being left unquoted as the engine indexes them as `true` and `false`.
+func (f *Filters) facetCondition(attribute string, value any) filterCondition {
+	var expr string
+
+	switch value := value.(type) {
+	case bool:
+		expr = strconv.FormatBool(value)
+	case string:
+		expr = f.quote(attribute, value)
+	default:
+		expr = f.quote(attribute, fmt.Sprint(value))
+	}
+
+	return filterCondition{expr: f.attribute(attribute) + ":" + expr, negatable: true}
+}
+
+// attribute returns the attribute name, and records an error if the engine can't parse it: the names must be made of
+// ASCII letters, digits and underscores, and not be a keyword.
+func (f *Filters) attribute(attribute string) string {
+	valid := attribute != ""
+
+	for _, r := range attribute {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_') {
+			valid = false
+		}
+	}
+
+	switch strings.ToUpper(attribute) {
+	case "AND", "OR", "NOT":
+		valid = false
+	}
+
+	if !valid {
+		f.fail(reportError("the attribute %q cannot be filtered on: filter attributes must be made of ASCII letters, digits and underscores, and not be AND, OR or NOT", attribute))
+	}
+
+	return attribute
+}
+
+// quote wraps a value in double quotes, and records an error if the engine can't parse it: quoted values can't be
+// empty nor contain double quotes, which can't be escaped.
+func (f *Filters) quote(attribute string, value string) string {
+	switch {
+	case value == "":
+		f.fail(reportError("the value of `%s` is empty, which cannot be filtered on", attribute))
+	case strings.Contains(value, `"`):
+		f.fail(reportError("the value %q of `%s` contains a double quote, which cannot be filtered on", value, attribute))
+	}
+
+	return `"` + value + `"`
+}
+
+// number formats a numeric value, and records an error if it is not finite.
+func (f *Filters) number(attribute string, value float64) string {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		f.fail(reportError("the value of `%s` is not finite", attribute))
+	}
+
+	return formatFilterNumber(value)
+}
+
+// fail records the first error, returned by Build.
+func (f *Filters) fail(err error) {
+	if f.err == nil {
+		f.err = err
+	}
+}
+
+// filterNumber returns the numeric value of the Go numeric types.
+func filterNumber(value any) (float64, bool) {
+	switch value := value.(type) {
+	case int:
+		return float64(value), true
+	case int8:
+		return float64(value), true
+	case int16:
+		return float64(value), true
+	case int32:
+		return float64(value), true
+	case int64:
+		return float64(value), true
+	case uint:
+		return float64(value), true
+	case uint8:
+		return float64(value), true
+	case uint16:
+		return float64(value), true
+	case uint32:
+		return float64(value), true
+	case uint64:
+		return float64(value), true
+	case float32:
+		return float64(value), true
+	case float64:
+		return value, true
+	default:
+		return 0, false
+	}
+}
+
+func formatFilterNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
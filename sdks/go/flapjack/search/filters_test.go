@@ -0,0 +1,191 @@
+package search_test
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/flapjacktest"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+)
+
+func TestFilters(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		filters *search.Filters
+		want    string
+	}{
+		{
+			name:    "empty",
+			filters: search.NewFilters(),
+			want:    "",
+		},
+		{
+			name:    "facet value",
+			filters: search.NewFilters().Eq("brand", "Apple"),
+			want:    `brand:"Apple"`,
+		},
+		{
+			name:    "quoted facet value",
+			filters: search.NewFilters().Eq("title", `Apple OR brand:Samsung \`).Eq("release_date", "2024").Eq("in_stock", true),
+			want:    `title:"Apple OR brand:Samsung \" AND release_date:"2024" AND in_stock:true`,
+		},
+		{
+			name:    "numeric comparisons",
+			filters: search.NewFilters().Eq("stock", 0).Neq("rating", 1).Gt("price", 9.99).Gte("price", 5).Lt("weight", 1e6).Lte("weight", -2.5),
+			want:    "stock = 0 AND rating != 1 AND price > 9.99 AND price >= 5 AND weight < 1000000 AND weight <= -2.5",
+		},
+		{
+			name:    "range",
+			filters: search.NewFilters().Range("price", 10, 100.5),
+			want:    "price:10 TO 100.5",
+		},
+		{
+			name:    "in",
+			filters: search.NewFilters().In("color", "red", "dark blue").In("sku", 12),
+			want:    `(color:"red" OR color:"dark blue") AND sku = 12`,
+		},
+		{
+			name: "or of and",
+			filters: search.NewFilters().Or(
+				search.NewFilters().Eq("brand", "Apple").Gt("price", 500),
+				search.NewFilters().Eq("on_sale", true),
+				search.NewFilters(),
+			),
+			want: `((brand:"Apple" AND price > 500) OR on_sale:true)`,
+		},
+		{
+			name:    "and inside or",
+			filters: search.NewFilters().Eq("category", "phones").Or(search.NewFilters().And(search.NewFilters().Eq("a", "1"), search.NewFilters().Eq("b", "2")), search.NewFilters().Eq("c", "3")),
+			want:    `category:"phones" AND ((a:"1" AND b:"2") OR c:"3")`,
+		},
+		{
+			name:    "not",
+			filters: search.NewFilters().Not(search.NewFilters().Eq("status", "sold out")).Not(search.NewFilters().Gt("price", 100)).Not(search.NewFilters().In("color", "red", "blue")).Not(nil),
+			want:    `NOT status:"sold out" AND NOT (price > 100) AND NOT (color:"red" OR color:"blue")`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tt.filters.Build()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("unexpected filters\n got: %s\nwant: %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFiltersValidation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		filters *search.Filters
+	}{
+		{name: "attribute with a space", filters: search.NewFilters().Eq("release date", "2024")},
+		{name: "attribute with a dot", filters: search.NewFilters().Gt("author.age", 30)},
+		{name: "empty attribute", filters: search.NewFilters().Range("", 1, 2)},
+		{name: "keyword attribute", filters: search.NewFilters().Eq("not", true)},
+		{name: "value with a double quote", filters: search.NewFilters().Eq("title", `Apple" OR brand:"Samsung`)},
+		{name: "empty value", filters: search.NewFilters().Eq("brand", "")},
+		{name: "infinite value", filters: search.NewFilters().Lt("price", math.Inf(1))},
+		{name: "no values", filters: search.NewFilters().In("size")},
+		{name: "nested", filters: search.NewFilters().Or(search.NewFilters().Eq("brand", "Apple"), search.NewFilters().Not(search.NewFilters().Eq("brand", "")))},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got, err := tt.filters.Build(); err == nil {
+				t.Errorf("expected an error, got %s", got)
+			}
+		})
+	}
+}
+
+func TestFiltersSearch(t *testing.T) {
+	t.Parallel()
+
+	srv := flapjacktest.NewServer()
+	t.Cleanup(srv.Close)
+
+	srv.AddObjects("products",
+		map[string]any{"objectID": "1", "brand": "Black & Decker", "color": "red", "price": 25.0, "on_sale": true},
+		map[string]any{"objectID": "2", "brand": "Acme", "color": []any{"red", "dark blue"}, "price": 150.0, "on_sale": false},
+		map[string]any{"objectID": "3", "brand": "Acme", "color": "green", "price": 9.99, "on_sale": true},
+	)
+
+	client, err := srv.NewClient()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		filters *search.Filters
+		want    []string
+	}{
+		{
+			name:    "facet value",
+			filters: search.NewFilters().Eq("brand", "Black & Decker"),
+			want:    []string{"1"},
+		},
+		{
+			name:    "in and range",
+			filters: search.NewFilters().In("color", "dark blue", "green").Range("price", 100, 200),
+			want:    []string{"2"},
+		},
+		{
+			name:    "or of and",
+			filters: search.NewFilters().Or(search.NewFilters().Eq("brand", "Acme").Lt("price", 10), search.NewFilters().Eq("on_sale", true).Gte("price", 25)),
+			want:    []string{"1", "3"},
+		},
+		{
+			name:    "not",
+			filters: search.NewFilters().Not(search.NewFilters().Eq("color", "red")).Not(search.NewFilters().Gt("price", 100)),
+			want:    []string{"3"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			filters, err := tt.filters.Build()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			res, err := client.SearchSingleIndex(client.NewApiSearchSingleIndexRequest("products").
+				WithSearchParams(search.SearchParamsObjectAsSearchParams(search.NewEmptySearchParamsObject().SetFilters(filters))))
+			if err != nil {
+				t.Fatalf("unexpected error for %s: %v", filters, err)
+			}
+
+			got := []string{}
+			for _, hit := range res.Hits {
+				got = append(got, hit.ObjectID)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("unexpected hits %v for %s, want %v", got, filters, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,249 @@
+package search
+
+import (
+	"strconv"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/utils"
+)
+
+// LatLng is a point, as a latitude and a longitude in degrees.
+type LatLng struct {
+	Lat float64
+	Lng float64
+}
+
+// String renders the point as `lat,lng`, the format of the `aroundLatLng` parameter.
+func (p LatLng) String() string {
+	return strconv.FormatFloat(p.Lat, 'f', -1, 64) + "," + strconv.FormatFloat(p.Lng, 'f', -1, 64)
+}
+
+// validate checks the point is on Earth, which catches most points whose latitude and longitude were swapped.
+func (p LatLng) validate() error {
+	if p.Lat < -90 || p.Lat > 90 {
+		return reportError("latitude %v of point %s is not between -90 and 90, coordinates must be given as latitude then longitude", p.Lat, p)
+	}
+
+	if p.Lng < -180 || p.Lng > 180 {
+		return reportError("longitude %v of point %s is not between -180 and 180", p.Lng, p)
+	}
+
+	return nil
+}
+
+/*
+GeoQuery builds the geo search parameters of a query, which otherwise have to be encoded by hand. Either search around a point:
+
+	opts, err := search.NewGeoQuery().
+		AroundLatLng(search.LatLng{Lat: 48.8566, Lng: 2.3522}).
+		AroundRadius(5000).
+		SearchForHitsOptions()
+
+	search.NewSearchForHits("restaurants", opts...)
+
+or inside bounding boxes and polygons, records matching any of the shapes.
+The parameters are validated when the options are built.
+*/
+type GeoQuery struct {
+	around    *LatLng
+	radius    *AroundRadius
+	precision *AroundPrecision
+	boxes     [][]float64
+	polygons  [][]float64
+	err       error
+}
+
+// NewGeoQuery returns an empty GeoQuery.
+func NewGeoQuery() *GeoQuery {
+	return &GeoQuery{}
+}
+
+// AroundLatLng searches around the point, ranking the records by their distance to it.
+func (g *GeoQuery) AroundLatLng(point LatLng) *GeoQuery {
+	g.fail(point.validate())
+	g.around = &point
+
+	return g
+}
+
+// AroundRadius only matches the records within `meters` of the point given to AroundLatLng.
+func (g *GeoQuery) AroundRadius(meters int32) *GeoQuery {
+	if meters < 1 {
+		g.fail(reportError("the radius must be at least 1 meter, got %d", meters))
+	}
+
+	g.radius = Int32AsAroundRadius(meters)
+
+	return g
+}
+
+// AroundRadiusAll matches the records regardless of their distance to the point given to AroundLatLng, which still ranks them.
+func (g *GeoQuery) AroundRadiusAll() *GeoQuery {
+	g.radius = AroundRadiusAllAsAroundRadius(AROUND_RADIUS_ALL_ALL)
+
+	return g
+}
+
+// AroundPrecision groups the distances by steps of `meters`, the records in the same step being ranked as equally distant.
+func (g *GeoQuery) AroundPrecision(meters int32) *GeoQuery {
+	if meters < 1 {
+		g.fail(reportError("the precision must be at least 1 meter, got %d", meters))
+	}
+
+	g.precision = Int32AsAroundPrecision(meters)
+
+	return g
+}
+
+/*
+AroundPrecisionRanges sets a precision per range of distances, each range applying from its `From` distance until the next range.
+
+	@param ranges ...ModelRange - Ranges, ordered by increasing `From`, with a positive precision as `Value`.
+	@return *GeoQuery - The query, for chaining.
+*/
+func (g *GeoQuery) AroundPrecisionRanges(ranges ...ModelRange) *GeoQuery {
+	if len(ranges) == 0 {
+		g.fail(reportError("the precision ranges are empty"))
+	}
+
+	for i, r := range ranges {
+		switch {
+		case r.GetFrom() < 0:
+			g.fail(reportError("precision range %d starts at a negative distance: %d", i, r.GetFrom()))
+		case r.GetValue() < 1:
+			g.fail(reportError("precision range %d must have a precision of at least 1 meter, got %d", i, r.GetValue()))
+		case i > 0 && r.GetFrom() <= ranges[i-1].GetFrom():
+			g.fail(reportError("precision range %d must start after range %d: %d <= %d", i, i-1, r.GetFrom(), ranges[i-1].GetFrom()))
+		}
+	}
+
+	g.precision = ArrayOfModelRangeAsAroundPrecision(append([]ModelRange{}, ranges...))
+
+	return g
+}
+
+// InsideBoundingBox matches the records inside the rectangle of the two opposite corners. Several boxes match the records inside any of them.
+func (g *GeoQuery) InsideBoundingBox(corner1 LatLng, corner2 LatLng) *GeoQuery {
+	g.fail(corner1.validate())
+	g.fail(corner2.validate())
+
+	if corner1.Lat == corner2.Lat || corner1.Lng == corner2.Lng {
+		g.fail(reportError("the bounding box of corners %s and %s is empty", corner1, corner2))
+	}
+
+	g.boxes = append(g.boxes, []float64{corner1.Lat, corner1.Lng, corner2.Lat, corner2.Lng})
+
+	return g
+}
+
+// InsidePolygon matches the records inside the polygon of at least 3 points. Several polygons match the records inside any of them.
+func (g *GeoQuery) InsidePolygon(points ...LatLng) *GeoQuery {
+	if len(points) < 3 {
+		g.fail(reportError("a polygon needs at least 3 points, got %d", len(points)))
+	}
+
+	polygon := make([]float64, 0, 2*len(points))
+
+	for _, point := range points {
+		g.fail(point.validate())
+		polygon = append(polygon, point.Lat, point.Lng)
+	}
+
+	g.polygons = append(g.polygons, polygon)
+
+	return g
+}
+
+/*
+SearchForHitsOptions validates the parameters and returns them as options of NewSearchForHits.
+
+	@return []SearchForHitsOption - The options.
+	@return error - The first invalid parameter.
+*/
+func (g *GeoQuery) SearchForHitsOptions() ([]SearchForHitsOption, error) {
+	err := g.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []SearchForHitsOption
+
+	if g.around != nil {
+		opts = append(opts, WithSearchForHitsAroundLatLng(g.around.String()))
+	}
+
+	if g.radius != nil {
+		opts = append(opts, WithSearchForHitsAroundRadius(*g.radius))
+	}
+
+	if g.precision != nil {
+		opts = append(opts, WithSearchForHitsAroundPrecision(*g.precision))
+	}
+
+	if g.boxes != nil {
+		opts = append(opts, WithSearchForHitsInsideBoundingBox(*utils.NewNullable(ArrayOfArrayOfFloat64AsInsideBoundingBox(g.boxes))))
+	}
+
+	if g.polygons != nil {
+		opts = append(opts, WithSearchForHitsInsidePolygon(g.polygons))
+	}
+
+	return opts, nil
+}
+
+/*
+SearchParamsObjectOptions validates the parameters and returns them as options of NewSearchParamsObject.
+
+	@return []SearchParamsObjectOption - The options.
+	@return error - The first invalid parameter.
+*/
+func (g *GeoQuery) SearchParamsObjectOptions() ([]SearchParamsObjectOption, error) {
+	err := g.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []SearchParamsObjectOption
+
+	if g.around != nil {
+		opts = append(opts, WithSearchParamsObjectAroundLatLng(g.around.String()))
+	}
+
+	if g.radius != nil {
+		opts = append(opts, WithSearchParamsObjectAroundRadius(*g.radius))
+	}
+
+	if g.precision != nil {
+		opts = append(opts, WithSearchParamsObjectAroundPrecision(*g.precision))
+	}
+
+	if g.boxes != nil {
+		opts = append(opts, WithSearchParamsObjectInsideBoundingBox(*utils.NewNullable(ArrayOfArrayOfFloat64AsInsideBoundingBox(g.boxes))))
+	}
+
+	if g.polygons != nil {
+		opts = append(opts, WithSearchParamsObjectInsidePolygon(g.polygons))
+	}
+
+	return opts, nil
+}
+
+// validate returns the first invalid parameter, or the first inconsistency between the parameters.
+func (g *GeoQuery) validate() error {
+	switch {
+	case g.err != nil:
+		return g.err
+	case g.around == nil && (g.radius != nil || g.precision != nil):
+		return reportError("the radius and precision of a geo query require AroundLatLng")
+	case g.around != nil && (g.boxes != nil || g.polygons != nil):
+		return reportError("a geo query cannot search both around a point and inside shapes")
+	default:
+		return nil
+	}
+}
+
+// fail records the first error, returned when the options are built.
+func (g *GeoQuery) fail(err error) {
+	if g.err == nil {
+		g.err = err
+	}
+}
@@ -0,0 +1,118 @@
+package search_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+)
+
+func TestGeoQuery(t *testing.T) {
+	t.Parallel()
+
+	paris := search.LatLng{Lat: 48.8566, Lng: 2.3522}
+	london := search.LatLng{Lat: 51.5072, Lng: -0.1276}
+
+	tests := []struct {
+		name    string
+		query   *search.GeoQuery
+		want    string
+		wantErr string
+	}{
+		{
+			name:  "around",
+			query: search.NewGeoQuery().AroundLatLng(paris).AroundRadius(5000).AroundPrecision(100),
+			want:  `{"indexName":"restaurants","aroundLatLng":"48.8566,2.3522","aroundRadius":5000,"aroundPrecision":100}`,
+		},
+		{
+			name: "around all with ranges",
+			query: search.NewGeoQuery().AroundLatLng(paris).AroundRadiusAll().AroundPrecisionRanges(
+				*search.NewModelRange(search.WithModelRangeFrom(0), search.WithModelRangeValue(10)),
+				*search.NewModelRange(search.WithModelRangeFrom(2000), search.WithModelRangeValue(1000)),
+			),
+			want: `{"indexName":"restaurants","aroundLatLng":"48.8566,2.3522","aroundRadius":"all","aroundPrecision":[{"from":0,"value":10},{"from":2000,"value":1000}]}`,
+		},
+		{
+			name: "shapes",
+			query: search.NewGeoQuery().
+				InsideBoundingBox(paris, london).
+				InsideBoundingBox(search.LatLng{Lat: 1, Lng: 2}, search.LatLng{Lat: 3, Lng: 4}).
+				InsidePolygon(paris, london, search.LatLng{Lat: 50.8503, Lng: 4.3517}),
+			want: `{"indexName":"restaurants","insideBoundingBox":[[48.8566,2.3522,51.5072,-0.1276],[1,2,3,4]],"insidePolygon":[[48.8566,2.3522,51.5072,-0.1276,50.8503,4.3517]]}`,
+		},
+		{
+			name:    "swapped coordinates",
+			query:   search.NewGeoQuery().AroundLatLng(search.LatLng{Lat: 151.2093, Lng: -33.8688}),
+			wantErr: "latitude then longitude",
+		},
+		{
+			name:    "longitude out of range",
+			query:   search.NewGeoQuery().InsidePolygon(paris, london, search.LatLng{Lat: 0, Lng: 200}),
+			wantErr: "longitude 200",
+		},
+		{
+			name:    "small polygon",
+			query:   search.NewGeoQuery().InsidePolygon(paris, london),
+			wantErr: "at least 3 points",
+		},
+		{
+			name:    "empty bounding box",
+			query:   search.NewGeoQuery().InsideBoundingBox(paris, search.LatLng{Lat: paris.Lat, Lng: 10}),
+			wantErr: "is empty",
+		},
+		{
+			name:    "zero radius",
+			query:   search.NewGeoQuery().AroundLatLng(paris).AroundRadius(0),
+			wantErr: "at least 1 meter",
+		},
+		{
+			name: "unordered ranges",
+			query: search.NewGeoQuery().AroundLatLng(paris).AroundPrecisionRanges(
+				*search.NewModelRange(search.WithModelRangeFrom(2000), search.WithModelRangeValue(1000)),
+				*search.NewModelRange(search.WithModelRangeFrom(0), search.WithModelRangeValue(10)),
+			),
+			wantErr: "must start after range 0",
+		},
+		{
+			name:    "radius without point",
+			query:   search.NewGeoQuery().AroundRadius(1000),
+			wantErr: "require AroundLatLng",
+		},
+		{
+			name:    "point and shapes",
+			query:   search.NewGeoQuery().AroundLatLng(paris).InsideBoundingBox(paris, london),
+			wantErr: "both around a point and inside shapes",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts, err := tt.query.SearchForHitsOptions()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected an error containing %q, got %v", tt.wantErr, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			sameJSON(t, search.NewSearchForHits("restaurants", opts...), tt.want)
+
+			paramsOpts, err := tt.query.SearchParamsObjectOptions()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			want := strings.Replace(tt.want, `"indexName":"restaurants",`, "", 1)
+			sameJSON(t, search.NewSearchParamsObject(paramsOpts...), want)
+		})
+	}
+}
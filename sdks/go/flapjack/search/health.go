@@ -0,0 +1,75 @@
+package search
+
+// Memory pressure levels reported by the `/health` endpoint.
+const (
+	// PressureLevelNormal allows every operation.
+	PressureLevelNormal = "normal"
+	// PressureLevelElevated rejects the writes and allows the reads.
+	PressureLevelElevated = "elevated"
+	// PressureLevelCritical rejects every request but the health checks.
+	PressureLevelCritical = "critical"
+)
+
+// HealthResponse is the health of a Flapjack server, as reported by its `/health` endpoint.
+type HealthResponse struct {
+	// Status is "ok" when the server is up.
+	Status               string `json:"status"`
+	ActiveWriters        int    `json:"active_writers"`
+	MaxConcurrentWriters int    `json:"max_concurrent_writers"`
+	FacetCacheEntries    int    `json:"facet_cache_entries"`
+	FacetCacheCap        int    `json:"facet_cache_cap"`
+	HeapAllocatedMB      int    `json:"heap_allocated_mb"`
+	SystemLimitMB        int    `json:"system_limit_mb"`
+	// PressureLevel is the memory pressure of the server, one of the PressureLevel constants.
+	PressureLevel string `json:"pressure_level"`
+	Allocator     string `json:"allocator"`
+	BuildProfile  string `json:"build_profile"`
+}
+
+// Degraded reports whether the server is not fully available: its status is not "ok", or its memory pressure rejects
+// some requests.
+func (h *HealthResponse) Degraded() bool {
+	return h.Status != "ok" || h.PressureLevel != PressureLevelNormal
+}
+
+// RejectsWrites reports whether the memory pressure of the server makes it reject the writes.
+func (h *HealthResponse) RejectsWrites() bool {
+	return h.PressureLevel == PressureLevelElevated || h.PressureLevel == PressureLevelCritical
+}
+
+/*
+GetHealth returns the health of the server answering the call, from its `/health` endpoint, to alert on degraded servers:
+
+	health, err := client.GetHealth()
+	if err != nil || health.Degraded() {
+		alert(health, err)
+	}
+
+	@param opts ...RequestOption - Optional parameters for the request.
+	@return *HealthResponse - The health of the server.
+	@return error - Error if any.
+*/
+func (c *APIClient) GetHealth(opts ...RequestOption) (*HealthResponse, error) {
+	res, resBody, err := c.CustomGetWithHTTPInfo(c.NewApiCustomGetRequest("health"), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if res == nil {
+		return nil, reportError("res is nil")
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 300 {
+		return nil, c.decodeError(res, resBody)
+	}
+
+	var health HealthResponse
+
+	err = c.decode(&health, resBody)
+	if err != nil {
+		return nil, reportError("cannot decode result: %w", err)
+	}
+
+	return &health, nil
+}
@@ -0,0 +1,66 @@
+package search_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+)
+
+func TestGetHealth(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		pressure          string
+		wantDegraded      bool
+		wantRejectsWrites bool
+	}{
+		{name: "normal", pressure: search.PressureLevelNormal},
+		{name: "elevated", pressure: search.PressureLevelElevated, wantDegraded: true, wantRejectsWrites: true},
+		{name: "critical", pressure: search.PressureLevelCritical, wantDegraded: true, wantRejectsWrites: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet || r.URL.Path != "/health" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+				}
+
+				_, _ = w.Write([]byte(`{"status":"ok","active_writers":1,"max_concurrent_writers":4,"facet_cache_entries":10,` +
+					`"facet_cache_cap":500,"heap_allocated_mb":120,"system_limit_mb":2048,"pressure_level":"` + tt.pressure + `",` +
+					`"allocator":"jemalloc","build_profile":"release"}`))
+			})
+
+			health, err := client.GetHealth()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if health.Status != "ok" || health.ActiveWriters != 1 || health.MaxConcurrentWriters != 4 || health.HeapAllocatedMB != 120 || health.SystemLimitMB != 2048 {
+				t.Errorf("unexpected health %+v", health)
+			}
+
+			if health.Degraded() != tt.wantDegraded || health.RejectsWrites() != tt.wantRejectsWrites {
+				t.Errorf("expected degraded %t and rejected writes %t, got %+v", tt.wantDegraded, tt.wantRejectsWrites, health)
+			}
+		})
+	}
+}
+
+func TestGetHealthError(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	if _, err := client.GetHealth(); err == nil {
+		t.Error("expected an error")
+	}
+}
@@ -0,0 +1,67 @@
+package search_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/call"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+// newTestClient returns a client whose only host is an httptest server backed by the given handler, configured with
+// the given options.
+func newTestClient(t *testing.T, handler http.HandlerFunc, opts ...transport.ClientOption) *search.APIClient {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	cfg := search.SearchConfiguration{
+		Configuration: transport.Configuration{
+			AppID:  "test-app",
+			ApiKey: "test-api-key",
+			Hosts: []transport.StatefulHost{
+				transport.NewStatefulHost("http", strings.TrimPrefix(srv.URL, "http://"), call.IsReadWrite),
+			},
+			DefaultHeader: make(map[string]string),
+		},
+	}
+
+	for _, opt := range opts {
+		opt(&cfg.Configuration)
+	}
+
+	client, err := search.NewClientWithConfig(cfg)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	return client
+}
+
+// sameJSON fails the test unless a value encodes to the JSON document, ignoring the escaping of the comparison
+// operators.
+func sameJSON(t *testing.T, value any, want string) {
+	t.Helper()
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got, wantValue any
+
+	if err = errors.Join(json.Unmarshal(raw, &got), json.Unmarshal([]byte(want), &wantValue)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(got, wantValue) {
+		t.Fatalf("unexpected JSON %s, want %s", raw, want)
+	}
+}
@@ -0,0 +1,14 @@
+package search
+
+// WithoutHighlighting asks the engine not to compute the `_highlightResult` and `_snippetResult` attributes of the
+// hits, so that they are neither sent nor decoded when they are not used. It overrides the `attributesToHighlight`
+// and `attributesToSnippet` parameters of the request.
+//
+// It applies to the operations whose body holds the search parameters, such as `searchSingleIndex` and `browse`. For
+// the `search` method, set these parameters on each request instead.
+func WithoutHighlighting() requestOption {
+	return WithBodyParams(map[string]any{
+		"attributesToHighlight": []string{},
+		"attributesToSnippet":   []string{},
+	})
+}
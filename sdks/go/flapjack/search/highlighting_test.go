@@ -0,0 +1,52 @@
+package search_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+)
+
+func TestWithoutHighlighting(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatalf("cannot read body: %v", err)
+		}
+
+		var params map[string]any
+
+		err = json.Unmarshal(body, &params)
+		if err != nil {
+			t.Fatalf("cannot decode body: %v", err)
+		}
+
+		for _, key := range []string{"attributesToHighlight", "attributesToSnippet"} {
+			if attrs, ok := params[key].([]any); !ok || len(attrs) != 0 {
+				t.Errorf("expected an empty %s, got %v", key, params[key])
+			}
+		}
+
+		if params["query"] != "phone" {
+			t.Errorf("expected the query to be kept, got %v", params["query"])
+		}
+
+		_, _ = w.Write([]byte(`{"hits":[{"objectID":"1"}],"nbHits":1,"processingTimeMS":1,"query":"phone","params":""}`))
+	})
+
+	res, err := client.SearchSingleIndex(client.NewApiSearchSingleIndexRequest("products").
+		WithSearchParams(search.SearchParamsObjectAsSearchParams(search.NewEmptySearchParamsObject().
+			SetQuery("phone").SetAttributesToHighlight([]string{"name"}))),
+		search.WithoutHighlighting())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(res.Hits) != 1 || res.Hits[0].HighlightResult != nil {
+		t.Errorf("unexpected hits %v", res.Hits)
+	}
+}
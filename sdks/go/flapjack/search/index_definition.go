@@ -0,0 +1,270 @@
+package search
+
+import (
+	"reflect"
+	"strings"
+)
+
+// IndexDefinition is the declarative definition of an index, which ApplyIndexDefinition converges a live index to.
+type IndexDefinition struct {
+	// Settings are the settings of the index. Only the settings set are
+	// managed, the other ones being left as they are.
+	Settings *IndexSettings
+	// Synonyms is the complete set of synonyms of the index, the other ones
+	// being deleted. The synonyms are left as they are when nil.
+	Synonyms []SynonymHit
+	// Rules is the complete set of rules of the index, the other ones being
+	// deleted. The rules are left as they are when nil.
+	Rules []Rule
+}
+
+// ObjectChanges lists the objectIDs of the synonyms or rules changed by ApplyIndexDefinition.
+type ObjectChanges struct {
+	Added   []string
+	Updated []string
+	Deleted []string
+}
+
+// Empty returns whether nothing changed.
+func (c ObjectChanges) Empty() bool {
+	return len(c.Added) == 0 && len(c.Updated) == 0 && len(c.Deleted) == 0
+}
+
+// IndexDefinitionChanges is the report of ApplyIndexDefinition.
+type IndexDefinitionChanges struct {
+	Settings SettingsDiff
+	Synonyms ObjectChanges
+	Rules    ObjectChanges
+}
+
+// Changed returns whether the index was changed.
+func (c *IndexDefinitionChanges) Changed() bool {
+	return len(c.Settings) > 0 || !c.Synonyms.Empty() || !c.Rules.Empty()
+}
+
+// String returns the changes as a plan, grouped by section.
+func (c *IndexDefinitionChanges) String() string {
+	if !c.Changed() {
+		return "No changes."
+	}
+
+	var b strings.Builder
+
+	writeSection := func(title string, lines []string) {
+		if len(lines) == 0 {
+			return
+		}
+
+		b.WriteString(title + ":\n")
+
+		for _, line := range lines {
+			b.WriteString("  " + line + "\n")
+		}
+	}
+
+	settingsLines := func(diff SettingsDiff) []string {
+		lines := make([]string, 0, len(diff))
+		for _, change := range diff {
+			lines = append(lines, change.String())
+		}
+
+		return lines
+	}
+
+	objectLines := func(changes ObjectChanges) []string {
+		var lines []string
+
+		for _, group := range []struct {
+			sign string
+			ids  []string
+		}{{"+", changes.Added}, {"~", changes.Updated}, {"-", changes.Deleted}} {
+			for _, id := range group.ids {
+				lines = append(lines, group.sign+" "+id)
+			}
+		}
+
+		return lines
+	}
+
+	writeSection("settings", settingsLines(c.Settings))
+	writeSection("synonyms", objectLines(c.Synonyms))
+	writeSection("rules", objectLines(c.Rules))
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+/*
+ApplyIndexDefinition converges an index to its definition, sending only what differs, and waits for the changes to be applied.
+Applying the same definition again changes nothing, which makes it suited to manage indices from code, like infrastructure.
+The engine has no replicas and drops the `replicas` setting, so a definition setting it is rejected.
+
+	@param indexName string - Index name.
+	@param def IndexDefinition - The definition of the index.
+	@param opts ...IterableOption - Optional parameters for the requests.
+	@return *IndexDefinitionChanges - What changed.
+	@return error - Error if any.
+*/
+func (c *APIClient) ApplyIndexDefinition(indexName string, def IndexDefinition, opts ...IterableOption) (*IndexDefinitionChanges, error) {
+	if def.Settings != nil && def.Settings.Replicas != nil {
+		return nil, reportError("the engine has no replicas, the `replicas` setting of an index definition is not supported")
+	}
+
+	changes := &IndexDefinitionChanges{}
+
+	var taskIDs []int64
+
+	settings := NewEmptyIndexSettings()
+	if def.Settings != nil {
+		settings = def.Settings
+	}
+
+	diff, res, err := c.ApplySettings(indexName, settings, toRequestOptions(opts)...)
+	if err != nil {
+		return nil, err
+	}
+
+	changes.Settings = diff
+
+	if res != nil {
+		taskIDs = append(taskIDs, res.TaskID)
+	}
+
+	if def.Synonyms != nil {
+		taskID, err := c.applySynonyms(indexName, def.Synonyms, &changes.Synonyms, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		taskIDs = append(taskIDs, taskID...)
+	}
+
+	if def.Rules != nil {
+		taskID, err := c.applyRules(indexName, def.Rules, &changes.Rules, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		taskIDs = append(taskIDs, taskID...)
+	}
+
+	err = <-c.WaitForTasks(indexName, taskIDs, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// applySynonyms replaces the synonyms of an index by the desired ones when they differ, and returns the task to wait for.
+func (c *APIClient) applySynonyms(indexName string, desired []SynonymHit, changes *ObjectChanges, opts []IterableOption) ([]int64, error) {
+	var current []SynonymHit
+
+	err := c.BrowseSynonyms(indexName, SearchSynonymsParams{}, append(append([]IterableOption{}, opts...), WithAggregator(func(res any, _ error) {
+		if res, ok := res.(*SearchSynonymsResponse); ok {
+			current = append(current, res.Hits...)
+		}
+	}))...)
+	if err != nil {
+		return nil, err
+	}
+
+	*changes, err = diffObjects(current, desired, func(synonym SynonymHit) string { return synonym.ObjectID })
+	if err != nil || changes.Empty() {
+		return nil, err
+	}
+
+	var res *UpdatedAtResponse
+
+	if len(desired) == 0 {
+		res, err = c.ClearSynonyms(c.NewApiClearSynonymsRequest(indexName), toRequestOptions(opts)...)
+	} else {
+		res, err = c.SaveSynonyms(c.NewApiSaveSynonymsRequest(indexName, desired).WithReplaceExistingSynonyms(true), toRequestOptions(opts)...)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return []int64{res.TaskID}, nil
+}
+
+// applyRules replaces the rules of an index by the desired ones when they differ, and returns the task to wait for.
+func (c *APIClient) applyRules(indexName string, desired []Rule, changes *ObjectChanges, opts []IterableOption) ([]int64, error) {
+	var current []Rule
+
+	err := c.BrowseRules(indexName, SearchRulesParams{}, append(append([]IterableOption{}, opts...), WithAggregator(func(res any, _ error) {
+		if res, ok := res.(*SearchRulesResponse); ok {
+			current = append(current, res.Hits...)
+		}
+	}))...)
+	if err != nil {
+		return nil, err
+	}
+
+	*changes, err = diffObjects(current, desired, func(rule Rule) string { return rule.ObjectID })
+	if err != nil || changes.Empty() {
+		return nil, err
+	}
+
+	var res *UpdatedAtResponse
+
+	if len(desired) == 0 {
+		res, err = c.ClearRules(c.NewApiClearRulesRequest(indexName), toRequestOptions(opts)...)
+	} else {
+		res, err = c.SaveRules(c.NewApiSaveRulesRequest(indexName, desired).WithClearExistingRules(true), toRequestOptions(opts)...)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return []int64{res.TaskID}, nil
+}
+
+// diffObjects compares synonyms or rules by objectID and content.
+func diffObjects[T any](current, desired []T, objectID func(T) string) (ObjectChanges, error) {
+	changes := ObjectChanges{}
+	currentValues := make(map[string]map[string]any, len(current))
+
+	for _, obj := range current {
+		values, err := jsonValues(obj)
+		if err != nil {
+			return changes, err
+		}
+
+		for _, attribute := range syncIgnoredAttributes {
+			delete(values, attribute)
+		}
+
+		currentValues[objectID(obj)] = values
+	}
+
+	desiredIDs := make(map[string]bool, len(desired))
+
+	for _, obj := range desired {
+		values, err := jsonValues(obj)
+		if err != nil {
+			return changes, err
+		}
+
+		id := objectID(obj)
+		desiredIDs[id] = true
+
+		existing, ok := currentValues[id]
+
+		switch {
+		case !ok:
+			changes.Added = append(changes.Added, id)
+		case !reflect.DeepEqual(existing, values):
+			changes.Updated = append(changes.Updated, id)
+		}
+	}
+
+	for _, obj := range current {
+		if id := objectID(obj); !desiredIDs[id] {
+			changes.Deleted = append(changes.Deleted, id)
+		}
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,92 @@
+package search_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/flapjacktest"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+)
+
+func TestApplyIndexDefinition(t *testing.T) {
+	t.Parallel()
+
+	srv := flapjacktest.NewServer()
+	t.Cleanup(srv.Close)
+
+	client, err := srv.NewClient()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	def := search.IndexDefinition{
+		Settings: search.NewEmptyIndexSettings().SetSearchableAttributes([]string{"name"}),
+		Synonyms: []search.SynonymHit{
+			*search.NewSynonymHit("crepe", search.SYNONYM_TYPE_SYNONYM, search.WithSynonymHitSynonyms([]string{"crepe", "galette"})),
+			*search.NewSynonymHit("waffle", search.SYNONYM_TYPE_SYNONYM, search.WithSynonymHitSynonyms([]string{"waffle", "gaufre"})),
+		},
+		Rules: []search.Rule{
+			*search.NewRule("promote-waffles", *search.NewEmptyConsequence(), search.WithRuleDescription("Promote waffles")),
+		},
+	}
+
+	changes, err := client.ApplyIndexDefinition("products", def)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantPlan := `settings:
+  + searchableAttributes: ["name"]
+synonyms:
+  + crepe
+  + waffle
+rules:
+  + promote-waffles`
+	if got := changes.String(); got != wantPlan {
+		t.Fatalf("unexpected changes\n got: %s\nwant: %s", got, wantPlan)
+	}
+
+	// Applying the same definition changes nothing.
+	changes, err = client.ApplyIndexDefinition("products", def)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if changes.Changed() {
+		t.Fatalf("expected no changes, got\n%s", changes)
+	}
+
+	def.Synonyms = []search.SynonymHit{
+		*search.NewSynonymHit("crepe", search.SYNONYM_TYPE_SYNONYM, search.WithSynonymHitSynonyms([]string{"crepe", "galette", "pancake"})),
+	}
+	def.Rules = []search.Rule{}
+
+	changes, err = client.ApplyIndexDefinition("products", def)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(changes.Synonyms, search.ObjectChanges{Updated: []string{"crepe"}, Deleted: []string{"waffle"}}) ||
+		!reflect.DeepEqual(changes.Rules, search.ObjectChanges{Deleted: []string{"promote-waffles"}}) ||
+		len(changes.Settings) != 0 {
+		t.Fatalf("unexpected changes\n%s", changes)
+	}
+
+	synonyms, err := client.SearchSynonyms(client.NewApiSearchSynonymsRequest("products"))
+	if err != nil || len(synonyms.Hits) != 1 || len(synonyms.Hits[0].Synonyms) != 3 {
+		t.Errorf("unexpected synonyms %+v: %v", synonyms, err)
+	}
+
+	rules, err := client.SearchRules(client.NewApiSearchRulesRequest("products"))
+	if err != nil || len(rules.Hits) != 0 {
+		t.Errorf("unexpected rules %+v: %v", rules, err)
+	}
+
+	def.Settings = search.NewEmptyIndexSettings().SetReplicas([]string{"products_price_asc"})
+
+	_, err = client.ApplyIndexDefinition("products", def)
+	if err == nil || !strings.Contains(err.Error(), "the engine has no replicas") {
+		t.Errorf("expected an error for the replicas, got %v", err)
+	}
+}
@@ -0,0 +1,235 @@
+package search
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/insights"
+)
+
+const (
+	// DefaultClickEventName is the name of the click events sent by InsightsCapture.
+	DefaultClickEventName = "Hit Clicked"
+	// DefaultConversionEventName is the name of the conversion events sent by InsightsCapture.
+	DefaultConversionEventName = "Hit Converted"
+	// DefaultMaxCapturedUserTokens is the number of user tokens whose last search InsightsCapture remembers.
+	DefaultMaxCapturedUserTokens = 10000
+)
+
+// InsightsCaptureConfig configures an InsightsCapture.
+type InsightsCaptureConfig struct {
+	// ClickEventName is the name of the click events. Defaults to DefaultClickEventName.
+	ClickEventName string
+	// ConversionEventName is the name of the conversion events. Defaults to DefaultConversionEventName.
+	ConversionEventName string
+	// MaxUserTokens is the number of user tokens whose last search is remembered, the least recent ones being forgotten first. Defaults to DefaultMaxCapturedUserTokens.
+	MaxUserTokens int
+}
+
+/*
+InsightsCapture wires searches to insights events: its searches enable `clickAnalytics` and remember the queryID of the last search of each user token, which SendClick and SendConversion then attach to the events, without passing the queryID around:
+
+	capture := search.NewInsightsCapture(searchClient, insightsClient, search.InsightsCaptureConfig{})
+
+	res, err := capture.Search("products", userToken, search.NewSearchParamsObject(search.WithSearchParamsObjectQuery("phone")))
+
+	// Later, when the user clicks the second hit.
+	_, err = capture.SendClick(userToken, res.Hits[1].ObjectID, 2)
+
+It is safe for concurrent use.
+*/
+type InsightsCapture struct {
+	client   *APIClient
+	insights *insights.APIClient
+	cfg      InsightsCaptureConfig
+
+	mu sync.Mutex
+	// queries holds the elements of recent, the last search of each user token, most recent first.
+	queries map[string]*list.Element
+	recent  *list.List
+}
+
+// capturedQuery is the last search of a user token.
+type capturedQuery struct {
+	tokens    insights.UserTokens
+	indexName string
+	queryID   string
+}
+
+/*
+NewInsightsCapture returns an InsightsCapture searching with `client` and sending the events with `insightsClient`.
+
+	@param client *APIClient - Client of the searches.
+	@param insightsClient *insights.APIClient - Client of the events.
+	@param cfg InsightsCaptureConfig - Configuration, whose zero value uses the defaults.
+	@return *InsightsCapture - The capture.
+*/
+func NewInsightsCapture(client *APIClient, insightsClient *insights.APIClient, cfg InsightsCaptureConfig) *InsightsCapture {
+	if cfg.ClickEventName == "" {
+		cfg.ClickEventName = DefaultClickEventName
+	}
+
+	if cfg.ConversionEventName == "" {
+		cfg.ConversionEventName = DefaultConversionEventName
+	}
+
+	if cfg.MaxUserTokens <= 0 {
+		cfg.MaxUserTokens = DefaultMaxCapturedUserTokens
+	}
+
+	return &InsightsCapture{
+		client:   client,
+		insights: insightsClient,
+		cfg:      cfg,
+		queries:  map[string]*list.Element{},
+		recent:   list.New(),
+	}
+}
+
+/*
+Search searches the index for the user with `clickAnalytics` enabled, and remembers the queryID of the response for the events of the user.
+
+	@param indexName string - Index name.
+	@param userToken string - Pseudonymous identifier of the user, sent as the `userToken` search parameter.
+	@param params *SearchParamsObject - Search parameters, which are not modified. Can be nil.
+	@param opts ...RequestOption - Optional parameters for the request.
+	@return *SearchResponse - The search response.
+	@return error - Error if any.
+*/
+func (c *InsightsCapture) Search(indexName string, userToken string, params *SearchParamsObject, opts ...RequestOption) (*SearchResponse, error) {
+	return c.SearchWithUserTokens(indexName, insights.UserTokens{UserToken: userToken}, params, opts...)
+}
+
+/*
+SearchWithUserTokens searches like Search for a user who may be signed in, such as the user tokens of an insights.UserTokenProvider.
+The search is sent with the user token of `tokens.SearchUserToken()`, and the events of SendClick and SendConversion, still identified by `tokens.UserToken`, carry the authenticated user token.
+
+	@param indexName string - Index name.
+	@param tokens insights.UserTokens - User tokens of the user.
+	@param params *SearchParamsObject - Search parameters, which are not modified. Can be nil.
+	@param opts ...RequestOption - Optional parameters for the request.
+	@return *SearchResponse - The search response.
+	@return error - Error if any.
+*/
+func (c *InsightsCapture) SearchWithUserTokens(indexName string, tokens insights.UserTokens, params *SearchParamsObject, opts ...RequestOption) (*SearchResponse, error) {
+	if tokens.UserToken == "" {
+		return nil, reportError("a user token is required to capture the queryID of a search")
+	}
+
+	captured := NewEmptySearchParamsObject()
+	if params != nil {
+		*captured = *params
+	}
+
+	captured.SetClickAnalytics(true)
+	captured.SetUserToken(tokens.SearchUserToken())
+
+	res, err := c.client.SearchSingleIndex(
+		c.client.NewApiSearchSingleIndexRequest(indexName).WithSearchParams(SearchParamsObjectAsSearchParams(captured)),
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if queryID := res.GetQueryID(); queryID != "" {
+		c.capture(capturedQuery{tokens: tokens, indexName: indexName, queryID: queryID})
+	}
+
+	return res, nil
+}
+
+/*
+SendClick sends a click event on a hit of the last search of the user.
+
+	@param userToken string - User token given to Search, or of the user tokens given to SearchWithUserTokens.
+	@param objectID string - ObjectID of the clicked hit.
+	@param position int32 - Position of the hit in the results, starting at 1 on the first page.
+	@param opts ...insights.RequestOption - Optional parameters for the request.
+	@return *insights.EventsResponse - Response of the `pushEvents` call.
+	@return error - Error if no search of the user was captured, or if the event cannot be sent.
+*/
+func (c *InsightsCapture) SendClick(userToken string, objectID string, position int32, opts ...insights.RequestOption) (*insights.EventsResponse, error) {
+	query, err := c.lastQuery(userToken)
+	if err != nil {
+		return nil, err
+	}
+
+	event := insights.NewClickedObjectIDsAfterSearch(
+		c.cfg.ClickEventName, query.indexName, userToken, query.queryID, []string{objectID}, []int32{position}, query.tokens.EventsItemsOptions()...,
+	)
+
+	return c.push(event, opts)
+}
+
+/*
+SendConversion sends a conversion event on a hit of the last search of the user.
+
+	@param userToken string - User token given to Search, or of the user tokens given to SearchWithUserTokens.
+	@param objectID string - ObjectID of the converted hit.
+	@param opts ...insights.RequestOption - Optional parameters for the request.
+	@return *insights.EventsResponse - Response of the `pushEvents` call.
+	@return error - Error if no search of the user was captured, or if the event cannot be sent.
+*/
+func (c *InsightsCapture) SendConversion(userToken string, objectID string, opts ...insights.RequestOption) (*insights.EventsResponse, error) {
+	query, err := c.lastQuery(userToken)
+	if err != nil {
+		return nil, err
+	}
+
+	event := insights.NewConvertedObjectIDsAfterSearch(
+		c.cfg.ConversionEventName, query.indexName, userToken, query.queryID, []string{objectID}, query.tokens.EventsItemsOptions()...,
+	)
+
+	return c.push(event, opts)
+}
+
+// LastQueryID returns the queryID of the last search of the user, if it is still remembered.
+func (c *InsightsCapture) LastQueryID(userToken string) (string, bool) {
+	query, err := c.lastQuery(userToken)
+	if err != nil {
+		return "", false
+	}
+
+	return query.queryID, true
+}
+
+func (c *InsightsCapture) push(event *insights.EventsItems, opts []insights.RequestOption) (*insights.EventsResponse, error) {
+	return c.insights.PushEvents( //nolint:wrapcheck
+		c.insights.NewApiPushEventsRequest(insights.NewInsightsEvents([]insights.EventsItems{*event})),
+		opts...,
+	)
+}
+
+// capture remembers the search as the last one of its user, forgetting the least recent user when there are too many.
+func (c *InsightsCapture) capture(query capturedQuery) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.queries[query.tokens.UserToken]; ok {
+		elem.Value = query
+		c.recent.MoveToFront(elem)
+
+		return
+	}
+
+	c.queries[query.tokens.UserToken] = c.recent.PushFront(query)
+
+	for c.recent.Len() > c.cfg.MaxUserTokens {
+		oldest := c.recent.Back()
+		c.recent.Remove(oldest)
+		delete(c.queries, oldest.Value.(capturedQuery).tokens.UserToken)
+	}
+}
+
+func (c *InsightsCapture) lastQuery(userToken string) (capturedQuery, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.queries[userToken]
+	if !ok {
+		return capturedQuery{}, reportError("no search was captured for the user token %q, or it was forgotten", userToken)
+	}
+
+	return elem.Value.(capturedQuery), nil
+}
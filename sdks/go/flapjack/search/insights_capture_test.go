@@ -0,0 +1,195 @@
+package search_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/call"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/insights"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+// newTestInsightsClient returns an insights client sending the events to handler.
+func newTestInsightsClient(t *testing.T, handler http.HandlerFunc) *insights.APIClient {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	client, err := insights.NewClientWithConfig(insights.InsightsConfiguration{
+		Configuration: transport.Configuration{
+			AppID:  "test-app",
+			ApiKey: "test-api-key",
+			Hosts: []transport.StatefulHost{
+				transport.NewStatefulHost("http", strings.TrimPrefix(srv.URL, "http://"), call.IsReadWrite),
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return client
+}
+
+func TestInsightsCapture(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu     sync.Mutex
+		params []map[string]any
+		events []map[string]any
+	)
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		mu.Lock()
+		params = append(params, body)
+		// A 32 characters hexadecimal queryID per user, such as 111... for user-1.
+		queryID := strings.Repeat(strings.TrimPrefix(body["userToken"].(string), "user-"), 32)
+		mu.Unlock()
+
+		_, _ = w.Write([]byte(`{"hits":[{"objectID":"phone1"}],"nbHits":1,"page":0,"hitsPerPage":20,"processingTimeMS":1,"query":"phone","params":"","queryID":"` + queryID + `"}`))
+	})
+
+	insightsClient := newTestInsightsClient(t, func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+
+		var body struct {
+			Events []map[string]any `json:"events"`
+		}
+		_ = json.Unmarshal(raw, &body)
+
+		mu.Lock()
+		events = append(events, body.Events...)
+		mu.Unlock()
+
+		_, _ = w.Write([]byte(`{"message":"OK","status":200}`))
+	})
+
+	capture := search.NewInsightsCapture(client, insightsClient, search.InsightsCaptureConfig{ConversionEventName: "Phone Bought"})
+
+	query := search.NewSearchParamsObject(search.WithSearchParamsObjectQuery("phone"))
+
+	_, err := capture.Search("products", "user-1", query)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = capture.Search("phones", "user-2", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if query.ClickAnalytics != nil || query.UserToken != nil {
+		t.Errorf("expected the parameters not to be modified, got %v", query)
+	}
+
+	if params[0]["clickAnalytics"] != true || params[0]["userToken"] != "user-1" || params[0]["query"] != "phone" {
+		t.Errorf("unexpected search parameters %v", params[0])
+	}
+
+	_, err = capture.SendClick("user-1", "phone1", 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = capture.SendConversion("user-2", "phone1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []map[string]any{
+		{"eventName": "Hit Clicked", "eventType": "click", "index": "products", "userToken": "user-1", "queryID": strings.Repeat("1", 32), "objectIDs": []any{"phone1"}, "positions": []any{float64(1)}},
+		{"eventName": "Phone Bought", "eventType": "conversion", "index": "phones", "userToken": "user-2", "queryID": strings.Repeat("2", 32), "objectIDs": []any{"phone1"}},
+	}
+
+	raw, _ := json.Marshal(want)
+	sameJSON(t, events, string(raw))
+
+	_, err = capture.SendClick("user-3", "phone1", 1)
+	if err == nil || !strings.Contains(err.Error(), `no search was captured for the user token "user-3"`) {
+		t.Errorf("expected an error for a user without searches, got %v", err)
+	}
+}
+
+func TestInsightsCaptureForgetsLeastRecentUsers(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		_, _ = w.Write([]byte(`{"hits":[],"nbHits":0,"page":0,"hitsPerPage":20,"processingTimeMS":1,"query":"","params":"","queryID":"query-` + body["userToken"].(string) + `"}`))
+	})
+
+	capture := search.NewInsightsCapture(client, nil, search.InsightsCaptureConfig{MaxUserTokens: 2})
+
+	for _, userToken := range []string{"user-1", "user-2", "user-1", "user-3"} {
+		_, err := capture.Search("products", userToken, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	for userToken, want := range map[string]bool{"user-1": true, "user-2": false, "user-3": true} {
+		if queryID, ok := capture.LastQueryID(userToken); ok != want || (ok && queryID != "query-"+userToken) {
+			t.Errorf("%s: expected remembered %v, got %q, %v", userToken, want, queryID, ok)
+		}
+	}
+
+	_, err := capture.Search("products", "", nil)
+	if err == nil {
+		t.Error("expected an error for a search without user token")
+	}
+}
+
+func TestInsightsCaptureAuthenticatedUser(t *testing.T) {
+	t.Parallel()
+
+	var searchUserToken string
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		searchUserToken, _ = body["userToken"].(string)
+
+		_, _ = w.Write([]byte(`{"hits":[],"nbHits":0,"page":0,"hitsPerPage":20,"processingTimeMS":1,"query":"","params":"","queryID":"` + strings.Repeat("a", 32) + `"}`))
+	})
+
+	var event map[string]any
+
+	insightsClient := newTestInsightsClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Events []map[string]any `json:"events"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		event = body.Events[0]
+
+		_, _ = w.Write([]byte(`{"message":"OK","status":200}`))
+	})
+
+	capture := search.NewInsightsCapture(client, insightsClient, search.InsightsCaptureConfig{})
+
+	_, err := capture.SearchWithUserTokens("products", insights.UserTokens{UserToken: "user-1", AuthenticatedUserToken: "account-7"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = capture.SendClick("user-1", "phone1", 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if searchUserToken != "account-7" || event["userToken"] != "user-1" || event["authenticatedUserToken"] != "account-7" {
+		t.Errorf("unexpected user tokens: searched as %q, event %v", searchUserToken, event)
+	}
+}
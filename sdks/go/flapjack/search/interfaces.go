@@ -0,0 +1,64 @@
+package search
+
+// The interfaces below are implemented by *APIClient, so that code depending on a part of the client can be tested
+// with mocks or fakes instead of a live server. Each of them includes the constructors of its requests.
+
+// SearchClient is the part of *APIClient searching indices.
+type SearchClient interface {
+	NewApiSearchRequest(searchMethodParams *SearchMethodParams) ApiSearchRequest
+	Search(r ApiSearchRequest, opts ...RequestOption) (*SearchResponses, error)
+	NewApiSearchSingleIndexRequest(indexName string) ApiSearchSingleIndexRequest
+	SearchSingleIndex(r ApiSearchSingleIndexRequest, opts ...RequestOption) (*SearchResponse, error)
+	NewApiSearchForFacetValuesRequest(indexName string, facetName string) ApiSearchForFacetValuesRequest
+	SearchForFacetValues(r ApiSearchForFacetValuesRequest, opts ...RequestOption) (*SearchForFacetValuesResponse, error)
+	NewApiBrowseRequest(indexName string) ApiBrowseRequest
+	Browse(r ApiBrowseRequest, opts ...RequestOption) (*BrowseResponse, error)
+	SearchForHits(r ApiSearchRequest, opts ...RequestOption) ([]SearchResponse, error)
+}
+
+// SettingsClient is the part of *APIClient managing the settings of indices.
+type SettingsClient interface {
+	NewApiGetSettingsRequest(indexName string) ApiGetSettingsRequest
+	GetSettings(r ApiGetSettingsRequest, opts ...RequestOption) (*SettingsResponse, error)
+	NewApiSetSettingsRequest(indexName string, indexSettings *IndexSettings) ApiSetSettingsRequest
+	SetSettings(r ApiSetSettingsRequest, opts ...RequestOption) (*UpdatedAtResponse, error)
+}
+
+// SynonymsClient is the part of *APIClient managing the synonyms of indices.
+type SynonymsClient interface {
+	NewApiSaveSynonymRequest(indexName string, objectID string, synonymHit *SynonymHit) ApiSaveSynonymRequest
+	SaveSynonym(r ApiSaveSynonymRequest, opts ...RequestOption) (*SaveSynonymResponse, error)
+	NewApiSaveSynonymsRequest(indexName string, synonymHit []SynonymHit) ApiSaveSynonymsRequest
+	SaveSynonyms(r ApiSaveSynonymsRequest, opts ...RequestOption) (*UpdatedAtResponse, error)
+	NewApiGetSynonymRequest(indexName string, objectID string) ApiGetSynonymRequest
+	GetSynonym(r ApiGetSynonymRequest, opts ...RequestOption) (*SynonymHit, error)
+	NewApiDeleteSynonymRequest(indexName string, objectID string) ApiDeleteSynonymRequest
+	DeleteSynonym(r ApiDeleteSynonymRequest, opts ...RequestOption) (*DeletedAtResponse, error)
+	NewApiSearchSynonymsRequest(indexName string) ApiSearchSynonymsRequest
+	SearchSynonyms(r ApiSearchSynonymsRequest, opts ...RequestOption) (*SearchSynonymsResponse, error)
+	NewApiClearSynonymsRequest(indexName string) ApiClearSynonymsRequest
+	ClearSynonyms(r ApiClearSynonymsRequest, opts ...RequestOption) (*UpdatedAtResponse, error)
+}
+
+// RulesClient is the part of *APIClient managing the rules of indices.
+type RulesClient interface {
+	NewApiSaveRuleRequest(indexName string, objectID string, rule *Rule) ApiSaveRuleRequest
+	SaveRule(r ApiSaveRuleRequest, opts ...RequestOption) (*UpdatedAtResponse, error)
+	NewApiSaveRulesRequest(indexName string, rules []Rule) ApiSaveRulesRequest
+	SaveRules(r ApiSaveRulesRequest, opts ...RequestOption) (*UpdatedAtResponse, error)
+	NewApiGetRuleRequest(indexName string, objectID string) ApiGetRuleRequest
+	GetRule(r ApiGetRuleRequest, opts ...RequestOption) (*Rule, error)
+	NewApiDeleteRuleRequest(indexName string, objectID string) ApiDeleteRuleRequest
+	DeleteRule(r ApiDeleteRuleRequest, opts ...RequestOption) (*UpdatedAtResponse, error)
+	NewApiSearchRulesRequest(indexName string) ApiSearchRulesRequest
+	SearchRules(r ApiSearchRulesRequest, opts ...RequestOption) (*SearchRulesResponse, error)
+	NewApiClearRulesRequest(indexName string) ApiClearRulesRequest
+	ClearRules(r ApiClearRulesRequest, opts ...RequestOption) (*UpdatedAtResponse, error)
+}
+
+var (
+	_ SearchClient   = (*APIClient)(nil)
+	_ SettingsClient = (*APIClient)(nil)
+	_ SynonymsClient = (*APIClient)(nil)
+	_ RulesClient    = (*APIClient)(nil)
+)
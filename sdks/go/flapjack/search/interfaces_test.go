@@ -0,0 +1,58 @@
+package search_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/utils"
+)
+
+// fakeSettings is a SettingsClient returning canned settings, the request constructors coming from the embedded
+// client.
+type fakeSettings struct {
+	*search.APIClient
+
+	settings *search.SettingsResponse
+	err      error
+}
+
+func (f *fakeSettings) GetSettings(search.ApiGetSettingsRequest, ...search.RequestOption) (*search.SettingsResponse, error) {
+	return f.settings, f.err
+}
+
+func (f *fakeSettings) SetSettings(search.ApiSetSettingsRequest, ...search.RequestOption) (*search.UpdatedAtResponse, error) {
+	return nil, errors.New("not implemented")
+}
+
+// hitsPerPage is the kind of application code depending on a part of the client only.
+func hitsPerPage(client search.SettingsClient, indexName string) (int32, error) {
+	settings, err := client.GetSettings(client.NewApiGetSettingsRequest(indexName))
+	if err != nil {
+		return 0, err //nolint:wrapcheck
+	}
+
+	return settings.GetHitsPerPage(), nil
+}
+
+func TestSettingsClientCanBeFaked(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeSettings{settings: &search.SettingsResponse{HitsPerPage: utils.ToPtr(int32(42))}}
+
+	got, err := hitsPerPage(fake, "products")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got != 42 {
+		t.Errorf("expected 42 hits per page, got %d", got)
+	}
+
+	fake.err = errors.New("boom")
+
+	_, err = hitsPerPage(fake, "products")
+	if err == nil {
+		t.Error("expected the error of the fake")
+	}
+}
@@ -0,0 +1,165 @@
+package search_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/errs"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/flapjacktest"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+)
+
+func TestCreateIterableHonorsContextCancellation(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	calls := 0
+	start := time.Now()
+
+	_, err := search.CreateIterable(
+		func(*int, error) (*int, error) {
+			calls++
+
+			return &calls, nil
+		},
+		func(*int, error) (bool, error) {
+			return false, nil
+		},
+		search.WithContext(ctx),
+		search.WithTimeout(func(int) time.Duration { return time.Hour }),
+	)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+
+	if calls != 1 {
+		t.Errorf("expected a single attempt before cancellation, got %d", calls)
+	}
+
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the wait to be interrupted, took %s", elapsed)
+	}
+}
+
+func TestCreateIterableStopsOnValidate(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+
+	res, err := search.CreateIterable(
+		func(*int, error) (*int, error) {
+			calls++
+
+			return &calls, nil
+		},
+		func(v *int, _ error) (bool, error) {
+			return *v == 3, nil
+		},
+		search.WithContext(context.Background()),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if *res != 3 {
+		t.Errorf("expected 3 attempts, got %d", *res)
+	}
+}
+
+func TestCreateIterableMaxDuration(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+
+	_, err := search.CreateIterable(
+		func(*int, error) (*int, error) {
+			calls++
+
+			return &calls, nil
+		},
+		func(*int, error) (bool, error) {
+			return false, nil
+		},
+		search.WithTimeout(func(int) time.Duration { return 20 * time.Millisecond }),
+		search.WithMaxDuration(50*time.Millisecond),
+	)
+
+	var waitErr *errs.WaitError
+	if !errors.As(err, &waitErr) {
+		t.Fatalf("expected a WaitError, got %v", err)
+	}
+
+	if calls < 2 || calls > 3 {
+		t.Errorf("expected 2 or 3 attempts within the budget, got %d", calls)
+	}
+}
+
+func TestCreateIterableWithClock(t *testing.T) {
+	t.Parallel()
+
+	clock := flapjacktest.NewClock(time.Time{})
+	calls := 0
+
+	_, err := search.CreateIterable(
+		func(*int, error) (*int, error) {
+			calls++
+
+			return &calls, nil
+		},
+		func(*int, error) (bool, error) {
+			return false, nil
+		},
+		search.WithTimeout(func(int) time.Duration { return time.Minute }),
+		search.WithMaxDuration(5*time.Minute),
+		search.WithClock(clock),
+	)
+
+	var waitErr *errs.WaitError
+	if !errors.As(err, &waitErr) {
+		t.Fatalf("expected a WaitError, got %v", err)
+	}
+
+	if calls != 6 || len(clock.Slept()) != 5 {
+		t.Errorf("expected 6 attempts and 5 waits within the budget, got %d attempts and waits %v", calls, clock.Slept())
+	}
+}
+
+func TestCreateIterableExponentialBackoff(t *testing.T) {
+	t.Parallel()
+
+	var delays []time.Duration
+
+	last := time.Now()
+
+	_, err := search.CreateIterable(
+		func(*int, error) (*int, error) {
+			now := time.Now()
+			delays = append(delays, now.Sub(last))
+			last = now
+
+			n := len(delays)
+
+			return &n, nil
+		},
+		func(v *int, _ error) (bool, error) {
+			return *v == 4, nil
+		},
+		search.WithExponentialBackoff(10*time.Millisecond, 25*time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// delays[0] is the first, immediate attempt; the next ones wait 10ms, 20ms, then 25ms (capped).
+	expected := []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 25 * time.Millisecond}
+	for i, want := range expected {
+		if got := delays[i+1]; got < want {
+			t.Errorf("retry %d: expected to wait at least %s, waited %s", i+1, want, got)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package search
+
+import "slices"
+
+/*
+LanguageConfig sets the languages of the queries, and the language-specific processing of their words: plurals and stop words:
+
+	opts, err := search.NewLanguageConfig(search.SUPPORTED_LANGUAGE_EN, search.SUPPORTED_LANGUAGE_FR).
+		IgnorePlurals(true).
+		RemoveStopWords(true).
+		SearchParamsObjectOptions()
+
+SupportedLanguage is a string type, so a misspelled language converted from a string, such as `search.SupportedLanguage("eng")`, compiles:
+the languages are validated when the options are built. The engine has no `indexLanguages` nor `decompoundQuery`, so there are no options for them.
+*/
+type LanguageConfig struct {
+	queryLanguages  []SupportedLanguage
+	ignorePlurals   *IgnorePlurals
+	removeStopWords *RemoveStopWords
+	languages       []SupportedLanguage
+}
+
+// NewLanguageConfig returns a LanguageConfig with the `queryLanguages`, the languages of the queries, none leaving them to the settings of the index. Duplicates are removed.
+func NewLanguageConfig(queryLanguages ...SupportedLanguage) *LanguageConfig {
+	l := &LanguageConfig{}
+
+	if len(queryLanguages) > 0 {
+		l.queryLanguages = l.collect(queryLanguages)
+	}
+
+	return l
+}
+
+// IgnorePlurals sets whether the plurals and other declensions of the words of the query match as their singular, in the query languages.
+func (l *LanguageConfig) IgnorePlurals(enabled bool) *LanguageConfig {
+	l.ignorePlurals = BoolAsIgnorePlurals(enabled)
+
+	return l
+}
+
+// IgnorePluralsFor ignores the plurals in the given languages only. Duplicates are removed.
+func (l *LanguageConfig) IgnorePluralsFor(languages ...SupportedLanguage) *LanguageConfig {
+	l.ignorePlurals = ArrayOfSupportedLanguageAsIgnorePlurals(l.collect(languages))
+
+	return l
+}
+
+// RemoveStopWords sets whether the stop words of the query, such as `the` or `and`, are ignored, in the query languages.
+func (l *LanguageConfig) RemoveStopWords(enabled bool) *LanguageConfig {
+	l.removeStopWords = BoolAsRemoveStopWords(enabled)
+
+	return l
+}
+
+// RemoveStopWordsFor removes the stop words of the given languages only. Duplicates are removed.
+func (l *LanguageConfig) RemoveStopWordsFor(languages ...SupportedLanguage) *LanguageConfig {
+	l.removeStopWords = ArrayOfSupportedLanguageAsRemoveStopWords(l.collect(languages))
+
+	return l
+}
+
+// collect returns the languages without duplicates, and keeps them to be validated.
+func (l *LanguageConfig) collect(languages []SupportedLanguage) []SupportedLanguage {
+	unique := make([]SupportedLanguage, 0, len(languages))
+
+	for _, language := range languages {
+		if !slices.Contains(unique, language) {
+			unique = append(unique, language)
+		}
+	}
+
+	l.languages = append(l.languages, unique...)
+
+	return unique
+}
+
+// validate returns the first unsupported language.
+func (l *LanguageConfig) validate() error {
+	for _, language := range l.languages {
+		if !language.IsValid() {
+			return reportError("unsupported language %q: valid values are %v", language, AllowedSupportedLanguageEnumValues)
+		}
+	}
+
+	return nil
+}
+
+/*
+SearchForHitsOptions validates the parameters and returns them as options of NewSearchForHits.
+
+	@return []SearchForHitsOption - The options.
+	@return error - The first invalid parameter.
+*/
+func (l *LanguageConfig) SearchForHitsOptions() ([]SearchForHitsOption, error) {
+	err := l.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []SearchForHitsOption
+
+	if l.queryLanguages != nil {
+		opts = append(opts, WithSearchForHitsQueryLanguages(l.queryLanguages))
+	}
+
+	if l.ignorePlurals != nil {
+		opts = append(opts, WithSearchForHitsIgnorePlurals(*l.ignorePlurals))
+	}
+
+	if l.removeStopWords != nil {
+		opts = append(opts, WithSearchForHitsRemoveStopWords(*l.removeStopWords))
+	}
+
+	return opts, nil
+}
+
+/*
+SearchParamsObjectOptions validates the parameters and returns them as options of NewSearchParamsObject.
+
+	@return []SearchParamsObjectOption - The options.
+	@return error - The first invalid parameter.
+*/
+func (l *LanguageConfig) SearchParamsObjectOptions() ([]SearchParamsObjectOption, error) {
+	err := l.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []SearchParamsObjectOption
+
+	if l.queryLanguages != nil {
+		opts = append(opts, WithSearchParamsObjectQueryLanguages(l.queryLanguages))
+	}
+
+	if l.ignorePlurals != nil {
+		opts = append(opts, WithSearchParamsObjectIgnorePlurals(*l.ignorePlurals))
+	}
+
+	if l.removeStopWords != nil {
+		opts = append(opts, WithSearchParamsObjectRemoveStopWords(*l.removeStopWords))
+	}
+
+	return opts, nil
+}
+
+/*
+IndexSettingsOptions validates the parameters and returns them as options of NewIndexSettings, making them the default of the searches.
+
+	@return []IndexSettingsOption - The options.
+	@return error - The first invalid parameter.
+*/
+func (l *LanguageConfig) IndexSettingsOptions() ([]IndexSettingsOption, error) {
+	err := l.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []IndexSettingsOption
+
+	if l.queryLanguages != nil {
+		opts = append(opts, WithIndexSettingsQueryLanguages(l.queryLanguages))
+	}
+
+	if l.ignorePlurals != nil {
+		opts = append(opts, WithIndexSettingsIgnorePlurals(*l.ignorePlurals))
+	}
+
+	if l.removeStopWords != nil {
+		opts = append(opts, WithIndexSettingsRemoveStopWords(*l.removeStopWords))
+	}
+
+	return opts, nil
+}
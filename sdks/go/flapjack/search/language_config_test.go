@@ -0,0 +1,59 @@
+package search_test
+
+import (
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+)
+
+func TestLanguageConfig(t *testing.T) {
+	t.Parallel()
+
+	opts, err := search.NewLanguageConfig(search.SUPPORTED_LANGUAGE_EN, search.SUPPORTED_LANGUAGE_DE, search.SUPPORTED_LANGUAGE_EN).
+		IgnorePlurals(true).
+		RemoveStopWordsFor(search.SUPPORTED_LANGUAGE_EN).
+		SearchParamsObjectOptions()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sameJSON(t, search.NewSearchParamsObject(opts...), `{
+		"queryLanguages": ["en", "de"],
+		"ignorePlurals": true,
+		"removeStopWords": ["en"]
+	}`)
+
+	settingsOpts, err := search.NewLanguageConfig(search.SUPPORTED_LANGUAGE_FR).
+		IgnorePluralsFor(search.SUPPORTED_LANGUAGE_FR).
+		IndexSettingsOptions()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sameJSON(t, search.NewIndexSettings(settingsOpts...), `{"queryLanguages": ["fr"], "ignorePlurals": ["fr"]}`)
+}
+
+func TestLanguageConfigValidation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		config *search.LanguageConfig
+	}{
+		{name: "query language", config: search.NewLanguageConfig("eng")},
+		{name: "plurals language", config: search.NewLanguageConfig().IgnorePluralsFor(search.SupportedLanguage("english"))},
+		{name: "stop words language", config: search.NewLanguageConfig().RemoveStopWordsFor(search.SupportedLanguage("EN"))},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := tt.config.SearchForHitsOptions(); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
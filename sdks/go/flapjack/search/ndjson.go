@@ -0,0 +1,99 @@
+package search
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+/*
+ImportNDJSON streams newline-delimited JSON records, one object per line, into `addObject` batches of the given index, chunked like ChunkedBatch.
+Blank lines are skipped. It reads the files written by ExportNDJSON, which makes it suited to backups and migrations.
+
+	@param indexName string - the index name to save objects into.
+	@param r io.Reader - The NDJSON records.
+	@param opts ...ChunkedBatchOption - Optional parameters for the request.
+	@return []BatchResponse - List of batch responses.
+	@return error - Error if any.
+*/
+func (c *APIClient) ImportNDJSON(indexName string, r io.Reader, opts ...ChunkedBatchOption) ([]BatchResponse, error) {
+	reader := bufio.NewReader(r)
+	line := 0
+
+	return c.importObjects(indexName, func() (map[string]any, error) {
+		for {
+			raw, err := reader.ReadBytes('\n')
+			if err != nil && !errors.Is(err, io.EOF) {
+				return nil, err //nolint:wrapcheck
+			}
+
+			if len(raw) == 0 && errors.Is(err, io.EOF) {
+				return nil, io.EOF
+			}
+
+			line++
+
+			raw = bytes.TrimSpace(raw)
+			if len(raw) == 0 {
+				continue
+			}
+
+			var obj map[string]any
+
+			err = json.Unmarshal(raw, &obj)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+
+			if obj == nil {
+				return nil, fmt.Errorf("line %d: the record is not a JSON object", line)
+			}
+
+			return obj, nil
+		}
+	}, opts...)
+}
+
+/*
+ExportNDJSON writes all the records of an index to `w` as newline-delimited JSON, one object per line, browsing the index with BrowseObjectsStream.
+The output can be loaded back with ImportNDJSON.
+
+	@param indexName string - Index name.
+	@param w io.Writer - Writer receiving the records.
+	@param opts ...RequestOption - Optional parameters for the requests.
+	@return error - Error if any.
+*/
+func (c *APIClient) ExportNDJSON(indexName string, w io.Writer, opts ...RequestOption) error {
+	buffered := bufio.NewWriter(w)
+
+	err := c.BrowseObjectsStream(indexName, BrowseParamsObject{}, func(hit json.RawMessage) error {
+		var compact bytes.Buffer
+
+		err := json.Compact(&compact, hit)
+		if err != nil {
+			return fmt.Errorf("cannot compact the record: %w", err)
+		}
+
+		compact.WriteByte('\n')
+
+		_, err = buffered.Write(compact.Bytes())
+		if err != nil {
+			return fmt.Errorf("cannot write the record: %w", err)
+		}
+
+		return nil
+	}, opts...)
+	if err != nil {
+		return err
+	}
+
+	err = buffered.Flush()
+	if err != nil {
+		return fmt.Errorf("cannot write the records: %w", err)
+	}
+
+	return nil
+}
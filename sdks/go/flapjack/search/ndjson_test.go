@@ -0,0 +1,89 @@
+package search_test
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/flapjacktest"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+)
+
+func TestExportImportNDJSON(t *testing.T) {
+	t.Parallel()
+
+	srv := flapjacktest.NewServer()
+	t.Cleanup(srv.Close)
+
+	client, err := srv.NewClient()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	srv.AddObjects("products",
+		map[string]any{"objectID": "1", "name": "Pancake", "price": 4.5},
+		map[string]any{"objectID": "2", "name": "Waffle", "tags": []any{"sweet"}},
+		map[string]any{"objectID": "3", "name": "Crepe"},
+	)
+
+	var out bytes.Buffer
+
+	err = client.ExportNDJSON("products", &out)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n"); len(lines) != 3 {
+		t.Fatalf("expected one line per record, got %q", out.String())
+	}
+
+	res, err := client.ImportNDJSON("products_copy", strings.NewReader(out.String()+"\n"),
+		search.WithBatchSize(2), search.WithWaitForTasks(true))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(res) != 2 {
+		t.Errorf("expected 2 batches, got %d", len(res))
+	}
+
+	if got, want := srv.Objects("products_copy"), srv.Objects("products"); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected imported objects\n got: %v\nwant: %v", got, want)
+	}
+}
+
+func TestImportNDJSONInvalidRecords(t *testing.T) {
+	t.Parallel()
+
+	srv := flapjacktest.NewServer()
+	t.Cleanup(srv.Close)
+
+	client, err := srv.NewClient()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		ndjson  string
+		wantErr string
+	}{
+		{name: "invalid JSON", ndjson: "{\"objectID\":\"1\"}\n\n{\"objectID\":", wantErr: "line 3:"},
+		{name: "not an object", ndjson: "null\n", wantErr: "line 1: the record is not a JSON object"},
+		{name: "array", ndjson: "[1]\n", wantErr: "line 1:"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := client.ImportNDJSON("products", strings.NewReader(tt.ndjson))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package search
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// ToObjects converts a slice of records of any JSON-serializable type (structs,
+// maps, ...) to the []map[string]any shape expected by SaveObjects,
+// PartialUpdateObjects and ChunkedBatch.
+//
+// Each record is JSON round-tripped, so `json` struct tags are honored, and its
+// numbers are kept as json.Number so that large integers are not rounded. The
+// objectID is the value of the field tagged `flapjack:"objectID"`, if any, or
+// of the field exposed as `objectID` in JSON.
+func ToObjects[T any](objects []T) ([]map[string]any, error) {
+	converted := make([]map[string]any, 0, len(objects))
+
+	for i, obj := range objects {
+		if m, ok := any(obj).(map[string]any); ok {
+			converted = append(converted, m)
+
+			continue
+		}
+
+		raw, err := json.Marshal(obj)
+		if err != nil {
+			return nil, reportError("cannot marshal object at position %d: %w", i, err)
+		}
+
+		var m map[string]any
+
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		decoder.UseNumber()
+
+		err = decoder.Decode(&m)
+		if err == nil && m == nil {
+			err = reportError("got null")
+		}
+
+		if err != nil {
+			return nil, reportError("object at position %d is not a JSON object: %w", i, err)
+		}
+
+		id, ok, err := taggedObjectID(reflect.ValueOf(obj))
+		if err != nil {
+			return nil, reportError("object at position %d: %w", i, err)
+		}
+
+		if ok {
+			m["objectID"] = id
+		}
+
+		converted = append(converted, m)
+	}
+
+	return converted, nil
+}
+
+// objectIDOf returns the objectID of the given record, if any. Numeric objectIDs are formatted in full, as `1000000`
+// rather than `1e+06`.
+func objectIDOf(obj map[string]any) (string, bool) {
+	var id string
+
+	switch value := obj["objectID"].(type) {
+	case nil:
+		return "", false
+	case string:
+		id = value
+	case json.Number:
+		id = value.String()
+	case float64:
+		id = strconv.FormatFloat(value, 'f', -1, 64)
+	case float32:
+		id = strconv.FormatFloat(float64(value), 'f', -1, 32)
+	default:
+		switch v := reflect.ValueOf(value); v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			id = strconv.FormatInt(v.Int(), 10)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			id = strconv.FormatUint(v.Uint(), 10)
+		default:
+			id = fmt.Sprint(value)
+		}
+	}
+
+	return id, id != ""
+}
+
+// requireObjectIDs makes sure every record carries an objectID, which the API requires for some actions.
+func requireObjectIDs(objects []map[string]any) error {
+	for i, obj := range objects {
+		if _, ok := objectIDOf(obj); !ok {
+			return reportError("object at position %d has no `objectID`, which is required for this operation", i)
+		}
+	}
+
+	return nil
+}
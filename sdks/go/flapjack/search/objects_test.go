@@ -0,0 +1,136 @@
+package search_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+)
+
+type product struct {
+	ObjectID string  `json:"objectID"`
+	Name     string  `json:"name"`
+	Price    float64 `json:"price,omitempty"`
+}
+
+func TestToObjects(t *testing.T) {
+	t.Parallel()
+
+	objects, err := search.ToObjects([]product{
+		{ObjectID: "1", Name: "iPhone", Price: 999},
+		{ObjectID: "2", Name: "Pixel"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(objects) != 2 {
+		t.Fatalf("expected 2 objects, got %d", len(objects))
+	}
+
+	if objects[0]["objectID"] != "1" || objects[0]["name"] != "iPhone" || objects[0]["price"] != json.Number("999") {
+		t.Errorf("unexpected first object: %v", objects[0])
+	}
+
+	if _, ok := objects[1]["price"]; ok {
+		t.Errorf("expected omitempty to be honored, got %v", objects[1])
+	}
+
+	_, err = search.ToObjects([]int{1, 2})
+	if err == nil {
+		t.Error("expected an error for non-object records")
+	}
+
+	_, err = search.ToObjects([]*product{nil})
+	if err == nil {
+		t.Error("expected an error for nil records")
+	}
+}
+
+func TestToObjectsKeepsLargeNumbers(t *testing.T) {
+	t.Parallel()
+
+	type order struct {
+		ObjectID int64  `json:"objectID"`
+		Total    uint64 `json:"total"`
+	}
+
+	objects, err := search.ToObjects([]order{{ObjectID: 9007199254740993, Total: 18446744073709551615}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if objects[0]["objectID"] != json.Number("9007199254740993") || objects[0]["total"] != json.Number("18446744073709551615") {
+		t.Errorf("unexpected object: %v", objects[0])
+	}
+}
+
+func TestNumericObjectIDs(t *testing.T) {
+	t.Parallel()
+
+	objects := []map[string]any{
+		{"objectID": 1e6},
+		{"objectID": json.Number("9007199254740993")},
+		{"objectID": int64(9007199254740993)},
+		{"objectID": 0.5},
+	}
+
+	split, err := search.SplitRecords(objects, search.RecordSplitConfig{Attribute: "text"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for i, want := range []string{"1000000", "9007199254740993", "9007199254740993", "0.5"} {
+		if got := split[i][search.DefaultSplitDistinctAttribute]; got != want {
+			t.Errorf("unexpected objectID %v for %v, want %s", got, objects[i]["objectID"], want)
+		}
+	}
+}
+
+func TestPartialUpdateObjectsRequiresObjectID(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("no request should be sent, got %s %s", r.Method, r.URL.Path)
+	})
+
+	_, err := client.PartialUpdateObjects("products", []map[string]any{{"objectID": "1"}, {"name": "no id"}})
+	if err == nil {
+		t.Fatal("expected an error for the object without objectID")
+	}
+}
+
+func TestGetObjectsByIDs(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/1/indexes/*/objects" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+
+		var payload search.GetObjectsParams
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("invalid body: %v", err)
+		}
+
+		if len(payload.Requests) != 3 || payload.Requests[1].ObjectID != "missing" || payload.Requests[2].IndexName != "products" {
+			t.Errorf("unexpected requests %v", payload.Requests)
+		}
+
+		if attrs := payload.Requests[0].AttributesToRetrieve; len(attrs) != 1 || attrs[0] != "name" {
+			t.Errorf("unexpected attributesToRetrieve %v", attrs)
+		}
+
+		_, _ = w.Write([]byte(`{"results":[{"objectID":"2","name":"Pixel"},null,{"objectID":"1","name":"iPhone"}]}`))
+	})
+
+	objects, err := client.GetObjectsByIDs("products", []string{"2", "missing", "1"}, []string{"name"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(objects) != 3 || objects[0]["objectID"] != "2" || objects[1] != nil || objects[2]["objectID"] != "1" {
+		t.Errorf("unexpected objects: %v", objects)
+	}
+}
@@ -0,0 +1,132 @@
+package search_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+)
+
+func TestOperationIndexHelpers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		call      func(c *search.APIClient) (*search.UpdatedAtResponse, error)
+		operation string
+		scope     []any
+	}{
+		{
+			name:      "copy everything",
+			call:      func(c *search.APIClient) (*search.UpdatedAtResponse, error) { return c.CopyIndex("src", "dst", nil) },
+			operation: "copy",
+		},
+		{
+			name: "copy scopes",
+			call: func(c *search.APIClient) (*search.UpdatedAtResponse, error) {
+				return c.CopyIndex("src", "dst", []search.ScopeType{search.SCOPE_TYPE_SETTINGS, search.SCOPE_TYPE_RULES})
+			},
+			operation: "copy",
+			scope:     []any{"settings", "rules"},
+		},
+		{
+			name:      "copy settings",
+			call:      func(c *search.APIClient) (*search.UpdatedAtResponse, error) { return c.CopySettings("src", "dst") },
+			operation: "copy",
+			scope:     []any{"settings"},
+		},
+		{
+			name:      "copy synonyms",
+			call:      func(c *search.APIClient) (*search.UpdatedAtResponse, error) { return c.CopySynonyms("src", "dst") },
+			operation: "copy",
+			scope:     []any{"synonyms"},
+		},
+		{
+			name:      "copy rules",
+			call:      func(c *search.APIClient) (*search.UpdatedAtResponse, error) { return c.CopyRules("src", "dst") },
+			operation: "copy",
+			scope:     []any{"rules"},
+		},
+		{
+			name:      "move",
+			call:      func(c *search.APIClient) (*search.UpdatedAtResponse, error) { return c.MoveIndex("src", "dst") },
+			operation: "move",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/1/indexes/src/operation" {
+					t.Errorf("unexpected path %s", r.URL.Path)
+				}
+
+				body, _ := io.ReadAll(r.Body)
+
+				var payload map[string]any
+				if err := json.Unmarshal(body, &payload); err != nil {
+					t.Errorf("invalid body: %v", err)
+				}
+
+				if payload["operation"] != tt.operation || payload["destination"] != "dst" {
+					t.Errorf("unexpected body %s", body)
+				}
+
+				scope, _ := payload["scope"].([]any)
+				if !reflect.DeepEqual(scope, tt.scope) {
+					t.Errorf("expected scope %v, got %v", tt.scope, scope)
+				}
+
+				_, _ = w.Write([]byte(`{"taskID":1,"updatedAt":"2026-01-01T00:00:00Z"}`))
+			})
+
+			res, err := tt.call(client)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if res.TaskID != 1 {
+				t.Errorf("expected taskID 1, got %d", res.TaskID)
+			}
+		})
+	}
+}
+
+func TestOperationIndexWaitsForTask(t *testing.T) {
+	t.Parallel()
+
+	var paths []string
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+
+		if strings.HasSuffix(r.URL.Path, "/task/7") {
+			_, _ = w.Write([]byte(`{"status":"published"}`))
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"taskID":7,"updatedAt":"2026-01-01T00:00:00Z"}`))
+	})
+
+	_, err := client.MoveIndex("tmp", "products", search.WithWaitForTasks(true))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := []string{"/1/indexes/tmp/operation", "/1/indexes/tmp/task/7"}; !reflect.DeepEqual(paths, want) {
+		t.Errorf("expected calls %v, got %v", want, paths)
+	}
+
+	_, err = client.CopyIndex("tmp", "tmp", nil)
+	if err == nil {
+		t.Error("expected an error when copying an index onto itself")
+	}
+}
@@ -0,0 +1,175 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// DefaultMaxConcurrentOperations is the default number of operations run at once by Parallel.
+const DefaultMaxConcurrentOperations = 8
+
+// --------- Parallel options ---------
+
+type ParallelOption interface {
+	RequestOption
+	parallel()
+}
+
+type parallelOption func(*config)
+
+var (
+	_ ParallelOption = (*parallelOption)(nil)
+	_ ParallelOption = (*requestOption)(nil)
+)
+
+func (p parallelOption) apply(c *config) {
+	p(c)
+}
+
+func (p parallelOption) parallel() {}
+
+func (r requestOption) parallel() {}
+
+// WithMaxConcurrentOperations the number of operations run at once by Parallel. Defaults to DefaultMaxConcurrentOperations.
+func WithMaxConcurrentOperations(maxConcurrentOperations int) parallelOption {
+	return parallelOption(func(c *config) {
+		c.maxConcurrentOperations = maxConcurrentOperations
+	})
+}
+
+// WithFailFast whether Parallel stops at the first failed operation, canceling the context of the running ones and not starting the others, like an errgroup. Defaults to false, every operation being run.
+func WithFailFast(failFast bool) parallelOption {
+	return parallelOption(func(c *config) {
+		c.failFast = failFast
+	})
+}
+
+// IndexOperation is an operation on an index run by Parallel, such as saving the records or the settings of a tenant.
+type IndexOperation struct {
+	// IndexName is the index of the operation, naming it in its error.
+	IndexName string
+	// Run runs the operation. Its context is the one given to Parallel, canceled when another operation fails with WithFailFast.
+	Run func(ctx context.Context) error
+}
+
+// ForEachIndex returns the operations running `fn` on each of the indices, to run the same operation on a fleet of indices.
+func ForEachIndex(indexNames []string, fn func(ctx context.Context, indexName string) error) []IndexOperation {
+	ops := make([]IndexOperation, 0, len(indexNames))
+
+	for _, indexName := range indexNames {
+		indexName := indexName
+
+		ops = append(ops, IndexOperation{IndexName: indexName, Run: func(ctx context.Context) error {
+			return fn(ctx, indexName)
+		}})
+	}
+
+	return ops
+}
+
+// IndexOperationError is the error of an operation of Parallel.
+type IndexOperationError struct {
+	// Position is the position of the operation, starting at 0.
+	Position  int
+	IndexName string
+	Err       error
+}
+
+func (e *IndexOperationError) Error() string {
+	return fmt.Sprintf("operation %d on index %s: %v", e.Position, e.IndexName, e.Err)
+}
+
+func (e *IndexOperationError) Unwrap() error {
+	return e.Err
+}
+
+/*
+Parallel runs operations on several indices at once, such as the indices of the tenants of a SaaS application, at most `WithMaxConcurrentOperations` at a time.
+Every operation is run, and the errors of the failed ones are returned joined, as *IndexOperationError in the order of the operations. With `WithFailFast(true)`, the first error cancels the context of the running operations and the others are not started, their error being the one of the context:
+
+	err := client.Parallel(search.ForEachIndex(tenantIndices, func(ctx context.Context, indexName string) error {
+		_, err := client.SetSettings(client.NewApiSetSettingsRequest(indexName, settings), search.WithContext(ctx))
+
+		return err
+	}), search.WithMaxConcurrentOperations(4))
+
+	@param ops []IndexOperation - The operations.
+	@param opts ...ParallelOption - Optional parameters, such as WithContext for the context of the operations.
+	@return error - The errors of the failed operations, if any, or an error before running any if one has no Run function.
+*/
+func (c *APIClient) Parallel(ops []IndexOperation, opts ...ParallelOption) error {
+	for i, op := range ops {
+		if op.Run == nil {
+			return reportError("the operation at position %d on index %s has no `Run` function", i, op.IndexName)
+		}
+	}
+
+	conf := config{maxConcurrentOperations: DefaultMaxConcurrentOperations}
+
+	for _, opt := range opts {
+		opt.apply(&conf)
+	}
+
+	ctx := conf.context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errs := make([]error, len(ops))
+	positions := make(chan int)
+
+	var wg sync.WaitGroup
+
+	for w := 0; w < max(conf.maxConcurrentOperations, 1); w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range positions {
+				if conf.failFast && ctx.Err() != nil {
+					errs[i] = fmt.Errorf("operation not run: %w", ctx.Err())
+
+					continue
+				}
+
+				errs[i] = ops[i].Run(ctx)
+
+				if errs[i] != nil && conf.failFast {
+					cancel()
+				}
+			}
+		}()
+	}
+
+dispatch:
+	for i := range ops {
+		select {
+		case positions <- i:
+		case <-ctx.Done():
+			for ; i < len(ops); i++ {
+				errs[i] = fmt.Errorf("operation not run: %w", ctx.Err())
+			}
+
+			break dispatch
+		}
+	}
+
+	close(positions)
+	wg.Wait()
+
+	opErrs := make([]error, 0)
+
+	for i, err := range errs {
+		if err != nil {
+			opErrs = append(opErrs, &IndexOperationError{Position: i, IndexName: ops[i].IndexName, Err: err})
+		}
+	}
+
+	return errors.Join(opErrs...)
+}
@@ -0,0 +1,272 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/errs"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+const (
+	// DefaultBatchWorkers is the default number of batches sent at once by ParallelChunkedBatch.
+	DefaultBatchWorkers = 4
+	// DefaultMaxBatchRetries is the default number of times ParallelChunkedBatch sends a failed batch again.
+	DefaultMaxBatchRetries = 2
+)
+
+// BatchError is the error of a batch of ParallelChunkedBatch, which failed after its retries.
+type BatchError struct {
+	// Batch is the position of the batch, starting at 0.
+	Batch int
+	// Start and End delimit the records of the batch, objects[Start:End].
+	Start int
+	End   int
+	Err   error
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("batch %d (objects %d to %d): %v", e.Batch, e.Start, e.End-1, e.Err)
+}
+
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
+
+// WithWorkers the number of batches sent at once by ParallelChunkedBatch. Default to DefaultBatchWorkers.
+func WithWorkers(workers int) chunkedBatchOption {
+	return chunkedBatchOption(func(c *config) {
+		c.workers = workers
+	})
+}
+
+// WithMaxBatchRetries the number of times ParallelChunkedBatch sends a batch again after a server error or a rate limit, on top of the retries of the transport. Default to DefaultMaxBatchRetries.
+func WithMaxBatchRetries(maxBatchRetries int) chunkedBatchOption {
+	return chunkedBatchOption(func(c *config) {
+		c.maxBatchRetries = maxBatchRetries
+	})
+}
+
+// chunk is a batch of records, objects[start:end].
+type chunk struct {
+	start    int
+	end      int
+	requests []BatchRequest
+	bytes    int64
+}
+
+/*
+ParallelChunkedBatch chunks the given `objects` like ChunkedBatch, but sends the `batch` requests with several workers at once, which is much faster for large datasets.
+A failed batch is sent again up to `WithMaxBatchRetries` times. The other batches are still sent, and the errors of the failed ones are returned joined, as *BatchError in the order of the batches.
+Use `WithWorkers` to set the number of workers, and `WithProgress` to follow the upload.
+
+	@param indexName string - the index name to save objects into.
+	@param objects []map[string]any - List of objects to save.
+	@param action Action - The action to perform on the objects.
+	@param opts ...ChunkedBatchOption - Optional parameters for the request.
+	@return []BatchResponse - List of the responses of the successful batches, in order.
+	@return error - Error if any.
+*/
+func (c *APIClient) ParallelChunkedBatch(indexName string, objects []map[string]any, action Action, opts ...ChunkedBatchOption) ([]BatchResponse, error) {
+	conf := config{
+		headerParams:    map[string]string{},
+		batchSize:       1000,
+		workers:         DefaultBatchWorkers,
+		maxBatchRetries: DefaultMaxBatchRetries,
+	}
+
+	for _, opt := range opts {
+		opt.apply(&conf)
+	}
+
+	switch action {
+	case ACTION_UPDATE_OBJECT, ACTION_PARTIAL_UPDATE_OBJECT, ACTION_PARTIAL_UPDATE_OBJECT_NO_CREATE, ACTION_DELETE_OBJECT:
+		err := requireObjectIDs(objects)
+		if err != nil {
+			return nil, err
+		}
+	default:
+	}
+
+	if conf.maxRecordBytes > 0 {
+		err := validateRecordSizes(objects, conf.maxRecordBytes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	chunks, err := chunkObjects(objects, action, conf.batchSize, conf.maxBatchBytes, conf.progress != nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := conf.context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	responses := make([]*BatchResponse, len(chunks))
+	errs := make([]error, len(chunks))
+	positions := make(chan int)
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		done     int
+		doneSize int64
+	)
+
+	for w := 0; w < max(conf.workers, 1); w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range positions {
+				responses[i], errs[i] = c.sendChunk(ctx, indexName, chunks[i], conf.maxBatchRetries, opts)
+
+				if conf.progress != nil {
+					mu.Lock()
+					done += chunks[i].end - chunks[i].start
+					doneSize += chunks[i].bytes
+					conf.progress(done, len(objects), doneSize)
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+dispatch:
+	for i := range chunks {
+		select {
+		case positions <- i:
+		case <-ctx.Done():
+			for ; i < len(chunks); i++ {
+				errs[i] = fmt.Errorf("batch not sent: %w", ctx.Err())
+			}
+
+			break dispatch
+		}
+	}
+
+	close(positions)
+	wg.Wait()
+
+	return c.collectChunks(indexName, chunks, responses, errs, conf, opts)
+}
+
+// collectChunks returns the responses of the successful batches and the errors of the failed ones, in order, once
+// their tasks are published if requested.
+func (c *APIClient) collectChunks(indexName string, chunks []chunk, responses []*BatchResponse, errs []error, conf config, opts []ChunkedBatchOption) ([]BatchResponse, error) {
+	succeeded := make([]BatchResponse, 0, len(chunks))
+	taskIDs := make([]int64, 0, len(chunks))
+	batchErrs := make([]error, 0)
+
+	for i, resp := range responses {
+		if errs[i] != nil {
+			batchErrs = append(batchErrs, &BatchError{Batch: i, Start: chunks[i].start, End: chunks[i].end, Err: errs[i]})
+
+			continue
+		}
+
+		succeeded = append(succeeded, *resp)
+		taskIDs = append(taskIDs, resp.TaskID)
+	}
+
+	if len(batchErrs) > 0 {
+		return succeeded, errors.Join(batchErrs...)
+	}
+
+	if conf.waitForTasks {
+		err := <-c.WaitForTasks(indexName, taskIDs, append(toIterableOptions(opts), WithMaxConcurrency(max(conf.workers, 1)))...)
+		if err != nil {
+			return succeeded, err
+		}
+	}
+
+	return succeeded, nil
+}
+
+// sendChunk sends a batch, again after the errors that may not happen on a new attempt.
+func (c *APIClient) sendChunk(ctx context.Context, indexName string, ch chunk, maxRetries int, opts []ChunkedBatchOption) (*BatchResponse, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := c.Batch(c.NewApiBatchRequest(indexName, NewBatchWriteParams(ch.requests)), toRequestOptions(opts)...)
+		if err == nil || attempt >= maxRetries || !isRetryableBatchError(err) {
+			return resp, err
+		}
+
+		err = c.clock().Sleep(ctx, min(100*time.Millisecond<<attempt, 5*time.Second))
+		if err != nil {
+			return nil, fmt.Errorf("batch interrupted after %d attempt(s): %w", attempt+1, err)
+		}
+	}
+}
+
+// isRetryableBatchError tells whether a batch may succeed when sent again: only the network failures, the timeouts of
+// the attempts, the rate limits and the server errors may. Local errors, such as invalid parameters or a closed client,
+// and the other API errors would fail the same way.
+func isRetryableBatchError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var apiErr *transport.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Status >= http.StatusInternalServerError || apiErr.Status == http.StatusTooManyRequests
+	}
+
+	if errors.Is(err, errs.ErrNoMoreHostToTry) || errors.Is(err, errs.ErrRateLimited) {
+		return true
+	}
+
+	var netErr net.Error
+
+	return errors.As(err, &netErr)
+}
+
+// chunkObjects splits the records in batches of at most batchSize records and, when set, maxBatchBytes bytes. The size
+// of the records is measured when maxBatchBytes is set or measure is true.
+func chunkObjects(objects []map[string]any, action Action, batchSize, maxBatchBytes int, measure bool) ([]chunk, error) {
+	batchSize = max(batchSize, 1)
+	chunks := make([]chunk, 0, len(objects)/batchSize+1)
+	current := chunk{}
+
+	for i, obj := range objects {
+		var objBytes int64
+
+		if maxBatchBytes > 0 || measure {
+			raw, err := json.Marshal(obj)
+			if err != nil {
+				return nil, fmt.Errorf("cannot compute the size of object at position %d: %w", i, err)
+			}
+
+			objBytes = int64(len(raw))
+		}
+
+		if len(current.requests) > 0 && maxBatchBytes > 0 && current.bytes+objBytes > int64(maxBatchBytes) {
+			chunks = append(chunks, current)
+			current = chunk{start: i}
+		}
+
+		current.requests = append(current.requests, *NewBatchRequest(action, obj))
+		current.bytes += objBytes
+		current.end = i + 1
+
+		if len(current.requests) == batchSize {
+			chunks = append(chunks, current)
+			current = chunk{start: i + 1}
+		}
+	}
+
+	if len(current.requests) > 0 {
+		chunks = append(chunks, current)
+	}
+
+	return chunks, nil
+}
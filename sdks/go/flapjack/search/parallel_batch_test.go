@@ -0,0 +1,183 @@
+package search_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/flapjacktest"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+// batchFirstObjectID returns the objectID of the first record of a batch request.
+func batchFirstObjectID(t *testing.T, r *http.Request) string {
+	t.Helper()
+
+	body, _ := io.ReadAll(r.Body)
+
+	var params struct {
+		Requests []struct {
+			Body map[string]any `json:"body"`
+		} `json:"requests"`
+	}
+
+	err := json.Unmarshal(body, &params)
+	if err != nil || len(params.Requests) == 0 {
+		t.Errorf("unexpected batch body %s", body)
+
+		return ""
+	}
+
+	id, _ := params.Requests[0].Body["objectID"].(string)
+
+	return id
+}
+
+func parallelObjects(n int) []map[string]any {
+	objects := make([]map[string]any, 0, n)
+	for i := 0; i < n; i++ {
+		objects = append(objects, map[string]any{"objectID": fmt.Sprint(i)})
+	}
+
+	return objects
+}
+
+func TestParallelChunkedBatch(t *testing.T) {
+	t.Parallel()
+
+	var inFlight, maxInFlight atomic.Int32
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+
+		for {
+			current := maxInFlight.Load()
+			if n <= current || maxInFlight.CompareAndSwap(current, n) {
+				break
+			}
+		}
+
+		id := batchFirstObjectID(t, r)
+		_, _ = fmt.Fprintf(w, `{"taskID":%s,"objectIDs":[%q]}`, id, id)
+	})
+
+	var (
+		mu       sync.Mutex
+		progress []int
+	)
+
+	res, err := client.ParallelChunkedBatch("products", parallelObjects(10), search.ACTION_ADD_OBJECT,
+		search.WithBatchSize(2), search.WithWorkers(3), search.WithProgress(func(done, total int, bytes int64) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			if total != 10 || bytes <= 0 {
+				t.Errorf("unexpected progress %d/%d (%d bytes)", done, total, bytes)
+			}
+
+			progress = append(progress, done)
+		}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(res) != 5 {
+		t.Fatalf("expected 5 responses, got %d", len(res))
+	}
+
+	for i, resp := range res {
+		if resp.TaskID != int64(2*i) {
+			t.Errorf("expected the responses in order, got task %d at position %d", resp.TaskID, i)
+		}
+	}
+
+	if maxInFlight.Load() > 3 {
+		t.Errorf("expected at most 3 concurrent batches, got %d", maxInFlight.Load())
+	}
+
+	if len(progress) != 5 || progress[4] != 10 {
+		t.Errorf("unexpected progress %v", progress)
+	}
+}
+
+func TestParallelChunkedBatchReportsFailedBatches(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+
+	calls := map[string]int{}
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		id := batchFirstObjectID(t, r)
+
+		mu.Lock()
+		calls[id]++
+		attempt := calls[id]
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case id == "2":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"Record is too big"}`))
+		case id == "6" && attempt == 1:
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"message":"Too many requests"}`))
+		default:
+			_, _ = fmt.Fprintf(w, `{"taskID":%s,"objectIDs":[%q]}`, id, id)
+		}
+	})
+
+	res, err := client.ParallelChunkedBatch("products", parallelObjects(8), search.ACTION_ADD_OBJECT, search.WithBatchSize(2))
+
+	var batchErr *search.BatchError
+	if !errors.As(err, &batchErr) || batchErr.Batch != 1 || batchErr.Start != 2 || batchErr.End != 4 {
+		t.Fatalf("expected the error of the second batch, got %v", err)
+	}
+
+	var apiErr *transport.APIError
+	if !errors.As(err, &apiErr) || apiErr.Status != http.StatusBadRequest {
+		t.Errorf("expected the API error of the batch, got %v", err)
+	}
+
+	if len(res) != 3 || res[0].TaskID != 0 || res[1].TaskID != 4 || res[2].TaskID != 6 {
+		t.Errorf("expected the responses of the other batches, got %+v", res)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if calls["2"] != 1 || calls["6"] != 2 {
+		t.Errorf("expected the rate limited batch only to be retried, got %v", calls)
+	}
+}
+
+func TestParallelChunkedBatchDoesNotRetryLocalErrors(t *testing.T) {
+	t.Parallel()
+
+	clock := flapjacktest.NewClock(time.Time{})
+
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		t.Error("unexpected request on a closed client")
+	}, transport.WithClock(clock))
+
+	_ = client.Close()
+
+	_, err := client.ParallelChunkedBatch("products", parallelObjects(4), search.ACTION_ADD_OBJECT, search.WithBatchSize(2))
+	if !errors.Is(err, transport.ErrClientClosed) {
+		t.Fatalf("expected the client closed error, got %v", err)
+	}
+
+	if slept := clock.Slept(); len(slept) != 0 {
+		t.Errorf("expected no retry of a local error, got backoffs %v", slept)
+	}
+}
@@ -0,0 +1,150 @@
+package search_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/flapjacktest"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+)
+
+func TestParallel(t *testing.T) {
+	t.Parallel()
+
+	srv := flapjacktest.NewServer()
+	t.Cleanup(srv.Close)
+
+	client, err := srv.NewClient()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tenants := []string{"tenant_a", "tenant_b", "tenant_c", "tenant_d", "tenant_e"}
+
+	var running, maxRunning atomic.Int32
+
+	err = client.Parallel(search.ForEachIndex(tenants, func(ctx context.Context, indexName string) error {
+		n := running.Add(1)
+		defer running.Add(-1)
+
+		for {
+			current := maxRunning.Load()
+			if n <= current || maxRunning.CompareAndSwap(current, n) {
+				break
+			}
+		}
+
+		time.Sleep(10 * time.Millisecond)
+
+		_, err := client.SaveObjects(indexName, []map[string]any{{"objectID": "1", "tenant": indexName}}, search.WithContext(ctx))
+
+		return err
+	}), search.WithMaxConcurrentOperations(2))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, tenant := range tenants {
+		if got := srv.Objects(tenant); len(got) != 1 || got[0]["tenant"] != tenant {
+			t.Errorf("unexpected records of %s: %v", tenant, got)
+		}
+	}
+
+	if got := maxRunning.Load(); got != 2 {
+		t.Errorf("expected at most 2 operations at once, got %d", got)
+	}
+}
+
+func TestParallelErrors(t *testing.T) {
+	t.Parallel()
+
+	errBoom := errors.New("boom")
+
+	var ran sync.Map
+
+	ops := search.ForEachIndex([]string{"a", "b", "c", "d"}, func(_ context.Context, indexName string) error {
+		ran.Store(indexName, true)
+
+		if indexName == "b" || indexName == "d" {
+			return fmt.Errorf("cannot save %s: %w", indexName, errBoom)
+		}
+
+		return nil
+	})
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	})
+
+	err := client.Parallel(ops, search.WithMaxConcurrentOperations(1))
+
+	want := "operation 1 on index b: cannot save b: boom\noperation 3 on index d: cannot save d: boom"
+	if err == nil || err.Error() != want {
+		t.Fatalf("expected error %q, got %v", want, err)
+	}
+
+	var opErr *search.IndexOperationError
+	if !errors.As(err, &opErr) || opErr.IndexName != "b" || !errors.Is(err, errBoom) {
+		t.Errorf("expected an *IndexOperationError wrapping the error, got %#v", err)
+	}
+
+	for _, indexName := range []string{"a", "b", "c", "d"} {
+		if _, ok := ran.Load(indexName); !ok {
+			t.Errorf("expected the operation on %s to run", indexName)
+		}
+	}
+}
+
+func TestParallelFailFast(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	})
+
+	var ran atomic.Int32
+
+	err := client.Parallel(search.ForEachIndex([]string{"a", "b", "c"}, func(ctx context.Context, indexName string) error {
+		ran.Add(1)
+
+		if indexName == "a" {
+			return errors.New("boom")
+		}
+
+		return nil
+	}), search.WithMaxConcurrentOperations(1), search.WithFailFast(true))
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the operations not run to fail with the context, got %v", err)
+	}
+
+	var opErr *search.IndexOperationError
+	if !errors.As(err, &opErr) || opErr.IndexName != "a" {
+		t.Errorf("expected the first error to be the one of the failed operation, got %v", err)
+	}
+
+	if got := ran.Load(); got != 1 {
+		t.Errorf("expected the operations to stop after the failure, got %d run", got)
+	}
+}
+
+func TestParallelRequiresRun(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	})
+
+	err := client.Parallel([]search.IndexOperation{{IndexName: "products"}})
+
+	want := "the operation at position 0 on index products has no `Run` function"
+	if err == nil || err.Error() != want {
+		t.Errorf("expected error %q, got %v", want, err)
+	}
+}
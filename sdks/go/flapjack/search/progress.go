@@ -0,0 +1,47 @@
+package search
+
+import "encoding/json"
+
+// ProgressFunc is called by the bulk helpers as their work progresses, with the number of records processed so far,
+// their total number, and the size in bytes of the JSON of the records processed so far. The total is -1 when it is
+// not known. Calls are never concurrent.
+type ProgressFunc func(done, total int, bytes int64)
+
+// WithProgress the function called by ChunkedBatch, ParallelChunkedBatch, ReplaceAllObjects, ImportCSV, ImportNDJSON, BrowseObjects, BrowseObjectsStream and ExportNDJSON every time a batch of records has been sent or a page of records received.
+func WithProgress(progress ProgressFunc) requestOption {
+	return requestOption(func(c *config) {
+		c.progress = progress
+	})
+}
+
+// progressTracker accumulates the progress of a browse and reports it to its function.
+type progressTracker struct {
+	fn    ProgressFunc
+	done  int
+	total int
+	bytes int64
+}
+
+func (p *progressTracker) add(records int, bytes int64, nbHits *int32) {
+	p.done += records
+	p.bytes += bytes
+
+	if nbHits != nil {
+		p.total = int(*nbHits)
+	}
+
+	p.fn(p.done, p.total, p.bytes)
+}
+
+func (p *progressTracker) addHits(hits []Hit, nbHits *int32) {
+	var bytes int64
+
+	for _, hit := range hits {
+		raw, err := json.Marshal(hit)
+		if err == nil {
+			bytes += int64(len(raw))
+		}
+	}
+
+	p.add(len(hits), bytes, nbHits)
+}
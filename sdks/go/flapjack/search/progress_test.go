@@ -0,0 +1,130 @@
+package search_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/flapjacktest"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+)
+
+type progressCall struct {
+	done  int
+	total int
+	bytes int64
+}
+
+func recordProgress(calls *[]progressCall) search.ProgressFunc {
+	return func(done, total int, bytes int64) {
+		*calls = append(*calls, progressCall{done: done, total: total, bytes: bytes})
+	}
+}
+
+func checkProgress(t *testing.T, calls []progressCall, wantDone []int, wantTotal int) {
+	t.Helper()
+
+	if len(calls) != len(wantDone) {
+		t.Fatalf("expected %d progress calls, got %+v", len(wantDone), calls)
+	}
+
+	for i, call := range calls {
+		if call.done != wantDone[i] || call.total != wantTotal || call.bytes <= 0 || i > 0 && call.bytes <= calls[i-1].bytes {
+			t.Errorf("unexpected progress call %d: %+v", i, call)
+		}
+	}
+}
+
+func TestChunkedBatchProgress(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"taskID":1,"objectIDs":[]}`))
+	})
+
+	var calls []progressCall
+
+	_, err := client.ChunkedBatch("products", parallelObjects(5), search.ACTION_ADD_OBJECT,
+		search.WithBatchSize(2), search.WithProgress(recordProgress(&calls)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	checkProgress(t, calls, []int{2, 4, 5}, 5)
+}
+
+func TestReplaceAllObjectsProgress(t *testing.T) {
+	t.Parallel()
+
+	srv := flapjacktest.NewServer()
+	t.Cleanup(srv.Close)
+
+	client, err := srv.NewClient()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var calls []progressCall
+
+	_, err = client.ReplaceAllObjects("products", parallelObjects(3),
+		search.WithBatchSize(2), search.WithProgress(recordProgress(&calls)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	checkProgress(t, calls, []int{2, 3}, 3)
+}
+
+func TestBrowseProgress(t *testing.T) {
+	t.Parallel()
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		var params map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&params)
+
+		if params["cursor"] == nil {
+			_, _ = w.Write([]byte(`{"nbHits":3,"hits":[{"objectID":"1"},{"objectID":"2"}],"cursor":"next"}`))
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"hits":[{"objectID":"3"}]}`))
+	}
+
+	tests := []struct {
+		name   string
+		browse func(client *search.APIClient, progress search.ProgressFunc) error
+	}{
+		{
+			name: "BrowseObjects",
+			browse: func(client *search.APIClient, progress search.ProgressFunc) error {
+				return client.BrowseObjects("products", search.BrowseParamsObject{}, search.WithProgress(progress))
+			},
+		},
+		{
+			name: "BrowseObjectsStream",
+			browse: func(client *search.APIClient, progress search.ProgressFunc) error {
+				return client.BrowseObjectsStream("products", search.BrowseParamsObject{}, func(json.RawMessage) error {
+					return nil
+				}, search.WithProgress(progress))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls []progressCall
+
+			err := tt.browse(newTestClient(t, handler), recordProgress(&calls))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			checkProgress(t, calls, []int{2, 3}, 3)
+		})
+	}
+}
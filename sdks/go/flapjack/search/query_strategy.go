@@ -0,0 +1,185 @@
+package search
+
+import "slices"
+
+/*
+QueryStrategy sets how the words of the queries are matched: which ones are prefixes, which ones are removed when nothing matches, and
+what counts as an exact match:
+
+	opts, err := search.NewQueryStrategy().
+		QueryType(search.QUERY_TYPE_PREFIX_LAST).
+		RemoveWordsIfNoResults(search.REMOVE_WORDS_IF_NO_RESULTS_LAST_WORDS).
+		SearchParamsObjectOptions()
+
+The enums of the package are string types, so a misspelled value converted from a string, such as `search.QueryType("prefixFirst")`,
+compiles: the values are validated when the options are built.
+*/
+type QueryStrategy struct {
+	queryType              *QueryType
+	removeWordsIfNoResults *RemoveWordsIfNoResults
+	exactOnSingleWordQuery *ExactOnSingleWordQuery
+	alternativesAsExact    []AlternativesAsExact
+	alternativesSet        bool
+}
+
+// NewQueryStrategy returns a QueryStrategy leaving every parameter to the settings of the index.
+func NewQueryStrategy() *QueryStrategy {
+	return &QueryStrategy{}
+}
+
+// QueryType sets which words of the query are matched as prefixes: the last one, all of them, or none.
+func (q *QueryStrategy) QueryType(queryType QueryType) *QueryStrategy {
+	q.queryType = &queryType
+
+	return q
+}
+
+// RemoveWordsIfNoResults sets which words of the query are removed, one at a time, until some records match when none match the whole query.
+func (q *QueryStrategy) RemoveWordsIfNoResults(strategy RemoveWordsIfNoResults) *QueryStrategy {
+	q.removeWordsIfNoResults = &strategy
+
+	return q
+}
+
+// ExactOnSingleWordQuery sets how the `exact` ranking criterion is computed for the queries of a single word.
+func (q *QueryStrategy) ExactOnSingleWordQuery(strategy ExactOnSingleWordQuery) *QueryStrategy {
+	q.exactOnSingleWordQuery = &strategy
+
+	return q
+}
+
+// AlternativesAsExact sets the alternatives of the query words, such as plurals and synonyms, that count as exact matches, none when called without one. Duplicates are removed.
+func (q *QueryStrategy) AlternativesAsExact(alternatives ...AlternativesAsExact) *QueryStrategy {
+	q.alternativesAsExact = []AlternativesAsExact{}
+	q.alternativesSet = true
+
+	for _, alternative := range alternatives {
+		if !slices.Contains(q.alternativesAsExact, alternative) {
+			q.alternativesAsExact = append(q.alternativesAsExact, alternative)
+		}
+	}
+
+	return q
+}
+
+// validate returns the first value outside of its enum.
+func (q *QueryStrategy) validate() error {
+	if q.queryType != nil && !q.queryType.IsValid() {
+		return reportError("invalid queryType %q: valid values are %v", *q.queryType, AllowedQueryTypeEnumValues)
+	}
+
+	if q.removeWordsIfNoResults != nil && !q.removeWordsIfNoResults.IsValid() {
+		return reportError("invalid removeWordsIfNoResults %q: valid values are %v", *q.removeWordsIfNoResults, AllowedRemoveWordsIfNoResultsEnumValues)
+	}
+
+	if q.exactOnSingleWordQuery != nil && !q.exactOnSingleWordQuery.IsValid() {
+		return reportError("invalid exactOnSingleWordQuery %q: valid values are %v", *q.exactOnSingleWordQuery, AllowedExactOnSingleWordQueryEnumValues)
+	}
+
+	for _, alternative := range q.alternativesAsExact {
+		if !alternative.IsValid() {
+			return reportError("invalid alternativesAsExact %q: valid values are %v", alternative, AllowedAlternativesAsExactEnumValues)
+		}
+	}
+
+	return nil
+}
+
+/*
+SearchForHitsOptions validates the parameters and returns them as options of NewSearchForHits.
+
+	@return []SearchForHitsOption - The options.
+	@return error - The first invalid parameter.
+*/
+func (q *QueryStrategy) SearchForHitsOptions() ([]SearchForHitsOption, error) {
+	err := q.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []SearchForHitsOption
+
+	if q.queryType != nil {
+		opts = append(opts, WithSearchForHitsQueryType(*q.queryType))
+	}
+
+	if q.removeWordsIfNoResults != nil {
+		opts = append(opts, WithSearchForHitsRemoveWordsIfNoResults(*q.removeWordsIfNoResults))
+	}
+
+	if q.exactOnSingleWordQuery != nil {
+		opts = append(opts, WithSearchForHitsExactOnSingleWordQuery(*q.exactOnSingleWordQuery))
+	}
+
+	if q.alternativesSet {
+		opts = append(opts, WithSearchForHitsAlternativesAsExact(q.alternativesAsExact))
+	}
+
+	return opts, nil
+}
+
+/*
+SearchParamsObjectOptions validates the parameters and returns them as options of NewSearchParamsObject.
+
+	@return []SearchParamsObjectOption - The options.
+	@return error - The first invalid parameter.
+*/
+func (q *QueryStrategy) SearchParamsObjectOptions() ([]SearchParamsObjectOption, error) {
+	err := q.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []SearchParamsObjectOption
+
+	if q.queryType != nil {
+		opts = append(opts, WithSearchParamsObjectQueryType(*q.queryType))
+	}
+
+	if q.removeWordsIfNoResults != nil {
+		opts = append(opts, WithSearchParamsObjectRemoveWordsIfNoResults(*q.removeWordsIfNoResults))
+	}
+
+	if q.exactOnSingleWordQuery != nil {
+		opts = append(opts, WithSearchParamsObjectExactOnSingleWordQuery(*q.exactOnSingleWordQuery))
+	}
+
+	if q.alternativesSet {
+		opts = append(opts, WithSearchParamsObjectAlternativesAsExact(q.alternativesAsExact))
+	}
+
+	return opts, nil
+}
+
+/*
+IndexSettingsOptions validates the parameters and returns them as options of NewIndexSettings, making them the default of the searches.
+
+	@return []IndexSettingsOption - The options.
+	@return error - The first invalid parameter.
+*/
+func (q *QueryStrategy) IndexSettingsOptions() ([]IndexSettingsOption, error) {
+	err := q.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []IndexSettingsOption
+
+	if q.queryType != nil {
+		opts = append(opts, WithIndexSettingsQueryType(*q.queryType))
+	}
+
+	if q.removeWordsIfNoResults != nil {
+		opts = append(opts, WithIndexSettingsRemoveWordsIfNoResults(*q.removeWordsIfNoResults))
+	}
+
+	if q.exactOnSingleWordQuery != nil {
+		opts = append(opts, WithIndexSettingsExactOnSingleWordQuery(*q.exactOnSingleWordQuery))
+	}
+
+	if q.alternativesSet {
+		opts = append(opts, WithIndexSettingsAlternativesAsExact(q.alternativesAsExact))
+	}
+
+	return opts, nil
+}
@@ -0,0 +1,69 @@
+package search_test
+
+import (
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+)
+
+func TestQueryStrategy(t *testing.T) {
+	t.Parallel()
+
+	strategy := search.NewQueryStrategy().
+		QueryType(search.QUERY_TYPE_PREFIX_NONE).
+		RemoveWordsIfNoResults(search.REMOVE_WORDS_IF_NO_RESULTS_LAST_WORDS).
+		ExactOnSingleWordQuery(search.EXACT_ON_SINGLE_WORD_QUERY_WORD).
+		AlternativesAsExact(search.ALTERNATIVES_AS_EXACT_IGNORE_PLURALS, search.ALTERNATIVES_AS_EXACT_IGNORE_PLURALS)
+
+	opts, err := strategy.SearchParamsObjectOptions()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sameJSON(t, search.NewSearchParamsObject(opts...), `{
+		"queryType": "prefixNone",
+		"removeWordsIfNoResults": "lastWords",
+		"exactOnSingleWordQuery": "word",
+		"alternativesAsExact": ["ignorePlurals"]
+	}`)
+
+	hitsOpts, err := search.NewQueryStrategy().AlternativesAsExact().SearchForHitsOptions()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sameJSON(t, search.NewSearchForHits("products", hitsOpts...), `{"indexName": "products", "alternativesAsExact": []}`)
+
+	settingsOpts, err := search.NewQueryStrategy().QueryType(search.QUERY_TYPE_PREFIX_ALL).IndexSettingsOptions()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sameJSON(t, search.NewIndexSettings(settingsOpts...), `{"queryType": "prefixAll"}`)
+}
+
+func TestQueryStrategyValidation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		strategy *search.QueryStrategy
+	}{
+		{name: "query type", strategy: search.NewQueryStrategy().QueryType("prefixFirst")},
+		{name: "remove words", strategy: search.NewQueryStrategy().RemoveWordsIfNoResults("someWords")},
+		{name: "exact on single word", strategy: search.NewQueryStrategy().ExactOnSingleWordQuery(search.ExactOnSingleWordQuery("all"))},
+		{name: "alternatives", strategy: search.NewQueryStrategy().AlternativesAsExact("typos")},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := tt.strategy.SearchParamsObjectOptions(); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package search
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// RawHitsSearchResponse is a SearchResponse whose hits are kept as raw JSON, to be decoded by the caller into its own
+// types. The embedded SearchResponse has no hits.
+type RawHitsSearchResponse struct {
+	SearchResponse
+
+	Hits []json.RawMessage `json:"hits"`
+}
+
+func (o *RawHitsSearchResponse) UnmarshalJSON(bytes []byte) error {
+	fields := map[string]json.RawMessage{}
+
+	err := json.Unmarshal(bytes, &fields)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal RawHitsSearchResponse: %w", err)
+	}
+
+	hits := []json.RawMessage{}
+
+	if raw, ok := fields["hits"]; ok {
+		err = json.Unmarshal(raw, &hits)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal hits in RawHitsSearchResponse: %w", err)
+		}
+
+		fields["hits"] = json.RawMessage("[]")
+	}
+
+	// Decode the rest of the response without its hits, so that they are not
+	// parsed into Hit models and additional properties.
+	rest, err := json.Marshal(fields)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal RawHitsSearchResponse: %w", err)
+	}
+
+	err = json.Unmarshal(rest, &o.SearchResponse)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal RawHitsSearchResponse: %w", err)
+	}
+
+	o.SearchResponse.Hits = nil
+	o.Hits = hits
+
+	return nil
+}
+
+func (o RawHitsSearchResponse) MarshalJSON() ([]byte, error) {
+	serialized, err := json.Marshal(o.SearchResponse)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal RawHitsSearchResponse: %w", err)
+	}
+
+	fields := map[string]json.RawMessage{}
+
+	err = json.Unmarshal(serialized, &fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal RawHitsSearchResponse: %w", err)
+	}
+
+	hits, err := json.Marshal(o.Hits)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal hits in RawHitsSearchResponse: %w", err)
+	}
+
+	fields["hits"] = hits
+
+	serialized, err = json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal RawHitsSearchResponse: %w", err)
+	}
+
+	return serialized, nil
+}
+
+/*
+SearchSingleIndexWithRawHits calls the `searchSingleIndex` method but keeps the hits as raw JSON instead of decoding
+them into Hit models, avoiding the allocation of their additional properties for callers decoding the hits into their
+own types.
+
+	@param r ApiSearchSingleIndexRequest - Body of the `searchSingleIndex` operation.
+	@param opts ...RequestOption - Optional parameters for the request.
+	@return *RawHitsSearchResponse - Search response with raw hits.
+	@return error - Error if any.
+*/
+func (c *APIClient) SearchSingleIndexWithRawHits(r ApiSearchSingleIndexRequest, opts ...RequestOption) (*RawHitsSearchResponse, error) {
+	var returnValue *RawHitsSearchResponse
+
+	res, resBody, err := c.SearchSingleIndexWithHTTPInfo(r, opts...)
+	if err != nil {
+		return returnValue, err
+	}
+
+	if res == nil {
+		return returnValue, reportError("res is nil")
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 300 {
+		return returnValue, c.decodeError(res, resBody)
+	}
+
+	err = c.decode(&returnValue, resBody)
+	if err != nil {
+		return returnValue, reportError("cannot decode result: %w", err)
+	}
+
+	return returnValue, nil
+}
+
+/*
+SearchForHitsWithRawHits calls the `search` method like SearchForHits, but keeps the hits as raw JSON instead of
+decoding them into Hit models. Results that are facet values are skipped.
+
+	@param r ApiSearchRequest - Body of the `search` operation.
+	@param opts ...RequestOption - Optional parameters for the request.
+	@return []RawHitsSearchResponse - List of search responses with raw hits.
+	@return error - Error if any.
+*/
+func (c *APIClient) SearchForHitsWithRawHits(r ApiSearchRequest, opts ...RequestOption) ([]RawHitsSearchResponse, error) {
+	res, resBody, err := c.SearchWithHTTPInfo(r, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if res == nil {
+		return nil, reportError("res is nil")
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 300 {
+		return nil, c.decodeError(res, resBody)
+	}
+
+	var results struct {
+		Results []json.RawMessage `json:"results"`
+	}
+
+	err = c.decode(&results, resBody)
+	if err != nil {
+		return nil, reportError("cannot decode result: %w", err)
+	}
+
+	responses := make([]RawHitsSearchResponse, 0, len(results.Results))
+
+	for _, result := range results.Results {
+		var probe struct {
+			Hits json.RawMessage `json:"hits"`
+		}
+
+		err = c.decode(&probe, result)
+		if err != nil {
+			return nil, reportError("cannot decode result: %w", err)
+		}
+
+		if probe.Hits == nil {
+			continue
+		}
+
+		var response RawHitsSearchResponse
+
+		err = c.decode(&response, result)
+		if err != nil {
+			return nil, reportError("cannot decode result: %w", err)
+		}
+
+		responses = append(responses, response)
+	}
+
+	return responses, nil
+}
@@ -0,0 +1,80 @@
+package search_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+)
+
+type rawProduct struct {
+	ObjectID string  `json:"objectID"`
+	Name     string  `json:"name"`
+	Price    float64 `json:"price"`
+}
+
+func TestSearchSingleIndexWithRawHits(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"hits":[{"objectID":"1","name":"Phone","price":199.5},{"objectID":"2","name":"Case","price":9}],
+			"nbHits":2,"page":0,"hitsPerPage":20,"processingTimeMS":1,"query":"phone","params":"query=phone","customField":true}`))
+	})
+
+	res, err := client.SearchSingleIndexWithRawHits(client.NewApiSearchSingleIndexRequest("products"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if res.GetNbHits() != 2 || res.Query != "phone" || res.SearchResponse.Hits != nil {
+		t.Errorf("unexpected response %v", res.SearchResponse)
+	}
+
+	if res.AdditionalProperties["customField"] != true {
+		t.Errorf("unexpected additional properties %v", res.AdditionalProperties)
+	}
+
+	if len(res.Hits) != 2 {
+		t.Fatalf("expected 2 raw hits, got %d", len(res.Hits))
+	}
+
+	var p rawProduct
+
+	err = json.Unmarshal(res.Hits[0], &p)
+	if err != nil || p != (rawProduct{ObjectID: "1", Name: "Phone", Price: 199.5}) {
+		t.Errorf("unexpected hit %+v (%v)", p, err)
+	}
+}
+
+func TestSearchForHitsWithRawHits(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results":[
+			{"hits":[{"objectID":"1","name":"Phone","price":199.5}],"nbHits":1,"processingTimeMS":1,"query":"phone","params":"","index":"products"},
+			{"facetHits":[{"value":"Apple","highlighted":"Apple","count":3}],"exhaustiveFacetsCount":true,"processingTimeMS":1}
+		]}`))
+	})
+
+	res, err := client.SearchForHitsWithRawHits(client.NewApiSearchRequest(search.NewEmptySearchMethodParams()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(res) != 1 || res[0].GetIndex() != "products" || len(res[0].Hits) != 1 {
+		t.Fatalf("unexpected responses %v", res)
+	}
+
+	out, err := json.Marshal(res[0])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var roundTrip search.SearchResponse
+
+	err = json.Unmarshal(out, &roundTrip)
+	if err != nil || len(roundTrip.Hits) != 1 || roundTrip.Hits[0].ObjectID != "1" {
+		t.Errorf("unexpected round trip %s (%v)", out, err)
+	}
+}
@@ -0,0 +1,210 @@
+package search
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultMaxRecordBytes is the maximum size of a record accepted by the engine, unless changed with its `FLAPJACK_MAX_DOC_MB` setting.
+const DefaultMaxRecordBytes = 3 * 1024 * 1024
+
+// DefaultSplitDistinctAttribute is the attribute SplitRecords sets to the objectID of the original record, to be used as `attributeForDistinct`.
+const DefaultSplitDistinctAttribute = "parentObjectID"
+
+// RecordTooLargeError is returned by ChunkedBatch and ParallelChunkedBatch, before any batch is sent, for a record larger than WithMaxRecordBytes.
+type RecordTooLargeError struct {
+	// Position is the position of the record in the objects.
+	Position int
+	ObjectID string
+	// Size is the size of the JSON-encoded record, in bytes.
+	Size     int
+	MaxBytes int
+}
+
+func (e *RecordTooLargeError) Error() string {
+	return fmt.Sprintf("object at position %d (objectID %q) is %d bytes, more than the maximum of %d bytes", e.Position, e.ObjectID, e.Size, e.MaxBytes)
+}
+
+// WithMaxRecordBytes the maximum size, in bytes, of a JSON-encoded record. ChunkedBatch and ParallelChunkedBatch check every record before sending the first batch, and return a *RecordTooLargeError for the first one exceeding it. Defaults to 0 (no check), use DefaultMaxRecordBytes for the engine default.
+func WithMaxRecordBytes(maxRecordBytes int) chunkedBatchOption {
+	return chunkedBatchOption(func(c *config) {
+		c.maxRecordBytes = maxRecordBytes
+	})
+}
+
+// validateRecordSizes returns a *RecordTooLargeError for the first record larger than maxRecordBytes.
+func validateRecordSizes(objects []map[string]any, maxRecordBytes int) error {
+	for i, obj := range objects {
+		raw, err := json.Marshal(obj)
+		if err != nil {
+			return fmt.Errorf("cannot compute the size of object at position %d: %w", i, err)
+		}
+
+		if len(raw) > maxRecordBytes {
+			objectID, _ := objectIDOf(obj)
+
+			return &RecordTooLargeError{Position: i, ObjectID: objectID, Size: len(raw), MaxBytes: maxRecordBytes}
+		}
+	}
+
+	return nil
+}
+
+// RecordSplitConfig configures SplitRecords.
+type RecordSplitConfig struct {
+	// Attribute is the text attribute split between the records. Required.
+	Attribute string
+	// MaxBytes is the maximum size of the JSON-encoded records. Defaults to DefaultMaxRecordBytes.
+	MaxBytes int
+	// DistinctAttribute is set to the objectID of the original record on each record. Defaults to DefaultSplitDistinctAttribute.
+	DistinctAttribute string
+}
+
+/*
+SplitRecords splits the records larger than `cfg.MaxBytes` in several records, each holding a chunk of the text of `cfg.Attribute` and the other attributes of the original record.
+The chunks are cut between words when possible, and their records get the objectIDs `<objectID>-0`, `<objectID>-1`... Every record, split or not, gets `cfg.DistinctAttribute` set to the objectID of the original record: set it as `attributeForDistinct` with `distinct` enabled to only retrieve the best chunk of each record.
+
+	@param objects []map[string]any - Records to split, which must have an objectID.
+	@param cfg RecordSplitConfig - Configuration of the split.
+	@return []map[string]any - The records, in order, the split ones replaced by their chunks.
+	@return error - Error if a record cannot be split under `cfg.MaxBytes`.
+*/
+func SplitRecords(objects []map[string]any, cfg RecordSplitConfig) ([]map[string]any, error) {
+	if cfg.Attribute == "" {
+		return nil, reportError("the attribute to split is required")
+	}
+
+	if cfg.MaxBytes <= 0 {
+		cfg.MaxBytes = DefaultMaxRecordBytes
+	}
+
+	if cfg.DistinctAttribute == "" {
+		cfg.DistinctAttribute = DefaultSplitDistinctAttribute
+	}
+
+	split := make([]map[string]any, 0, len(objects))
+
+	for i, obj := range objects {
+		records, err := splitRecord(obj, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("cannot split object at position %d: %w", i, err)
+		}
+
+		split = append(split, records...)
+	}
+
+	return split, nil
+}
+
+func splitRecord(obj map[string]any, cfg RecordSplitConfig) ([]map[string]any, error) {
+	objectID, ok := objectIDOf(obj)
+	if !ok {
+		return nil, reportError("the record has no `objectID`")
+	}
+
+	record := maps.Clone(obj)
+	record[cfg.DistinctAttribute] = objectID
+
+	raw, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(raw) <= cfg.MaxBytes {
+		return []map[string]any{record}, nil
+	}
+
+	text, ok := obj[cfg.Attribute].(string)
+	if !ok {
+		return nil, reportError("the record is %d bytes, and its attribute `%s` is not a text to split", len(raw), cfg.Attribute)
+	}
+
+	// The size of the other attributes, with the longest possible objectID.
+	record[cfg.Attribute] = ""
+	record["objectID"] = objectID + "-" + strconv.Itoa(len(text))
+
+	raw, err = json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+
+	// A rune takes up to 6 bytes once escaped, such as `\u2028`.
+	budget := cfg.MaxBytes - len(raw)
+	if budget < 6 {
+		return nil, reportError("the attributes other than `%s` take %d of the %d bytes of a record", cfg.Attribute, len(raw), cfg.MaxBytes)
+	}
+
+	chunks := splitText(text, budget)
+	records := make([]map[string]any, 0, len(chunks))
+
+	for i, chunk := range chunks {
+		part := maps.Clone(record)
+		part["objectID"] = objectID + "-" + strconv.Itoa(i)
+		part[cfg.Attribute] = chunk
+		records = append(records, part)
+	}
+
+	return records, nil
+}
+
+// splitText cuts the text in chunks whose JSON encoding is at most budget bytes, between words when possible.
+func splitText(text string, budget int) []string {
+	var (
+		chunks []string
+		chunk  strings.Builder
+		size   int
+	)
+
+	for _, word := range strings.SplitAfter(text, " ") {
+		for word != "" {
+			cost := escapedLen(word)
+
+			switch {
+			case size+cost <= budget:
+				chunk.WriteString(word)
+				size += cost
+				word = ""
+			case size > 0:
+				chunks = append(chunks, chunk.String())
+				chunk.Reset()
+				size = 0
+			default:
+				// The word alone is too long, it is cut between runes.
+				n := 0
+
+				for n < len(word) {
+					_, width := utf8.DecodeRuneInString(word[n:])
+
+					runeCost := escapedLen(word[n : n+width])
+					if size+runeCost > budget {
+						break
+					}
+
+					size += runeCost
+					n += width
+				}
+
+				chunks = append(chunks, word[:n])
+				word = word[n:]
+				size = 0
+			}
+		}
+	}
+
+	if size > 0 {
+		chunks = append(chunks, chunk.String())
+	}
+
+	return chunks
+}
+
+// escapedLen returns the length of the text once encoded as a JSON string, without its quotes.
+func escapedLen(s string) int {
+	raw, _ := json.Marshal(s)
+
+	return len(raw) - 2
+}
@@ -0,0 +1,129 @@
+package search_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+)
+
+func TestMaxRecordBytes(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("no request should be sent, got %s %s", r.Method, r.URL.Path)
+	})
+
+	objects := []map[string]any{
+		{"objectID": "1", "body": "short"},
+		{"objectID": "2", "body": strings.Repeat("long ", 20)},
+	}
+
+	for name, batch := range map[string]func(indexName string, objects []map[string]any, action search.Action, opts ...search.ChunkedBatchOption) ([]search.BatchResponse, error){
+		"ChunkedBatch":         client.ChunkedBatch,
+		"ParallelChunkedBatch": client.ParallelChunkedBatch,
+	} {
+		_, err := batch("articles", objects, search.ACTION_ADD_OBJECT, search.WithMaxRecordBytes(64), search.WithBatchSize(1))
+
+		var tooLarge *search.RecordTooLargeError
+		if !errors.As(err, &tooLarge) || tooLarge.Position != 1 || tooLarge.ObjectID != "2" || tooLarge.Size != 126 || tooLarge.MaxBytes != 64 {
+			t.Errorf("%s: expected a RecordTooLargeError for the second record, got %v", name, err)
+		}
+	}
+}
+
+func TestSplitRecords(t *testing.T) {
+	t.Parallel()
+
+	text := "Pancakes <are> flat cakes, " + strings.Repeat("é", 40) + " cooked on a hot griddle. " + strings.Repeat("Flapjacks too. ", 10)
+
+	records, err := search.SplitRecords([]map[string]any{
+		{"objectID": "short", "title": "Waffles", "body": "Crisp."},
+		{"objectID": "long", "title": "Pancakes", "body": text},
+	}, search.RecordSplitConfig{Attribute: "body", MaxBytes: 120})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if first := records[0]; len(first) != 4 || first["objectID"] != "short" || first["parentObjectID"] != "short" || first["body"] != "Crisp." {
+		t.Errorf("unexpected unsplit record %v", first)
+	}
+
+	if len(records) < 4 {
+		t.Fatalf("expected the long record to be split, got %v", records)
+	}
+
+	var joined strings.Builder
+
+	for i, record := range records[1:] {
+		raw, err := json.Marshal(record)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(raw) > 120 {
+			t.Errorf("record %d is %d bytes: %s", i, len(raw), raw)
+		}
+
+		if record["objectID"] != "long-"+string(rune('0'+i)) || record["parentObjectID"] != "long" || record["title"] != "Pancakes" {
+			t.Errorf("unexpected chunk record %d: %v", i, record)
+		}
+
+		joined.WriteString(record["body"].(string))
+	}
+
+	if joined.String() != text {
+		t.Errorf("expected the chunks to join into the text\n got: %q\nwant: %q", joined.String(), text)
+	}
+}
+
+func TestSplitRecordsErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		record  map[string]any
+		cfg     search.RecordSplitConfig
+		wantErr string
+	}{
+		{
+			name:    "no attribute",
+			record:  map[string]any{"objectID": "1"},
+			wantErr: "attribute to split is required",
+		},
+		{
+			name:    "no objectID",
+			record:  map[string]any{"body": "text"},
+			cfg:     search.RecordSplitConfig{Attribute: "body"},
+			wantErr: "position 0: the record has no `objectID`",
+		},
+		{
+			name:    "not a text",
+			record:  map[string]any{"objectID": "1", "body": []any{strings.Repeat("a", 100)}},
+			cfg:     search.RecordSplitConfig{Attribute: "body", MaxBytes: 50},
+			wantErr: "is not a text to split",
+		},
+		{
+			name:    "other attributes too large",
+			record:  map[string]any{"objectID": "1", "title": strings.Repeat("a", 100), "body": "text"},
+			cfg:     search.RecordSplitConfig{Attribute: "body", MaxBytes: 100},
+			wantErr: "the attributes other than `body` take",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := search.SplitRecords([]map[string]any{tt.record}, tt.cfg)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package search_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+func TestWithExtraParams(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Feature-Flag"); got != "new-ranking" {
+			t.Errorf("unexpected X-Feature-Flag header %q", got)
+		}
+
+		if got := r.URL.Query()["tag"]; len(got) != 2 || got[0] != "a" || got[1] != "b" {
+			t.Errorf("unexpected tag query params %v", got)
+		}
+
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	_, err := client.GetSettings(client.NewApiGetSettingsRequest("products"),
+		search.WithExtraHeaders(map[string]string{"X-Feature-Flag": "new-ranking"}),
+		search.WithExtraQueryParams(url.Values{"tag": {"a"}}),
+		search.WithExtraQueryParams(url.Values{"tag": {"b"}}),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestWithResponseInfo(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "42")
+		w.Header().Set(transport.RequestIDHeader, "server-id")
+		_, _ = w.Write([]byte(`{"hitsPerPage": 20}`))
+	})
+
+	var info transport.ResponseInfo
+
+	settings, err := client.GetSettings(client.NewApiGetSettingsRequest("products"), search.WithResponseInfo(&info))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if settings.GetHitsPerPage() != 20 {
+		t.Errorf("expected the decoded body, got %v", settings)
+	}
+
+	if info.StatusCode != http.StatusOK || info.Header.Get("X-RateLimit-Remaining") != "42" || info.RequestID != "server-id" {
+		t.Errorf("unexpected response info %+v", info)
+	}
+
+	if info.Host == "" || info.Attempts != 1 || info.Duration <= 0 {
+		t.Errorf("expected the host, attempts and duration of the call, got %+v", info)
+	}
+}
@@ -0,0 +1,79 @@
+package search
+
+import (
+	"encoding/json"
+	"time"
+)
+
+/*
+ExhaustiveFlags returns whether the counts of the response are exact, from its `exhaustive` object, completed by the deprecated `exhaustiveNbHits`, `exhaustiveFacetsCount` and `exhaustiveTypo` flags for the ones it doesn't set.
+Use Approximate to detect approximate counts, to display "about 1,000 results" for instance.
+
+	@return Exhaustive - The flags of the response, those not reported being nil.
+*/
+func (o *SearchResponse) ExhaustiveFlags() Exhaustive {
+	exhaustive := o.GetExhaustive()
+
+	if exhaustive.NbHits == nil {
+		exhaustive.NbHits = o.ExhaustiveNbHits
+	}
+
+	if exhaustive.FacetsCount == nil {
+		exhaustive.FacetsCount = o.ExhaustiveFacetsCount
+	}
+
+	if exhaustive.Typo == nil {
+		exhaustive.Typo = o.ExhaustiveTypo
+	}
+
+	return exhaustive
+}
+
+// Approximate returns whether any of the reported flags is false, some counts of the response being approximated.
+func (e Exhaustive) Approximate() bool {
+	for _, flag := range []*bool{e.FacetsCount, e.FacetValues, e.NbHits, e.RulesMatch, e.Typo} {
+		if flag != nil && !*flag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ProcessingTime returns the `processingTimeMS` of the response, the time the engine spent processing the search.
+func (o *SearchResponse) ProcessingTime() time.Duration {
+	return time.Duration(o.GetProcessingTimeMS()) * time.Millisecond
+}
+
+// ServerTime returns the `serverTimeMS` of the response, the time the server spent on the request, queueing included.
+func (o *SearchResponse) ServerTime() time.Duration {
+	return time.Duration(o.GetServerTimeMS()) * time.Millisecond
+}
+
+/*
+ProcessingTimings returns the time spent in each stage of the search, from the `processingTimingsMS` of the response, to find the slow ones.
+Flapjack reports the stages, such as `queue`, `search`, `highlight` and `total`, in microseconds despite the name of the field. Nested stages are named by their path, such as `fetch.total`.
+
+	@return map[string]time.Duration - The time spent by stage, empty when not reported.
+*/
+func (o *SearchResponse) ProcessingTimings() map[string]time.Duration {
+	timings := map[string]time.Duration{}
+	flattenTimings(timings, "", o.GetProcessingTimingsMS())
+
+	return timings
+}
+
+func flattenTimings(timings map[string]time.Duration, prefix string, values map[string]any) {
+	for name, value := range values {
+		switch value := value.(type) {
+		case float64:
+			timings[prefix+name] = time.Duration(value * float64(time.Microsecond))
+		case json.Number:
+			if micros, err := value.Float64(); err == nil {
+				timings[prefix+name] = time.Duration(micros * float64(time.Microsecond))
+			}
+		case map[string]any:
+			flattenTimings(timings, prefix+name+".", value)
+		}
+	}
+}
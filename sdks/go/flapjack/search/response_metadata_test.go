@@ -0,0 +1,103 @@
+package search_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/utils"
+)
+
+func TestExhaustiveFlags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		response        string
+		wantNbHits      *bool
+		wantApproximate bool
+	}{
+		{
+			name:     "not reported",
+			response: `{}`,
+		},
+		{
+			name:            "exhaustive object",
+			response:        `{"exhaustive": {"nbHits": true, "typo": false}, "exhaustiveNbHits": false}`,
+			wantNbHits:      utils.ToPtr(true),
+			wantApproximate: true,
+		},
+		{
+			name:            "deprecated flags",
+			response:        `{"exhaustive": {"facetsCount": true}, "exhaustiveNbHits": false, "exhaustiveTypo": true}`,
+			wantNbHits:      utils.ToPtr(false),
+			wantApproximate: true,
+		},
+		{
+			name:       "exact counts",
+			response:   `{"exhaustive": {"nbHits": true, "facetsCount": true}, "exhaustiveTypo": true}`,
+			wantNbHits: utils.ToPtr(true),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var res search.SearchResponse
+
+			err := json.Unmarshal([]byte(tt.response), &res)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			flags := res.ExhaustiveFlags()
+
+			if !reflect.DeepEqual(flags.NbHits, tt.wantNbHits) {
+				t.Errorf("expected nbHits %v, got %v", tt.wantNbHits, flags.NbHits)
+			}
+
+			if got := flags.Approximate(); got != tt.wantApproximate {
+				t.Errorf("expected Approximate() = %v, got %v", tt.wantApproximate, got)
+			}
+		})
+	}
+}
+
+func TestProcessingTimings(t *testing.T) {
+	t.Parallel()
+
+	var res search.SearchResponse
+
+	err := json.Unmarshal([]byte(`{
+		"processingTimeMS": 3,
+		"serverTimeMS": 4,
+		"processingTimingsMS": {"queue": 250, "search": 2100, "total": 3400, "fetch": {"total": 1500.5}, "label": "ignored"}
+	}`), &res)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := res.ProcessingTime(); got != 3*time.Millisecond {
+		t.Errorf("unexpected processing time %v", got)
+	}
+
+	if got := res.ServerTime(); got != 4*time.Millisecond {
+		t.Errorf("unexpected server time %v", got)
+	}
+
+	want := map[string]time.Duration{
+		"queue":       250 * time.Microsecond,
+		"search":      2100 * time.Microsecond,
+		"total":       3400 * time.Microsecond,
+		"fetch.total": 1500500 * time.Nanosecond,
+	}
+
+	if got := res.ProcessingTimings(); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected timings\n got: %v\nwant: %v", got, want)
+	}
+}
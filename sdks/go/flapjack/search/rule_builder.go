@@ -0,0 +1,291 @@
+package search
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+const (
+	// maxPromotedRecords is the maximum number of records promoted by a rule.
+	maxPromotedRecords = 300
+	// maxPromotedGroupSize is the maximum number of records of a group promoted at the same position.
+	maxPromotedGroupSize = 100
+	// maxRuleUserDataBytes is the maximum size of the minified JSON of the user data of a rule.
+	maxRuleUserDataBytes = 1024
+)
+
+/*
+NewTimeRangeBetween returns the time range from `from` until `until`, for the validity of a rule.
+
+	@param from time.Time - Start of the range.
+	@param until time.Time - End of the range, after its start.
+	@return *TimeRange - The time range, in seconds since the Unix epoch.
+	@return error - Error if the range is empty.
+*/
+func NewTimeRangeBetween(from time.Time, until time.Time) (*TimeRange, error) {
+	if !until.After(from) {
+		return nil, reportError("the time range from %s until %s is empty", from.UTC().Format(time.RFC3339), until.UTC().Format(time.RFC3339))
+	}
+
+	return NewTimeRange(WithTimeRangeFrom(from.Unix()), WithTimeRangeUntil(until.Unix())), nil
+}
+
+/*
+RuleBuilder builds a merchandising rule, validating its consequences before it is saved:
+
+	rule, err := search.NewRuleBuilder("summer-sale").
+		Conditions(*search.NewCondition(search.WithConditionPattern("sale"), search.WithConditionAnchoring(search.ANCHORING_CONTAINS))).
+		Promote("phone-1", 0).
+		PromoteGroup([]string{"case-1", "case-2"}, 3).
+		Hide("phone-old").
+		UserData(map[string]any{"banner": "summer.png"}).
+		ValidBetween(start, end).
+		Build()
+
+	_, err = client.SaveRule(client.NewApiSaveRuleRequest("products", rule.ObjectID, rule))
+*/
+type RuleBuilder struct {
+	rule    Rule
+	context RuleContext
+	err     error
+}
+
+// NewRuleBuilder returns a builder of the rule with the given objectID.
+func NewRuleBuilder(objectID string) *RuleBuilder {
+	b := &RuleBuilder{rule: Rule{ObjectID: objectID}}
+
+	if objectID == "" {
+		b.fail(reportError("the objectID of a rule is required"))
+	}
+
+	return b
+}
+
+// Description describes the purpose of the rule.
+func (b *RuleBuilder) Description(description string) *RuleBuilder {
+	b.rule.Description = &description
+
+	return b
+}
+
+// Conditions adds conditions triggering the rule. A rule without conditions applies to every search.
+func (b *RuleBuilder) Conditions(conditions ...Condition) *RuleBuilder {
+	b.rule.Conditions = append(b.rule.Conditions, conditions...)
+
+	return b
+}
+
+// Enabled enables or disables the rule.
+func (b *RuleBuilder) Enabled(enabled bool) *RuleBuilder {
+	b.rule.Enabled = &enabled
+
+	return b
+}
+
+// Tags adds tags to the rule, to find it with SearchRules.
+func (b *RuleBuilder) Tags(tags ...string) *RuleBuilder {
+	b.rule.Tags = append(b.rule.Tags, tags...)
+
+	return b
+}
+
+// Params sets the search parameters the rule applies. The engine only applies a `query` string, replacing the query of the search, so the other parameters, such as filters, and the edits of the query are rejected.
+func (b *RuleBuilder) Params(params ConsequenceParams) *RuleBuilder {
+	b.fail(checkConsequenceParams(params))
+	b.rule.Consequence.Params = &params
+
+	return b
+}
+
+// checkConsequenceParams returns an error for the first parameter of a consequence the engine doesn't apply.
+func checkConsequenceParams(params ConsequenceParams) error {
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return reportError("cannot encode the params of the rule: %w", err)
+	}
+
+	var fields map[string]json.RawMessage
+
+	err = json.Unmarshal(raw, &fields)
+	if err != nil {
+		return reportError("cannot decode the params of the rule: %w", err)
+	}
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		if name != "query" {
+			names = append(names, name)
+		}
+	}
+
+	if len(names) > 0 {
+		sort.Strings(names)
+
+		return reportError("the engine only applies the query of the params of a rule, not %q", names[0])
+	}
+
+	if params.Query != nil && params.Query.String == nil {
+		return reportError("the engine only applies a query string in the params of a rule, not edits of the query")
+	}
+
+	return nil
+}
+
+// Promote pins the record at the position of the results, starting at 0.
+func (b *RuleBuilder) Promote(objectID string, position int32) *RuleBuilder {
+	if objectID == "" {
+		b.fail(reportError("the objectID of a promoted record is empty"))
+	}
+
+	b.checkPosition(position)
+	b.rule.Consequence.Promote = append(b.rule.Consequence.Promote, *PromoteObjectIDAsPromote(NewPromoteObjectID(objectID, position)))
+
+	return b
+}
+
+// PromoteGroup pins the records, in order, from the position of the results, starting at 0.
+func (b *RuleBuilder) PromoteGroup(objectIDs []string, position int32) *RuleBuilder {
+	switch {
+	case len(objectIDs) == 0:
+		b.fail(reportError("the group of promoted records at position %d is empty", position))
+	case len(objectIDs) > maxPromotedGroupSize:
+		b.fail(reportError("the group of promoted records at position %d has %d records, more than the maximum of %d", position, len(objectIDs), maxPromotedGroupSize))
+	}
+
+	for _, objectID := range objectIDs {
+		if objectID == "" {
+			b.fail(reportError("the group of promoted records at position %d has an empty objectID", position))
+		}
+	}
+
+	b.checkPosition(position)
+	b.rule.Consequence.Promote = append(b.rule.Consequence.Promote, *PromoteObjectIDsAsPromote(NewPromoteObjectIDs(append([]string{}, objectIDs...), position)))
+
+	return b
+}
+
+// FilterPromotes whether the promoted records must match the filters of the search to be shown.
+func (b *RuleBuilder) FilterPromotes(filterPromotes bool) *RuleBuilder {
+	b.rule.Consequence.FilterPromotes = &filterPromotes
+
+	return b
+}
+
+// Hide removes the records from the results.
+func (b *RuleBuilder) Hide(objectIDs ...string) *RuleBuilder {
+	for _, objectID := range objectIDs {
+		if objectID == "" {
+			b.fail(reportError("the objectID of a hidden record is empty"))
+		}
+
+		b.rule.Consequence.Hide = append(b.rule.Consequence.Hide, *NewConsequenceHide(objectID))
+	}
+
+	return b
+}
+
+// UserData returns the data in the `userData` of the responses of the searches triggering the rule, such as a banner to display. It is limited to 1 kB of minified JSON.
+func (b *RuleBuilder) UserData(data map[string]any) *RuleBuilder {
+	raw, err := json.Marshal(data)
+
+	switch {
+	case err != nil:
+		b.fail(reportError("cannot encode the user data of the rule: %w", err))
+	case len(raw) > maxRuleUserDataBytes:
+		b.fail(reportError("the user data of the rule is %d bytes, more than the maximum of %d bytes", len(raw), maxRuleUserDataBytes))
+	}
+
+	b.rule.Consequence.UserData = data
+
+	return b
+}
+
+// ValidBetween only applies the rule from `from` until `until`. Several ranges apply the rule during any of them.
+func (b *RuleBuilder) ValidBetween(from time.Time, until time.Time) *RuleBuilder {
+	timeRange, err := NewTimeRangeBetween(from, until)
+	if err != nil {
+		b.fail(err)
+
+		return b
+	}
+
+	b.rule.Validity = append(b.rule.Validity, *timeRange)
+
+	return b
+}
+
+/*
+Build returns the rule, to give to SaveRule or SaveRules.
+
+	@return *Rule - The rule.
+	@return error - The first invalid part of the rule, or an inconsistency between its consequences.
+*/
+func (b *RuleBuilder) Build() (*Rule, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	consequence := b.rule.Consequence
+	if consequence.Params == nil && len(consequence.Promote) == 0 && len(consequence.Hide) == 0 && consequence.UserData == nil {
+		return nil, reportError("the rule %q has no consequence", b.rule.ObjectID)
+	}
+
+	promoted := map[string]bool{}
+	positions := map[int32]bool{}
+
+	for _, promote := range consequence.Promote {
+		objectIDs, position := promotedRecords(promote)
+
+		if positions[position] {
+			return nil, reportError("several records are promoted at position %d", position)
+		}
+
+		positions[position] = true
+
+		for _, objectID := range objectIDs {
+			if promoted[objectID] {
+				return nil, reportError("the record %q is promoted several times", objectID)
+			}
+
+			promoted[objectID] = true
+		}
+	}
+
+	if len(promoted) > maxPromotedRecords {
+		return nil, reportError("the rule promotes %d records, more than the maximum of %d", len(promoted), maxPromotedRecords)
+	}
+
+	for _, hide := range consequence.Hide {
+		if promoted[hide.ObjectID] {
+			return nil, reportError("the record %q is both promoted and hidden", hide.ObjectID)
+		}
+	}
+
+	rule := b.rule
+	rule.Conditions = b.conditionsWithContext()
+
+	return &rule, nil
+}
+
+// promotedRecords returns the objectIDs promoted by the consequence, and their position.
+func promotedRecords(promote Promote) ([]string, int32) {
+	if promote.PromoteObjectID != nil {
+		return []string{promote.PromoteObjectID.ObjectID}, promote.PromoteObjectID.Position
+	}
+
+	return promote.PromoteObjectIDs.ObjectIDs, promote.PromoteObjectIDs.Position
+}
+
+func (b *RuleBuilder) checkPosition(position int32) {
+	if position < 0 {
+		b.fail(reportError("the position of promoted records cannot be negative, got %d", position))
+	}
+}
+
+// fail records the first error, returned by Build.
+func (b *RuleBuilder) fail(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
@@ -0,0 +1,147 @@
+package search_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/flapjacktest"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+)
+
+func TestRuleBuilder(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2026, time.June, 21, 0, 0, 0, 0, time.UTC)
+
+	rule, err := search.NewRuleBuilder("summer-sale").
+		Description("Summer sale").
+		Conditions(*search.NewCondition(search.WithConditionPattern("sale"), search.WithConditionAnchoring(search.ANCHORING_CONTAINS))).
+		Promote("phone-1", 0).
+		PromoteGroup([]string{"case-1", "case-2"}, 3).
+		FilterPromotes(true).
+		Hide("phone-old", "case-old").
+		UserData(map[string]any{"banner": "summer.png"}).
+		Params(*search.NewConsequenceParams(search.WithConsequenceParamsQuery(*search.StringAsConsequenceQuery("summer")))).
+		ValidBetween(start, start.AddDate(0, 1, 0)).
+		Tags("seasonal").
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	srv := flapjacktest.NewServer()
+	t.Cleanup(srv.Close)
+
+	client, err := srv.NewClient()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = client.SaveRule(client.NewApiSaveRuleRequest("products", rule.ObjectID, rule))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	saved, err := client.GetRule(client.NewApiGetRuleRequest("products", "summer-sale"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sameJSON(t, saved, `{
+		"objectID": "summer-sale",
+		"description": "Summer sale",
+		"conditions": [{"pattern": "sale", "anchoring": "contains"}],
+		"consequence": {
+			"promote": [{"objectID": "phone-1", "position": 0}, {"objectIDs": ["case-1", "case-2"], "position": 3}],
+			"filterPromotes": true,
+			"hide": [{"objectID": "phone-old"}, {"objectID": "case-old"}],
+			"userData": {"banner": "summer.png"},
+			"params": {"query": "summer"}
+		},
+		"validity": [{"from": 1782000000, "until": 1784592000}],
+		"tags": ["seasonal"]
+	}`)
+}
+
+func TestRuleBuilderErrors(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		builder *search.RuleBuilder
+		wantErr string
+	}{
+		{
+			name:    "no objectID",
+			builder: search.NewRuleBuilder("").Hide("1"),
+			wantErr: "objectID of a rule is required",
+		},
+		{
+			name:    "no consequence",
+			builder: search.NewRuleBuilder("rule").Description("Nothing"),
+			wantErr: "has no consequence",
+		},
+		{
+			name:    "negative position",
+			builder: search.NewRuleBuilder("rule").Promote("1", -1),
+			wantErr: "cannot be negative",
+		},
+		{
+			name:    "large group",
+			builder: search.NewRuleBuilder("rule").PromoteGroup(make([]string, 101), 0),
+			wantErr: "has 101 records, more than the maximum of 100",
+		},
+		{
+			name:    "same position",
+			builder: search.NewRuleBuilder("rule").Promote("1", 0).PromoteGroup([]string{"2", "3"}, 0),
+			wantErr: "several records are promoted at position 0",
+		},
+		{
+			name:    "promoted twice",
+			builder: search.NewRuleBuilder("rule").Promote("1", 0).PromoteGroup([]string{"2", "1"}, 1),
+			wantErr: `the record "1" is promoted several times`,
+		},
+		{
+			name:    "promoted and hidden",
+			builder: search.NewRuleBuilder("rule").Promote("1", 0).Hide("1"),
+			wantErr: `the record "1" is both promoted and hidden`,
+		},
+		{
+			name:    "large user data",
+			builder: search.NewRuleBuilder("rule").UserData(map[string]any{"banner": strings.Repeat("b", 1024)}),
+			wantErr: "more than the maximum of 1024 bytes",
+		},
+		{
+			name:    "params filters",
+			builder: search.NewRuleBuilder("rule").Params(*search.NewConsequenceParams(search.WithConsequenceParamsFilters("brand:Acme"))),
+			wantErr: `the engine only applies the query of the params of a rule, not "filters"`,
+		},
+		{
+			name: "params query edits",
+			builder: search.NewRuleBuilder("rule").Params(*search.NewConsequenceParams(search.WithConsequenceParamsQuery(
+				*search.ConsequenceQueryObjectAsConsequenceQuery(search.NewConsequenceQueryObject(search.WithConsequenceQueryObjectRemove([]string{"cheap"})))))),
+			wantErr: "not edits of the query",
+		},
+		{
+			name:    "empty validity",
+			builder: search.NewRuleBuilder("rule").Hide("1").ValidBetween(now, now.Add(-time.Hour)),
+			wantErr: "is empty",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := tt.builder.Build()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
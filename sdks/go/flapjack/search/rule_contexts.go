@@ -0,0 +1,121 @@
+package search
+
+import (
+	"regexp"
+)
+
+var ruleContextRegexp = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// RuleContext is a context of the searches, such as `mobile` or `logged_in`, activating the rules whose conditions have it.
+type RuleContext string
+
+// Validate checks the context only has letters, digits, `-` and `_`, like the contexts accepted by the engine.
+func (c RuleContext) Validate() error {
+	if !ruleContextRegexp.MatchString(string(c)) {
+		return reportError("the rule context %q must only have letters, digits, `-` and `_`", string(c))
+	}
+
+	return nil
+}
+
+/*
+RuleContexts is the set of contexts of a search, combined without duplicates. The engine only matches the rules against the first context of the set, so add the contexts by decreasing priority:
+
+	contexts := search.NewRuleContexts("mobile")
+	if user != nil {
+		contexts = contexts.With("logged_in")
+	}
+
+	opt, err := contexts.SearchForHitsOption()
+*/
+type RuleContexts []RuleContext
+
+// NewRuleContexts returns the set of the contexts.
+func NewRuleContexts(contexts ...RuleContext) RuleContexts {
+	return RuleContexts(nil).With(contexts...)
+}
+
+// With returns the set combined with the contexts, in order and without duplicates. The set is not modified.
+func (r RuleContexts) With(contexts ...RuleContext) RuleContexts {
+	combined := make(RuleContexts, 0, len(r)+len(contexts))
+	seen := make(map[RuleContext]bool, len(r)+len(contexts))
+
+	for _, set := range []RuleContexts{r, contexts} {
+		for _, context := range set {
+			if !seen[context] {
+				seen[context] = true
+				combined = append(combined, context)
+			}
+		}
+	}
+
+	return combined
+}
+
+// Strings validates the contexts and returns them as the `ruleContexts` parameter.
+func (r RuleContexts) Strings() ([]string, error) {
+	contexts := make([]string, 0, len(r))
+
+	for _, context := range r {
+		err := context.Validate()
+		if err != nil {
+			return nil, err
+		}
+
+		contexts = append(contexts, string(context))
+	}
+
+	return contexts, nil
+}
+
+// SearchForHitsOption validates the contexts and returns them as an option of NewSearchForHits.
+func (r RuleContexts) SearchForHitsOption() (SearchForHitsOption, error) {
+	contexts, err := r.Strings()
+	if err != nil {
+		return nil, err
+	}
+
+	return WithSearchForHitsRuleContexts(contexts), nil
+}
+
+// SearchParamsObjectOption validates the contexts and returns them as an option of NewSearchParamsObject.
+func (r RuleContexts) SearchParamsObjectOption() (SearchParamsObjectOption, error) {
+	contexts, err := r.Strings()
+	if err != nil {
+		return nil, err
+	}
+
+	return WithSearchParamsObjectRuleContexts(contexts), nil
+}
+
+// Context only triggers the rule in the searches with the context: the conditions without a context get it, and a rule without conditions gets a condition matching every query with the context.
+func (b *RuleBuilder) Context(context RuleContext) *RuleBuilder {
+	b.fail(context.Validate())
+	b.context = context
+
+	return b
+}
+
+// conditionsWithContext returns the conditions of the rule with the context given to Context, if any.
+func (b *RuleBuilder) conditionsWithContext() []Condition {
+	if b.context == "" {
+		return b.rule.Conditions
+	}
+
+	if len(b.rule.Conditions) == 0 {
+		// The engine requires a pattern and an anchoring, and an empty pattern contained in every query matches them all.
+		return []Condition{*NewCondition(WithConditionPattern(""), WithConditionAnchoring(ANCHORING_CONTAINS), WithConditionContext(string(b.context)))}
+	}
+
+	conditions := make([]Condition, 0, len(b.rule.Conditions))
+
+	for _, condition := range b.rule.Conditions {
+		if condition.Context == nil {
+			condition.SetContext(string(b.context))
+		}
+
+		conditions = append(conditions, condition)
+	}
+
+	return conditions
+}
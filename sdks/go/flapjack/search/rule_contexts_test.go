@@ -0,0 +1,124 @@
+package search_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+)
+
+func TestRuleContexts(t *testing.T) {
+	t.Parallel()
+
+	base := search.NewRuleContexts("mobile", "mobile")
+	contexts := base.With("logged_in", "mobile", "fr")
+
+	if want := (search.RuleContexts{"mobile"}); !reflect.DeepEqual(base, want) {
+		t.Errorf("expected the set not to be modified, got %v", base)
+	}
+
+	opt, err := contexts.SearchForHitsOption()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := search.NewSearchForHits("products", opt).RuleContexts; !reflect.DeepEqual(got, []string{"mobile", "logged_in", "fr"}) {
+		t.Errorf("unexpected ruleContexts %v", got)
+	}
+
+	paramsOpt, err := contexts.SearchParamsObjectOption()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := search.NewSearchParamsObject(paramsOpt).RuleContexts; len(got) != 3 {
+		t.Errorf("unexpected ruleContexts %v", got)
+	}
+
+	_, err = contexts.With("logged in").SearchForHitsOption()
+	if err == nil || !strings.Contains(err.Error(), `the rule context "logged in" must only have letters`) {
+		t.Errorf("expected an error for the invalid context, got %v", err)
+	}
+}
+
+func TestRuleBuilderContext(t *testing.T) {
+	t.Parallel()
+
+	pattern := *search.NewCondition(search.WithConditionPattern("sale"), search.WithConditionAnchoring(search.ANCHORING_CONTAINS))
+	desktop := *search.NewCondition(search.WithConditionPattern("deal"), search.WithConditionAnchoring(search.ANCHORING_IS), search.WithConditionContext("desktop"))
+
+	tests := []struct {
+		name       string
+		conditions []search.Condition
+		want       string
+	}{
+		{
+			name: "no conditions",
+			want: `[{"pattern": "", "anchoring": "contains", "context": "mobile"}]`,
+		},
+		{
+			name:       "conditions",
+			conditions: []search.Condition{pattern, desktop},
+			want:       `[{"pattern": "sale", "anchoring": "contains", "context": "mobile"}, {"pattern": "deal", "anchoring": "is", "context": "desktop"}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rule, err := search.NewRuleBuilder("mobile-sale").Context("mobile").Conditions(tt.conditions...).Hide("1").Build()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			sameJSON(t, rule.Conditions, tt.want)
+			engineConditions(t, rule.Conditions)
+
+			if pattern.Context != nil {
+				t.Errorf("expected the given conditions not to be modified, got %v", pattern)
+			}
+		})
+	}
+
+	_, err := search.NewRuleBuilder("rule").Context("").Hide("1").Build()
+	if err == nil || !strings.Contains(err.Error(), "must only have letters") {
+		t.Errorf("expected an error for the empty context, got %v", err)
+	}
+}
+
+// engineConditions decodes the conditions like the engine, which requires a pattern and one of its anchorings.
+func engineConditions(t *testing.T, conditions []search.Condition) {
+	t.Helper()
+
+	raw, err := json.Marshal(conditions)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var decoded []struct {
+		Pattern   *string `json:"pattern"`
+		Anchoring *string `json:"anchoring"`
+		Context   *string `json:"context"`
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+
+	if err = decoder.Decode(&decoded); err != nil {
+		t.Fatalf("unexpected conditions %s: %v", raw, err)
+	}
+
+	anchorings := map[string]bool{"is": true, "startsWith": true, "endsWith": true, "contains": true}
+
+	for _, condition := range decoded {
+		if condition.Pattern == nil || condition.Anchoring == nil || !anchorings[*condition.Anchoring] {
+			t.Errorf("expected a pattern and an anchoring in the conditions %s", raw)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package search
+
+/*
+ReplaceAllRules replaces the rules of an index by the given ones, the other rules being deleted, for rule deployments from CI pipelines.
+The rules are saved with `clearExistingRules`, and an empty set clears the rules of the index. Waits for the task to be processed when `WithWaitForTasks(true)` is given.
+
+	@param indexName string - the index whose rules are replaced.
+	@param rules []Rule - The complete set of rules of the index.
+	@param opts ...ChunkedBatchOption - Optional parameters for the request.
+	@return *UpdatedAtResponse - The response of the saveRules or clearRules call.
+	@return error - Error if a rule has no objectID or the same objectID as another one, or if the request fails.
+*/
+func (c *APIClient) ReplaceAllRules(indexName string, rules []Rule, opts ...ChunkedBatchOption) (*UpdatedAtResponse, error) {
+	conf := config{}
+
+	for _, opt := range opts {
+		opt.apply(&conf)
+	}
+
+	err := requireUniqueObjectIDs("rule", rules, func(rule Rule) string { return rule.ObjectID })
+	if err != nil {
+		return nil, err
+	}
+
+	var res *UpdatedAtResponse
+
+	if len(rules) == 0 {
+		res, err = c.ClearRules(c.NewApiClearRulesRequest(indexName), toRequestOptions(opts)...)
+	} else {
+		res, err = c.SaveRules(c.NewApiSaveRulesRequest(indexName, rules).WithClearExistingRules(true), toRequestOptions(opts)...)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if conf.waitForTasks {
+		_, err = c.WaitForTask(indexName, res.TaskID, toIterableOptions(opts)...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,91 @@
+package search_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/flapjacktest"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+)
+
+func TestReplaceAllRules(t *testing.T) {
+	t.Parallel()
+
+	srv := flapjacktest.NewServer()
+	t.Cleanup(srv.Close)
+
+	client, err := srv.NewClient()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = client.SaveRules(client.NewApiSaveRulesRequest("products", []search.Rule{
+		*search.NewRule("stale", *search.NewEmptyConsequence(), search.WithRuleDescription("Winter sale")),
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	promote, err := search.NewRuleBuilder("promote-waffles").Promote("waffle-1", 0).Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	hide, err := search.NewRuleBuilder("hide-crepes").Hide("crepe-old").Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = client.ReplaceAllRules("products", []search.Rule{*promote, *hide}, search.WithWaitForTasks(true))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := ruleIDs(t, client); strings.Join(got, ",") != "promote-waffles,hide-crepes" {
+		t.Errorf("expected the stale rule to be replaced, got %v", got)
+	}
+
+	_, err = client.ReplaceAllRules("products", []search.Rule{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := ruleIDs(t, client); len(got) != 0 {
+		t.Errorf("expected an empty set to clear the rules, got %v", got)
+	}
+}
+
+func TestReplaceAllRulesRequiresUniqueObjectIDs(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	})
+
+	_, err := client.ReplaceAllRules("products", []search.Rule{
+		*search.NewRule("promo", *search.NewEmptyConsequence()),
+		*search.NewRule("promo", *search.NewEmptyConsequence()),
+	})
+
+	want := `the objectID "promo" of the rule at position 1 is already used by another rule`
+	if err == nil || err.Error() != want {
+		t.Errorf("expected error %q, got %v", want, err)
+	}
+}
+
+func ruleIDs(t *testing.T, client *search.APIClient) []string {
+	t.Helper()
+
+	res, err := client.SearchRules(client.NewApiSearchRulesRequest("products"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ids := make([]string, 0, len(res.Hits))
+	for _, hit := range res.Hits {
+		ids = append(ids, hit.ObjectID)
+	}
+
+	return ids
+}
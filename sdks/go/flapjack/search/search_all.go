@@ -0,0 +1,155 @@
+package search
+
+// --------- SearchAll options ---------
+
+type SearchAllOption interface {
+	RequestOption
+	searchAll()
+}
+
+type searchAllOption func(*config)
+
+var (
+	_ SearchAllOption = (*searchAllOption)(nil)
+	_ SearchAllOption = (*requestOption)(nil)
+)
+
+func (s searchAllOption) apply(c *config) {
+	s(c)
+}
+
+func (s searchAllOption) searchAll() {}
+
+func (r requestOption) searchAll() {}
+
+// WithMaxHits the maximum number of hits returned by the iterator of SearchAll, which stops fetching pages once reached. Defaults to 0 (all the hits).
+func WithMaxHits(maxHits int) searchAllOption {
+	return searchAllOption(func(c *config) {
+		c.maxHits = maxHits
+	})
+}
+
+/*
+SearchIterator walks the hits of a search page after page, fetching each page when the hits of the previous one are consumed:
+
+	it := client.SearchAll("products", *search.NewSearchParamsObject(search.WithSearchParamsObjectQuery("phone")), search.WithMaxHits(100))
+	for it.Next() {
+		fmt.Println(it.Hit().ObjectID)
+	}
+
+	if err := it.Err(); err != nil {
+		return err
+	}
+
+It is not safe for concurrent use.
+*/
+type SearchIterator struct {
+	client    *APIClient
+	indexName string
+	params    SearchParamsObject
+	opts      []RequestOption
+	maxHits   int
+
+	res      *SearchResponse
+	pending  []Hit
+	hit      Hit
+	returned int
+	done     bool
+	err      error
+}
+
+/*
+SearchAll returns an iterator over all the hits of the search, from the page of `params` on, requesting `hitsPerPage` hits per page until the last page or the WithMaxHits limit.
+The hits beyond the `paginationLimitedTo` setting of the index are not reachable by searches, use BrowseObjects to go through every record.
+
+	@param indexName string - Index name.
+	@param params SearchParamsObject - Search parameters.
+	@param opts ...SearchAllOption - Optional parameters for the requests, such as WithMaxHits.
+	@return *SearchIterator - The iterator, which performs no request until Next is called.
+*/
+func (c *APIClient) SearchAll(indexName string, params SearchParamsObject, opts ...SearchAllOption) *SearchIterator {
+	conf := config{}
+
+	for _, opt := range opts {
+		opt.apply(&conf)
+	}
+
+	if params.Page == nil {
+		params.SetPage(0)
+	}
+
+	return &SearchIterator{
+		client:    c,
+		indexName: indexName,
+		params:    params,
+		opts:      toRequestOptions(opts),
+		maxHits:   conf.maxHits,
+	}
+}
+
+// Next advances to the next hit, fetching the next page if needed, and returns false at the end of the hits or on error.
+func (it *SearchIterator) Next() bool {
+	for {
+		if it.err != nil || (it.maxHits > 0 && it.returned >= it.maxHits) {
+			return false
+		}
+
+		if len(it.pending) > 0 {
+			it.hit, it.pending = it.pending[0], it.pending[1:]
+			it.returned++
+
+			return true
+		}
+
+		if it.done {
+			return false
+		}
+
+		it.fetch()
+	}
+}
+
+// Hit returns the current hit.
+func (it *SearchIterator) Hit() Hit {
+	return it.hit
+}
+
+// Response returns the response of the last fetched page, for its number of hits or facets, or nil before the first page.
+func (it *SearchIterator) Response() *SearchResponse {
+	return it.res
+}
+
+// Err returns the error which stopped the iteration, if any.
+func (it *SearchIterator) Err() error {
+	return it.err
+}
+
+// fetch fetches the next page, and marks the iteration as done after the last one.
+func (it *SearchIterator) fetch() {
+	params := it.params
+
+	res, err := it.client.SearchSingleIndex(
+		it.client.NewApiSearchSingleIndexRequest(it.indexName).WithSearchParams(SearchParamsObjectAsSearchParams(&params)),
+		it.opts...,
+	)
+	if err != nil {
+		it.err = err
+
+		return
+	}
+
+	it.res = res
+	it.pending = res.Hits
+
+	page := it.params.GetPage()
+	it.params.SetPage(page + 1)
+
+	switch {
+	case len(res.Hits) == 0:
+		it.done = true
+	case res.NbPages != nil:
+		it.done = page+1 >= res.GetNbPages()
+	default:
+		it.done = res.HitsPerPage != nil && int32(len(res.Hits)) < res.GetHitsPerPage()
+	}
+}
@@ -0,0 +1,127 @@
+package search_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+)
+
+// newPagedTestClient returns a client searching an index of nbHits hits, failing on the page failPage if not negative.
+func newPagedTestClient(t *testing.T, nbHits int, failPage int, requests *atomic.Int32) *search.APIClient {
+	t.Helper()
+
+	return newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		var params struct {
+			Page        int `json:"page"`
+			HitsPerPage int `json:"hitsPerPage"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&params)
+
+		if params.Page == failPage {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"invalid page","status":400}`))
+
+			return
+		}
+
+		hits := []string{}
+		for i := params.Page * params.HitsPerPage; i < min((params.Page+1)*params.HitsPerPage, nbHits); i++ {
+			hits = append(hits, fmt.Sprintf(`{"objectID":"%d"}`, i))
+		}
+
+		nbPages := (nbHits + params.HitsPerPage - 1) / params.HitsPerPage
+
+		_, _ = fmt.Fprintf(w, `{"hits":[%s],"nbHits":%d,"nbPages":%d,"page":%d,"hitsPerPage":%d,"processingTimeMS":1,"query":"","params":""}`,
+			strings.Join(hits, ","), nbHits, nbPages, params.Page, params.HitsPerPage)
+	})
+}
+
+func TestSearchAll(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		nbHits       int
+		page         int32
+		opts         []search.SearchAllOption
+		wantHits     int
+		wantFirst    string
+		wantRequests int32
+	}{
+		{name: "all pages", nbHits: 25, wantHits: 25, wantFirst: "0", wantRequests: 3},
+		{name: "exact pages", nbHits: 20, wantHits: 20, wantFirst: "0", wantRequests: 2},
+		{name: "max hits", nbHits: 25, opts: []search.SearchAllOption{search.WithMaxHits(12)}, wantHits: 12, wantFirst: "0", wantRequests: 2},
+		{name: "from a page", nbHits: 25, page: 1, wantHits: 15, wantFirst: "10", wantRequests: 2},
+		{name: "no hits", nbHits: 0, wantHits: 0, wantRequests: 1},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var requests atomic.Int32
+
+			client := newPagedTestClient(t, tt.nbHits, -1, &requests)
+
+			params := search.NewSearchParamsObject(search.WithSearchParamsObjectHitsPerPage(10))
+			if tt.page > 0 {
+				params.SetPage(tt.page)
+			}
+
+			it := client.SearchAll("products", *params, tt.opts...)
+
+			var objectIDs []string
+			for it.Next() {
+				objectIDs = append(objectIDs, it.Hit().ObjectID)
+			}
+
+			if err := it.Err(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(objectIDs) != tt.wantHits || (tt.wantHits > 0 && objectIDs[0] != tt.wantFirst) {
+				t.Errorf("expected %d hits from %q, got %v", tt.wantHits, tt.wantFirst, objectIDs)
+			}
+
+			if got := requests.Load(); got != tt.wantRequests {
+				t.Errorf("expected %d requests, got %d", tt.wantRequests, got)
+			}
+
+			if it.Response().GetNbHits() != int32(tt.nbHits) {
+				t.Errorf("expected the response of the last page, got %v", it.Response())
+			}
+		})
+	}
+}
+
+func TestSearchAllError(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+
+	client := newPagedTestClient(t, 25, 1, &requests)
+
+	it := client.SearchAll("products", *search.NewSearchParamsObject(search.WithSearchParamsObjectHitsPerPage(10)))
+
+	hits := 0
+	for it.Next() {
+		hits++
+	}
+
+	if hits != 10 || it.Err() == nil || !strings.Contains(it.Err().Error(), "invalid page") {
+		t.Errorf("expected the first page then the error, got %d hits and %v", hits, it.Err())
+	}
+
+	if it.Next() {
+		t.Error("expected the iteration to stay stopped after an error")
+	}
+}
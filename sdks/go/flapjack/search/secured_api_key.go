@@ -0,0 +1,175 @@
+package search
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/utils"
+)
+
+/*
+SecuredApiKeyRestrictionsBuilder builds the restrictions of a secured API key, validating them before the key is generated instead of producing a key the engine rejects or applies differently:
+
+	restrictions, err := search.NewSecuredApiKeyRestrictionsBuilder().
+		Filters("tenant:acme").
+		ValidFor(time.Hour).
+		RestrictIndices("products", "dev_*").
+		Build()
+	if err != nil {
+		return err
+	}
+
+	key, err := client.GenerateSecuredApiKey(searchApiKey, restrictions)
+
+It only builds the restrictions the engine enforces: the filters, the expiry, the indices and the hitsPerPage search parameter. The engine ignores `restrictSources`, `userToken` and the other search parameters, so a key relying on them would not be restricted.
+*/
+type SecuredApiKeyRestrictionsBuilder struct {
+	restrictions SecuredApiKeyRestrictions
+	params       []SearchParamsObjectOption
+	expiresAt    *time.Time
+	validFor     time.Duration
+	clock        transport.Clock
+	err          error
+}
+
+// NewSecuredApiKeyRestrictionsBuilder returns a builder without restrictions.
+func NewSecuredApiKeyRestrictionsBuilder() *SecuredApiKeyRestrictionsBuilder {
+	return &SecuredApiKeyRestrictionsBuilder{clock: transport.SystemClock{}}
+}
+
+// Filters applies the filters, such as the expression of a Filters builder, to every search made with the key. The filters given at search time are combined with them with `AND`.
+func (b *SecuredApiKeyRestrictionsBuilder) Filters(filters string) *SecuredApiKeyRestrictionsBuilder {
+	if strings.TrimSpace(filters) == "" {
+		b.fail(reportError("the filters of a secured API key cannot be empty"))
+	}
+
+	b.restrictions.Filters = &filters
+
+	return b
+}
+
+// ValidUntil makes the key expire at the given time, which must be in the future when the restrictions are built.
+func (b *SecuredApiKeyRestrictionsBuilder) ValidUntil(expiresAt time.Time) *SecuredApiKeyRestrictionsBuilder {
+	b.expiresAt = &expiresAt
+	b.validFor = 0
+
+	return b
+}
+
+// ValidFor makes the key expire once the duration has elapsed from the time the restrictions are built.
+func (b *SecuredApiKeyRestrictionsBuilder) ValidFor(d time.Duration) *SecuredApiKeyRestrictionsBuilder {
+	if d <= 0 {
+		b.fail(reportError("the validity of a secured API key must be positive, got %s", d))
+	}
+
+	b.expiresAt = nil
+	b.validFor = d
+
+	return b
+}
+
+// Clock sets the clock telling the time of ValidFor and checking the expiry, such as the one of a client's configuration. Defaults to the system clock.
+func (b *SecuredApiKeyRestrictionsBuilder) Clock(clock transport.Clock) *SecuredApiKeyRestrictionsBuilder {
+	b.clock = clock
+
+	return b
+}
+
+// RestrictIndices only allows the key to access the given indices. The names can start or end with a `*` wildcard, such as `dev_*`.
+func (b *SecuredApiKeyRestrictionsBuilder) RestrictIndices(indices ...string) *SecuredApiKeyRestrictionsBuilder {
+	if len(indices) == 0 {
+		b.fail(reportError("the indices of a secured API key cannot be empty"))
+	}
+
+	for i, index := range indices {
+		switch {
+		case strings.Trim(index, "*") == "":
+			b.fail(reportError("index %d of a secured API key is %q, which names no index", i, index))
+		case strings.Contains(strings.Trim(index, "*"), "*"):
+			b.fail(reportError("index %d of a secured API key is %q, wildcards can only be at the start or the end", i, index))
+		}
+	}
+
+	b.restrictions.RestrictIndices = append(b.restrictions.RestrictIndices, indices...)
+
+	return b
+}
+
+// SearchParams forces search parameters on every search made with the key. The engine only enforces hitsPerPage, as the
+// maximum number of hits per page: Build rejects the other parameters. Use Filters for the filters.
+func (b *SecuredApiKeyRestrictionsBuilder) SearchParams(opts ...SearchParamsObjectOption) *SecuredApiKeyRestrictionsBuilder {
+	b.params = append(b.params, opts...)
+
+	return b
+}
+
+/*
+Build returns the restrictions, to give to GenerateSecuredApiKey.
+
+	@return *SecuredApiKeyRestrictions - The restrictions.
+	@return error - The first invalid restriction.
+*/
+func (b *SecuredApiKeyRestrictionsBuilder) Build() (*SecuredApiKeyRestrictions, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	restrictions := b.restrictions
+	restrictions.RestrictIndices = append([]string(nil), b.restrictions.RestrictIndices...)
+
+	now := b.clock.Now()
+
+	expiresAt := b.expiresAt
+	if b.validFor > 0 {
+		expiresAt = utils.ToPtr(now.Add(b.validFor))
+	}
+
+	if expiresAt != nil {
+		if !expiresAt.After(now) {
+			return nil, reportError("the secured API key would already be expired at %s", expiresAt.UTC().Format(time.RFC3339))
+		}
+
+		restrictions.ValidUntil = utils.ToPtr(expiresAt.Unix())
+	}
+
+	if len(b.params) > 0 {
+		params := NewSearchParamsObject(b.params...)
+
+		// The search parameters are merged over the other restrictions when the key is generated.
+		if params.Filters != nil {
+			return nil, reportError("the filters of a secured API key must be set with Filters, not SearchParams")
+		}
+
+		fields, err := json.Marshal(params)
+		if err != nil {
+			return nil, fmt.Errorf("cannot encode the search parameters of a secured API key: %w", err)
+		}
+
+		var names map[string]json.RawMessage
+
+		err = json.Unmarshal(fields, &names)
+		if err != nil {
+			return nil, fmt.Errorf("cannot encode the search parameters of a secured API key: %w", err)
+		}
+
+		for name := range names {
+			if name != "hitsPerPage" {
+				return nil, reportError("the engine doesn't enforce the search parameter %q of a secured API key, only hitsPerPage", name)
+			}
+		}
+
+		restrictions.SearchParams = params
+	}
+
+	return &restrictions, nil
+}
+
+// fail records the first error, returned by Build.
+func (b *SecuredApiKeyRestrictionsBuilder) fail(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
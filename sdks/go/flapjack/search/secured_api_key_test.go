@@ -0,0 +1,147 @@
+package search_test
+
+import (
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/flapjacktest"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+)
+
+func TestSecuredApiKeyRestrictionsBuilder(t *testing.T) {
+	t.Parallel()
+
+	expiresAt := time.Now().Add(time.Hour)
+
+	restrictions, err := search.NewSecuredApiKeyRestrictionsBuilder().
+		Filters(search.NewFilters().Eq("tenant", "acme").String()).
+		ValidUntil(expiresAt).
+		RestrictIndices("products", "dev_*").
+		SearchParams(search.WithSearchParamsObjectHitsPerPage(5)).
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("no request should be sent, got %s %s", r.Method, r.URL.Path)
+	})
+
+	key, err := client.GenerateSecuredApiKey("parent-key", restrictions)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, param := range []string{
+		`filters=tenant%3A%22acme%22`,
+		"hitsPerPage=5",
+		"restrictIndices=products%2Cdev_%2A",
+	} {
+		if !strings.Contains(string(decoded), param) {
+			t.Errorf("expected the key to contain %q, got %s", param, decoded)
+		}
+	}
+
+	validity, err := client.GetSecuredApiKeyRemainingValidity(key)
+	if err != nil || validity <= 59*time.Minute || validity > time.Hour {
+		t.Errorf("expected the key to be valid for an hour, got %v: %v", validity, err)
+	}
+}
+
+func TestSecuredApiKeyRestrictionsBuilderClock(t *testing.T) {
+	t.Parallel()
+
+	clock := flapjacktest.NewClock(time.Time{})
+
+	restrictions, err := search.NewSecuredApiKeyRestrictionsBuilder().ValidFor(time.Hour).Clock(clock).Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := clock.Now().Add(time.Hour).Unix(); restrictions.GetValidUntil() != want {
+		t.Errorf("expected the key to expire at %d, got %d", want, restrictions.GetValidUntil())
+	}
+}
+
+func TestSecuredApiKeyRestrictionsBuilderErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		builder *search.SecuredApiKeyRestrictionsBuilder
+		wantErr string
+	}{
+		{
+			name:    "empty filters",
+			builder: search.NewSecuredApiKeyRestrictionsBuilder().Filters(" "),
+			wantErr: "filters of a secured API key cannot be empty",
+		},
+		{
+			name:    "expired",
+			builder: search.NewSecuredApiKeyRestrictionsBuilder().ValidUntil(time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)).Clock(flapjacktest.NewClock(time.Time{})),
+			wantErr: "would already be expired at 2025-12-31T00:00:00Z",
+		},
+		{
+			name:    "negative validity",
+			builder: search.NewSecuredApiKeyRestrictionsBuilder().ValidFor(-time.Hour),
+			wantErr: "validity of a secured API key must be positive",
+		},
+		{
+			name:    "no indices",
+			builder: search.NewSecuredApiKeyRestrictionsBuilder().RestrictIndices(),
+			wantErr: "indices of a secured API key cannot be empty",
+		},
+		{
+			name:    "wildcard index",
+			builder: search.NewSecuredApiKeyRestrictionsBuilder().RestrictIndices("products", "*"),
+			wantErr: "index 1 of a secured API key is \"*\", which names no index",
+		},
+		{
+			name:    "inner wildcard",
+			builder: search.NewSecuredApiKeyRestrictionsBuilder().RestrictIndices("dev_*_products"),
+			wantErr: "wildcards can only be at the start or the end",
+		},
+		{
+			name:    "filters in search params",
+			builder: search.NewSecuredApiKeyRestrictionsBuilder().SearchParams(search.WithSearchParamsObjectFilters("tenant:acme")),
+			wantErr: "must be set with Filters",
+		},
+		{
+			name:    "user token in search params",
+			builder: search.NewSecuredApiKeyRestrictionsBuilder().SearchParams(search.WithSearchParamsObjectUserToken("user-42")),
+			wantErr: "doesn't enforce the search parameter \"userToken\"",
+		},
+		{
+			name: "geo search params",
+			builder: search.NewSecuredApiKeyRestrictionsBuilder().
+				SearchParams(search.WithSearchParamsObjectHitsPerPage(5), search.WithSearchParamsObjectAroundLatLng("40.71,-74.01")),
+			wantErr: "doesn't enforce the search parameter \"aroundLatLng\"",
+		},
+		{
+			name:    "first error",
+			builder: search.NewSecuredApiKeyRestrictionsBuilder().Filters("").RestrictIndices(),
+			wantErr: "filters of a secured API key cannot be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := tt.builder.Build()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
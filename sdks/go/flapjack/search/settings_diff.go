@@ -0,0 +1,167 @@
+package search
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+// SettingChange is the change of one setting, named after its JSON name.
+type SettingChange struct {
+	Name string
+	// From is the current value, nil when the setting is not set.
+	From any
+	// To is the desired value.
+	To any
+}
+
+// String returns the change as a plan line: `+ name: to` for a setting not set yet, `~ name: from => to` otherwise.
+func (c SettingChange) String() string {
+	if c.From == nil {
+		return fmt.Sprintf("+ %s: %s", c.Name, settingValue(c.To))
+	}
+
+	return fmt.Sprintf("~ %s: %s => %s", c.Name, settingValue(c.From), settingValue(c.To))
+}
+
+// SettingsDiff is the list of the settings to change, sorted by name.
+type SettingsDiff []SettingChange
+
+// String returns the changes as a plan, one per line.
+func (d SettingsDiff) String() string {
+	if len(d) == 0 {
+		return "No changes."
+	}
+
+	lines := make([]string, 0, len(d))
+	for _, change := range d {
+		lines = append(lines, change.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+/*
+DiffSettings compares the current settings of an index to the desired ones, to show the plan of a "settings as code" workflow before applying it.
+Only the settings set in `desired` are compared, the other ones being left as they are by SetSettings.
+
+	@param current *SettingsResponse - The current settings, as returned by GetSettings.
+	@param desired *IndexSettings - The desired settings.
+	@return SettingsDiff - The settings to change.
+	@return error - Error if any.
+*/
+func DiffSettings(current *SettingsResponse, desired *IndexSettings) (SettingsDiff, error) {
+	currentValues, err := jsonValues(current)
+	if err != nil {
+		return nil, err
+	}
+
+	desiredValues, err := jsonValues(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(desiredValues))
+	for name := range desiredValues {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	diff := SettingsDiff{}
+
+	for _, name := range names {
+		if !reflect.DeepEqual(currentValues[name], desiredValues[name]) {
+			diff = append(diff, SettingChange{Name: name, From: currentValues[name], To: desiredValues[name]})
+		}
+	}
+
+	return diff, nil
+}
+
+/*
+ApplySettings sends the settings of `desired` which differ from the current settings of the index, and nothing when they all match.
+An index which doesn't exist yet has no settings, and is created.
+Use WaitForTask with the task ID of the response to wait for the changes to be applied.
+
+	@param indexName string - Index name.
+	@param desired *IndexSettings - The desired settings.
+	@param opts ...RequestOption - Optional parameters for the requests.
+	@return SettingsDiff - The settings changed.
+	@return *UpdatedAtResponse - The response of the `setSettings` request, nil when nothing changed.
+	@return error - Error if any.
+*/
+func (c *APIClient) ApplySettings(indexName string, desired *IndexSettings, opts ...RequestOption) (SettingsDiff, *UpdatedAtResponse, error) {
+	current, err := c.GetSettings(c.NewApiGetSettingsRequest(indexName), opts...)
+	if errors.Is(err, transport.ErrNotFound) {
+		current, err = NewEmptySettingsResponse(), nil
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	diff, err := DiffSettings(current, desired)
+	if err != nil || len(diff) == 0 {
+		return diff, nil, err
+	}
+
+	changed := make(map[string]any, len(diff))
+	for _, change := range diff {
+		changed[change.Name] = change.To
+	}
+
+	raw, err := json.Marshal(changed)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot encode the changed settings: %w", err)
+	}
+
+	var settings IndexSettings
+
+	err = json.Unmarshal(raw, &settings)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot decode the changed settings: %w", err)
+	}
+
+	res, err := c.SetSettings(c.NewApiSetSettingsRequest(indexName, &settings), opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return diff, res, nil
+}
+
+// jsonValues returns the fields set of a model, by JSON name, as generic JSON values so that they can be compared.
+func jsonValues(model any) (map[string]any, error) {
+	values := map[string]any{}
+
+	if model == nil || reflect.ValueOf(model).Kind() == reflect.Pointer && reflect.ValueOf(model).IsNil() {
+		return values, nil
+	}
+
+	raw, err := json.Marshal(model)
+	if err != nil {
+		return nil, fmt.Errorf("cannot encode %T: %w", model, err)
+	}
+
+	err = json.Unmarshal(raw, &values)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode %T: %w", model, err)
+	}
+
+	return values, nil
+}
+
+func settingValue(value any) string {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+
+	return string(raw)
+}
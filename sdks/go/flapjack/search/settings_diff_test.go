@@ -0,0 +1,108 @@
+package search_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/flapjacktest"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+)
+
+func TestDiffSettings(t *testing.T) {
+	t.Parallel()
+
+	current := search.NewEmptySettingsResponse().
+		SetSearchableAttributes([]string{"name"}).
+		SetHitsPerPage(20).
+		SetCustomRanking([]string{"desc(popularity)"})
+
+	tests := []struct {
+		name     string
+		desired  *search.IndexSettings
+		wantPlan string
+	}{
+		{
+			name:     "no changes",
+			desired:  search.NewEmptyIndexSettings().SetSearchableAttributes([]string{"name"}).SetHitsPerPage(20),
+			wantPlan: "No changes.",
+		},
+		{
+			name:     "nil desired",
+			wantPlan: "No changes.",
+		},
+		{
+			name: "changes",
+			desired: search.NewEmptyIndexSettings().
+				SetSearchableAttributes([]string{"name", "brand"}).
+				SetHitsPerPage(20).
+				SetAttributesForFaceting([]string{"brand"}),
+			wantPlan: `+ attributesForFaceting: ["brand"]` + "\n" + `~ searchableAttributes: ["name"] => ["name","brand"]`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			diff, err := search.DiffSettings(current, tt.desired)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := diff.String(); got != tt.wantPlan {
+				t.Errorf("unexpected plan\n got: %s\nwant: %s", got, tt.wantPlan)
+			}
+		})
+	}
+}
+
+func TestApplySettings(t *testing.T) {
+	t.Parallel()
+
+	srv := flapjacktest.NewServer()
+	t.Cleanup(srv.Close)
+
+	client, err := srv.NewClient()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	srv.AddObjects("products", map[string]any{"objectID": "1"})
+
+	_, err = client.SetSettings(client.NewApiSetSettingsRequest("products", search.NewEmptyIndexSettings().
+		SetSearchableAttributes([]string{"name"}).
+		SetCustomRanking([]string{"desc(popularity)"})))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	desired := search.NewEmptyIndexSettings().
+		SetSearchableAttributes([]string{"name"}).
+		SetHitsPerPage(50)
+
+	diff, res, err := client.ApplySettings("products", desired)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(diff) != 1 || diff[0].Name != "hitsPerPage" || res == nil {
+		t.Fatalf("unexpected changes %v, %v", diff, res)
+	}
+
+	want := map[string]any{
+		"searchableAttributes": []any{"name"},
+		"customRanking":        []any{"desc(popularity)"},
+		"hitsPerPage":          float64(50),
+	}
+	if got := srv.Settings("products"); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected settings\n got: %v\nwant: %v", got, want)
+	}
+
+	// Applying the same settings again sends nothing.
+	diff, res, err = client.ApplySettings("products", desired)
+	if err != nil || len(diff) != 0 || res != nil {
+		t.Errorf("expected no changes, got %v, %v, %v", diff, res, err)
+	}
+}
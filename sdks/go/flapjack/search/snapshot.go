@@ -0,0 +1,291 @@
+package search
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// SnapshotVersion is the version of the archives written by Snapshot, a format of this client unrelated to the
+// archives of the engine's `/export` and `/import` endpoints.
+const SnapshotVersion = 1
+
+// snapshotHeader is the first entry of a snapshot archive.
+type snapshotHeader struct {
+	Version   int       `json:"version"`
+	IndexName string    `json:"indexName"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// snapshotEntry is one of the entries following the header of a snapshot archive, which holds one of its fields.
+type snapshotEntry struct {
+	Settings json.RawMessage `json:"settings,omitempty"`
+	Synonym  *SynonymHit     `json:"synonym,omitempty"`
+	Rule     *Rule           `json:"rule,omitempty"`
+	Record   json.RawMessage `json:"record,omitempty"`
+}
+
+/*
+Snapshot writes an archive of an index to `w`: its settings, synonyms, rules and records, which Restore loads back into an index.
+The archive is a gzip-compressed stream of JSON lines, the records being browsed with BrowseObjectsStream so that memory stays bounded.
+
+It is a records-plus-settings dump, not an engine snapshot: it only holds what GetSettings, BrowseSynonyms, BrowseRules and
+browse return, without the index files, API keys or analytics. It is not a point-in-time copy either: the engine
+invalidates the browse cursor when the index changes, so a write during Snapshot makes it fail.
+
+The format belongs to this client: it is built with the public API, so it can be restored into any engine version or application, but it is not the tar.gz of the index files served by the engine's `/1/indexes/{indexName}/export` and `/snapshot` endpoints.
+Archives written by Snapshot can't be sent to the engine's `/import` endpoint, nor can Restore load the archives of `/export`. Use these endpoints for backups of the index files.
+
+	@param indexName string - Index name.
+	@param w io.Writer - Writer receiving the archive.
+	@param opts ...IterableOption - Optional parameters for the requests.
+	@return error - Error if any.
+*/
+func (c *APIClient) Snapshot(indexName string, w io.Writer, opts ...IterableOption) error {
+	zw := gzip.NewWriter(w)
+	encoder := json.NewEncoder(zw)
+
+	err := encoder.Encode(snapshotHeader{Version: SnapshotVersion, IndexName: indexName, CreatedAt: c.clock().Now().UTC()})
+	if err != nil {
+		return fmt.Errorf("cannot write the snapshot: %w", err)
+	}
+
+	settingsResp, err := c.GetSettings(c.NewApiGetSettingsRequest(indexName), toRequestOptions(opts)...)
+	if err != nil {
+		return err
+	}
+
+	settings, err := json.Marshal(settingsResp)
+	if err != nil {
+		return fmt.Errorf("cannot encode the settings: %w", err)
+	}
+
+	entries := []snapshotEntry{{Settings: settings}}
+
+	err = c.BrowseSynonyms(indexName, SearchSynonymsParams{}, append(append([]IterableOption{}, opts...), WithAggregator(func(res any, _ error) {
+		if res, ok := res.(*SearchSynonymsResponse); ok {
+			for i := range res.Hits {
+				entries = append(entries, snapshotEntry{Synonym: &res.Hits[i]})
+			}
+		}
+	}))...)
+	if err != nil {
+		return err
+	}
+
+	err = c.BrowseRules(indexName, SearchRulesParams{}, append(append([]IterableOption{}, opts...), WithAggregator(func(res any, _ error) {
+		if res, ok := res.(*SearchRulesResponse); ok {
+			for i := range res.Hits {
+				entries = append(entries, snapshotEntry{Rule: &res.Hits[i]})
+			}
+		}
+	}))...)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		err = encoder.Encode(entry)
+		if err != nil {
+			return fmt.Errorf("cannot write the snapshot: %w", err)
+		}
+	}
+
+	err = c.BrowseObjectsStream(indexName, BrowseParamsObject{}, func(hit json.RawMessage) error {
+		err := encoder.Encode(snapshotEntry{Record: hit})
+		if err != nil {
+			return fmt.Errorf("cannot write the snapshot: %w", err)
+		}
+
+		return nil
+	}, toRequestOptions(opts)...)
+	if err != nil {
+		return err
+	}
+
+	err = zw.Close()
+	if err != nil {
+		return fmt.Errorf("cannot write the snapshot: %w", err)
+	}
+
+	return nil
+}
+
+/*
+Restore loads an archive written by Snapshot into the target index, which is replaced atomically once the settings, synonyms, rules and records are reindexed.
+The archive is restored into a temporary index, moved to the target index after all its tasks are published. The `replicas` setting is not restored, replicas belonging to the snapshotted index.
+Only the archives of Snapshot are supported, not the ones of the engine's `/export` endpoint.
+
+	@param r io.Reader - The archive.
+	@param targetIndex string - Index to restore the archive into.
+	@param opts ...ChunkedBatchOption - Optional parameters for the requests.
+	@return error - Error if any.
+*/
+func (c *APIClient) Restore(r io.Reader, targetIndex string, opts ...ChunkedBatchOption) error {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("cannot read the snapshot: %w", err)
+	}
+	defer zr.Close()
+
+	decoder := json.NewDecoder(bufio.NewReader(zr))
+
+	var header snapshotHeader
+
+	err = decoder.Decode(&header)
+	if err != nil {
+		return fmt.Errorf("cannot read the snapshot: %w", err)
+	}
+
+	if header.Version != SnapshotVersion {
+		return reportError("unsupported snapshot version %d", header.Version)
+	}
+
+	tmpIndexName := fmt.Sprintf("%s_tmp_%d", targetIndex, c.clock().Now().UnixNano())
+
+	err = c.restoreInto(decoder, tmpIndexName, opts)
+	if err != nil {
+		_, _ = c.DeleteIndex(c.NewApiDeleteIndexRequest(tmpIndexName))
+
+		return err
+	}
+
+	moveResp, err := c.OperationIndex(
+		c.NewApiOperationIndexRequest(tmpIndexName, NewOperationIndexParams(OPERATION_TYPE_MOVE, targetIndex)),
+		toRequestOptions(opts)...)
+	if err != nil {
+		_, _ = c.DeleteIndex(c.NewApiDeleteIndexRequest(tmpIndexName))
+
+		return err
+	}
+
+	_, err = c.WaitForTask(tmpIndexName, moveResp.TaskID, toIterableOptions(opts)...)
+
+	return err
+}
+
+// restoreInto loads the entries of an archive into an index, and waits for all their tasks.
+func (c *APIClient) restoreInto(decoder *json.Decoder, indexName string, opts []ChunkedBatchOption) error {
+	var (
+		taskIDs  []int64
+		synonyms []SynonymHit
+		rules    []Rule
+	)
+
+	conf := config{batchSize: 1000}
+
+	for _, opt := range opts {
+		opt.apply(&conf)
+	}
+
+	flushSynonyms := func() error {
+		if len(synonyms) == 0 {
+			return nil
+		}
+
+		res, err := c.SaveSynonyms(c.NewApiSaveSynonymsRequest(indexName, synonyms), toRequestOptions(opts)...)
+		if err != nil {
+			return err
+		}
+
+		taskIDs = append(taskIDs, res.TaskID)
+		synonyms = nil
+
+		return nil
+	}
+
+	flushRules := func() error {
+		if len(rules) == 0 {
+			return nil
+		}
+
+		res, err := c.SaveRules(c.NewApiSaveRulesRequest(indexName, rules), toRequestOptions(opts)...)
+		if err != nil {
+			return err
+		}
+
+		taskIDs = append(taskIDs, res.TaskID)
+		rules = nil
+
+		return nil
+	}
+
+	next := func() (map[string]any, error) {
+		for {
+			var entry snapshotEntry
+
+			err := decoder.Decode(&entry)
+			if errors.Is(err, io.EOF) {
+				err = flushSynonyms()
+				if err == nil {
+					err = flushRules()
+				}
+
+				if err != nil {
+					return nil, err
+				}
+
+				return nil, io.EOF
+			}
+
+			if err != nil {
+				return nil, fmt.Errorf("cannot read the snapshot: %w", err)
+			}
+
+			switch {
+			case entry.Record != nil:
+				var record map[string]any
+
+				err = json.Unmarshal(entry.Record, &record)
+				if err != nil {
+					return nil, fmt.Errorf("cannot read the snapshot: %w", err)
+				}
+
+				return record, nil
+			case entry.Settings != nil:
+				var settings IndexSettings
+
+				err = json.Unmarshal(entry.Settings, &settings)
+				if err != nil {
+					return nil, fmt.Errorf("cannot read the snapshot settings: %w", err)
+				}
+
+				settings.Replicas = nil
+
+				res, err := c.SetSettings(c.NewApiSetSettingsRequest(indexName, &settings), toRequestOptions(opts)...)
+				if err != nil {
+					return nil, err
+				}
+
+				taskIDs = append(taskIDs, res.TaskID)
+			case entry.Synonym != nil:
+				synonyms = append(synonyms, *entry.Synonym)
+
+				if len(synonyms) == max(conf.batchSize, 1) {
+					err = flushSynonyms()
+				}
+			case entry.Rule != nil:
+				rules = append(rules, *entry.Rule)
+
+				if len(rules) == max(conf.batchSize, 1) {
+					err = flushRules()
+				}
+			}
+
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	_, err := c.importObjects(indexName, next, append(append([]ChunkedBatchOption{}, opts...), WithWaitForTasks(true))...)
+	if err != nil {
+		return err
+	}
+
+	return <-c.WaitForTasks(indexName, taskIDs, toIterableOptions(opts)...)
+}
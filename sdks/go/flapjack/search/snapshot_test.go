@@ -0,0 +1,114 @@
+package search_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/flapjacktest"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+)
+
+func TestSnapshotRestore(t *testing.T) {
+	t.Parallel()
+
+	srv := flapjacktest.NewServer()
+	t.Cleanup(srv.Close)
+
+	client, err := srv.NewClient()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	srv.AddObjects("products",
+		map[string]any{"objectID": "1", "name": "Pancake"},
+		map[string]any{"objectID": "2", "name": "Waffle"},
+		map[string]any{"objectID": "3", "name": "Crepe"},
+	)
+
+	_, err = client.SetSettings(client.NewApiSetSettingsRequest("products", search.NewEmptyIndexSettings().
+		SetSearchableAttributes([]string{"name"}).
+		SetReplicas([]string{"products_by_price"})))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = client.SaveSynonyms(client.NewApiSaveSynonymsRequest("products", []search.SynonymHit{
+		*search.NewSynonymHit("crepe", search.SYNONYM_TYPE_SYNONYM, search.WithSynonymHitSynonyms([]string{"crepe", "galette"})),
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = client.SaveRules(client.NewApiSaveRulesRequest("products", []search.Rule{
+		*search.NewRule("promote-waffles", *search.NewEmptyConsequence(), search.WithRuleDescription("Promote waffles")),
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var archive bytes.Buffer
+
+	err = client.Snapshot("products", &archive)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The target index is replaced.
+	srv.AddObjects("products_restored", map[string]any{"objectID": "stale"})
+
+	err = client.Restore(&archive, "products_restored", search.WithBatchSize(2))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, want := srv.Objects("products_restored"), srv.Objects("products"); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected restored objects\n got: %v\nwant: %v", got, want)
+	}
+
+	settings := srv.Settings("products_restored")
+	if !reflect.DeepEqual(settings["searchableAttributes"], []any{"name"}) || settings["replicas"] != nil {
+		t.Errorf("unexpected restored settings %v", settings)
+	}
+
+	synonyms, err := client.SearchSynonyms(client.NewApiSearchSynonymsRequest("products_restored"))
+	if err != nil || len(synonyms.Hits) != 1 || synonyms.Hits[0].ObjectID != "crepe" {
+		t.Errorf("unexpected restored synonyms %+v: %v", synonyms, err)
+	}
+
+	rules, err := client.SearchRules(client.NewApiSearchRulesRequest("products_restored").
+		WithSearchRulesParams(search.NewEmptySearchRulesParams().SetHitsPerPage(10)))
+	if err != nil || len(rules.Hits) != 1 || rules.Hits[0].GetDescription() != "Promote waffles" {
+		t.Errorf("unexpected restored rules %+v: %v", rules, err)
+	}
+}
+
+func TestRestoreInvalidArchive(t *testing.T) {
+	t.Parallel()
+
+	srv := flapjacktest.NewServer()
+	t.Cleanup(srv.Close)
+
+	client, err := srv.NewClient()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var archive bytes.Buffer
+
+	zw := gzip.NewWriter(&archive)
+	_, _ = zw.Write([]byte(`{"version":99,"indexName":"products"}` + "\n"))
+	_ = zw.Close()
+
+	err = client.Restore(&archive, "products")
+	if err == nil || !strings.Contains(err.Error(), "unsupported snapshot version 99") {
+		t.Errorf("expected a version error, got %v", err)
+	}
+
+	err = client.Restore(strings.NewReader("not an archive"), "products")
+	if err == nil {
+		t.Error("expected an error for an invalid archive")
+	}
+}
@@ -0,0 +1,247 @@
+package search
+
+import (
+	"reflect"
+	"strings"
+)
+
+// The `flapjack` struct tag options read by SettingsFromStruct, besides objectIDTag.
+const (
+	searchableTag = "searchable"
+	facetTag      = "facet"
+	rankingTag    = "ranking"
+)
+
+// tagOption is an option of a `flapjack` struct tag, such as `facet=searchable`.
+type tagOption struct {
+	name  string
+	value string
+}
+
+// parseFlapjackTag returns the comma-separated options of a `flapjack` struct tag, in order.
+func parseFlapjackTag(tag string) []tagOption {
+	var options []tagOption
+
+	for _, option := range strings.Split(tag, ",") {
+		option = strings.TrimSpace(option)
+		if option == "" {
+			continue
+		}
+
+		name, value, _ := strings.Cut(option, "=")
+		options = append(options, tagOption{name: name, value: value})
+	}
+
+	return options
+}
+
+// hasTagOption returns whether the `flapjack` struct tag of the field has the given option.
+func hasTagOption(field reflect.StructField, name string) bool {
+	for _, option := range parseFlapjackTag(field.Tag.Get("flapjack")) {
+		if option.name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+/*
+SettingsFromStruct derives the searchableAttributes, attributesForFaceting and customRanking settings from the `flapjack` tags of a struct, keeping the configuration of an index next to the type of its records.
+The attributes are named by their `json` tags, nested structs giving paths such as `brand.name`, and are listed in the order of the fields. The options of a tag are separated by commas:
+
+  - `searchable` adds the attribute to searchableAttributes, `searchable=unordered` as `unordered(attribute)`.
+  - `facet` adds the attribute to attributesForFaceting, `facet=searchable`, `facet=filterOnly` and `facet=afterDistinct` with the matching modifier.
+  - `ranking=asc` and `ranking=desc` add `asc(attribute)` or `desc(attribute)` to customRanking.
+  - `objectID` marks the objectID of the record, as for SaveStruct.
+
+For example:
+
+	type Product struct {
+		SKU        string `json:"-" flapjack:"objectID"`
+		Name       string `json:"name" flapjack:"searchable"`
+		Brand      string `json:"brand" flapjack:"searchable,facet=searchable"`
+		Popularity int    `json:"popularity" flapjack:"ranking=desc"`
+	}
+
+	settings, err := search.SettingsFromStruct(Product{})
+
+Only the settings with at least one attribute are set, so the result can be given to ApplySettings without changing the others.
+
+	@param record any - Struct, or pointer to a struct, of the records of the index.
+	@return *IndexSettings - The derived settings.
+	@return error - Error if a tag is invalid.
+*/
+func SettingsFromStruct(record any) (*IndexSettings, error) {
+	typ := reflect.TypeOf(record)
+	for typ != nil && typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return nil, reportError("settings can only be derived from a struct, not %T", record)
+	}
+
+	var attributes structAttributes
+
+	err := attributes.collect(typ, "", map[reflect.Type]bool{})
+	if err != nil {
+		return nil, err
+	}
+
+	settings := NewEmptyIndexSettings()
+
+	if len(attributes.searchable) > 0 {
+		settings.SetSearchableAttributes(attributes.searchable)
+	}
+
+	if len(attributes.faceting) > 0 {
+		settings.SetAttributesForFaceting(attributes.faceting)
+	}
+
+	if len(attributes.customRanking) > 0 {
+		settings.SetCustomRanking(attributes.customRanking)
+	}
+
+	return settings, nil
+}
+
+// structAttributes are the attributes of the settings derived by SettingsFromStruct.
+type structAttributes struct {
+	searchable    []string
+	faceting      []string
+	customRanking []string
+}
+
+// collect adds the attributes of the tagged fields of the struct, whose attributes are prefixed with prefix.
+func (a *structAttributes) collect(typ reflect.Type, prefix string, visiting map[reflect.Type]bool) error {
+	// Recursive types have no settings at an unbounded depth.
+	if visiting[typ] {
+		return nil
+	}
+
+	visiting[typ] = true
+	defer delete(visiting, typ)
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		nested := nestedStruct(field.Type)
+
+		// Embedded structs without a JSON name have their fields promoted, as with encoding/json.
+		if field.Anonymous && nested != nil && field.Tag.Get("json") == "" {
+			err := a.collect(nested, prefix, visiting)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		name, ok := jsonFieldName(field)
+		if prefix == "" && hasTagOption(field, objectIDTag) {
+			name, ok = "objectID", true
+		}
+
+		options := parseFlapjackTag(field.Tag.Get("flapjack"))
+
+		if !ok {
+			if len(options) > 0 {
+				return reportError("field %s has `flapjack` tag options but is not encoded in JSON", field.Name)
+			}
+
+			continue
+		}
+
+		attribute := prefix + name
+
+		for _, option := range options {
+			err := a.add(attribute, option)
+			if err != nil {
+				return reportError("attribute `%s`: %v", attribute, err)
+			}
+		}
+
+		if nested != nil {
+			err := a.collect(nested, attribute+".", visiting)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// add adds the attribute to the settings of the tag option.
+func (a *structAttributes) add(attribute string, option tagOption) error {
+	switch option.name {
+	case objectIDTag:
+		if option.value == "" {
+			return nil
+		}
+	case searchableTag:
+		switch option.value {
+		case "":
+			a.searchable = append(a.searchable, attribute)
+
+			return nil
+		case "unordered":
+			a.searchable = append(a.searchable, "unordered("+attribute+")")
+
+			return nil
+		}
+	case facetTag:
+		switch option.value {
+		case "":
+			a.faceting = append(a.faceting, attribute)
+
+			return nil
+		case "searchable", "filterOnly", "afterDistinct":
+			a.faceting = append(a.faceting, option.value+"("+attribute+")")
+
+			return nil
+		}
+	case rankingTag:
+		if option.value == "asc" || option.value == "desc" {
+			a.customRanking = append(a.customRanking, option.value+"("+attribute+")")
+
+			return nil
+		}
+	default:
+		return reportError("unknown `flapjack` tag option %q", option.name)
+	}
+
+	return reportError("invalid value %q for the `flapjack` tag option %q", option.value, option.name)
+}
+
+// jsonFieldName returns the name of the field in JSON, and false if it is not encoded.
+func jsonFieldName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = field.Name
+	}
+
+	return name, true
+}
+
+// nestedStruct returns the struct type held by a field, through pointers, slices and arrays, or nil.
+func nestedStruct(typ reflect.Type) reflect.Type {
+	for typ.Kind() == reflect.Pointer || typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array {
+		typ = typ.Elem()
+	}
+
+	if typ.Kind() != reflect.Struct {
+		return nil
+	}
+
+	return typ
+}
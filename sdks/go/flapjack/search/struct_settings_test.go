@@ -0,0 +1,126 @@
+package search_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+)
+
+type settingsBrand struct {
+	Name    string `json:"name" flapjack:"searchable,facet=searchable"`
+	Country string `json:"country,omitempty" flapjack:"facet=filterOnly"`
+}
+
+type settingsRatings struct {
+	Score float64 `json:"score" flapjack:"ranking=desc"`
+}
+
+type settingsProduct struct {
+	settingsRatings
+	SKU         string           `json:"-" flapjack:"objectID,facet"`
+	Name        string           `json:"name" flapjack:"searchable"`
+	Description string           `json:"description" flapjack:"searchable=unordered"`
+	Brand       *settingsBrand   `json:"brand"`
+	Variants    []settingsBrand  `json:"variants"`
+	Price       float64          `flapjack:"facet,ranking=asc"`
+	Related     *settingsProduct `json:"related"`
+	Internal    string           `json:"-"`
+}
+
+func TestSettingsFromStruct(t *testing.T) {
+	t.Parallel()
+
+	settings, err := search.SettingsFromStruct(&settingsProduct{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := []string{"name", "unordered(description)", "brand.name", "variants.name"}; !reflect.DeepEqual(settings.SearchableAttributes, want) {
+		t.Errorf("unexpected searchableAttributes\n got: %v\nwant: %v", settings.SearchableAttributes, want)
+	}
+
+	if want := []string{"objectID", "searchable(brand.name)", "filterOnly(brand.country)", "searchable(variants.name)", "filterOnly(variants.country)", "Price"}; !reflect.DeepEqual(settings.AttributesForFaceting, want) {
+		t.Errorf("unexpected attributesForFaceting\n got: %v\nwant: %v", settings.AttributesForFaceting, want)
+	}
+
+	if want := []string{"desc(score)", "asc(Price)"}; !reflect.DeepEqual(settings.CustomRanking, want) {
+		t.Errorf("unexpected customRanking\n got: %v\nwant: %v", settings.CustomRanking, want)
+	}
+
+	objects, err := search.ToObjects([]settingsProduct{{SKU: "P-1"}})
+	if err != nil || objects[0]["objectID"] != "P-1" {
+		t.Errorf("expected the objectID to be read from a tag with several options, got %v: %v", objects, err)
+	}
+}
+
+func TestSettingsFromStructOnlySetsTaggedSettings(t *testing.T) {
+	t.Parallel()
+
+	settings, err := search.SettingsFromStruct(settingsRatings{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if settings.SearchableAttributes != nil || settings.AttributesForFaceting != nil || len(settings.CustomRanking) != 1 {
+		t.Errorf("expected only customRanking to be set, got %+v", settings)
+	}
+}
+
+func TestSettingsFromStructErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		record  any
+		wantErr string
+	}{
+		{
+			name:    "not a struct",
+			record:  []settingsProduct{},
+			wantErr: "only be derived from a struct",
+		},
+		{
+			name: "unknown option",
+			record: struct {
+				Name string `json:"name" flapjack:"sortable"`
+			}{},
+			wantErr: "unknown `flapjack` tag option \"sortable\"",
+		},
+		{
+			name: "invalid ranking",
+			record: struct {
+				Price int `json:"price" flapjack:"ranking=up"`
+			}{},
+			wantErr: "invalid value \"up\" for the `flapjack` tag option \"ranking\"",
+		},
+		{
+			name: "invalid facet",
+			record: struct {
+				Brand string `json:"brand" flapjack:"facet=hidden"`
+			}{},
+			wantErr: "attribute `brand`: invalid value \"hidden\"",
+		},
+		{
+			name: "not encoded",
+			record: struct {
+				Name string `json:"-" flapjack:"searchable"`
+			}{},
+			wantErr: "not encoded in JSON",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := search.SettingsFromStruct(tt.record)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
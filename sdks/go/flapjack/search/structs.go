@@ -0,0 +1,169 @@
+package search
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// objectIDTag is the `flapjack` struct tag of the field holding the objectID of a record.
+const objectIDTag = "objectID"
+
+// WithAutoGenerateObjectID whether SaveStruct and SaveStructs save the records without an objectID, which is then generated by the engine, instead of failing.
+func WithAutoGenerateObjectID(autoGenerateObjectID bool) chunkedBatchOption {
+	return chunkedBatchOption(func(c *config) {
+		c.autoGenerateObjectID = autoGenerateObjectID
+	})
+}
+
+/*
+SaveStruct saves a record given as a struct, whose attributes are named by their `json` tags.
+The objectID of the record is the value of the field tagged `flapjack:"objectID"`, a string or an integer, or of the field named `objectID` in JSON:
+
+	type Product struct {
+		SKU  string `json:"-" flapjack:"objectID"`
+		Name string `json:"name"`
+	}
+
+	res, err := client.SaveStruct("products", Product{SKU: "P-1", Name: "Red phone"})
+
+The record must have an objectID, unless WithAutoGenerateObjectID(true) is given.
+
+	@param indexName string - Index name.
+	@param object any - Struct, pointer to a struct or map to save.
+	@param opts ...ChunkedBatchOption - Optional parameters for the request, such as WithWaitForTasks.
+	@return *SaveObjectResponse - Response of the `saveObject` call.
+	@return error - Error if any.
+*/
+func (c *APIClient) SaveStruct(indexName string, object any, opts ...ChunkedBatchOption) (*SaveObjectResponse, error) {
+	conf := config{}
+
+	for _, opt := range opts {
+		opt.apply(&conf)
+	}
+
+	objects, err := structsToObjects([]any{object}, conf.autoGenerateObjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.SaveObject(c.NewApiSaveObjectRequest(indexName, objects[0]), toRequestOptions(opts)...)
+	if err != nil {
+		return nil, err
+	}
+
+	if conf.waitForTasks {
+		_, err = c.WaitForTask(indexName, res.TaskID, toIterableOptions(opts)...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+/*
+SaveStructs saves records given as a slice of structs, like SaveStruct, with SaveObjects.
+
+	@param indexName string - Index name.
+	@param objects any - Slice of structs, pointers to structs or maps to save.
+	@param opts ...ChunkedBatchOption - Optional parameters for the requests.
+	@return []BatchResponse - List of batch responses.
+	@return error - Error if any.
+*/
+func (c *APIClient) SaveStructs(indexName string, objects any, opts ...ChunkedBatchOption) ([]BatchResponse, error) {
+	value := reflect.ValueOf(objects)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return nil, reportError("records can only be saved from a slice, not %T", objects)
+	}
+
+	items := make([]any, 0, value.Len())
+	for i := 0; i < value.Len(); i++ {
+		items = append(items, value.Index(i).Interface())
+	}
+
+	conf := config{}
+
+	for _, opt := range opts {
+		opt.apply(&conf)
+	}
+
+	converted, err := structsToObjects(items, conf.autoGenerateObjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.SaveObjects(indexName, converted, opts...)
+}
+
+// structsToObjects converts the records, which must have an objectID unless it is generated by the engine.
+func structsToObjects(objects []any, autoGenerateObjectID bool) ([]map[string]any, error) {
+	converted, err := ToObjects(objects)
+	if err != nil {
+		return nil, err
+	}
+
+	if autoGenerateObjectID {
+		return converted, nil
+	}
+
+	for i, obj := range converted {
+		if _, ok := objectIDOf(obj); !ok {
+			return nil, fmt.Errorf("object at position %d has no `objectID`: tag a field with `flapjack:\"objectID\"`, or use WithAutoGenerateObjectID(true)", i)
+		}
+	}
+
+	return converted, nil
+}
+
+// taggedObjectID returns the value of the field tagged `flapjack:"objectID"` of a struct, if any and not empty.
+func taggedObjectID(value reflect.Value) (string, bool, error) {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return "", false, nil
+		}
+
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return "", false, nil
+	}
+
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+
+		if !hasTagOption(field, objectIDTag) {
+			if field.Anonymous {
+				id, ok, err := taggedObjectID(value.Field(i))
+				if ok || err != nil {
+					return id, ok, err
+				}
+			}
+
+			continue
+		}
+
+		id := value.Field(i)
+		for id.Kind() == reflect.Pointer {
+			if id.IsNil() {
+				return "", false, nil
+			}
+
+			id = id.Elem()
+		}
+
+		switch id.Kind() {
+		case reflect.String:
+			return id.String(), id.String() != "", nil
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return strconv.FormatInt(id.Int(), 10), true, nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return strconv.FormatUint(id.Uint(), 10), true, nil
+		default:
+			return "", false, fmt.Errorf("field %s tagged `flapjack:\"objectID\"` must be a string or an integer, not %s", field.Name, field.Type)
+		}
+	}
+
+	return "", false, nil
+}
@@ -0,0 +1,116 @@
+package search_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/flapjacktest"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+)
+
+type taggedProduct struct {
+	SKU  string `json:"-" flapjack:"objectID"`
+	Name string `json:"name"`
+}
+
+type stockedProduct struct {
+	taggedProduct
+	Stock int `json:"stock"`
+}
+
+type numberedProduct struct {
+	ID   *uint32 `json:"id" flapjack:"objectID"`
+	Name string  `json:"name"`
+}
+
+func TestSaveStructs(t *testing.T) {
+	t.Parallel()
+
+	srv := flapjacktest.NewServer()
+	t.Cleanup(srv.Close)
+
+	client, err := srv.NewClient()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	id := uint32(3)
+
+	_, err = client.SaveStructs("products", []any{
+		taggedProduct{SKU: "1", Name: "Red phone"},
+		&stockedProduct{taggedProduct: taggedProduct{SKU: "2", Name: "Blue phone"}, Stock: 4},
+		numberedProduct{ID: &id, Name: "Green phone"},
+		map[string]any{"objectID": "4", "name": "Phone case"},
+	}, search.WithWaitForTasks(true))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []map[string]any{
+		{"objectID": "1", "name": "Red phone"},
+		{"objectID": "2", "name": "Blue phone", "stock": float64(4)},
+		{"objectID": "3", "id": float64(3), "name": "Green phone"},
+		{"objectID": "4", "name": "Phone case"},
+	}
+	if got := srv.Objects("products"); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected objects\n got: %v\nwant: %v", got, want)
+	}
+
+	res, err := client.SaveStruct("products", &taggedProduct{SKU: "1", Name: "Red smartphone"}, search.WithWaitForTasks(true))
+	if err != nil || res.GetObjectID() != "1" {
+		t.Fatalf("unexpected response %+v: %v", res, err)
+	}
+
+	if got := srv.Objects("products")[0]; got["name"] != "Red smartphone" {
+		t.Errorf("expected the record to be replaced, got %v", got)
+	}
+}
+
+func TestSaveStructsObjectID(t *testing.T) {
+	t.Parallel()
+
+	srv := flapjacktest.NewServer()
+	t.Cleanup(srv.Close)
+
+	client, err := srv.NewClient()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = client.SaveStructs("products", []numberedProduct{{Name: "Green phone"}})
+	if err == nil || !strings.Contains(err.Error(), "has no `objectID`") {
+		t.Errorf("expected an error for the missing objectID, got %v", err)
+	}
+
+	_, err = client.SaveStruct("products", taggedProduct{Name: "Red phone"})
+	if err == nil || !strings.Contains(err.Error(), "WithAutoGenerateObjectID") {
+		t.Errorf("expected an error for the missing objectID, got %v", err)
+	}
+
+	_, err = client.SaveStructs("products", []struct {
+		ID float64 `flapjack:"objectID"`
+	}{{ID: 1}})
+	if err == nil || !strings.Contains(err.Error(), "must be a string or an integer") {
+		t.Errorf("expected an error for the objectID type, got %v", err)
+	}
+
+	_, err = client.SaveStructs("products", taggedProduct{SKU: "1"})
+	if err == nil || !strings.Contains(err.Error(), "from a slice") {
+		t.Errorf("expected an error for the non-slice records, got %v", err)
+	}
+
+	if len(srv.Objects("products")) != 0 {
+		t.Fatalf("expected no records to be saved")
+	}
+
+	_, err = client.SaveStructs("products", []taggedProduct{{Name: "Red phone"}},
+		search.WithAutoGenerateObjectID(true), search.WithWaitForTasks(true))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if objects := srv.Objects("products"); len(objects) != 1 || objects[0]["objectID"] == "" {
+		t.Errorf("expected a record with a generated objectID, got %v", objects)
+	}
+}
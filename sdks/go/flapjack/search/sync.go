@@ -0,0 +1,162 @@
+package search
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+)
+
+// SyncObjectsResponse is the outcome of SyncObjects.
+type SyncObjectsResponse struct {
+	// Added lists the objectIDs of the records missing from the index.
+	Added []string
+	// Updated lists the objectIDs of the records whose content changed.
+	Updated []string
+	// Deleted lists the objectIDs of the records missing from the dataset.
+	Deleted []string
+	// Unchanged is the number of records left untouched.
+	Unchanged int
+	// BatchResponses are the responses of the `batch` requests sent.
+	BatchResponses []BatchResponse
+}
+
+// syncIgnoredAttributes are the attributes returned by the engine which aren't part of the records.
+var syncIgnoredAttributes = []string{"_highlightResult", "_snippetResult", "_rankingInfo", "_distinctSeqID"}
+
+/*
+SyncObjects makes the records of an index match the given dataset, sending only the necessary operations instead of a full reindex.
+It browses the index, compares its records to the dataset by objectID and content hash, then adds the new records, replaces the changed ones and deletes the ones missing from the dataset.
+
+	@param indexName string - Index name.
+	@param objects []map[string]any - The complete dataset, every record having an objectID.
+	@param opts ...ChunkedBatchOption - Optional parameters for the requests.
+	@return *SyncObjectsResponse - The changes made.
+	@return error - Error if any.
+*/
+func (c *APIClient) SyncObjects(indexName string, objects []map[string]any, opts ...ChunkedBatchOption) (*SyncObjectsResponse, error) {
+	err := requireObjectIDs(objects)
+	if err != nil {
+		return nil, err
+	}
+
+	desired := make(map[string][sha256.Size]byte, len(objects))
+	order := make([]string, 0, len(objects))
+	byID := make(map[string]map[string]any, len(objects))
+
+	for i, obj := range objects {
+		objectID, _ := objectIDOf(obj)
+
+		hash, err := recordHash(obj, objectID)
+		if err != nil {
+			return nil, fmt.Errorf("cannot hash object at position %d: %w", i, err)
+		}
+
+		if _, ok := desired[objectID]; !ok {
+			order = append(order, objectID)
+		}
+
+		desired[objectID] = hash
+		byID[objectID] = obj
+	}
+
+	res := &SyncObjectsResponse{}
+	existing := map[string][sha256.Size]byte{}
+
+	err = c.BrowseObjectsStream(indexName, BrowseParamsObject{}, func(hit json.RawMessage) error {
+		var record map[string]any
+
+		err := json.Unmarshal(hit, &record)
+		if err != nil {
+			return fmt.Errorf("cannot decode record: %w", err)
+		}
+
+		for _, attribute := range syncIgnoredAttributes {
+			delete(record, attribute)
+		}
+
+		objectID, _ := objectIDOf(record)
+
+		if _, ok := desired[objectID]; !ok {
+			res.Deleted = append(res.Deleted, objectID)
+
+			return nil
+		}
+
+		existing[objectID], err = recordHash(record, objectID)
+
+		return err
+	}, toRequestOptions(opts)...)
+	if err != nil {
+		return nil, err
+	}
+
+	var added, updated []map[string]any
+
+	for _, objectID := range order {
+		hash, ok := existing[objectID]
+
+		switch {
+		case !ok:
+			res.Added = append(res.Added, objectID)
+			added = append(added, byID[objectID])
+		case hash != desired[objectID]:
+			res.Updated = append(res.Updated, objectID)
+			updated = append(updated, byID[objectID])
+		default:
+			res.Unchanged++
+		}
+	}
+
+	deleted := make([]map[string]any, 0, len(res.Deleted))
+	for _, objectID := range res.Deleted {
+		deleted = append(deleted, map[string]any{"objectID": objectID})
+	}
+
+	for _, step := range []struct {
+		action  Action
+		objects []map[string]any
+	}{
+		{ACTION_ADD_OBJECT, added},
+		{ACTION_UPDATE_OBJECT, updated},
+		{ACTION_DELETE_OBJECT, deleted},
+	} {
+		if len(step.objects) == 0 {
+			continue
+		}
+
+		batchResponses, err := c.ChunkedBatch(indexName, step.objects, step.action, opts...)
+		if err != nil {
+			return nil, err
+		}
+
+		res.BatchResponses = append(res.BatchResponses, batchResponses...)
+	}
+
+	return res, nil
+}
+
+// recordHash hashes the canonical JSON of a record, whose objectID is normalized to a string. The record is JSON
+// round-tripped first, so that records decoded from the API and built by hand hash the same.
+func recordHash(record map[string]any, objectID string) ([sha256.Size]byte, error) {
+	raw, err := json.Marshal(record)
+	if err != nil {
+		return [sha256.Size]byte{}, fmt.Errorf("cannot encode record: %w", err)
+	}
+
+	var canonical map[string]any
+
+	err = json.Unmarshal(raw, &canonical)
+	if err != nil {
+		return [sha256.Size]byte{}, fmt.Errorf("cannot decode record: %w", err)
+	}
+
+	canonical["objectID"] = objectID
+
+	// Maps are encoded with sorted keys, which makes the encoding canonical.
+	raw, err = json.Marshal(canonical)
+	if err != nil {
+		return [sha256.Size]byte{}, fmt.Errorf("cannot encode record: %w", err)
+	}
+
+	return sha256.Sum256(raw), nil
+}
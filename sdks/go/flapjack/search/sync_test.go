@@ -0,0 +1,67 @@
+package search_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/flapjacktest"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+)
+
+func TestSyncObjects(t *testing.T) {
+	t.Parallel()
+
+	srv := flapjacktest.NewServer()
+	t.Cleanup(srv.Close)
+
+	client, err := srv.NewClient()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	srv.AddObjects("products",
+		map[string]any{"objectID": "1", "name": "Pancake", "price": 4.5, "tags": []any{"sweet"}},
+		map[string]any{"objectID": "2", "name": "Waffle", "price": 6},
+		map[string]any{"objectID": "3", "name": "Crepe"},
+	)
+
+	dataset := []map[string]any{
+		{"objectID": "1", "tags": []string{"sweet"}, "price": 4.5, "name": "Pancake"},
+		{"objectID": "2", "name": "Waffle", "price": 7},
+		{"objectID": "4", "name": "Scone"},
+	}
+
+	res, err := client.SyncObjects("products", dataset, search.WithWaitForTasks(true))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(res.Added, []string{"4"}) || !reflect.DeepEqual(res.Updated, []string{"2"}) ||
+		!reflect.DeepEqual(res.Deleted, []string{"3"}) || res.Unchanged != 1 || len(res.BatchResponses) != 3 {
+		t.Fatalf("unexpected changes %+v", res)
+	}
+
+	want := []map[string]any{
+		{"objectID": "1", "name": "Pancake", "price": 4.5, "tags": []any{"sweet"}},
+		{"objectID": "2", "name": "Waffle", "price": float64(7)},
+		{"objectID": "4", "name": "Scone"},
+	}
+	if got := srv.Objects("products"); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected objects\n got: %v\nwant: %v", got, want)
+	}
+
+	// Once in sync, nothing is sent.
+	res, err = client.SyncObjects("products", dataset)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(res.Added)+len(res.Updated)+len(res.Deleted) != 0 || res.Unchanged != 3 || len(res.BatchResponses) != 0 {
+		t.Errorf("expected no changes, got %+v", res)
+	}
+
+	_, err = client.SyncObjects("products", []map[string]any{{"name": "No objectID"}})
+	if err == nil {
+		t.Error("expected an error for a record without objectID")
+	}
+}
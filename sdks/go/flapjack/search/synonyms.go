@@ -0,0 +1,65 @@
+package search
+
+/*
+ReplaceAllSynonyms replaces the synonyms of an index by the given ones, the other synonyms being deleted, so a set of synonyms can be deployed as a whole.
+The synonyms are saved with `replaceExistingSynonyms`, and an empty set clears the synonyms of the index. Waits for the task to be processed when `WithWaitForTasks(true)` is given.
+
+	@param indexName string - the index whose synonyms are replaced.
+	@param synonyms []SynonymHit - The complete set of synonyms of the index.
+	@param opts ...ChunkedBatchOption - Optional parameters for the request.
+	@return *UpdatedAtResponse - The response of the saveSynonyms or clearSynonyms call.
+	@return error - Error if a synonym has no objectID or the same objectID as another one, or if the request fails.
+*/
+func (c *APIClient) ReplaceAllSynonyms(indexName string, synonyms []SynonymHit, opts ...ChunkedBatchOption) (*UpdatedAtResponse, error) {
+	conf := config{}
+
+	for _, opt := range opts {
+		opt.apply(&conf)
+	}
+
+	err := requireUniqueObjectIDs("synonym", synonyms, func(synonym SynonymHit) string { return synonym.ObjectID })
+	if err != nil {
+		return nil, err
+	}
+
+	var res *UpdatedAtResponse
+
+	if len(synonyms) == 0 {
+		res, err = c.ClearSynonyms(c.NewApiClearSynonymsRequest(indexName), toRequestOptions(opts)...)
+	} else {
+		res, err = c.SaveSynonyms(c.NewApiSaveSynonymsRequest(indexName, synonyms).WithReplaceExistingSynonyms(true), toRequestOptions(opts)...)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if conf.waitForTasks {
+		_, err = c.WaitForTask(indexName, res.TaskID, toIterableOptions(opts)...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+// requireUniqueObjectIDs checks that the synonyms or rules all have a distinct objectID.
+func requireUniqueObjectIDs[T any](kind string, items []T, objectID func(T) string) error {
+	seen := make(map[string]bool, len(items))
+
+	for i, item := range items {
+		id := objectID(item)
+
+		switch {
+		case id == "":
+			return reportError("the %s at position %d has no objectID", kind, i)
+		case seen[id]:
+			return reportError("the objectID %q of the %s at position %d is already used by another %s", id, kind, i, kind)
+		}
+
+		seen[id] = true
+	}
+
+	return nil
+}
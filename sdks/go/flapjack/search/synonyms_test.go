@@ -0,0 +1,107 @@
+package search_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/flapjacktest"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+)
+
+func TestReplaceAllSynonyms(t *testing.T) {
+	t.Parallel()
+
+	srv := flapjacktest.NewServer()
+	t.Cleanup(srv.Close)
+
+	client, err := srv.NewClient()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = client.SaveSynonyms(client.NewApiSaveSynonymsRequest("products", []search.SynonymHit{
+		*search.NewSynonymHit("stale", search.SYNONYM_TYPE_SYNONYM, search.WithSynonymHitSynonyms([]string{"pan", "skillet"})),
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = client.ReplaceAllSynonyms("products", []search.SynonymHit{
+		*search.NewSynonymHit("crepe", search.SYNONYM_TYPE_SYNONYM, search.WithSynonymHitSynonyms([]string{"crepe", "galette"})),
+		*search.NewSynonymHit("waffle", search.SYNONYM_TYPE_ONEWAYSYNONYM, search.WithSynonymHitInput("waffle"), search.WithSynonymHitSynonyms([]string{"gaufre"})),
+	}, search.WithWaitForTasks(true))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := synonymIDs(t, client); strings.Join(got, ",") != "crepe,waffle" {
+		t.Errorf("expected the stale synonym to be replaced, got %v", got)
+	}
+
+	_, err = client.ReplaceAllSynonyms("products", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := synonymIDs(t, client); len(got) != 0 {
+		t.Errorf("expected an empty set to clear the synonyms, got %v", got)
+	}
+}
+
+func TestReplaceAllSynonymsRequiresUniqueObjectIDs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		synonyms []search.SynonymHit
+		wantErr  string
+	}{
+		{
+			name:     "missing objectID",
+			synonyms: []search.SynonymHit{*search.NewSynonymHit("", search.SYNONYM_TYPE_SYNONYM)},
+			wantErr:  "the synonym at position 0 has no objectID",
+		},
+		{
+			name: "duplicate objectID",
+			synonyms: []search.SynonymHit{
+				*search.NewSynonymHit("crepe", search.SYNONYM_TYPE_SYNONYM),
+				*search.NewSynonymHit("crepe", search.SYNONYM_TYPE_ONEWAYSYNONYM),
+			},
+			wantErr: `the objectID "crepe" of the synonym at position 1 is already used by another synonym`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			})
+
+			_, err := client.ReplaceAllSynonyms("products", tt.synonyms)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func synonymIDs(t *testing.T, client *search.APIClient) []string {
+	t.Helper()
+
+	res, err := client.SearchSynonyms(client.NewApiSearchSynonymsRequest("products"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ids := make([]string, 0, len(res.Hits))
+	for _, hit := range res.Hits {
+		ids = append(ids, hit.ObjectID)
+	}
+
+	return ids
+}
@@ -0,0 +1,249 @@
+package search
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+const (
+	// DefaultTenantSeparator separates the tenant ID from the index name in the indices of a TenantIndexManager.
+	DefaultTenantSeparator = "_"
+	// DefaultTenantKeyValidity is the validity of the secured API keys generated by a TenantIndexManager.
+	DefaultTenantKeyValidity = time.Hour
+)
+
+var tenantIDRegexp = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// TenantIndexConfig configures a TenantIndexManager.
+type TenantIndexConfig struct {
+	// IndexName is the name shared by the indices of the tenants, such as `products`. Required.
+	IndexName string
+	// Suffix places the tenant ID after the index name, as in `products_acme`, instead of before it, as in `acme_products`.
+	Suffix bool
+	// Separator separates the tenant ID from the index name. Defaults to DefaultTenantSeparator.
+	Separator string
+	// SearchApiKey is the search API key the secured API keys of the tenants are derived from. Required by SecuredApiKey, SearchClient and Search.
+	SearchApiKey string
+	// KeyValidity is the validity of the secured API keys of the tenants. Defaults to DefaultTenantKeyValidity.
+	KeyValidity time.Duration
+}
+
+/*
+TenantIndexManager manages an index per tenant of a multi-tenant application, named after the tenant ID.
+Its searches are made with a secured API key restricted to the index of the tenant, so a tenant can never read the records of another one, and its writes go to the index of the tenant:
+
+	tenants, err := search.NewTenantIndexManager(client, search.TenantIndexConfig{IndexName: "products", SearchApiKey: searchApiKey})
+
+	_, err = tenants.SaveObjects("acme", objects) // to acme_products
+	res, err := tenants.Search("acme", search.NewSearchParamsObject(search.WithSearchParamsObjectQuery("phone")))
+	key, err := tenants.SecuredApiKey("acme") // for the frontend of acme
+*/
+type TenantIndexManager struct {
+	client *APIClient
+	cfg    TenantIndexConfig
+}
+
+/*
+NewTenantIndexManager returns a TenantIndexManager writing with `client`, which needs an API key allowed to write to the indices of the tenants.
+
+	@param client *APIClient - Client of the writes, from which the clients of the searches are derived.
+	@param cfg TenantIndexConfig - Configuration of the manager.
+	@return *TenantIndexManager - The manager.
+	@return error - Error if the configuration is invalid.
+*/
+func NewTenantIndexManager(client *APIClient, cfg TenantIndexConfig) (*TenantIndexManager, error) {
+	if cfg.IndexName == "" {
+		return nil, reportError("the `IndexName` of the indices of the tenants is required")
+	}
+
+	if cfg.Separator == "" {
+		cfg.Separator = DefaultTenantSeparator
+	}
+
+	if cfg.KeyValidity <= 0 {
+		cfg.KeyValidity = DefaultTenantKeyValidity
+	}
+
+	return &TenantIndexManager{client: client, cfg: cfg}, nil
+}
+
+// IndexName returns the name of the index of the tenant. Tenant IDs only have letters, digits, `-` and `_`.
+func (m *TenantIndexManager) IndexName(tenantID string) (string, error) {
+	if !tenantIDRegexp.MatchString(tenantID) {
+		return "", reportError("the tenant ID %q must only have letters, digits, `-` and `_`", tenantID)
+	}
+
+	if m.cfg.Suffix {
+		return m.cfg.IndexName + m.cfg.Separator + tenantID, nil
+	}
+
+	return tenantID + m.cfg.Separator + m.cfg.IndexName, nil
+}
+
+// TenantID returns the tenant of an index, if it is the index of a tenant.
+func (m *TenantIndexManager) TenantID(indexName string) (string, bool) {
+	var (
+		tenantID string
+		ok       bool
+	)
+
+	if m.cfg.Suffix {
+		tenantID, ok = strings.CutPrefix(indexName, m.cfg.IndexName+m.cfg.Separator)
+	} else {
+		tenantID, ok = strings.CutSuffix(indexName, m.cfg.Separator+m.cfg.IndexName)
+	}
+
+	return tenantID, ok && tenantIDRegexp.MatchString(tenantID)
+}
+
+/*
+SecuredApiKey returns a secured API key only allowed to search the index of the tenant, for its frontend. The key expires after the `KeyValidity` of the configuration.
+The key is restricted to the exact name of the index, excluding its replicas, as a wildcard on it could match the index of another tenant.
+
+	@param tenantID string - ID of the tenant.
+	@return string - The secured API key.
+	@return error - Error if the tenant ID is invalid or no `SearchApiKey` is configured.
+*/
+func (m *TenantIndexManager) SecuredApiKey(tenantID string) (string, error) {
+	if m.cfg.SearchApiKey == "" {
+		return "", reportError("a `SearchApiKey` is required to generate the secured API keys of the tenants")
+	}
+
+	indexName, err := m.IndexName(tenantID)
+	if err != nil {
+		return "", err
+	}
+
+	restrictions, err := NewSecuredApiKeyRestrictionsBuilder().
+		RestrictIndices(indexName).
+		ValidFor(m.cfg.KeyValidity).
+		Clock(m.client.clock()).
+		Build()
+	if err != nil {
+		return "", err
+	}
+
+	return m.client.GenerateSecuredApiKey(m.cfg.SearchApiKey, restrictions)
+}
+
+// SearchClient returns a client derived from the one of the manager with the secured API key of the tenant, which can only search the index of the tenant.
+func (m *TenantIndexManager) SearchClient(tenantID string) (*APIClient, error) {
+	key, err := m.SecuredApiKey(tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.client.WithConfiguration(transport.ConfigurationOverrides{ApiKey: key}), nil
+}
+
+/*
+Search searches the index of the tenant with its secured API key.
+
+	@param tenantID string - ID of the tenant.
+	@param params *SearchParamsObject - Search parameters. Can be nil.
+	@param opts ...RequestOption - Optional parameters for the request.
+	@return *SearchResponse - The search response.
+	@return error - Error if any.
+*/
+func (m *TenantIndexManager) Search(tenantID string, params *SearchParamsObject, opts ...RequestOption) (*SearchResponse, error) {
+	client, err := m.SearchClient(tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	indexName, err := m.IndexName(tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	req := client.NewApiSearchSingleIndexRequest(indexName)
+	if params != nil {
+		req = req.WithSearchParams(SearchParamsObjectAsSearchParams(params))
+	}
+
+	return client.SearchSingleIndex(req, opts...)
+}
+
+/*
+SaveObjects saves the records in the index of the tenant, like APIClient.SaveObjects.
+
+	@param tenantID string - ID of the tenant.
+	@param objects []map[string]any - The records to save.
+	@param opts ...ChunkedBatchOption - Optional parameters for the request.
+	@return []BatchResponse - The responses of the batches.
+	@return error - Error if any.
+*/
+func (m *TenantIndexManager) SaveObjects(tenantID string, objects []map[string]any, opts ...ChunkedBatchOption) ([]BatchResponse, error) {
+	indexName, err := m.IndexName(tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.client.SaveObjects(indexName, objects, opts...)
+}
+
+/*
+DeleteObjects deletes the records from the index of the tenant, like APIClient.DeleteObjects.
+
+	@param tenantID string - ID of the tenant.
+	@param objectIDs []string - ObjectIDs of the records to delete.
+	@param opts ...ChunkedBatchOption - Optional parameters for the request.
+	@return []BatchResponse - The responses of the batches.
+	@return error - Error if any.
+*/
+func (m *TenantIndexManager) DeleteObjects(tenantID string, objectIDs []string, opts ...ChunkedBatchOption) ([]BatchResponse, error) {
+	indexName, err := m.IndexName(tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.client.DeleteObjects(indexName, objectIDs, opts...)
+}
+
+/*
+DeleteTenant deletes the index of the tenant, with its records, settings, synonyms and rules, when the tenant leaves.
+
+	@param tenantID string - ID of the tenant.
+	@param opts ...RequestOption - Optional parameters for the request.
+	@return *DeletedAtResponse - The response of the deleteIndex call.
+	@return error - Error if any.
+*/
+func (m *TenantIndexManager) DeleteTenant(tenantID string, opts ...RequestOption) (*DeletedAtResponse, error) {
+	indexName, err := m.IndexName(tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.client.DeleteIndex(m.client.NewApiDeleteIndexRequest(indexName), opts...)
+}
+
+/*
+ForEachTenant returns the operations running `fn` on the index of each tenant, to give to Parallel, such as to apply the same settings to every tenant.
+
+	@param tenantIDs []string - IDs of the tenants.
+	@param fn func(ctx context.Context, tenantID string, indexName string) error - The operation.
+	@return []IndexOperation - The operations.
+	@return error - Error if a tenant ID is invalid.
+*/
+func (m *TenantIndexManager) ForEachTenant(tenantIDs []string, fn func(ctx context.Context, tenantID string, indexName string) error) ([]IndexOperation, error) {
+	ops := make([]IndexOperation, 0, len(tenantIDs))
+
+	for _, tenantID := range tenantIDs {
+		indexName, err := m.IndexName(tenantID)
+		if err != nil {
+			return nil, err
+		}
+
+		tenantID := tenantID
+
+		ops = append(ops, IndexOperation{IndexName: indexName, Run: func(ctx context.Context) error {
+			return fn(ctx, tenantID, indexName)
+		}})
+	}
+
+	return ops, nil
+}
@@ -0,0 +1,199 @@
+package search_test
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/flapjacktest"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+)
+
+func TestTenantIndexManagerIndexName(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	})
+
+	tests := []struct {
+		name      string
+		cfg       search.TenantIndexConfig
+		tenantID  string
+		wantIndex string
+	}{
+		{
+			name:      "prefix",
+			cfg:       search.TenantIndexConfig{IndexName: "products"},
+			tenantID:  "acme",
+			wantIndex: "acme_products",
+		},
+		{
+			name:      "suffix",
+			cfg:       search.TenantIndexConfig{IndexName: "products", Suffix: true},
+			tenantID:  "acme",
+			wantIndex: "products_acme",
+		},
+		{
+			name:      "separator",
+			cfg:       search.TenantIndexConfig{IndexName: "products", Separator: "--"},
+			tenantID:  "acme_eu",
+			wantIndex: "acme_eu--products",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tenants, err := search.NewTenantIndexManager(client, tt.cfg)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			indexName, err := tenants.IndexName(tt.tenantID)
+			if err != nil || indexName != tt.wantIndex {
+				t.Fatalf("expected the index %s, got %s: %v", tt.wantIndex, indexName, err)
+			}
+
+			if tenantID, ok := tenants.TenantID(indexName); !ok || tenantID != tt.tenantID {
+				t.Errorf("expected the tenant %s, got %s (%v)", tt.tenantID, tenantID, ok)
+			}
+
+			if tenantID, ok := tenants.TenantID(tt.cfg.IndexName); ok {
+				t.Errorf("expected the shared index not to be the index of a tenant, got %s", tenantID)
+			}
+		})
+	}
+}
+
+func TestTenantIndexManagerErrors(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	})
+
+	if _, err := search.NewTenantIndexManager(client, search.TenantIndexConfig{}); err == nil ||
+		err.Error() != "the `IndexName` of the indices of the tenants is required" {
+		t.Errorf("expected an error for the missing index name, got %v", err)
+	}
+
+	tenants, err := search.NewTenantIndexManager(client, search.TenantIndexConfig{IndexName: "products"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, tenantID := range []string{"", "acme/../other", "acme,other"} {
+		want := `the tenant ID "` + tenantID + "\" must only have letters, digits, `-` and `_`"
+		if _, err := tenants.SaveObjects(tenantID, []map[string]any{{"objectID": "1"}}); err == nil || err.Error() != want {
+			t.Errorf("expected error %q, got %v", want, err)
+		}
+	}
+
+	want := "a `SearchApiKey` is required to generate the secured API keys of the tenants"
+	if _, err := tenants.Search("acme", nil); err == nil || err.Error() != want {
+		t.Errorf("expected error %q, got %v", want, err)
+	}
+}
+
+func TestTenantIndexManagerSearch(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu     sync.Mutex
+		path   string
+		apiKey string
+	)
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		path, apiKey = r.URL.Path, r.Header.Get("X-Algolia-API-Key")
+		mu.Unlock()
+
+		_, _ = w.Write([]byte(`{"hits": [], "nbHits": 0, "page": 0, "hitsPerPage": 20, "nbPages": 0, "processingTimeMS": 1, "query": "phone", "params": ""}`))
+	})
+
+	tenants, err := search.NewTenantIndexManager(client, search.TenantIndexConfig{IndexName: "products", SearchApiKey: "search-key"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = tenants.Search("acme", search.NewSearchParamsObject(search.WithSearchParamsObjectQuery("phone")))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if path != "/1/indexes/acme_products/query" {
+		t.Errorf("expected the search to target the index of the tenant, got %s", path)
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(apiKey)
+	if err != nil {
+		t.Fatalf("expected the search to use a secured API key, got %q: %v", apiKey, err)
+	}
+
+	if !strings.Contains(string(decoded), "restrictIndices=acme_products&") && !strings.HasSuffix(string(decoded), "restrictIndices=acme_products") {
+		t.Errorf("expected the key to be restricted to the index of the tenant, got %s", decoded)
+	}
+
+	if !strings.Contains(string(decoded), "validUntil=") {
+		t.Errorf("expected the key to expire, got %s", decoded)
+	}
+}
+
+func TestTenantIndexManagerWrites(t *testing.T) {
+	t.Parallel()
+
+	srv := flapjacktest.NewServer()
+	t.Cleanup(srv.Close)
+
+	client, err := srv.NewClient()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tenants, err := search.NewTenantIndexManager(client, search.TenantIndexConfig{IndexName: "products", Suffix: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ops, err := tenants.ForEachTenant([]string{"acme", "globex"}, func(ctx context.Context, tenantID, _ string) error {
+		_, err := tenants.SaveObjects(tenantID, []map[string]any{
+			{"objectID": "1", "tenant": tenantID},
+			{"objectID": "2", "tenant": tenantID},
+		}, search.WithContext(ctx))
+
+		return err
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := client.Parallel(ops); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := tenants.DeleteObjects("acme", []string{"2"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sameJSON(t, srv.Objects("products_acme"), `[{"objectID": "1", "tenant": "acme"}]`)
+	sameJSON(t, srv.Objects("products_globex"), `[{"objectID": "1", "tenant": "globex"}, {"objectID": "2", "tenant": "globex"}]`)
+
+	if _, err := tenants.DeleteTenant("globex"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := srv.Objects("products_globex"); len(got) != 0 {
+		t.Errorf("expected the index of the tenant to be deleted, got %v", got)
+	}
+}
@@ -0,0 +1,142 @@
+package search
+
+/*
+TypoConfig sets how typos are tolerated: whether they are, and the shortest words tolerating one and two typos:
+
+	opts, err := search.NewTypoConfig().
+		MinWordSizes(4, 8).
+		IndexSettingsOptions()
+
+The engine tolerates a typo or none: it only reads `typoTolerance: false` in the searches and the min word sizes in
+the settings of the index, so the options fail with the parameters it would ignore. It has no `min` or `strict`
+tolerance, nor any of `allowTyposOnNumericTokens`, `disableTypoToleranceOnAttributes` and
+`disableTypoToleranceOnWords`.
+
+The parameters are validated when the options are built.
+*/
+type TypoConfig struct {
+	tolerance            *bool
+	minWordSizeFor1Typo  *int32
+	minWordSizeFor2Typos *int32
+	err                  error
+}
+
+// NewTypoConfig returns a TypoConfig leaving every parameter to the settings of the index.
+func NewTypoConfig() *TypoConfig {
+	return &TypoConfig{}
+}
+
+// Tolerance sets whether typos are tolerated, `false` matching the words of the query exactly. It is a search parameter only.
+func (t *TypoConfig) Tolerance(tolerate bool) *TypoConfig {
+	t.tolerance = &tolerate
+
+	return t
+}
+
+// MinWordSizes sets the shortest words, in characters, tolerating one typo and two typos. The engine defaults are 4 and 8, and it tolerates one typo at most, whatever the length of the word. It is a setting of the index only.
+func (t *TypoConfig) MinWordSizes(oneTypo int32, twoTypos int32) *TypoConfig {
+	if oneTypo < 1 || twoTypos < oneTypo {
+		t.fail(reportError("the min word sizes for typos must be positive, the one for 2 typos not below the one for 1 typo, got %d and %d", oneTypo, twoTypos))
+	}
+
+	t.minWordSizeFor1Typo = &oneTypo
+	t.minWordSizeFor2Typos = &twoTypos
+
+	return t
+}
+
+// fail records the first error, returned when the options are built.
+func (t *TypoConfig) fail(err error) {
+	if t.err == nil {
+		t.err = err
+	}
+}
+
+// validateSearch returns the first invalid parameter, or the first one which is a setting of the index only.
+func (t *TypoConfig) validateSearch() error {
+	if t.err != nil {
+		return t.err
+	}
+
+	if t.minWordSizeFor1Typo != nil {
+		return reportError("the min word sizes for typos are a setting of the index only, the engine ignores them in a search")
+	}
+
+	return nil
+}
+
+// validateSettings returns the first invalid parameter, or the first one which is a search parameter only.
+func (t *TypoConfig) validateSettings() error {
+	if t.err != nil {
+		return t.err
+	}
+
+	if t.tolerance != nil {
+		return reportError("typoTolerance is a search parameter only, the engine ignores it in the settings of an index")
+	}
+
+	return nil
+}
+
+/*
+SearchForHitsOptions validates the parameters and returns them as options of NewSearchForHits.
+
+	@return []SearchForHitsOption - The options.
+	@return error - The first invalid parameter.
+*/
+func (t *TypoConfig) SearchForHitsOptions() ([]SearchForHitsOption, error) {
+	err := t.validateSearch()
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []SearchForHitsOption
+
+	if t.tolerance != nil {
+		opts = append(opts, WithSearchForHitsTypoTolerance(*BoolAsTypoTolerance(*t.tolerance)))
+	}
+
+	return opts, nil
+}
+
+/*
+SearchParamsObjectOptions validates the parameters and returns them as options of NewSearchParamsObject.
+
+	@return []SearchParamsObjectOption - The options.
+	@return error - The first invalid parameter.
+*/
+func (t *TypoConfig) SearchParamsObjectOptions() ([]SearchParamsObjectOption, error) {
+	err := t.validateSearch()
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []SearchParamsObjectOption
+
+	if t.tolerance != nil {
+		opts = append(opts, WithSearchParamsObjectTypoTolerance(*BoolAsTypoTolerance(*t.tolerance)))
+	}
+
+	return opts, nil
+}
+
+/*
+IndexSettingsOptions validates the parameters and returns them as options of NewIndexSettings, making them the default of the searches.
+
+	@return []IndexSettingsOption - The options.
+	@return error - The first invalid parameter.
+*/
+func (t *TypoConfig) IndexSettingsOptions() ([]IndexSettingsOption, error) {
+	err := t.validateSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []IndexSettingsOption
+
+	if t.minWordSizeFor1Typo != nil {
+		opts = append(opts, WithIndexSettingsMinWordSizefor1Typo(*t.minWordSizeFor1Typo), WithIndexSettingsMinWordSizefor2Typos(*t.minWordSizeFor2Typos))
+	}
+
+	return opts, nil
+}
@@ -0,0 +1,68 @@
+package search_test
+
+import (
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+)
+
+func TestTypoConfig(t *testing.T) {
+	t.Parallel()
+
+	settingsOpts, err := search.NewTypoConfig().MinWordSizes(3, 7).IndexSettingsOptions()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sameJSON(t, search.NewIndexSettings(settingsOpts...), `{
+		"minWordSizefor1Typo": 3,
+		"minWordSizefor2Typos": 7
+	}`)
+
+	opts, err := search.NewTypoConfig().Tolerance(false).SearchParamsObjectOptions()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sameJSON(t, search.NewSearchParamsObject(opts...), `{"typoTolerance": false}`)
+
+	hitsOpts, err := search.NewTypoConfig().Tolerance(true).SearchForHitsOptions()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sameJSON(t, search.NewSearchForHits("products", hitsOpts...), `{"indexName": "products", "typoTolerance": true}`)
+}
+
+func TestTypoConfigValidation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		config   *search.TypoConfig
+		settings bool
+	}{
+		{name: "word sizes", config: search.NewTypoConfig().MinWordSizes(8, 4), settings: true},
+		{name: "word sizes in a search", config: search.NewTypoConfig().MinWordSizes(4, 8)},
+		{name: "tolerance in the settings", config: search.NewTypoConfig().Tolerance(false), settings: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var err error
+			if tt.settings {
+				_, err = tt.config.IndexSettingsOptions()
+			} else {
+				_, err = tt.config.SearchParamsObjectOptions()
+			}
+
+			if err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
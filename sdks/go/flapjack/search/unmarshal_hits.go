@@ -0,0 +1,98 @@
+package search
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+/*
+UnmarshalHits decodes the hits into `v`, a pointer to a slice of structs or maps, which replaces its content.
+Each hit is decoded from its JSON form, with its `objectID` and other attributes as well as the `_highlightResult`, `_snippetResult` and `_rankingInfo` ones:
+
+	var products []struct {
+		ObjectID string  `json:"objectID"`
+		Name     string  `json:"name"`
+		Price    float64 `json:"price"`
+	}
+
+	err := res.UnmarshalHits(&products)
+
+	@param v any - Pointer to the slice to decode the hits into.
+	@return error - Error naming the hit and the field which could not be decoded, if any.
+*/
+func (o *SearchResponse) UnmarshalHits(v any) error {
+	return unmarshalHits(o.Hits, v)
+}
+
+/*
+UnmarshalHits decodes the hits into `v`, a pointer to a slice of structs or maps, which replaces its content, as SearchResponse.UnmarshalHits.
+
+	@param v any - Pointer to the slice to decode the hits into.
+	@return error - Error naming the hit and the field which could not be decoded, if any.
+*/
+func (o *BrowseResponse) UnmarshalHits(v any) error {
+	return unmarshalHits(o.Hits, v)
+}
+
+/*
+UnmarshalHits decodes the raw hits into `v`, a pointer to a slice of structs or maps, which replaces its content, as SearchResponse.UnmarshalHits.
+
+	@param v any - Pointer to the slice to decode the hits into.
+	@return error - Error naming the hit and the field which could not be decoded, if any.
+*/
+func (o *RawHitsSearchResponse) UnmarshalHits(v any) error {
+	return decodeHits(len(o.Hits), func(i int) (json.RawMessage, string, error) {
+		var probe struct {
+			ObjectID any `json:"objectID"`
+		}
+
+		// The objectID only names the hit in errors.
+		_ = json.Unmarshal(o.Hits[i], &probe)
+
+		id, _ := objectIDOf(map[string]any{"objectID": probe.ObjectID})
+
+		return o.Hits[i], id, nil
+	}, v)
+}
+
+func unmarshalHits(hits []Hit, v any) error {
+	return decodeHits(len(hits), func(i int) (json.RawMessage, string, error) {
+		raw, err := json.Marshal(hits[i])
+
+		return raw, hits[i].ObjectID, err
+	}, v)
+}
+
+// decodeHits decodes the `n` hits returned by `hit`, with their objectID, into the slice pointed to by `v`.
+func decodeHits(n int, hit func(i int) (json.RawMessage, string, error), v any) error {
+	ptr := reflect.ValueOf(v)
+	if ptr.Kind() != reflect.Pointer || ptr.IsNil() || ptr.Elem().Kind() != reflect.Slice {
+		return reportError("hits can only be decoded into a pointer to a slice, not %T", v)
+	}
+
+	slice := reflect.MakeSlice(ptr.Elem().Type(), n, n)
+
+	for i := 0; i < n; i++ {
+		raw, objectID, err := hit(i)
+		if err != nil {
+			return fmt.Errorf("cannot encode hit at position %d (objectID %q): %w", i, objectID, err)
+		}
+
+		err = json.Unmarshal(raw, slice.Index(i).Addr().Interface())
+		if err != nil {
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &typeErr) && typeErr.Field != "" {
+				return fmt.Errorf("cannot decode hit at position %d (objectID %q): field `%s` cannot hold a JSON %s as %s: %w",
+					i, objectID, typeErr.Field, typeErr.Value, typeErr.Type, err)
+			}
+
+			return fmt.Errorf("cannot decode hit at position %d (objectID %q): %w", i, objectID, err)
+		}
+	}
+
+	ptr.Elem().Set(slice)
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package search_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+)
+
+type hitProduct struct {
+	ObjectID    string   `json:"objectID"`
+	Name        string   `json:"name"`
+	Price       float64  `json:"price"`
+	Tags        []string `json:"tags"`
+	RankingInfo *struct {
+		NbTypos int `json:"nbTypos"`
+	} `json:"_rankingInfo"`
+}
+
+func TestUnmarshalHits(t *testing.T) {
+	t.Parallel()
+
+	var res search.SearchResponse
+
+	err := json.Unmarshal([]byte(`{"hits":[
+		{"objectID":"1","name":"Red phone","price":499.9,"tags":["new"],"_rankingInfo":{"nbTypos":1,"firstMatchedWord":0,"geoDistance":0,"nbExactWords":1,"userScore":3}},
+		{"objectID":"2","name":"Blue phone","price":299}
+	],"nbHits":2,"page":0,"nbPages":1,"hitsPerPage":20,"processingTimeMS":1,"query":"phone","params":""}`), &res)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	products := []hitProduct{{ObjectID: "stale"}, {}, {}}
+
+	err = res.UnmarshalHits(&products)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(products) != 2 || products[0].ObjectID != "1" || products[0].Price != 499.9 || !reflect.DeepEqual(products[0].Tags, []string{"new"}) ||
+		products[0].RankingInfo == nil || products[0].RankingInfo.NbTypos != 1 || products[1].Name != "Blue phone" || products[1].RankingInfo != nil {
+		t.Errorf("unexpected products %+v", products)
+	}
+
+	var rawRes search.RawHitsSearchResponse
+
+	err = json.Unmarshal([]byte(`{"hits":[{"objectID":"1","price":10}],"nbHits":1,"page":0,"nbPages":1,"hitsPerPage":20,"processingTimeMS":1,"query":"","params":""}`), &rawRes)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = rawRes.UnmarshalHits(&products)
+	if err != nil || len(products) != 1 || products[0].Price != 10 {
+		t.Errorf("unexpected raw products %+v: %v", products, err)
+	}
+
+	var records []map[string]any
+
+	err = res.UnmarshalHits(&records)
+	if err != nil || len(records) != 2 || records[1]["objectID"] != "2" || records[1]["price"] != float64(299) {
+		t.Errorf("unexpected records %v: %v", records, err)
+	}
+}
+
+func TestUnmarshalHitsErrors(t *testing.T) {
+	t.Parallel()
+
+	res := search.BrowseResponse{Hits: []search.Hit{
+		{ObjectID: "1", AdditionalProperties: map[string]any{"price": 10}},
+		{ObjectID: "2", AdditionalProperties: map[string]any{"price": "free"}},
+	}}
+
+	var products []hitProduct
+
+	err := res.UnmarshalHits(&products)
+	if err == nil || !strings.Contains(err.Error(), `hit at position 1 (objectID "2"): field `+"`price`"+` cannot hold a JSON string as float64`) {
+		t.Errorf("expected an error naming the hit and the field, got %v", err)
+	}
+
+	raw := search.RawHitsSearchResponse{Hits: []json.RawMessage{[]byte(`{"objectID":"1","price":10}`), []byte(`{"objectID":3,"price":"free"}`)}}
+
+	err = raw.UnmarshalHits(&products)
+	if err == nil || !strings.Contains(err.Error(), `hit at position 1 (objectID "3")`) {
+		t.Errorf("expected an error naming the raw hit, got %v", err)
+	}
+
+	err = res.UnmarshalHits(products)
+	if err == nil || !strings.Contains(err.Error(), "pointer to a slice") {
+		t.Errorf("expected an error for the non-pointer destination, got %v", err)
+	}
+}
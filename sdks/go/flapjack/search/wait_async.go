@@ -0,0 +1,125 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultMaxConcurrency is the default maximum number of task statuses fetched at once by WaitForTasks.
+const DefaultMaxConcurrency = 8
+
+// WithMaxConcurrency the maximum number of task statuses fetched at once by WaitForTasks, shared by all its tasks. Default to DefaultMaxConcurrency.
+func WithMaxConcurrency(maxConcurrency int) iterableOption {
+	return iterableOption(func(c *config) {
+		c.maxConcurrency = maxConcurrency
+	})
+}
+
+/*
+WaitForTaskAsync waits for a task to be published in the background, like WaitForTask.
+The returned channel receives the error of the wait, nil once the task is published, then is closed.
+
+	@param indexName string - Index name.
+	@param taskID int64 - Task ID.
+	@param opts ...IterableOption - Optional parameters for the request.
+	@return <-chan error - Channel receiving the result of the wait.
+*/
+func (c *APIClient) WaitForTaskAsync(indexName string, taskID int64, opts ...IterableOption) <-chan error {
+	done := make(chan error, 1)
+
+	go func() {
+		defer close(done)
+
+		_, err := c.WaitForTask(indexName, taskID, opts...)
+		done <- err
+	}()
+
+	return done
+}
+
+/*
+WaitForTasks waits for several tasks of an index to be published in the background, polling them concurrently.
+The task statuses fetched at once are limited by the WithMaxConcurrency option, whatever the number of tasks.
+The returned channel receives nil once all the tasks are published, or the errors of the tasks that could not be waited for, then is closed.
+
+	@param indexName string - Index name.
+	@param taskIDs []int64 - Task IDs.
+	@param opts ...IterableOption - Optional parameters for the request.
+	@return <-chan error - Channel receiving the result of the waits.
+*/
+func (c *APIClient) WaitForTasks(indexName string, taskIDs []int64, opts ...IterableOption) <-chan error {
+	conf := config{maxConcurrency: DefaultMaxConcurrency}
+
+	for _, opt := range opts {
+		opt.apply(&conf)
+	}
+
+	ctx := conf.context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	limiter := make(chan struct{}, max(conf.maxConcurrency, 1))
+	done := make(chan error, 1)
+
+	go func() {
+		defer close(done)
+
+		var wg sync.WaitGroup
+
+		errs := make([]error, len(taskIDs))
+
+		for i, taskID := range taskIDs {
+			wg.Add(1)
+
+			go func(i int, taskID int64) {
+				defer wg.Done()
+
+				_, err := c.waitForTask(ctx, indexName, taskID, limiter, opts...)
+				if err != nil {
+					errs[i] = fmt.Errorf("task %d: %w", taskID, err)
+				}
+			}(i, taskID)
+		}
+
+		wg.Wait()
+
+		done <- errors.Join(errs...)
+	}()
+
+	return done
+}
+
+// waitForTask polls the status of a task until it is published. When limiter is set, every call holds one of its slots.
+func (c *APIClient) waitForTask(ctx context.Context, indexName string, taskID int64, limiter chan struct{}, opts ...IterableOption) (*GetTaskResponse, error) {
+	// provide a default timeout function
+	opts = append([]IterableOption{WithTimeout(func(count int) time.Duration {
+		return time.Duration(min(200*count, 5000)) * time.Millisecond
+	}), WithMaxRetries(50), WithClock(c.clock())}, opts...)
+
+	return CreateIterable(
+		func(*GetTaskResponse, error) (*GetTaskResponse, error) {
+			if limiter != nil {
+				select {
+				case limiter <- struct{}{}:
+					defer func() { <-limiter }()
+				case <-ctx.Done():
+					return nil, fmt.Errorf("cannot get task: %w", ctx.Err())
+				}
+			}
+
+			return c.GetTask(c.NewApiGetTaskRequest(indexName, taskID), toRequestOptions(opts)...)
+		},
+		func(response *GetTaskResponse, err error) (bool, error) {
+			if err != nil || response == nil {
+				return false, err
+			}
+
+			return response.Status == TASK_STATUS_PUBLISHED, nil
+		},
+		opts...,
+	)
+}
@@ -0,0 +1,116 @@
+package search_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+func noDelay(int) time.Duration { return 0 }
+
+func TestWaitForTaskAsync(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) < 3 {
+			_, _ = w.Write([]byte(`{"status":"notPublished"}`))
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"status":"published"}`))
+	})
+
+	done := client.WaitForTaskAsync("products", 42, search.WithTimeout(noDelay))
+
+	if err := <-done; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, ok := <-done; ok {
+		t.Error("expected the channel to be closed")
+	}
+
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 calls, got %d", calls.Load())
+	}
+}
+
+func TestWaitForTasksLimitsConcurrency(t *testing.T) {
+	t.Parallel()
+
+	var (
+		inFlight, maxInFlight atomic.Int32
+		mu                    sync.Mutex
+		polls                 = map[string]int{}
+	)
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+
+		for {
+			current := maxInFlight.Load()
+			if n <= current || maxInFlight.CompareAndSwap(current, n) {
+				break
+			}
+		}
+
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		polls[r.URL.Path]++
+		published := polls[r.URL.Path] > 1
+		mu.Unlock()
+
+		if !published {
+			_, _ = w.Write([]byte(`{"status":"notPublished"}`))
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"status":"published"}`))
+	})
+
+	err := <-client.WaitForTasks("products", []int64{1, 2, 3, 4, 5, 6}, search.WithMaxConcurrency(2), search.WithTimeout(noDelay))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if maxInFlight.Load() > 2 {
+		t.Errorf("expected at most 2 concurrent calls, got %d", maxInFlight.Load())
+	}
+
+	if len(polls) != 6 {
+		t.Errorf("expected the 6 tasks to be polled, got %v", polls)
+	}
+}
+
+func TestWaitForTasksReportsFailedTasks(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/task/2") {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Task does not exist"}`))
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"status":"published"}`))
+	})
+
+	err := <-client.WaitForTasks("products", []int64{1, 2, 3})
+	if !errors.Is(err, transport.ErrNotFound) || !strings.Contains(err.Error(), "task 2:") || strings.Contains(err.Error(), "task 1:") {
+		t.Errorf("expected the error of task 2 only, got %v", err)
+	}
+}
@@ -0,0 +1,514 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrWriteQueued is returned by the WriteQueue for the write operations persisted to be replayed later.
+var ErrWriteQueued = errors.New("write operation queued")
+
+// DefaultReplayInterval is the default interval at which a WriteQueue replays its queued operations.
+const DefaultReplayInterval = 30 * time.Second
+
+// The write operations supported by the WriteQueue.
+const (
+	QueuedWriteBatch               = "batch"
+	QueuedWritePartialUpdateObject = "partialUpdateObject"
+	QueuedWriteDeleteObject        = "deleteObject"
+)
+
+// WriteQueueConfig configures a WriteQueue.
+type WriteQueueConfig struct {
+	// Dir is the directory where the queued operations are persisted, one
+	// file per operation. It is created if needed.
+	Dir string
+	// ReplayInterval is the interval at which the queued operations are
+	// replayed in the background, DefaultReplayInterval when zero. A negative
+	// interval disables the background replays, see WriteQueue.Replay. The
+	// interval is waited for with the Clock of the client: with a clock that
+	// doesn't wait, such as flapjacktest.Clock, disable the background
+	// replays and call Replay.
+	ReplayInterval time.Duration
+	// OnDiscard, when set, is called for the queued operations that can't be
+	// replayed, such as invalid records rejected by the server or files that
+	// can't be decoded. They are moved to the `failed` subdirectory of Dir.
+	OnDiscard func(op QueuedWrite, err error)
+}
+
+// QueuedWrite is a write operation persisted by a WriteQueue.
+type QueuedWrite struct {
+	Sequence          uint64          `json:"sequence"`
+	Operation         string          `json:"operation"`
+	IndexName         string          `json:"indexName"`
+	ObjectID          string          `json:"objectID,omitempty"`
+	Body              json.RawMessage `json:"body,omitempty"`
+	CreateIfNotExists *bool           `json:"createIfNotExists,omitempty"`
+	QueuedAt          time.Time       `json:"queuedAt"`
+}
+
+// WriteQueue sends write operations with a client and, when they fail because the hosts are unreachable, unavailable
+// or rate limiting, persists them to disk to replay them once the hosts recover, for deployments with flaky
+// connectivity. The operations are sent in order: while some are queued, the new ones are queued after them.
+type WriteQueue struct {
+	client *APIClient
+	cfg    WriteQueueConfig
+
+	// sendMu orders the sends, and is held while an operation is sent. mu
+	// only guards the queue, and is never held across a request.
+	sendMu  sync.Mutex
+	mu      sync.Mutex
+	pending []QueuedWrite
+	next    uint64
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+/*
+NewWriteQueue creates a write queue sending its operations with the given client, and loads the operations persisted in the directory of the configuration by a previous queue.
+The queue replays its operations in the background until it is closed.
+
+	@param client *APIClient - Client sending the operations.
+	@param cfg WriteQueueConfig - Configuration of the queue.
+	@return *WriteQueue - The write queue.
+	@return error - Error if any.
+*/
+func NewWriteQueue(client *APIClient, cfg WriteQueueConfig) (*WriteQueue, error) {
+	if cfg.Dir == "" {
+		return nil, reportError("`Dir` is required to create a write queue.")
+	}
+
+	err := os.MkdirAll(cfg.Dir, 0o700)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create the write queue directory: %w", err)
+	}
+
+	q := &WriteQueue{client: client, cfg: cfg, stop: make(chan struct{}), done: make(chan struct{})}
+
+	err = q.load()
+	if err != nil {
+		return nil, err
+	}
+
+	interval := cfg.ReplayInterval
+	if interval == 0 {
+		interval = DefaultReplayInterval
+	}
+
+	if interval < 0 {
+		close(q.done)
+
+		return q, nil
+	}
+
+	go q.replayEvery(interval)
+
+	return q, nil
+}
+
+// Close stops the background replays. The queued operations stay on disk, to be loaded by the next queue.
+func (q *WriteQueue) Close() error {
+	select {
+	case <-q.stop:
+	default:
+		close(q.stop)
+	}
+
+	<-q.done
+
+	return nil
+}
+
+// Pending returns the queued operations, in order.
+func (q *WriteQueue) Pending() []QueuedWrite {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return append([]QueuedWrite(nil), q.pending...)
+}
+
+/*
+Batch sends a `batch` request like APIClient.Batch, or queues it when the hosts can't be reached.
+
+	@param r ApiBatchRequest - Body of the `batch` operation.
+	@return *BatchResponse - The response, nil when queued.
+	@return error - Error if any, matching ErrWriteQueued when queued.
+*/
+func (q *WriteQueue) Batch(r ApiBatchRequest) (*BatchResponse, error) {
+	var res *BatchResponse
+
+	err := q.write(QueuedWrite{Operation: QueuedWriteBatch, IndexName: r.indexName}, r.batchWriteParams, func() (err error) {
+		res, err = q.client.Batch(r)
+
+		return err
+	})
+
+	return res, err
+}
+
+/*
+PartialUpdateObject sends a `partialUpdateObject` request like APIClient.PartialUpdateObject, or queues it when the hosts can't be reached.
+
+	@param r ApiPartialUpdateObjectRequest - Body of the `partialUpdateObject` operation.
+	@return *UpdatedAtWithObjectIdResponse - The response, nil when queued.
+	@return error - Error if any, matching ErrWriteQueued when queued.
+*/
+func (q *WriteQueue) PartialUpdateObject(r ApiPartialUpdateObjectRequest) (*UpdatedAtWithObjectIdResponse, error) {
+	var res *UpdatedAtWithObjectIdResponse
+
+	op := QueuedWrite{Operation: QueuedWritePartialUpdateObject, IndexName: r.indexName, ObjectID: r.objectID, CreateIfNotExists: r.createIfNotExists}
+
+	err := q.write(op, r.attributesToUpdate, func() (err error) {
+		res, err = q.client.PartialUpdateObject(r)
+
+		return err
+	})
+
+	return res, err
+}
+
+/*
+DeleteObject sends a `deleteObject` request like APIClient.DeleteObject, or queues it when the hosts can't be reached.
+
+	@param r ApiDeleteObjectRequest - Body of the `deleteObject` operation.
+	@return *DeletedAtResponse - The response, nil when queued.
+	@return error - Error if any, matching ErrWriteQueued when queued.
+*/
+func (q *WriteQueue) DeleteObject(r ApiDeleteObjectRequest) (*DeletedAtResponse, error) {
+	var res *DeletedAtResponse
+
+	err := q.write(QueuedWrite{Operation: QueuedWriteDeleteObject, IndexName: r.indexName, ObjectID: r.objectID}, nil, func() (err error) {
+		res, err = q.client.DeleteObject(r)
+
+		return err
+	})
+
+	return res, err
+}
+
+/*
+Replay sends the queued operations in order, and stops at the first one that still can't be sent because of the network or the server, whose error is returned.
+The operations rejected by the server, or that can't be decoded or sent for another reason, are discarded so that they don't block the queue, see WriteQueueConfig.OnDiscard.
+
+	@return error - Error if any.
+*/
+func (q *WriteQueue) Replay() error {
+	q.sendMu.Lock()
+	defer q.sendMu.Unlock()
+
+	for {
+		q.mu.Lock()
+		if len(q.pending) == 0 {
+			q.mu.Unlock()
+
+			return nil
+		}
+
+		op := q.pending[0]
+		q.mu.Unlock()
+
+		err := q.send(op)
+		if err != nil && isRetryableBatchError(err) {
+			return err
+		}
+
+		if err != nil {
+			err = q.discard(op, err)
+		} else {
+			err = os.Remove(q.path(op))
+		}
+
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("cannot remove queued operation %d: %w", op.Sequence, err)
+		}
+
+		q.mu.Lock()
+		q.pending = q.pending[1:]
+		q.mu.Unlock()
+	}
+}
+
+// write sends an operation, unless operations are already queued, and queues it when it can't be sent because of the
+// network or the server. Invalid operations and the other errors are returned without queuing.
+func (q *WriteQueue) write(op QueuedWrite, body any, send func() error) error {
+	err := op.validate(body)
+	if err != nil {
+		return err
+	}
+
+	cause := errors.New("earlier operations are queued")
+
+	// The operations queued behind others are queued at once, without
+	// waiting for the send in progress.
+	q.mu.Lock()
+	queued := len(q.pending) > 0
+	q.mu.Unlock()
+
+	if queued {
+		return q.enqueue(op, body, cause)
+	}
+
+	q.sendMu.Lock()
+	defer q.sendMu.Unlock()
+
+	q.mu.Lock()
+	queued = len(q.pending) > 0
+	q.mu.Unlock()
+
+	if !queued {
+		err := send()
+		if err == nil || !isRetryableBatchError(err) {
+			return err
+		}
+
+		cause = err
+	}
+
+	return q.enqueue(op, body, cause)
+}
+
+// validate checks the parameters of an operation before it is sent or queued, so that an operation that could never
+// be sent doesn't wait in the queue.
+func (op QueuedWrite) validate(body any) error {
+	if op.IndexName == "" {
+		return reportError("Parameter `indexName` is required when calling `%s`.", op.Operation)
+	}
+
+	if op.Operation != QueuedWriteBatch && op.ObjectID == "" {
+		return reportError("Parameter `objectID` is required when calling `%s`.", op.Operation)
+	}
+
+	if op.Operation != QueuedWriteDeleteObject && isNilBody(body) {
+		return reportError("The body of `%s` is required.", op.Operation)
+	}
+
+	return nil
+}
+
+// isNilBody reports whether the body of an operation is missing, including typed nil pointers and maps.
+func isNilBody(body any) bool {
+	switch b := body.(type) {
+	case nil:
+		return true
+	case *BatchWriteParams:
+		return b == nil
+	case map[string]any:
+		return b == nil
+	default:
+		return false
+	}
+}
+
+// enqueue persists an operation after the queued ones.
+func (q *WriteQueue) enqueue(op QueuedWrite, body any, cause error) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if body != nil {
+		raw, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("cannot encode the operation to queue: %w", err)
+		}
+
+		op.Body = raw
+	}
+
+	op.Sequence = q.next
+	op.QueuedAt = q.client.clock().Now().UTC()
+
+	err := q.persist(op)
+	if err != nil {
+		return fmt.Errorf("cannot queue the operation after %w: %w", cause, err)
+	}
+
+	q.next++
+	q.pending = append(q.pending, op)
+
+	return fmt.Errorf("%w: %w", ErrWriteQueued, cause)
+}
+
+// send sends a queued operation.
+func (q *WriteQueue) send(op QueuedWrite) error {
+	var err error
+
+	switch op.Operation {
+	case QueuedWriteBatch:
+		var params BatchWriteParams
+
+		err = json.Unmarshal(op.Body, &params)
+		if err == nil {
+			_, err = q.client.Batch(q.client.NewApiBatchRequest(op.IndexName, &params))
+		}
+	case QueuedWritePartialUpdateObject:
+		var attributes map[string]any
+
+		err = json.Unmarshal(op.Body, &attributes)
+		if err == nil {
+			r := q.client.NewApiPartialUpdateObjectRequest(op.IndexName, op.ObjectID, attributes)
+			r.createIfNotExists = op.CreateIfNotExists
+			_, err = q.client.PartialUpdateObject(r)
+		}
+	case QueuedWriteDeleteObject:
+		_, err = q.client.DeleteObject(q.client.NewApiDeleteObjectRequest(op.IndexName, op.ObjectID))
+	default:
+		return reportError("unknown queued operation %q", op.Operation)
+	}
+
+	if err != nil {
+		return fmt.Errorf("cannot replay queued operation %d: %w", op.Sequence, err)
+	}
+
+	return nil
+}
+
+// discard moves an operation rejected by the server to the `failed` subdirectory.
+func (q *WriteQueue) discard(op QueuedWrite, cause error) error {
+	failedDir := filepath.Join(q.cfg.Dir, "failed")
+
+	err := os.MkdirAll(failedDir, 0o700)
+	if err == nil {
+		err = os.Rename(q.path(op), filepath.Join(failedDir, filepath.Base(q.path(op))))
+	}
+
+	if q.cfg.OnDiscard != nil {
+		q.cfg.OnDiscard(op, cause)
+	}
+
+	return err
+}
+
+// discardFile moves the file of an operation that can't be decoded to the `failed` subdirectory, keeping its sequence.
+func (q *WriteQueue) discardFile(name string, cause error) error {
+	failedDir := filepath.Join(q.cfg.Dir, "failed")
+
+	err := os.MkdirAll(failedDir, 0o700)
+	if err == nil {
+		err = os.Rename(filepath.Join(q.cfg.Dir, name), filepath.Join(failedDir, name))
+	}
+
+	if err != nil {
+		return fmt.Errorf("cannot discard queued operation %s: %w", name, err)
+	}
+
+	if q.cfg.OnDiscard != nil {
+		sequence, _ := strconv.ParseUint(strings.TrimSuffix(name, ".json"), 10, 64)
+		q.cfg.OnDiscard(QueuedWrite{Sequence: sequence}, cause)
+	}
+
+	return nil
+}
+
+func (q *WriteQueue) replayEvery(interval time.Duration) {
+	defer close(q.done)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		select {
+		case <-q.stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	for q.client.clock().Sleep(ctx, interval) == nil {
+		_ = q.Replay()
+	}
+}
+
+func (q *WriteQueue) path(op QueuedWrite) string {
+	return filepath.Join(q.cfg.Dir, fmt.Sprintf("%020d.json", op.Sequence))
+}
+
+// persist writes an operation to its file, atomically.
+func (q *WriteQueue) persist(op QueuedWrite) error {
+	raw, err := json.Marshal(op)
+	if err != nil {
+		return fmt.Errorf("cannot encode queued operation: %w", err)
+	}
+
+	tmp := q.path(op) + ".tmp"
+
+	err = os.WriteFile(tmp, raw, 0o600)
+	if err != nil {
+		return fmt.Errorf("cannot write queued operation: %w", err)
+	}
+
+	err = os.Rename(tmp, q.path(op))
+	if err != nil {
+		return fmt.Errorf("cannot write queued operation: %w", err)
+	}
+
+	return nil
+}
+
+// load reads the operations queued in the directory, in order.
+func (q *WriteQueue) load() error {
+	entries, err := os.ReadDir(q.cfg.Dir)
+	if err != nil {
+		return fmt.Errorf("cannot read the write queue directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+
+		raw, err := os.ReadFile(filepath.Join(q.cfg.Dir, entry.Name()))
+		if err != nil {
+			return fmt.Errorf("cannot read queued operation: %w", err)
+		}
+
+		var op QueuedWrite
+
+		err = json.Unmarshal(raw, &op)
+		if err != nil {
+			err = q.discardFile(entry.Name(), fmt.Errorf("cannot decode queued operation %s: %w", entry.Name(), err))
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		q.pending = append(q.pending, op)
+	}
+
+	sort.Slice(q.pending, func(i, j int) bool { return q.pending[i].Sequence < q.pending[j].Sequence })
+
+	if len(q.pending) > 0 {
+		q.next = q.pending[len(q.pending)-1].Sequence + 1
+	}
+
+	// The discarded operations keep their sequence in the `failed`
+	// subdirectory, which must not be reused.
+	failed, err := os.ReadDir(filepath.Join(q.cfg.Dir, "failed"))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("cannot read the failed operations directory: %w", err)
+	}
+
+	for _, entry := range failed {
+		name, ok := strings.CutSuffix(entry.Name(), ".json")
+		if !ok {
+			continue
+		}
+
+		sequence, err := strconv.ParseUint(name, 10, 64)
+		if err == nil && sequence >= q.next {
+			q.next = sequence + 1
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,439 @@
+package search_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/call"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/flapjacktest"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+// writeQueueServer answers with a 503 while down is set, and records the calls it answered successfully.
+func writeQueueServer(t *testing.T, down *atomic.Bool) (*search.APIClient, func() []string) {
+	t.Helper()
+
+	var (
+		mu    sync.Mutex
+		calls []string
+	)
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if down.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"message":"Service unavailable"}`))
+
+			return
+		}
+
+		if r.URL.Path == "/1/indexes/products/invalid/partial" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"Invalid attribute"}`))
+
+			return
+		}
+
+		mu.Lock()
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+
+		_, _ = w.Write([]byte(`{"taskID":1,"objectIDs":["1"],"objectID":"1","updatedAt":"2024-01-01T00:00:00Z","deletedAt":"2024-01-01T00:00:00Z"}`))
+	})
+
+	return client, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return append([]string(nil), calls...)
+	}
+}
+
+func TestWriteQueuePersistsAndReplaysWrites(t *testing.T) {
+	t.Parallel()
+
+	var down atomic.Bool
+
+	down.Store(true)
+
+	client, calls := writeQueueServer(t, &down)
+	dir := filepath.Join(t.TempDir(), "queue")
+
+	q, err := search.NewWriteQueue(client, search.WriteQueueConfig{Dir: dir, ReplayInterval: -1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = q.Batch(client.NewApiBatchRequest("products", search.NewBatchWriteParams([]search.BatchRequest{
+		*search.NewBatchRequest(search.ACTION_ADD_OBJECT, map[string]any{"objectID": "1"}),
+	})))
+	if !errors.Is(err, search.ErrWriteQueued) {
+		t.Fatalf("expected the batch to be queued, got %v", err)
+	}
+
+	// Queued behind the batch, without being sent, even though the server is back.
+	down.Store(false)
+
+	_, err = q.DeleteObject(client.NewApiDeleteObjectRequest("products", "2"))
+	if !errors.Is(err, search.ErrWriteQueued) {
+		t.Fatalf("expected the deletion to be queued, got %v", err)
+	}
+
+	if len(calls()) != 0 {
+		t.Fatalf("expected no successful call, got %v", calls())
+	}
+
+	_ = q.Close()
+
+	// A new queue loads the operations persisted by the previous one.
+	q, err = search.NewWriteQueue(client, search.WriteQueueConfig{Dir: dir, ReplayInterval: -1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Cleanup(func() { _ = q.Close() })
+
+	if pending := q.Pending(); len(pending) != 2 || pending[0].Operation != search.QueuedWriteBatch || pending[1].Operation != search.QueuedWriteDeleteObject {
+		t.Fatalf("unexpected pending operations %+v", pending)
+	}
+
+	err = q.Replay()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := calls()
+	if len(got) != 2 || got[0] != "POST /1/indexes/products/batch" || got[1] != "DELETE /1/indexes/products/2" {
+		t.Errorf("expected the operations to be replayed in order, got %v", got)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(q.Pending()) != 0 || len(files) != 0 {
+		t.Errorf("expected the queue to be empty, got %+v and %v", q.Pending(), files)
+	}
+
+	// Once empty, the operations are sent right away.
+	_, err = q.DeleteObject(client.NewApiDeleteObjectRequest("products", "3"))
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestWriteQueueDiscardsRejectedWrites(t *testing.T) {
+	t.Parallel()
+
+	var down atomic.Bool
+
+	down.Store(true)
+
+	client, calls := writeQueueServer(t, &down)
+	dir := t.TempDir()
+
+	var discarded []search.QueuedWrite
+
+	q, err := search.NewWriteQueue(client, search.WriteQueueConfig{
+		Dir:            dir,
+		ReplayInterval: -1,
+		OnDiscard: func(op search.QueuedWrite, err error) {
+			var apiErr *transport.APIError
+			if !errors.As(err, &apiErr) || apiErr.Status != http.StatusBadRequest {
+				t.Errorf("unexpected discard error %v", err)
+			}
+
+			discarded = append(discarded, op)
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Cleanup(func() { _ = q.Close() })
+
+	for _, objectID := range []string{"invalid", "1"} {
+		_, err = q.PartialUpdateObject(client.NewApiPartialUpdateObjectRequest("products", objectID, map[string]any{"stock": 1}).WithCreateIfNotExists(false))
+		if !errors.Is(err, search.ErrWriteQueued) {
+			t.Fatalf("expected the update to be queued, got %v", err)
+		}
+	}
+
+	down.Store(false)
+
+	err = q.Replay()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(discarded) != 1 || discarded[0].ObjectID != "invalid" {
+		t.Errorf("expected the invalid update to be discarded, got %+v", discarded)
+	}
+
+	if got := calls(); len(got) != 1 || got[0] != "POST /1/indexes/products/1/partial" {
+		t.Errorf("expected the valid update to be replayed, got %v", got)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "failed", "00000000000000000000.json")); err != nil {
+		t.Errorf("expected the discarded operation to be kept: %v", err)
+	}
+
+	// Operations rejected right away are not queued.
+	_, err = q.PartialUpdateObject(client.NewApiPartialUpdateObjectRequest("products", "invalid", map[string]any{"stock": 1}))
+	if err == nil || errors.Is(err, search.ErrWriteQueued) {
+		t.Errorf("expected the rejection to be returned, got %v", err)
+	}
+}
+
+func TestWriteQueueKeepsDiscardedSequences(t *testing.T) {
+	t.Parallel()
+
+	var down atomic.Bool
+
+	client, _ := writeQueueServer(t, &down)
+	dir := t.TempDir()
+
+	for _, want := range []string{"00000000000000000000.json", "00000000000000000001.json"} {
+		down.Store(true)
+
+		q, err := search.NewWriteQueue(client, search.WriteQueueConfig{Dir: dir, ReplayInterval: -1})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		_, err = q.PartialUpdateObject(client.NewApiPartialUpdateObjectRequest("products", "invalid", map[string]any{"stock": 1}))
+		if !errors.Is(err, search.ErrWriteQueued) {
+			t.Fatalf("expected the update to be queued, got %v", err)
+		}
+
+		down.Store(false)
+
+		err = q.Replay()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		_ = q.Close()
+
+		if _, err := os.Stat(filepath.Join(dir, "failed", want)); err != nil {
+			t.Errorf("expected the discarded operation %s to be kept: %v", want, err)
+		}
+	}
+
+	if files, _ := filepath.Glob(filepath.Join(dir, "failed", "*.json")); len(files) != 2 {
+		t.Errorf("expected both discarded operations to be kept, got %v", files)
+	}
+}
+
+func TestWriteQueueReturnsInvalidWrites(t *testing.T) {
+	t.Parallel()
+
+	var down atomic.Bool
+
+	down.Store(true)
+
+	client, calls := writeQueueServer(t, &down)
+
+	q, err := search.NewWriteQueue(client, search.WriteQueueConfig{Dir: t.TempDir(), ReplayInterval: -1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Cleanup(func() { _ = q.Close() })
+
+	_, err = q.DeleteObject(client.NewApiDeleteObjectRequest("products", "1"))
+	if !errors.Is(err, search.ErrWriteQueued) {
+		t.Fatalf("expected the deletion to be queued, got %v", err)
+	}
+
+	// Invalid operations fail at once, even behind queued ones.
+	for name, write := range map[string]func() error{
+		"no index name": func() error {
+			_, err := q.Batch(client.NewApiBatchRequest("", search.NewBatchWriteParams(nil)))
+
+			return err
+		},
+		"no batch params": func() error {
+			_, err := q.Batch(client.NewApiBatchRequest("products", nil))
+
+			return err
+		},
+		"no objectID": func() error {
+			_, err := q.PartialUpdateObject(client.NewApiPartialUpdateObjectRequest("products", "", map[string]any{"stock": 1}))
+
+			return err
+		},
+	} {
+		err := write()
+		if err == nil || errors.Is(err, search.ErrWriteQueued) {
+			t.Errorf("%s: expected the error to be returned without queuing, got %v", name, err)
+		}
+	}
+
+	down.Store(false)
+
+	err = q.Replay()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := calls(); len(got) != 1 || got[0] != "DELETE /1/indexes/products/1" || len(q.Pending()) != 0 {
+		t.Errorf("expected only the valid deletion to be replayed, got %v and %+v", got, q.Pending())
+	}
+}
+
+func TestWriteQueueDiscardsUndecodableWrites(t *testing.T) {
+	t.Parallel()
+
+	var down atomic.Bool
+
+	client, calls := writeQueueServer(t, &down)
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"00000000000000000000.json": `not json`,
+		"00000000000000000001.json": `{"sequence":1,"operation":"batch","indexName":"products","body":"not params"}`,
+		"00000000000000000002.json": `{"sequence":2,"operation":"deleteObject","indexName":"products","objectID":"2"}`,
+	}
+
+	for name, content := range files {
+		err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	var discarded []uint64
+
+	q, err := search.NewWriteQueue(client, search.WriteQueueConfig{
+		Dir:            dir,
+		ReplayInterval: -1,
+		OnDiscard:      func(op search.QueuedWrite, _ error) { discarded = append(discarded, op.Sequence) },
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Cleanup(func() { _ = q.Close() })
+
+	err = q.Replay()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(discarded) != 2 || discarded[0] != 0 || discarded[1] != 1 {
+		t.Errorf("expected the undecodable operations to be discarded, got %v", discarded)
+	}
+
+	if got := calls(); len(got) != 1 || got[0] != "DELETE /1/indexes/products/2" {
+		t.Errorf("expected the operation behind them to be replayed, got %v", got)
+	}
+
+	if failed, _ := filepath.Glob(filepath.Join(dir, "failed", "*.json")); len(failed) != 2 {
+		t.Errorf("expected the undecodable operations to be kept, got %v", failed)
+	}
+}
+
+func TestWriteQueueDoesNotBlockWhileSending(t *testing.T) {
+	t.Parallel()
+
+	var down atomic.Bool
+
+	down.Store(true)
+
+	received := make(chan struct{})
+	release := make(chan struct{})
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if down.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		received <- struct{}{}
+		<-release
+
+		_, _ = w.Write([]byte(`{"taskID":1,"deletedAt":"2024-01-01T00:00:00Z"}`))
+	})
+
+	q, err := search.NewWriteQueue(client, search.WriteQueueConfig{Dir: t.TempDir(), ReplayInterval: -1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Cleanup(func() { _ = q.Close() })
+
+	_, err = q.DeleteObject(client.NewApiDeleteObjectRequest("products", "1"))
+	if !errors.Is(err, search.ErrWriteQueued) {
+		t.Fatalf("expected the deletion to be queued, got %v", err)
+	}
+
+	down.Store(false)
+
+	replayed := make(chan error, 1)
+
+	go func() {
+		replayed <- q.Replay()
+	}()
+
+	<-received
+
+	// While the replay waits for the server, the queue can be read and written.
+	if pending := q.Pending(); len(pending) != 1 {
+		t.Errorf("unexpected pending operations %+v", pending)
+	}
+
+	_, err = q.DeleteObject(client.NewApiDeleteObjectRequest("products", "2"))
+	if !errors.Is(err, search.ErrWriteQueued) {
+		t.Errorf("expected the deletion to be queued behind the replayed one, got %v", err)
+	}
+
+	close(release)
+
+	<-received
+
+	if err := <-replayed; err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if pending := q.Pending(); len(pending) != 0 {
+		t.Errorf("expected the queue to be empty, got %+v", pending)
+	}
+}
+
+func TestWriteQueueDatesOperationsWithClock(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(srv.Close)
+
+	clock := flapjacktest.NewClock(time.Time{})
+
+	client, err := search.NewClient("test-app", "test-api-key",
+		transport.WithHosts(transport.NewStatefulHost("http", strings.TrimPrefix(srv.URL, "http://"), call.IsReadWrite)),
+		transport.WithClock(clock),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	q, err := search.NewWriteQueue(client, search.WriteQueueConfig{Dir: t.TempDir(), ReplayInterval: -1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Cleanup(func() { _ = q.Close() })
+
+	_, err = q.DeleteObject(client.NewApiDeleteObjectRequest("products", "1"))
+	if !errors.Is(err, search.ErrWriteQueued) {
+		t.Fatalf("expected the deletion to be queued, got %v", err)
+	}
+
+	if pending := q.Pending(); len(pending) != 1 || !pending[0].QueuedAt.Equal(clock.Now()) {
+		t.Errorf("expected the operation to be queued at %s, got %+v", clock.Now(), pending)
+	}
+}
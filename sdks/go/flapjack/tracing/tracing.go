@@ -0,0 +1,135 @@
+// Package tracing instruments the HTTP requests of the API clients, creating a span for every attempt.
+//
+// It doesn't depend on any tracing library: Tracer is a small interface that an OpenTelemetry tracer can
+// implement with a few lines of code.
+//
+//	type otelTracer struct{ tracer trace.Tracer }
+//
+//	func (t otelTracer) Start(ctx context.Context, name string) (context.Context, tracing.Span) {
+//		ctx, span := t.tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient))
+//		otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(tracing.RequestFromContext(ctx).Header))
+//
+//		return ctx, otelSpan{span}
+//	}
+//
+//	type otelSpan struct{ span trace.Span }
+//
+//	func (s otelSpan) SetAttributes(attrs ...tracing.Attribute) {
+//		for _, attr := range attrs {
+//			s.span.SetAttributes(attribute.String(attr.Key, fmt.Sprint(attr.Value)))
+//		}
+//	}
+//
+//	func (s otelSpan) RecordError(err error) {
+//		s.span.RecordError(err)
+//		s.span.SetStatus(codes.Error, err.Error())
+//	}
+//
+//	func (s otelSpan) End() { s.span.End() }
+//
+// The instrumented requester is then set on the client configuration:
+//
+//	cfg.Requester = tracing.NewRequester(transport.NewDefaultRequester(nil), otelTracer{otel.Tracer("flapjack")})
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+// Attribute keys set on the spans, following the OpenTelemetry semantic conventions for HTTP clients.
+const (
+	AttributeOperationName  = "flapjack.operation.name"
+	AttributeRequestMethod  = "http.request.method"
+	AttributeResendCount    = "http.request.resend_count"
+	AttributeResponseStatus = "http.response.status_code"
+	AttributeServerAddress  = "server.address"
+	AttributeURLPath        = "url.path"
+)
+
+// Attribute is a key-value pair attached to a span.
+type Attribute struct {
+	Key   string
+	Value any
+}
+
+// Span is a single traced HTTP attempt.
+type Span interface {
+	SetAttributes(attrs ...Attribute)
+	RecordError(err error)
+	End()
+}
+
+// Tracer starts the span of an HTTP attempt. The request being sent is available through RequestFromContext,
+// for instance to inject propagation headers.
+type Tracer interface {
+	Start(ctx context.Context, spanName string) (context.Context, Span)
+}
+
+type requestKey struct{}
+
+// RequestFromContext returns the request being traced, from the context given to Tracer.Start.
+func RequestFromContext(ctx context.Context) *http.Request {
+	req, _ := ctx.Value(requestKey{}).(*http.Request)
+
+	return req
+}
+
+type requester struct {
+	next   transport.Requester
+	tracer Tracer
+}
+
+// NewRequester wraps the given requester so that every HTTP attempt is traced with the given tracer.
+func NewRequester(next transport.Requester, tracer Tracer) transport.Requester {
+	if next == nil {
+		next = transport.NewDefaultRequester(nil)
+	}
+
+	return &requester{
+		next:   next,
+		tracer: tracer,
+	}
+}
+
+func (r *requester) Request(req *http.Request, timeout time.Duration, connectTimeout time.Duration) (*http.Response, error) {
+	operationName, ok := transport.OperationNameFromContext(req.Context())
+	if !ok {
+		operationName = req.Method
+	}
+
+	ctx, span := r.tracer.Start(context.WithValue(req.Context(), requestKey{}, req), "flapjack "+operationName)
+	defer span.End()
+
+	attrs := []Attribute{
+		{Key: AttributeOperationName, Value: operationName},
+		{Key: AttributeRequestMethod, Value: req.Method},
+		{Key: AttributeServerAddress, Value: req.URL.Host},
+		{Key: AttributeURLPath, Value: req.URL.Path},
+	}
+
+	if attempt := transport.AttemptFromContext(req.Context()); attempt > 0 {
+		attrs = append(attrs, Attribute{Key: AttributeResendCount, Value: attempt})
+	}
+
+	span.SetAttributes(attrs...)
+
+	res, err := r.next.Request(req.WithContext(ctx), timeout, connectTimeout)
+	if err != nil {
+		span.RecordError(err)
+
+		return res, err
+	}
+
+	span.SetAttributes(Attribute{Key: AttributeResponseStatus, Value: res.StatusCode})
+
+	if res.StatusCode >= http.StatusBadRequest {
+		span.RecordError(errors.New(http.StatusText(res.StatusCode)))
+	}
+
+	return res, nil
+}
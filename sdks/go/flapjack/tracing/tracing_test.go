@@ -0,0 +1,119 @@
+package tracing_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/call"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/search"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/tracing"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+type recordedSpan struct {
+	name  string
+	attrs map[string]any
+	err   error
+	ended bool
+}
+
+func (s *recordedSpan) SetAttributes(attrs ...tracing.Attribute) {
+	for _, attr := range attrs {
+		s.attrs[attr.Key] = attr.Value
+	}
+}
+
+func (s *recordedSpan) RecordError(err error) { s.err = err }
+func (s *recordedSpan) End()                  { s.ended = true }
+
+type recordingTracer struct {
+	mu    sync.Mutex
+	spans []*recordedSpan
+}
+
+func (t *recordingTracer) Start(ctx context.Context, name string) (context.Context, tracing.Span) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	tracing.RequestFromContext(ctx).Header.Set("traceparent", "00-test")
+
+	span := &recordedSpan{name: name, attrs: map[string]any{}}
+	t.spans = append(t.spans, span)
+
+	return ctx, span
+}
+
+func TestRequesterTracesEveryAttempt(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("traceparent") != "00-test" {
+			t.Errorf("expected the propagation header to be sent")
+		}
+
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"hits":[],"nbHits":0}`))
+	}))
+	defer srv.Close()
+
+	tracer := &recordingTracer{}
+
+	client, err := search.NewClientWithConfig(search.SearchConfiguration{
+		Configuration: transport.Configuration{
+			AppID:  "test-app",
+			ApiKey: "test-api-key",
+			Hosts: []transport.StatefulHost{
+				transport.NewStatefulHost("http", strings.TrimPrefix(srv.URL, "http://"), call.IsReadWrite),
+			},
+			DefaultHeader:   make(map[string]string),
+			Requester:       tracing.NewRequester(nil, tracer),
+			ReadRetryPolicy: &transport.RetryPolicy{MaxAttempts: 2},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	_, err = client.SearchSingleIndex(client.NewApiSearchSingleIndexRequest("products"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(tracer.spans) != 2 {
+		t.Fatalf("expected a span per attempt, got %d", len(tracer.spans))
+	}
+
+	first, second := tracer.spans[0], tracer.spans[1]
+
+	if first.name != "flapjack searchSingleIndex" || first.attrs[tracing.AttributeOperationName] != "searchSingleIndex" {
+		t.Errorf("unexpected span %q with attributes %v", first.name, first.attrs)
+	}
+
+	if first.attrs[tracing.AttributeResponseStatus] != http.StatusServiceUnavailable || first.err == nil || !first.ended {
+		t.Errorf("expected the first attempt to be recorded as failed, got %+v", first)
+	}
+
+	if _, ok := first.attrs[tracing.AttributeResendCount]; ok {
+		t.Errorf("expected no resend count on the first attempt")
+	}
+
+	if second.attrs[tracing.AttributeResendCount] != 1 || second.attrs[tracing.AttributeResponseStatus] != http.StatusOK || second.err != nil {
+		t.Errorf("unexpected second attempt %+v", second)
+	}
+
+	if second.attrs[tracing.AttributeServerAddress] != strings.TrimPrefix(srv.URL, "http://") || second.attrs[tracing.AttributeURLPath] != "/1/indexes/products/query" {
+		t.Errorf("unexpected request attributes %v", second.attrs)
+	}
+}
@@ -0,0 +1,165 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/errs"
+)
+
+// RequestIDHeader is the default header identifying a request in the server logs, see Configuration.RequestIDHeader.
+const RequestIDHeader = "X-Request-Id"
+
+// Sentinel errors matched by errors.Is against an APIError of the corresponding status.
+var (
+	ErrNotFound    = errs.ErrNotFound
+	ErrForbidden   = errs.ErrForbidden
+	ErrRateLimited = errs.ErrRateLimited
+)
+
+// APIError is returned by the API clients for every response with a non-2xx status.
+//
+//	var apiErr *transport.APIError
+//	if errors.As(err, &apiErr) {
+//		log.Printf("request %s failed with %d on %v", apiErr.RequestID, apiErr.Status, apiErr.Hosts)
+//	}
+//
+//	if errors.Is(err, transport.ErrNotFound) {
+//		// ...
+//	}
+type APIError struct {
+	Message string `json:"message"`
+	Status  int    `json:"status"`
+	// RequestID identifies the request in the server logs. When the server didn't send it, it is the request ID sent by
+	// the client, if any.
+	RequestID string `json:"-"`
+	// Hosts are the hosts attempted for the call, one per attempt, the last one having sent the response.
+	Hosts                []string       `json:"-"`
+	AdditionalProperties map[string]any `json:"-"`
+}
+
+// NewAPIError creates the error of a response with a non-2xx status from its already read body.
+func NewAPIError(res *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		Message:   string(body), // default to the full body if we cannot guess the type of the error.
+		Status:    res.StatusCode,
+		RequestID: res.Header.Get(requestIDHeaderOf(res)),
+	}
+
+	if res.Request != nil {
+		apiErr.Hosts = attemptedHostsFromContext(res.Request.Context())
+	}
+
+	if strings.Contains(res.Header.Get("Content-Type"), "application/json") {
+		var errBase map[string]any
+
+		err := json.Unmarshal(body, &errBase)
+		if err != nil {
+			apiErr.Message = fmt.Sprintf("failed to unmarshal response body: %v", err)
+
+			return apiErr.withSentRequestID(res)
+		}
+
+		if message, ok := errBase["message"].(string); ok {
+			apiErr.Message = message
+		}
+
+		if requestID, ok := errBase["request_id"].(string); ok && apiErr.RequestID == "" {
+			apiErr.RequestID = requestID
+		}
+
+		delete(errBase, "message")
+		apiErr.AdditionalProperties = errBase
+	} else if strings.Contains(res.Header.Get("Content-Type"), "text/html") {
+		apiErr.Message = http.StatusText(res.StatusCode)
+	}
+
+	return apiErr.withSentRequestID(res)
+}
+
+// withSentRequestID falls back to the request ID sent by the client, if any, when the server didn't return one.
+func (e *APIError) withSentRequestID(res *http.Response) *APIError {
+	if e.RequestID == "" && res.Request != nil {
+		e.RequestID, _ = RequestIDFromContext(res.Request.Context())
+	}
+
+	return e
+}
+
+func (e APIError) Error() string {
+	return fmt.Sprintf("API error [%d] %s", e.Status, e.Message)
+}
+
+func (o APIError) MarshalJSON() ([]byte, error) {
+	toSerialize := map[string]any{
+		"message": o.Message,
+	}
+
+	for key, value := range o.AdditionalProperties {
+		toSerialize[key] = value
+	}
+
+	serialized, err := json.Marshal(toSerialize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal APIError: %w", err)
+	}
+
+	return serialized, nil
+}
+
+func (o *APIError) UnmarshalJSON(bytes []byte) error {
+	type _APIError APIError
+
+	apiErr := _APIError{}
+
+	err := json.Unmarshal(bytes, &apiErr)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal APIError: %w", err)
+	}
+
+	*o = APIError(apiErr)
+
+	additionalProperties := make(map[string]any)
+
+	err = json.Unmarshal(bytes, &additionalProperties)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal additionalProperties in APIError: %w", err)
+	}
+
+	delete(additionalProperties, "message")
+	o.AdditionalProperties = additionalProperties
+
+	return nil
+}
+
+// Is reports whether the error is an APIError, or matches one of the ErrNotFound, ErrForbidden and ErrRateLimited
+// sentinels.
+func (e APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.Status == http.StatusNotFound
+	case ErrForbidden:
+		return e.Status == http.StatusForbidden
+	case ErrRateLimited:
+		return e.Status == http.StatusTooManyRequests
+	}
+
+	_, ok := target.(*APIError)
+
+	return ok
+}
+
+type attemptedHostsKey struct{}
+
+func withAttemptedHosts(ctx context.Context, hosts []string) context.Context {
+	return context.WithValue(ctx, attemptedHostsKey{}, hosts)
+}
+
+func attemptedHostsFromContext(ctx context.Context) []string {
+	hosts, _ := ctx.Value(attemptedHostsKey{}).([]string)
+
+	return hosts
+}
@@ -0,0 +1,98 @@
+package transport_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/call"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/errs"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+func TestNewAPIError(t *testing.T) {
+	t.Parallel()
+
+	var down, up *httptest.Server
+
+	down = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(down.Close)
+
+	up = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set(transport.RequestIDHeader, "req-42")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Index products does not exist","error":"index_not_found"}`))
+	}))
+	t.Cleanup(up.Close)
+
+	tr := newTransport(transport.Configuration{}, down, up)
+
+	res, body, err := tr.Request(context.Background(), newRequest(t), call.Read, transport.RequestConfiguration{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	apiErr := transport.NewAPIError(res, body)
+
+	if apiErr.Status != http.StatusNotFound || apiErr.Message != "Index products does not exist" || apiErr.RequestID != "req-42" {
+		t.Errorf("unexpected error %+v", apiErr)
+	}
+
+	if apiErr.AdditionalProperties["error"] != "index_not_found" {
+		t.Errorf("unexpected additional properties %v", apiErr.AdditionalProperties)
+	}
+
+	wantHosts := []string{strings.TrimPrefix(down.URL, "http://"), strings.TrimPrefix(up.URL, "http://")}
+	if strings.Join(apiErr.Hosts, ",") != strings.Join(wantHosts, ",") {
+		t.Errorf("expected hosts %v, got %v", wantHosts, apiErr.Hosts)
+	}
+}
+
+func TestAPIErrorSentinels(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		status int
+		want   error
+		others []error
+	}{
+		{status: http.StatusNotFound, want: transport.ErrNotFound, others: []error{transport.ErrForbidden, transport.ErrRateLimited}},
+		{status: http.StatusForbidden, want: transport.ErrForbidden, others: []error{transport.ErrNotFound, transport.ErrRateLimited}},
+		{status: http.StatusTooManyRequests, want: transport.ErrRateLimited, others: []error{transport.ErrNotFound, transport.ErrForbidden}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			t.Parallel()
+
+			err := error(&transport.APIError{Status: tt.status})
+			wrapped := errors.Join(errors.New("failed"), err)
+
+			if !errors.Is(wrapped, tt.want) {
+				t.Errorf("expected %v to match %v", wrapped, tt.want)
+			}
+
+			for _, other := range tt.others {
+				if errors.Is(wrapped, other) {
+					t.Errorf("expected %v not to match %v", wrapped, other)
+				}
+			}
+
+			var apiErr *transport.APIError
+			if !errors.As(wrapped, &apiErr) || apiErr.Status != tt.status {
+				t.Errorf("expected %v to unwrap to the API error", wrapped)
+			}
+		})
+	}
+
+	if !errors.Is(errs.NewRateLimitedError("localhost", 0), transport.ErrRateLimited) {
+		t.Error("expected a RateLimitedError to match ErrRateLimited")
+	}
+}
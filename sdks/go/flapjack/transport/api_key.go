@@ -0,0 +1,27 @@
+package transport
+
+import (
+	"sync/atomic"
+)
+
+// APIKey is an API key that can be replaced while requests are in flight.
+type APIKey struct {
+	value atomic.Pointer[string]
+}
+
+func NewAPIKey(apiKey string) *APIKey {
+	k := &APIKey{}
+	k.Set(apiKey)
+
+	return k
+}
+
+// Get returns the current API key.
+func (k *APIKey) Get() string {
+	return *k.value.Load()
+}
+
+// Set replaces the API key.
+func (k *APIKey) Set(apiKey string) {
+	k.value.Store(&apiKey)
+}
@@ -0,0 +1,174 @@
+package transport
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/call"
+)
+
+const (
+	DefaultFailureThreshold = 1
+	DefaultProbePath        = "/health"
+)
+
+// CircuitBreakerPolicy controls when a host is marked down and how it is
+// brought back up.
+//
+// Calls skip the hosts that are down, unless all the hosts of a call are down,
+// in which case they are all tried again.
+type CircuitBreakerPolicy struct {
+	// FailureThreshold is the number of consecutive failures, network errors
+	// or 5xx responses, after which a host is marked down.
+	// DefaultFailureThreshold is used when zero.
+	FailureThreshold int
+	// ProbeInterval is the interval at which the hosts that are down are
+	// probed in the background, with a GET request to ProbePath. A host is
+	// marked up as soon as a probe succeeds. When zero, hosts are not probed
+	// and are marked up again after DefaultResetPeriod. The interval is
+	// waited for with the Clock of the configuration.
+	ProbeInterval time.Duration
+	// ProbePath is the path of the probe requests, DefaultProbePath when
+	// empty.
+	ProbePath string
+	// ProbeTimeout is the timeout of the probe requests, DefaultReadTimeout
+	// when zero.
+	ProbeTimeout time.Duration
+}
+
+// HostStatus is a snapshot of the health of a host.
+type HostStatus struct {
+	Scheme string
+	Host   string
+	// Up is false when the host is skipped by the calls.
+	Up bool
+	// ConsecutiveFailures is the number of failures since the last success.
+	ConsecutiveFailures int
+	// ConsecutiveTimeouts is the number of timeouts since the last success,
+	// each of them extending the timeout of the next calls to the host.
+	ConsecutiveTimeouts int
+	// Read and Write tell which kinds of calls are sent to the host.
+	Read  bool
+	Write bool
+	// LastUpdate is the time of the last change of the status.
+	LastUpdate time.Time
+}
+
+// HostStatuses returns the current health of the hosts, in order.
+func (t *Transport) HostStatuses() []HostStatus {
+	return t.retryStrategy.hostStatuses()
+}
+
+// startProbing starts probing the hosts that are down in the background,
+// unless already started. Probing stops once all the hosts are up, or the
+// transport is closed.
+func (t *Transport) startProbing() {
+	if t.circuitBreaker.ProbeInterval <= 0 || t.closed.Load() || !t.probing.CompareAndSwap(false, true) {
+		return
+	}
+
+	go t.probe()
+}
+
+func (t *Transport) probe() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		select {
+		case <-t.done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	for {
+		if t.clock.Sleep(ctx, t.circuitBreaker.ProbeInterval) != nil {
+			return
+		}
+
+		hosts := t.retryStrategy.downHosts()
+		if len(hosts) == 0 {
+			t.probing.Store(false)
+
+			// A host may have been marked down before probing was
+			// flagged as stopped, in which case it is taken over.
+			if len(t.retryStrategy.downHosts()) == 0 || !t.probing.CompareAndSwap(false, true) {
+				return
+			}
+
+			continue
+		}
+
+		for _, h := range hosts {
+			if t.probeHost(h) {
+				t.retryStrategy.recover(h)
+			}
+		}
+	}
+}
+
+// probeHost tells whether the host answers the probe request successfully.
+func (t *Transport) probeHost(h Host) bool {
+	timeout := t.circuitBreaker.ProbeTimeout
+	if timeout <= 0 {
+		timeout = DefaultReadTimeout
+	}
+
+	path := t.circuitBreaker.ProbePath
+	if path == "" {
+		path = DefaultProbePath
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://localhost"+path, nil)
+	if err != nil {
+		return false
+	}
+
+	res, err := t.request(req, h, timeout, t.connectTimeout)
+	if err != nil {
+		t.logProbe(ctx, h, 0, err)
+
+		return false
+	}
+
+	_, _ = io.Copy(io.Discard, res.Body)
+	_ = res.Body.Close()
+
+	t.logProbe(ctx, h, res.StatusCode, nil)
+
+	return is2xx(res.StatusCode)
+}
+
+func (t *Transport) logProbe(ctx context.Context, h Host, code int, err error) {
+	if t.logger == nil || !t.logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+
+	if err != nil {
+		t.logger.LogAttrs(ctx, slog.LevelDebug, "flapjack: host probe failed", slog.String("host", h.host), slog.Any("error", err))
+
+		return
+	}
+
+	t.logger.LogAttrs(ctx, slog.LevelDebug, "flapjack: host probed", slog.String("host", h.host), slog.Int("status", code))
+}
+
+func (h *StatefulHost) status() HostStatus {
+	return HostStatus{
+		Scheme:              h.scheme,
+		Host:                h.host,
+		Up:                  !h.isDown,
+		ConsecutiveFailures: h.failures,
+		ConsecutiveTimeouts: h.retryCount,
+		Read:                h.accept(call.Read),
+		Write:               h.accept(call.Write),
+		LastUpdate:          h.lastUpdate,
+	}
+}
@@ -0,0 +1,104 @@
+package transport_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/call"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+func TestTransportMarksHostDownAfterThreshold(t *testing.T) {
+	t.Parallel()
+
+	var failingCalls, healthyCalls atomic.Int32
+
+	tr := newTransport(
+		transport.Configuration{CircuitBreaker: &transport.CircuitBreakerPolicy{FailureThreshold: 2}},
+		flakyServer(t, 100, &failingCalls),
+		flakyServer(t, 0, &healthyCalls),
+	)
+
+	for i := 0; i < 3; i++ {
+		_, _, err := tr.Request(context.Background(), newRequest(t), call.Read, transport.RequestConfiguration{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		statuses := tr.HostStatuses()
+		if len(statuses) != 2 {
+			t.Fatalf("expected 2 host statuses, got %d", len(statuses))
+		}
+
+		if wantUp := i == 0; statuses[0].Up != wantUp {
+			t.Errorf("call %d: expected the failing host up=%t, got %+v", i, wantUp, statuses[0])
+		}
+
+		if !statuses[1].Up || statuses[1].ConsecutiveFailures != 0 {
+			t.Errorf("call %d: expected the healthy host up, got %+v", i, statuses[1])
+		}
+	}
+
+	if failingCalls.Load() != 2 {
+		t.Errorf("expected the failing host to be skipped once down, got %d calls", failingCalls.Load())
+	}
+
+	if healthyCalls.Load() != 3 {
+		t.Errorf("expected 3 calls to the healthy host, got %d", healthyCalls.Load())
+	}
+}
+
+func TestTransportProbesDownHosts(t *testing.T) {
+	t.Parallel()
+
+	var healthy atomic.Bool
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		if r.URL.Path == transport.DefaultProbePath {
+			w.WriteHeader(http.StatusOK)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	tr := newTransport(transport.Configuration{
+		CircuitBreaker: &transport.CircuitBreakerPolicy{ProbeInterval: 10 * time.Millisecond},
+	}, srv)
+
+	_, _, err := tr.Request(context.Background(), newRequest(t), call.Read, transport.RequestConfiguration{})
+	if err == nil {
+		t.Fatal("expected the call to fail")
+	}
+
+	if status := tr.HostStatuses()[0]; status.Up || status.ConsecutiveFailures != 1 || !status.Read || !status.Write {
+		t.Fatalf("expected the host down, got %+v", status)
+	}
+
+	healthy.Store(true)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !tr.HostStatuses()[0].Up {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the host to be marked up by a probe")
+		}
+
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if status := tr.HostStatuses()[0]; status.ConsecutiveFailures != 0 {
+		t.Errorf("expected the failures to be reset, got %+v", status)
+	}
+}
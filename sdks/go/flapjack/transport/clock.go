@@ -0,0 +1,53 @@
+package transport
+
+import (
+	"context"
+	"time"
+)
+
+// Clock tells the time and waits for the retries of the clients: the backoff
+// between attempts, the Retry-After delays, the cache TTLs and the polling of
+// the WaitFor helpers. Tests replace it to fast-forward time instead of
+// sleeping, see flapjacktest.Clock.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// Sleep waits for d, or until ctx is done in which case it returns the
+	// error of ctx.
+	Sleep(ctx context.Context, d time.Duration) error
+}
+
+// SystemClock is the Clock of the time package, used when
+// Configuration.Clock is nil.
+type SystemClock struct{}
+
+var _ Clock = SystemClock{}
+
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (SystemClock) Sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// ClockOf returns the Clock of the configuration, SystemClock when nil.
+func ClockOf(cfg Configuration) Clock {
+	if cfg.Clock == nil {
+		return SystemClock{}
+	}
+
+	return cfg.Clock
+}
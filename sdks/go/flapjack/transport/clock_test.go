@@ -0,0 +1,151 @@
+package transport_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/call"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/flapjacktest"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+func TestTransportWaitsWithClock(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch calls.Add(1) {
+		case 1:
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	clock := flapjacktest.NewClock(time.Time{})
+
+	tr := newTransport(transport.Configuration{
+		ReadRetryPolicy: &transport.RetryPolicy{MaxAttempts: 2, BaseDelay: time.Hour, MaxDelay: time.Hour},
+		Clock:           clock,
+	}, srv)
+
+	start := time.Now()
+
+	_, _, err := tr.Request(context.Background(), newRequest(t), call.Read, transport.RequestConfiguration{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the waits to be skipped, took %s", elapsed)
+	}
+
+	if want := []time.Duration{30 * time.Second, time.Hour}; !reflect.DeepEqual(clock.Slept(), want) {
+		t.Errorf("expected the waits %v, got %v", want, clock.Slept())
+	}
+}
+
+func TestHostsExpireWithClock(t *testing.T) {
+	t.Parallel()
+
+	var downCalls atomic.Int32
+
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		downCalls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(down.Close)
+
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(up.Close)
+
+	clock := flapjacktest.NewClock(time.Time{})
+
+	tr := newTransport(transport.Configuration{Clock: clock}, down, up)
+
+	request := func() {
+		t.Helper()
+
+		_, _, err := tr.Request(context.Background(), newRequest(t), call.Read, transport.RequestConfiguration{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	request()
+
+	if status := tr.HostStatuses()[0]; status.Up || !status.LastUpdate.Equal(clock.Now()) {
+		t.Fatalf("expected the host to be marked down at %s, got %+v", clock.Now(), status)
+	}
+
+	// The host stays down until the reset period of the clock is over.
+	clock.Advance(transport.DefaultResetPeriod)
+	request()
+
+	clock.Advance(time.Second)
+	request()
+
+	if got := downCalls.Load(); got != 2 {
+		t.Errorf("expected the host to be retried once expired, got %d calls", got)
+	}
+}
+
+func TestSystemClockSleepHonorsContext(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := (transport.SystemClock{}).Sleep(ctx, time.Hour); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+
+	if err := (transport.SystemClock{}).Sleep(context.Background(), time.Millisecond); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestResponseInfoWithClock(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusTooManyRequests)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	clock := flapjacktest.NewClock(time.Time{})
+	start := clock.Now()
+
+	var info transport.ResponseInfo
+
+	_, _, err := newTransport(transport.Configuration{Clock: clock}, srv).Request(context.Background(), newRequest(t), call.Read, transport.RequestConfiguration{ResponseInfo: &info})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if info.Duration != 30*time.Second || !info.ReceivedAt.Equal(start.Add(30*time.Second)) {
+		t.Errorf("expected the response to be received 30s after the start, got %s at %s", info.Duration, info.ReceivedAt)
+	}
+}
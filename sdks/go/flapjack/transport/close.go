@@ -0,0 +1,30 @@
+package transport
+
+import (
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/errs"
+)
+
+// ErrClientClosed is returned by the calls of a transport after it has been closed.
+var ErrClientClosed = errs.ErrClientClosed
+
+// Close stops the background probing of the hosts and closes the idle connections of the requester, if it has a
+// CloseIdleConnections method like *http.Client. The calls started after Close fail with ErrClientClosed, while the
+// calls in progress run to completion. Closing a transport twice is a no-op.
+func (t *Transport) Close() error {
+	if !t.closed.CompareAndSwap(false, true) {
+		return nil
+	}
+
+	close(t.done)
+
+	if requester, ok := t.requester.(interface{ CloseIdleConnections() }); ok {
+		requester.CloseIdleConnections()
+	}
+
+	return nil
+}
+
+// CloseIdleConnections closes the idle connections of the *http.Client of the requester.
+func (r *defaultRequester) CloseIdleConnections() {
+	r.client.CloseIdleConnections()
+}
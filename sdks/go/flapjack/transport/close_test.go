@@ -0,0 +1,109 @@
+package transport_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/call"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+// closingRequester records the calls to CloseIdleConnections.
+type closingRequester struct {
+	transport.Requester
+	closed atomic.Int32
+}
+
+func (r *closingRequester) CloseIdleConnections() {
+	r.closed.Add(1)
+}
+
+func TestTransportClose(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	srv := flakyServer(t, 0, &calls)
+	requester := &closingRequester{Requester: transport.NewDefaultRequester(nil)}
+
+	tr := transport.New(transport.Configuration{
+		Hosts:     []transport.StatefulHost{transport.NewStatefulHost("http", strings.TrimPrefix(srv.URL, "http://"), call.IsReadWrite)},
+		Requester: requester,
+	})
+
+	if _, _, err := tr.Request(context.Background(), newRequest(t), call.Read, transport.RequestConfiguration{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := tr.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := tr.Close(); err != nil {
+		t.Fatalf("expected closing twice to be a no-op, got %v", err)
+	}
+
+	if got := requester.closed.Load(); got != 1 {
+		t.Errorf("expected the idle connections to be closed once, got %d", got)
+	}
+
+	_, _, err := tr.Request(context.Background(), newRequest(t), call.Read, transport.RequestConfiguration{})
+	if !errors.Is(err, transport.ErrClientClosed) {
+		t.Errorf("expected ErrClientClosed, got %v", err)
+	}
+
+	if calls.Load() != 1 {
+		t.Errorf("expected no request after Close, got %d", calls.Load())
+	}
+}
+
+func TestTransportCloseStopsProbing(t *testing.T) {
+	t.Parallel()
+
+	var probes atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == transport.DefaultProbePath {
+			probes.Add(1)
+		}
+
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(srv.Close)
+
+	tr := newTransport(transport.Configuration{
+		CircuitBreaker: &transport.CircuitBreakerPolicy{ProbeInterval: 5 * time.Millisecond},
+	}, srv)
+
+	if _, _, err := tr.Request(context.Background(), newRequest(t), call.Read, transport.RequestConfiguration{}); err == nil {
+		t.Fatal("expected the call to fail")
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for probes.Load() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the host to be probed")
+		}
+
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	_ = tr.Close()
+
+	// Let a probe in progress finish.
+	time.Sleep(50 * time.Millisecond)
+
+	before := probes.Load()
+
+	time.Sleep(50 * time.Millisecond)
+
+	if after := probes.Load(); after != before {
+		t.Errorf("expected the probing to stop, got %d probes after Close", after-before)
+	}
+}
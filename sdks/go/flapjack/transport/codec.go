@@ -0,0 +1,22 @@
+package transport
+
+import "encoding/json"
+
+// Codec encodes the request bodies and decodes the response bodies of the API clients. Models implement
+// json.Marshaler and json.Unmarshaler, so any codec honoring these interfaces, such as jsoniter, sonic or go-json,
+// can replace encoding/json.
+type Codec interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+// JSONCodec is the default Codec, backed by encoding/json.
+type JSONCodec struct{}
+
+func (JSONCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v) //nolint:wrapcheck
+}
+
+func (JSONCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v) //nolint:wrapcheck
+}
@@ -0,0 +1,95 @@
+package transport_test
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/call"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/compression"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+func TestTransportCompression(t *testing.T) {
+	t.Parallel()
+
+	// Zstd stands for any algorithm registered by the application.
+	compression.Register(compression.ZSTD,
+		func(w io.Writer) (io.WriteCloser, error) { return flate.NewWriter(w, flate.BestSpeed) },
+		func(r io.Reader) (io.ReadCloser, error) { return flate.NewReader(r), nil },
+	)
+
+	tests := []struct {
+		name               string
+		compression        compression.Compression
+		scope              compression.Scope
+		kind               call.Kind
+		wantEncoding       string
+		wantAcceptEncoding string
+	}{
+		{name: "none", compression: compression.NONE, kind: call.Write, wantEncoding: ""},
+		{name: "gzip", compression: compression.GZIP, kind: call.Read, wantEncoding: "gzip"},
+		{name: "zstd", compression: compression.ZSTD, kind: call.Read, wantEncoding: "zstd", wantAcceptEncoding: "zstd, gzip"},
+		{name: "zstd write only on read", compression: compression.ZSTD, scope: compression.WRITE_CALLS, kind: call.Read, wantEncoding: ""},
+		{name: "zstd write only on write", compression: compression.ZSTD, scope: compression.WRITE_CALLS, kind: call.Write, wantEncoding: "zstd", wantAcceptEncoding: "zstd, gzip"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if got := r.Header.Get("Content-Encoding"); got != tt.wantEncoding {
+					t.Errorf("expected Content-Encoding %q, got %q", tt.wantEncoding, got)
+				}
+
+				body := io.Reader(r.Body)
+				if tt.wantEncoding != "" {
+					reader, err := compression.NewReader(compression.FromContentEncoding(tt.wantEncoding), r.Body)
+					if err != nil {
+						t.Fatalf("cannot decompress body: %v", err)
+					}
+
+					body = reader
+				}
+
+				if content, err := io.ReadAll(body); err != nil || string(content) != `{"query":"phone"}` {
+					t.Errorf("unexpected body %q (%v)", content, err)
+				}
+
+				if tt.wantAcceptEncoding == "" {
+					_, _ = w.Write([]byte(`{"nbHits":1}`))
+
+					return
+				}
+
+				if got := r.Header.Get("Accept-Encoding"); got != tt.wantAcceptEncoding {
+					t.Errorf("expected Accept-Encoding %q, got %q", tt.wantAcceptEncoding, got)
+				}
+
+				w.Header().Set("Content-Encoding", "gzip")
+
+				gw := gzip.NewWriter(w)
+				_, _ = gw.Write([]byte(`{"nbHits":1}`))
+				_ = gw.Close()
+			}))
+			t.Cleanup(srv.Close)
+
+			tr := newTransport(transport.Configuration{Compression: tt.compression, CompressionScope: tt.scope}, srv)
+
+			_, body, err := tr.Request(context.Background(), newRequest(t), tt.kind, transport.RequestConfiguration{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if string(body) != `{"nbHits":1}` {
+				t.Errorf("unexpected response body %q", body)
+			}
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package transport
 
 import (
+	"log/slog"
+	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/compression"
@@ -10,19 +13,92 @@ type Configuration struct {
 	AppID  string
 	ApiKey string //nolint:staticcheck
 
-	Hosts                           []StatefulHost
-	DefaultHeader                   map[string]string
-	UserAgent                       string
-	Requester                       Requester
-	ReadTimeout                     time.Duration
-	WriteTimeout                    time.Duration
-	ConnectTimeout                  time.Duration
-	Compression                     compression.Compression
+	Hosts          []StatefulHost
+	DefaultHeader  map[string]string
+	UserAgent      string
+	Requester      Requester
+	ReadTimeout    time.Duration
+	WriteTimeout   time.Duration
+	ConnectTimeout time.Duration
+	// Compression compresses the request bodies. The Flapjack engine doesn't
+	// decompress them: leave it at compression.NONE against a Flapjack server.
+	Compression compression.Compression
+	// CompressionScope selects whether Compression applies to every call or
+	// to write calls only.
+	CompressionScope compression.Scope
+	// ExposeIntermediateNetworkErrors makes calls failing on every host
+	// return an errs.MultiHostError listing the failure of each attempt,
+	// instead of errs.ErrNoMoreHostToTry.
 	ExposeIntermediateNetworkErrors bool
+	// ExposeRateLimitErrors makes calls answered with a `429 Too Many
+	// Requests` status fail with an errs.RateLimitedError instead of waiting
+	// for the `Retry-After` delay.
+	ExposeRateLimitErrors bool
+	// ReadRetryPolicy and WriteRetryPolicy control how read and write calls
+	// are retried, DefaultRetryPolicy is used when nil.
+	ReadRetryPolicy  *RetryPolicy
+	WriteRetryPolicy *RetryPolicy
+	// MetricsCollector, when set, is notified of every attempt and call.
+	MetricsCollector MetricsCollector
+	// Logger, when set, receives debug logs of the host selection, retries,
+	// timeouts and response statuses of every call. Use slog.New to wrap any
+	// slog.Handler.
+	Logger *slog.Logger
+	// Codec encodes request bodies and decodes response bodies, JSONCodec is
+	// used when nil.
+	Codec Codec
+	// HTTPTransport, when set, is the transport of the default requester,
+	// used as is. It is ignored when a Requester is set.
+	HTTPTransport *http.Transport
+	// HTTPTransportOptions tune the transport of the default requester when
+	// neither Requester nor HTTPTransport is set.
+	HTTPTransportOptions HTTPTransportOptions
+	// CircuitBreaker controls when hosts are marked down and whether they are
+	// probed in the background. When nil, a host is marked down after a
+	// single failure and tried again after DefaultResetPeriod.
+	CircuitBreaker *CircuitBreakerPolicy
+	// ReadHosts and WriteHosts, when set, are the hosts of the read and write
+	// calls respectively, whatever their accept function, and replace Hosts
+	// for their kind of calls. This routes the reads to read replicas and the
+	// writes to the primary hosts, for instance.
+	ReadHosts  []StatefulHost
+	WriteHosts []StatefulHost
+	// ReadFromWriteHosts sends the read calls to the write hosts once all
+	// the read hosts are down.
+	ReadFromWriteHosts bool
+	// Failover, when set, is the secondary application the calls switch to
+	// once all the hosts of the primary application have failed.
+	Failover *Failover
+	// RequestIDHeader is the header carrying the request ID of the calls,
+	// RequestIDHeader when empty. The request ID is taken from the context,
+	// see WithRequestID, or generated by RequestIDGenerator when set.
+	RequestIDHeader    string
+	RequestIDGenerator func() string
+	// ReadRateLimit and WriteRateLimit, when set, cap the rate and the
+	// concurrency of the read and write calls respectively. Calls over the
+	// limits wait for their turn.
+	ReadRateLimit  *RateLimit
+	WriteRateLimit *RateLimit
+	// Clock tells the time of the calls, of their metrics and of the
+	// helpers, and waits between their retries, SystemClock when nil. It
+	// also times the health of the hosts, the failback and the background
+	// probes. Tests set a fake clock to skip the waits.
+	Clock Clock
 }
 
 type RequestConfiguration struct {
 	ReadTimeout    *time.Duration
 	WriteTimeout   *time.Duration
 	ConnectTimeout *time.Duration
+	// ExtraHeaders are set on the request of a single call, overriding the
+	// headers of the same name.
+	ExtraHeaders map[string]string
+	// ExtraQueryParams are appended to the query string of a single call.
+	ExtraQueryParams url.Values
+	// Stream makes successful responses return their body unread, to be
+	// read and closed by the caller, instead of buffering it.
+	Stream bool
+	// ResponseInfo, when set, is filled with the metadata of the response
+	// of a single call, even when it fails.
+	ResponseInfo *ResponseInfo
 }
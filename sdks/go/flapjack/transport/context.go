@@ -0,0 +1,41 @@
+package transport
+
+import (
+	"context"
+)
+
+type contextKey int
+
+const (
+	operationNameKey contextKey = iota
+	attemptKey
+)
+
+// WithOperationName returns a copy of ctx carrying the name of the API operation, such as `searchSingleIndex`,
+// performed by the requests using it.
+func WithOperationName(ctx context.Context, operationName string) context.Context {
+	if ctx == nil {
+		return ctx
+	}
+
+	return context.WithValue(ctx, operationNameKey, operationName)
+}
+
+// OperationNameFromContext returns the name of the API operation of a request's context, if any.
+func OperationNameFromContext(ctx context.Context) (string, bool) {
+	operationName, ok := ctx.Value(operationNameKey).(string)
+
+	return operationName, ok
+}
+
+func withAttempt(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, attemptKey, attempt)
+}
+
+// AttemptFromContext returns the attempt number of a request's context, set by the transport before calling the
+// Requester: 0 for the first attempt, then 1 for the first retry, and so on.
+func AttemptFromContext(ctx context.Context) int {
+	attempt, _ := ctx.Value(attemptKey).(int)
+
+	return attempt
+}
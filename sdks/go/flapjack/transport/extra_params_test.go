@@ -0,0 +1,42 @@
+package transport_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/call"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+func TestTransportAppliesExtraParams(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Forwarded-For"); got != "203.0.113.7" {
+			t.Errorf("unexpected X-Forwarded-For header %q", got)
+		}
+
+		if got := r.URL.RawQuery; got != "getVersion=2&analyticsTags=mobile&analyticsTags=beta" {
+			t.Errorf("unexpected query string %q", got)
+		}
+
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	req, err := http.NewRequest(http.MethodGet, "http://placeholder/1/indexes/products/settings?getVersion=2", nil)
+	if err != nil {
+		t.Fatalf("cannot create request: %v", err)
+	}
+
+	_, _, err = newTransport(transport.Configuration{}, srv).Request(context.Background(), req, call.Read, transport.RequestConfiguration{
+		ExtraHeaders:     map[string]string{"X-Forwarded-For": "203.0.113.7"},
+		ExtraQueryParams: url.Values{"analyticsTags": {"mobile", "beta"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
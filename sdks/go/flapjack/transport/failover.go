@@ -0,0 +1,166 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/call"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/errs"
+)
+
+const (
+	ApplicationIDHeader = "X-Algolia-Application-Id"
+	APIKeyHeader        = "X-Algolia-API-Key"
+)
+
+// Failover is a secondary application, such as a replica of the primary one
+// in another region, that the calls switch to once all the hosts of the
+// primary application have failed.
+//
+// Once switched, the calls are sent to the secondary application for
+// FailbackAfter, then to the primary application again.
+type Failover struct {
+	AppID  string
+	ApiKey string //nolint:staticcheck
+	// Hosts are the hosts of the secondary application, required by the
+	// clients without default hosts per application.
+	Hosts []StatefulHost
+	// FailbackAfter is how long the calls are sent to the secondary
+	// application before trying the primary one again, DefaultResetPeriod
+	// when zero.
+	FailbackAfter time.Duration
+	// OnFailover, when set, is called when the calls switch from an
+	// application to the other.
+	OnFailover func(event FailoverEvent)
+}
+
+// FailoverEvent describes a switch between the primary and the secondary
+// applications.
+type FailoverEvent struct {
+	// From and To are the IDs of the applications the calls switch from and
+	// to.
+	From string
+	To   string
+	// Err is the error of the call that failed on all the hosts of the
+	// primary application, nil when switching back to it.
+	Err error
+}
+
+type failover struct {
+	sync.Mutex
+
+	cfg           Failover
+	primaryAppID  string
+	retryStrategy *RetryStrategy
+	active        bool
+	since         time.Time
+	clock         Clock
+}
+
+func newFailover(cfg Configuration, failureThreshold int) *failover {
+	retryStrategy := newRetryStrategy(cfg.Failover.Hosts, cfg.ReadTimeout, cfg.WriteTimeout, ClockOf(cfg))
+	retryStrategy.failureThreshold = failureThreshold
+
+	return &failover{
+		cfg:           *cfg.Failover,
+		primaryAppID:  cfg.AppID,
+		retryStrategy: retryStrategy,
+		clock:         ClockOf(cfg),
+	}
+}
+
+// useSecondary tells whether calls are currently sent to the secondary
+// application, switching back to the primary one after FailbackAfter.
+func (f *failover) useSecondary() bool {
+	f.Lock()
+
+	if !f.active {
+		f.Unlock()
+
+		return false
+	}
+
+	failbackAfter := f.cfg.FailbackAfter
+	if failbackAfter <= 0 {
+		failbackAfter = DefaultResetPeriod
+	}
+
+	if f.clock.Now().Sub(f.since) <= failbackAfter {
+		f.Unlock()
+
+		return true
+	}
+
+	f.active = false
+	f.Unlock()
+
+	f.notify(FailoverEvent{From: f.cfg.AppID, To: f.primaryAppID})
+
+	return false
+}
+
+// activate switches the calls to the secondary application.
+func (f *failover) activate(err error) {
+	f.Lock()
+
+	if f.active {
+		f.Unlock()
+
+		return
+	}
+
+	f.active = true
+	f.since = f.clock.Now()
+	f.Unlock()
+
+	f.notify(FailoverEvent{From: f.primaryAppID, To: f.cfg.AppID, Err: err})
+}
+
+func (f *failover) notify(event FailoverEvent) {
+	if f.cfg.OnFailover != nil {
+		f.cfg.OnFailover(event)
+	}
+}
+
+// requestWithFailover sends the request to the primary application, and to
+// the secondary one once all the primary hosts have failed.
+func (t *Transport) requestWithFailover(ctx context.Context, req *http.Request, k call.Kind, c RequestConfiguration, stats *callStats, log *logger) (*http.Response, []byte, error) {
+	if t.failover == nil {
+		return t.doRequest(ctx, req, k, c, stats, log, t.retryStrategy)
+	}
+
+	// Keep a copy of the request as built by the client, the primary
+	// attempts modifying it.
+	req, err := prepareRetryableRequest(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	secondary := req.Clone(req.Context())
+
+	if !t.failover.useSecondary() {
+		res, body, err := t.doRequest(ctx, req, k, c, stats, log, t.retryStrategy)
+		if err == nil || ctx.Err() != nil || !errors.Is(err, errs.ErrNoMoreHostToTry) {
+			return res, body, err
+		}
+
+		log.debug(ctx, "flapjack: failing over to the secondary application", slog.String("app_id", t.failover.cfg.AppID))
+		t.failover.activate(err)
+	}
+
+	if secondary.GetBody != nil {
+		secondary.Body, err = secondary.GetBody()
+		if err != nil {
+			return nil, nil, err //nolint:wrapcheck
+		}
+	}
+
+	secondary.Header.Set(ApplicationIDHeader, t.failover.cfg.AppID)
+	secondary.Header.Set(APIKeyHeader, t.failover.cfg.ApiKey)
+
+	return t.doRequest(ctx, secondary, k, c, stats, log, t.failover.retryStrategy)
+}
@@ -0,0 +1,165 @@
+package transport_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/call"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/flapjacktest"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+// failoverRecorder records the failover events.
+type failoverRecorder struct {
+	sync.Mutex
+	events []transport.FailoverEvent
+}
+
+func (r *failoverRecorder) record(event transport.FailoverEvent) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.events = append(r.events, event)
+}
+
+func (r *failoverRecorder) get() []transport.FailoverEvent {
+	r.Lock()
+	defer r.Unlock()
+
+	return append([]transport.FailoverEvent(nil), r.events...)
+}
+
+func TestTransportFailsOverToSecondaryApplication(t *testing.T) {
+	t.Parallel()
+
+	var primaryCalls, secondaryCalls atomic.Int32
+
+	primary := flakyServer(t, 100, &primaryCalls)
+
+	secondary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		secondaryCalls.Add(1)
+
+		body, _ := io.ReadAll(r.Body)
+		if r.Header.Get(transport.ApplicationIDHeader) != "backup-app" || r.Header.Get(transport.APIKeyHeader) != "backup-key" || string(body) != `{"query":"phone"}` {
+			w.WriteHeader(http.StatusForbidden)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(secondary.Close)
+
+	var recorder failoverRecorder
+
+	tr := newTransport(transport.Configuration{
+		AppID: "primary-app",
+		Failover: &transport.Failover{
+			AppID:      "backup-app",
+			ApiKey:     "backup-key",
+			Hosts:      []transport.StatefulHost{hostOf(secondary)},
+			OnFailover: recorder.record,
+		},
+	}, primary)
+
+	for i := 0; i < 2; i++ {
+		req := newRequest(t)
+		req.Header.Set(transport.ApplicationIDHeader, "primary-app")
+		req.Header.Set(transport.APIKeyHeader, "primary-key")
+
+		res, _, err := tr.Request(context.Background(), req, call.Read, transport.RequestConfiguration{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if res.StatusCode != http.StatusOK {
+			t.Fatalf("expected the secondary application to answer, got %d", res.StatusCode)
+		}
+	}
+
+	if primaryCalls.Load() != 1 || secondaryCalls.Load() != 2 {
+		t.Errorf("expected 1 call to the primary and 2 to the secondary, got %d and %d", primaryCalls.Load(), secondaryCalls.Load())
+	}
+
+	events := recorder.get()
+	if len(events) != 1 || events[0].From != "primary-app" || events[0].To != "backup-app" || events[0].Err == nil {
+		t.Errorf("expected a single failover event, got %+v", events)
+	}
+}
+
+func TestTransportFailsBackToPrimaryApplication(t *testing.T) {
+	t.Parallel()
+
+	var primaryCalls, secondaryCalls atomic.Int32
+
+	primary := flakyServer(t, 1, &primaryCalls)
+	secondary := flakyServer(t, 0, &secondaryCalls)
+
+	var recorder failoverRecorder
+
+	clock := flapjacktest.NewClock(time.Time{})
+
+	tr := newTransport(transport.Configuration{
+		AppID: "primary-app",
+		Failover: &transport.Failover{
+			AppID:         "backup-app",
+			Hosts:         []transport.StatefulHost{hostOf(secondary)},
+			FailbackAfter: time.Minute,
+			OnFailover:    recorder.record,
+		},
+		Clock: clock,
+	}, primary)
+
+	for i := 0; i < 2; i++ {
+		if i > 0 {
+			clock.Advance(2 * time.Minute)
+		}
+
+		_, _, err := tr.Request(context.Background(), newRequest(t), call.Read, transport.RequestConfiguration{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if primaryCalls.Load() != 2 || secondaryCalls.Load() != 1 {
+		t.Errorf("expected 2 calls to the primary and 1 to the secondary, got %d and %d", primaryCalls.Load(), secondaryCalls.Load())
+	}
+
+	events := recorder.get()
+	if len(events) != 2 || events[1].From != "backup-app" || events[1].To != "primary-app" || events[1].Err != nil {
+		t.Errorf("expected a failover and a failback event, got %+v", events)
+	}
+}
+
+func TestTransportDoesNotFailOverOnClientErrors(t *testing.T) {
+	t.Parallel()
+
+	var secondaryCalls atomic.Int32
+
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(primary.Close)
+
+	tr := newTransport(transport.Configuration{
+		Failover: &transport.Failover{
+			AppID: "backup-app",
+			Hosts: []transport.StatefulHost{hostOf(flakyServer(t, 0, &secondaryCalls))},
+		},
+	}, primary)
+
+	res, _, err := tr.Request(context.Background(), newRequest(t), call.Read, transport.RequestConfiguration{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if res.StatusCode != http.StatusNotFound || secondaryCalls.Load() != 0 {
+		t.Errorf("expected the 404 of the primary without failover, got %d and %d secondary calls", res.StatusCode, secondaryCalls.Load())
+	}
+}
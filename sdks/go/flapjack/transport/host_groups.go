@@ -0,0 +1,73 @@
+package transport
+
+import (
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/call"
+)
+
+// hostsOf returns the hosts of the configuration, the ReadHosts and WriteHosts
+// groups replacing Hosts for their kind of calls. Hosts listed in several
+// groups are merged, so that their state is shared by all kinds of calls.
+func hostsOf(cfg Configuration) []StatefulHost {
+	if len(cfg.ReadHosts) == 0 && len(cfg.WriteHosts) == 0 {
+		return cfg.Hosts
+	}
+
+	var (
+		hosts []StatefulHost
+		index = map[string]int{}
+	)
+
+	add := func(h StatefulHost, kinds, fallback func(k call.Kind) bool) {
+		key := h.scheme + "://" + h.host
+
+		i, ok := index[key]
+		if !ok {
+			h.accept = kinds
+			h.fallback = fallback
+			index[key] = len(hosts)
+			hosts = append(hosts, h)
+
+			return
+		}
+
+		accept, previousFallback := hosts[i].accept, hosts[i].fallback
+		hosts[i].accept = func(k call.Kind) bool { return accept(k) || kinds(k) }
+
+		if fallback != nil {
+			hosts[i].fallback = func(k call.Kind) bool {
+				return fallback(k) || (previousFallback != nil && previousFallback(k))
+			}
+		}
+	}
+
+	for _, h := range cfg.ReadHosts {
+		add(h, call.IsRead, nil)
+	}
+
+	var writeFallback func(k call.Kind) bool
+	if cfg.ReadFromWriteHosts {
+		writeFallback = call.IsRead
+	}
+
+	for _, h := range cfg.WriteHosts {
+		add(h, call.IsWrite, writeFallback)
+	}
+
+	// The kinds of calls without a group of their own keep using Hosts.
+	for _, h := range cfg.Hosts {
+		accept := h.accept
+		kinds := func(k call.Kind) bool {
+			if call.IsRead(k) && len(cfg.ReadHosts) > 0 || call.IsWrite(k) && len(cfg.WriteHosts) > 0 {
+				return false
+			}
+
+			return accept(k)
+		}
+
+		if kinds(call.Read) || kinds(call.Write) {
+			add(h, kinds, nil)
+		}
+	}
+
+	return hosts
+}
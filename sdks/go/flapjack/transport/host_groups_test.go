@@ -0,0 +1,115 @@
+package transport_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/call"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+func hostOf(srv *httptest.Server) transport.StatefulHost {
+	return transport.NewStatefulHost("http", strings.TrimPrefix(srv.URL, "http://"), call.IsReadWrite)
+}
+
+func TestTransportRoutesCallsToHostGroups(t *testing.T) {
+	t.Parallel()
+
+	var replicaCalls, primaryCalls atomic.Int32
+
+	replica := flakyServer(t, 0, &replicaCalls)
+	primary := flakyServer(t, 0, &primaryCalls)
+
+	tr := transport.New(transport.Configuration{
+		ReadHosts:  []transport.StatefulHost{hostOf(replica)},
+		WriteHosts: []transport.StatefulHost{hostOf(primary)},
+	})
+
+	for _, k := range []call.Kind{call.Read, call.Read, call.Write} {
+		_, _, err := tr.Request(context.Background(), newRequest(t), k, transport.RequestConfiguration{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if replicaCalls.Load() != 2 || primaryCalls.Load() != 1 {
+		t.Errorf("expected 2 reads on the replica and 1 write on the primary, got %d and %d", replicaCalls.Load(), primaryCalls.Load())
+	}
+
+	statuses := tr.HostStatuses()
+	if len(statuses) != 2 || !statuses[0].Read || statuses[0].Write || statuses[1].Read || !statuses[1].Write {
+		t.Errorf("unexpected host statuses: %+v", statuses)
+	}
+}
+
+func TestTransportHostGroupsKeepHostsForOtherCalls(t *testing.T) {
+	t.Parallel()
+
+	var replicaCalls, defaultCalls atomic.Int32
+
+	replica := flakyServer(t, 0, &replicaCalls)
+	defaultHost := flakyServer(t, 0, &defaultCalls)
+
+	tr := transport.New(transport.Configuration{
+		Hosts:     []transport.StatefulHost{hostOf(defaultHost)},
+		ReadHosts: []transport.StatefulHost{hostOf(replica)},
+	})
+
+	for _, k := range []call.Kind{call.Read, call.Write} {
+		_, _, err := tr.Request(context.Background(), newRequest(t), k, transport.RequestConfiguration{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if replicaCalls.Load() != 1 || defaultCalls.Load() != 1 {
+		t.Errorf("expected the read on the replica and the write on the default host, got %d and %d", replicaCalls.Load(), defaultCalls.Load())
+	}
+}
+
+func TestTransportReadsFallBackToWriteHosts(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		readFromWriteHosts bool
+		wantPrimaryCalls   int32
+	}{
+		{name: "with fallback", readFromWriteHosts: true, wantPrimaryCalls: 1},
+		{name: "without fallback", readFromWriteHosts: false, wantPrimaryCalls: 0},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var replicaCalls, primaryCalls atomic.Int32
+
+			replica := flakyServer(t, 100, &replicaCalls)
+			primary := flakyServer(t, 0, &primaryCalls)
+
+			tr := transport.New(transport.Configuration{
+				ReadHosts:          []transport.StatefulHost{hostOf(replica)},
+				WriteHosts:         []transport.StatefulHost{hostOf(primary)},
+				ReadFromWriteHosts: tt.readFromWriteHosts,
+			})
+
+			// The first read marks the replica down.
+			_, _, _ = tr.Request(context.Background(), newRequest(t), call.Read, transport.RequestConfiguration{})
+			_, _, err := tr.Request(context.Background(), newRequest(t), call.Read, transport.RequestConfiguration{})
+
+			if primaryCalls.Load() != tt.wantPrimaryCalls {
+				t.Errorf("expected %d reads on the primary, got %d", tt.wantPrimaryCalls, primaryCalls.Load())
+			}
+
+			if tt.readFromWriteHosts && err != nil {
+				t.Errorf("expected the read to succeed on the primary, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/call"
+)
+
+// logger emits the debug logs of a call. A nil logger, or one whose handler
+// doesn't enable the debug level, logs nothing.
+type logger struct {
+	logger *slog.Logger
+	attrs  []slog.Attr
+}
+
+func newLogger(ctx context.Context, l *slog.Logger, k call.Kind) *logger {
+	if l == nil || !l.Enabled(ctx, slog.LevelDebug) {
+		return nil
+	}
+
+	attrs := []slog.Attr{slog.String("kind", kindName(k))}
+	if operationName, ok := OperationNameFromContext(ctx); ok {
+		attrs = append(attrs, slog.String("operation", operationName))
+	}
+
+	if requestID, ok := RequestIDFromContext(ctx); ok {
+		attrs = append(attrs, slog.String("request_id", requestID))
+	}
+
+	return &logger{
+		logger: l,
+		attrs:  attrs,
+	}
+}
+
+func (l *logger) debug(ctx context.Context, msg string, attrs ...slog.Attr) {
+	if l == nil {
+		return
+	}
+
+	l.logger.LogAttrs(ctx, slog.LevelDebug, msg, append(attrs, l.attrs...)...)
+}
+
+func isTimeout(err error) bool {
+	var nerr net.Error
+
+	return errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &nerr) && nerr.Timeout())
+}
+
+func kindName(k call.Kind) string {
+	if k == call.Read {
+		return "read"
+	}
+
+	return "write"
+}
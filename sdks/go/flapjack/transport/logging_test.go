@@ -0,0 +1,71 @@
+package transport_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/call"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+func TestTransportLogsRetries(t *testing.T) {
+	t.Parallel()
+
+	var (
+		calls atomic.Int32
+		buf   bytes.Buffer
+	)
+
+	srv := flakyServer(t, 1, &calls)
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	tr := newTransport(transport.Configuration{Logger: logger}, srv, srv)
+
+	ctx := transport.WithOperationName(context.Background(), "searchSingleIndex")
+
+	_, _, err := tr.Request(ctx, newRequest(t), call.Read, transport.RequestConfiguration{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	logs := buf.String()
+
+	for _, want := range []string{
+		`msg="flapjack: sending request"`,
+		`msg="flapjack: received response"`,
+		"status=500",
+		`msg="flapjack: retrying request"`,
+		"attempt=1",
+		"status=200",
+		"operation=searchSingleIndex",
+		"kind=read",
+	} {
+		if !strings.Contains(logs, want) {
+			t.Errorf("expected logs to contain %q, got:\n%s", want, logs)
+		}
+	}
+}
+
+func TestTransportDoesNotLogAboveDebugLevel(t *testing.T) {
+	t.Parallel()
+
+	var (
+		calls atomic.Int32
+		buf   bytes.Buffer
+	)
+
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	tr := newTransport(transport.Configuration{Logger: logger}, flakyServer(t, 0, &calls))
+
+	_, _, err := tr.Request(context.Background(), newRequest(t), call.Read, transport.RequestConfiguration{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if buf.Len() != 0 {
+		t.Errorf("expected no logs at the default level, got:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,130 @@
+package transport
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/call"
+)
+
+// MetricsCollector receives measurements of the calls performed by the transport. Implementations must be safe for
+// concurrent use, and should return quickly as they are called synchronously.
+type MetricsCollector interface {
+	// ObserveAttempt is called after every HTTP attempt.
+	ObserveAttempt(m AttemptMetrics)
+	// ObserveRequest is called once per call, after its last attempt.
+	ObserveRequest(m RequestMetrics)
+}
+
+// AttemptMetrics describes a single HTTP attempt.
+type AttemptMetrics struct {
+	// OperationName is the API operation, such as `searchSingleIndex`, empty if unknown.
+	OperationName string
+	Kind          call.Kind
+	Host          string
+	// Attempt is 0 for the first attempt of a call, 1 for the first retry, and so on.
+	Attempt int
+	// StatusCode is the HTTP status of the response, 0 when no response was received.
+	StatusCode int
+	Duration   time.Duration
+	Err        error
+}
+
+// RequestMetrics describes a call, made of one or more attempts.
+type RequestMetrics struct {
+	// OperationName is the API operation, such as `searchSingleIndex`, empty if unknown.
+	OperationName string
+	Kind          call.Kind
+	Attempts      int
+	// Failovers is the number of times the call moved on to another host.
+	Failovers int
+	// StatusCode is the HTTP status of the final response, 0 when no response was received.
+	StatusCode int
+	Duration   time.Duration
+	Err        error
+}
+
+// StatusClass returns the class of an HTTP status code, such as `2xx`, or `error` when no response was received.
+func StatusClass(statusCode int) string {
+	if statusCode < 100 || statusCode > 599 {
+		return "error"
+	}
+
+	return fmt.Sprintf("%dxx", statusCode/100)
+}
+
+type callStats struct {
+	collector     MetricsCollector
+	operationName string
+	kind          call.Kind
+	clock         Clock
+	start         time.Time
+	attempts      int
+	failovers     int
+	lastHost      string
+}
+
+func newCallStats(ctx context.Context, collector MetricsCollector, k call.Kind, clock Clock) *callStats {
+	if collector == nil {
+		return nil
+	}
+
+	operationName, _ := OperationNameFromContext(ctx)
+
+	return &callStats{
+		collector:     collector,
+		operationName: operationName,
+		kind:          k,
+		clock:         clock,
+		start:         clock.Now(),
+	}
+}
+
+func (s *callStats) observeAttempt(h Host, attempt int, start time.Time, res *http.Response, err error) {
+	if s == nil {
+		return
+	}
+
+	if s.attempts > 0 && s.lastHost != h.host {
+		s.failovers++
+	}
+
+	s.attempts++
+	s.lastHost = h.host
+
+	s.collector.ObserveAttempt(AttemptMetrics{
+		OperationName: s.operationName,
+		Kind:          s.kind,
+		Host:          h.host,
+		Attempt:       attempt,
+		StatusCode:    statusCodeOf(res),
+		Duration:      s.clock.Now().Sub(start),
+		Err:           err,
+	})
+}
+
+func (s *callStats) observeRequest(res *http.Response, err error) {
+	if s == nil {
+		return
+	}
+
+	s.collector.ObserveRequest(RequestMetrics{
+		OperationName: s.operationName,
+		Kind:          s.kind,
+		Attempts:      s.attempts,
+		Failovers:     s.failovers,
+		StatusCode:    statusCodeOf(res),
+		Duration:      s.clock.Now().Sub(s.start),
+		Err:           err,
+	})
+}
+
+func statusCodeOf(res *http.Response) int {
+	if res == nil {
+		return 0
+	}
+
+	return res.StatusCode
+}
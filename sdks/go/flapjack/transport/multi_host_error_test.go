@@ -0,0 +1,71 @@
+package transport_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/call"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/errs"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+func TestTransportExposesMultiHostError(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	failing := flakyServer(t, 100, &calls)
+
+	closed := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	closed.Close()
+
+	tr := newTransport(transport.Configuration{ExposeIntermediateNetworkErrors: true}, failing, closed)
+
+	_, _, err := tr.Request(context.Background(), newRequest(t), call.Read, transport.RequestConfiguration{})
+
+	if !errors.Is(err, errs.ErrNoMoreHostToTry) {
+		t.Errorf("expected %v to match ErrNoMoreHostToTry", err)
+	}
+
+	var multiErr *errs.MultiHostError
+	if !errors.As(err, &multiErr) {
+		t.Fatalf("expected a MultiHostError, got %T", err)
+	}
+
+	if len(multiErr.Errors) != 2 {
+		t.Fatalf("expected 2 host errors, got %d", len(multiErr.Errors))
+	}
+
+	first, second := multiErr.Errors[0], multiErr.Errors[1]
+
+	if first.Host != strings.TrimPrefix(failing.URL, "http://") || first.Attempt != 0 || first.StatusCode != http.StatusInternalServerError {
+		t.Errorf("unexpected first host error %+v", first)
+	}
+
+	if second.Host != strings.TrimPrefix(closed.URL, "http://") || second.Attempt != 1 || second.StatusCode != 0 || second.Err == nil {
+		t.Errorf("unexpected second host error %+v", second)
+	}
+
+	if first.Time.IsZero() || second.Time.Before(first.Time) {
+		t.Errorf("unexpected attempt times %s and %s", first.Time, second.Time)
+	}
+
+	var hostErr *errs.HostError
+	if !errors.As(err, &hostErr) || hostErr != first {
+		t.Errorf("expected errors.As to find the first host error, got %v", hostErr)
+	}
+
+	var noMoreHostErr *errs.NoMoreHostToTryError
+	if !errors.As(err, &noMoreHostErr) {
+		t.Fatalf("expected errors.As to find a NoMoreHostToTryError, got %T", err)
+	}
+
+	if intermediate := noMoreHostErr.IntermediateNetworkErrors(); len(intermediate) != 2 || intermediate[0] != first || intermediate[1] != second {
+		t.Errorf("expected the host errors as intermediate network errors, got %v", intermediate)
+	}
+}
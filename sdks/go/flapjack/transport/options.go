@@ -0,0 +1,118 @@
+package transport
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/compression"
+)
+
+// ClientOption customizes the configuration of a client created with the
+// NewClient function of its package, as an alternative to building the whole
+// configuration for NewClientWithConfig.
+type ClientOption func(cfg *Configuration)
+
+// WithHosts replaces the default hosts of the client.
+func WithHosts(hosts ...StatefulHost) ClientOption {
+	return func(cfg *Configuration) {
+		cfg.Hosts = hosts
+	}
+}
+
+// WithRequester sets the requester sending the HTTP requests.
+func WithRequester(requester Requester) ClientOption {
+	return func(cfg *Configuration) {
+		cfg.Requester = requester
+	}
+}
+
+// WithHTTPClient sends the HTTP requests through an existing *http.Client, see
+// NewRequesterFromHTTPClient.
+func WithHTTPClient(client *http.Client) ClientOption {
+	return WithRequester(NewRequesterFromHTTPClient(client))
+}
+
+// WithReadTimeout sets the default timeout of the read calls.
+func WithReadTimeout(timeout time.Duration) ClientOption {
+	return func(cfg *Configuration) {
+		cfg.ReadTimeout = timeout
+	}
+}
+
+// WithWriteTimeout sets the default timeout of the write calls.
+func WithWriteTimeout(timeout time.Duration) ClientOption {
+	return func(cfg *Configuration) {
+		cfg.WriteTimeout = timeout
+	}
+}
+
+// WithConnectTimeout sets the timeout of the connections to the hosts.
+func WithConnectTimeout(timeout time.Duration) ClientOption {
+	return func(cfg *Configuration) {
+		cfg.ConnectTimeout = timeout
+	}
+}
+
+// WithUserAgentSegment appends a segment, such as `MyApp (1.2.0)`, to the
+// user agent of the client, see UserAgentSegment.
+func WithUserAgentSegment(segment string) ClientOption {
+	return func(cfg *Configuration) {
+		cfg.UserAgent = AppendUserAgentSegment(cfg.UserAgent, segment)
+	}
+}
+
+// WithDefaultHeader sets a header sent with every request.
+func WithDefaultHeader(key, value string) ClientOption {
+	return func(cfg *Configuration) {
+		if cfg.DefaultHeader == nil {
+			cfg.DefaultHeader = map[string]string{}
+		}
+
+		cfg.DefaultHeader[key] = value
+	}
+}
+
+// WithCompression sets the compression of the request bodies.
+func WithCompression(c compression.Compression) ClientOption {
+	return func(cfg *Configuration) {
+		cfg.Compression = c
+	}
+}
+
+// WithRetryPolicy sets the retry policy of both the read and write calls.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(cfg *Configuration) {
+		cfg.ReadRetryPolicy = &policy
+		cfg.WriteRetryPolicy = &policy
+	}
+}
+
+// WithReadRateLimit caps the rate and the concurrency of the read calls.
+func WithReadRateLimit(limit RateLimit) ClientOption {
+	return func(cfg *Configuration) {
+		cfg.ReadRateLimit = &limit
+	}
+}
+
+// WithWriteRateLimit caps the rate and the concurrency of the write calls.
+func WithWriteRateLimit(limit RateLimit) ClientOption {
+	return func(cfg *Configuration) {
+		cfg.WriteRateLimit = &limit
+	}
+}
+
+// WithLogger sets the logger receiving the debug logs of the calls.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(cfg *Configuration) {
+		cfg.Logger = logger
+	}
+}
+
+// WithClock sets the clock timing the retries of the calls and of the
+// helpers, to fast-forward them in tests.
+func WithClock(clock Clock) ClientOption {
+	return func(cfg *Configuration) {
+		cfg.Clock = clock
+	}
+}
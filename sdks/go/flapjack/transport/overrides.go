@@ -0,0 +1,72 @@
+package transport
+
+import (
+	"time"
+)
+
+// ConfigurationOverrides are the settings of a client derived from another one
+// with its WithConfiguration method. Zero values keep the settings of the
+// original client.
+type ConfigurationOverrides struct {
+	// ApiKey replaces the API key, such as the key of a tenant.
+	ApiKey string //nolint:staticcheck
+	// DefaultHeader is merged into the default headers of the original
+	// client.
+	DefaultHeader map[string]string
+	// ReadTimeout and WriteTimeout replace the default timeouts of the read
+	// and write calls.
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+}
+
+// Apply returns a copy of the configuration with the overrides applied, along
+// with the timeouts the derived client must give to each of its calls, the
+// transport being shared with the original client.
+func (o ConfigurationOverrides) Apply(cfg Configuration) (Configuration, RequestConfiguration) {
+	var timeouts RequestConfiguration
+
+	if o.ApiKey != "" {
+		cfg.ApiKey = o.ApiKey
+	}
+
+	defaultHeader := make(map[string]string, len(cfg.DefaultHeader)+len(o.DefaultHeader))
+	for k, v := range cfg.DefaultHeader {
+		defaultHeader[k] = v
+	}
+
+	for k, v := range o.DefaultHeader {
+		defaultHeader[k] = v
+	}
+
+	cfg.DefaultHeader = defaultHeader
+
+	if o.ReadTimeout > 0 {
+		cfg.ReadTimeout = o.ReadTimeout
+		timeouts.ReadTimeout = &o.ReadTimeout
+	}
+
+	if o.WriteTimeout > 0 {
+		cfg.WriteTimeout = o.WriteTimeout
+		timeouts.WriteTimeout = &o.WriteTimeout
+	}
+
+	return cfg, timeouts
+}
+
+// InheritTimeouts returns the request configuration with its unset timeouts
+// taken from defaults.
+func (c RequestConfiguration) InheritTimeouts(defaults RequestConfiguration) RequestConfiguration {
+	if c.ReadTimeout == nil {
+		c.ReadTimeout = defaults.ReadTimeout
+	}
+
+	if c.WriteTimeout == nil {
+		c.WriteTimeout = defaults.WriteTimeout
+	}
+
+	if c.ConnectTimeout == nil {
+		c.ConnectTimeout = defaults.ConnectTimeout
+	}
+
+	return c
+}
@@ -0,0 +1,131 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Rate limit headers returned by the servers, or the proxies and gateways in front of them. The `RateLimit-*` headers
+// of the IETF draft are read when the `X-RateLimit-*` ones are missing.
+const (
+	RateLimitLimitHeader     = "X-RateLimit-Limit"
+	RateLimitRemainingHeader = "X-RateLimit-Remaining"
+	RateLimitResetHeader     = "X-RateLimit-Reset"
+)
+
+// resetEpochThreshold separates the reset headers given as a Unix timestamp from the ones given as a number of
+// seconds, which are much smaller.
+const resetEpochThreshold = 1_000_000_000
+
+// RateLimitInfo is the rate limit of the calls, as reported by the rate limit headers of a response.
+type RateLimitInfo struct {
+	// Limit is the number of calls allowed in the current window, -1 when not reported.
+	Limit int
+	// Remaining is the number of calls left in the current window, -1 when not reported.
+	Remaining int
+	// Reset is when the current window ends, zero when not reported.
+	Reset time.Time
+	// RetryAfter is the delay asked by a `Retry-After` header, 0 when not reported.
+	RetryAfter time.Duration
+	// ReceivedAt is when the response was received.
+	ReceivedAt time.Time
+}
+
+// ParseRateLimitInfo reads the rate limit headers of a response received at `now`, reporting whether it has any.
+func ParseRateLimitInfo(header http.Header, now time.Time) (RateLimitInfo, bool) {
+	info := RateLimitInfo{Limit: -1, Remaining: -1, ReceivedAt: now}
+	found := false
+
+	if limit, ok := rateLimitCount(header, RateLimitLimitHeader); ok {
+		info.Limit, found = limit, true
+	}
+
+	if remaining, ok := rateLimitCount(header, RateLimitRemainingHeader); ok {
+		info.Remaining, found = remaining, true
+	}
+
+	if reset, ok := rateLimitCount(header, RateLimitResetHeader); ok {
+		if reset >= resetEpochThreshold {
+			info.Reset = time.Unix(int64(reset), 0)
+		} else {
+			info.Reset = now.Add(time.Duration(reset) * time.Second)
+		}
+
+		found = true
+	}
+
+	if retryAfter, ok := parseRetryAfter(header.Get("Retry-After"), now); ok {
+		info.RetryAfter, found = retryAfter, true
+	}
+
+	return info, found
+}
+
+// rateLimitCount reads a non-negative rate limit header, or its IETF draft equivalent.
+func rateLimitCount(header http.Header, name string) (int, bool) {
+	value := header.Get(name)
+	if value == "" {
+		value = header.Get(strings.TrimPrefix(name, "X-"))
+	}
+
+	count, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || count < 0 {
+		return 0, false
+	}
+
+	return count, true
+}
+
+// Exhausted reports whether no call is left in the current window, or the server asked to wait.
+func (i RateLimitInfo) Exhausted() bool {
+	return i.Remaining == 0 || i.RetryAfter > 0
+}
+
+// Delay returns how long to wait at `now` before the next call, to self-throttle before being answered with a
+// `429 Too Many Requests`: 0 while calls are left, else until the end of the window or the delay asked by the server.
+func (i RateLimitInfo) Delay(now time.Time) time.Duration {
+	if !i.Exhausted() {
+		return 0
+	}
+
+	delay := i.ReceivedAt.Add(i.RetryAfter).Sub(now)
+
+	if i.Remaining == 0 && !i.Reset.IsZero() {
+		delay = max(delay, i.Reset.Sub(now))
+	}
+
+	return max(delay, 0)
+}
+
+// RateLimit returns the rate limit reported by the response, if any.
+func (i ResponseInfo) RateLimit() (RateLimitInfo, bool) {
+	if i.Header == nil {
+		return RateLimitInfo{}, false
+	}
+
+	return ParseRateLimitInfo(i.Header, i.ReceivedAt)
+}
+
+// LastRateLimit returns the rate limit reported by the last response received with rate limit headers, if any. The
+// transport is shared by derived clients, so is their last rate limit.
+func (t *Transport) LastRateLimit() (RateLimitInfo, bool) {
+	info := t.lastRateLimit.Load()
+	if info == nil {
+		return RateLimitInfo{}, false
+	}
+
+	return *info, true
+}
+
+// recordRateLimit keeps the rate limit reported by a response, if any.
+func (t *Transport) recordRateLimit(res *http.Response) {
+	if res == nil {
+		return
+	}
+
+	if info, ok := ParseRateLimitInfo(res.Header, t.clock.Now()); ok {
+		t.lastRateLimit.Store(&info)
+	}
+}
@@ -0,0 +1,123 @@
+package transport_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/call"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+func TestParseRateLimitInfo(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		header    http.Header
+		want      transport.RateLimitInfo
+		wantFound bool
+		wantDelay time.Duration
+	}{
+		{
+			name: "reset as a timestamp",
+			header: http.Header{
+				"X-Ratelimit-Limit":     {"100"},
+				"X-Ratelimit-Remaining": {"42"},
+				"X-Ratelimit-Reset":     {"1767323105"},
+			},
+			want:      transport.RateLimitInfo{Limit: 100, Remaining: 42, Reset: time.Unix(1767323105, 0), ReceivedAt: now},
+			wantFound: true,
+		},
+		{
+			name: "exhausted with the reset as seconds",
+			header: http.Header{
+				"Ratelimit-Limit":     {"100"},
+				"Ratelimit-Remaining": {"0"},
+				"Ratelimit-Reset":     {"30"},
+			},
+			want:      transport.RateLimitInfo{Limit: 100, Remaining: 0, Reset: now.Add(30 * time.Second), ReceivedAt: now},
+			wantFound: true,
+			wantDelay: 30 * time.Second,
+		},
+		{
+			name:      "retry after",
+			header:    http.Header{"Retry-After": {"5"}},
+			want:      transport.RateLimitInfo{Limit: -1, Remaining: -1, RetryAfter: 5 * time.Second, ReceivedAt: now},
+			wantFound: true,
+			wantDelay: 5 * time.Second,
+		},
+		{
+			name:   "invalid",
+			header: http.Header{"X-Ratelimit-Remaining": {"many"}},
+			want:   transport.RateLimitInfo{Limit: -1, Remaining: -1, ReceivedAt: now},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, found := transport.ParseRateLimitInfo(tt.header, now)
+			if found != tt.wantFound || got != tt.want {
+				t.Errorf("expected %+v (%v), got %+v (%v)", tt.want, tt.wantFound, got, found)
+			}
+
+			if delay := got.Delay(now); delay != tt.wantDelay {
+				t.Errorf("expected a delay of %s, got %s", tt.wantDelay, delay)
+			}
+		})
+	}
+}
+
+func TestTransportLastRateLimit(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/1/indexes/products/query" {
+			w.Header().Set(transport.RateLimitLimitHeader, "100")
+			w.Header().Set(transport.RateLimitRemainingHeader, "99")
+		}
+
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	tr := newTransport(transport.Configuration{}, srv)
+
+	if _, ok := tr.LastRateLimit(); ok {
+		t.Error("expected no rate limit before any call")
+	}
+
+	var info transport.ResponseInfo
+
+	_, _, err := tr.Request(context.Background(), newRequest(t), call.Read, transport.RequestConfiguration{ResponseInfo: &info})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if limit, ok := info.RateLimit(); !ok || limit.Remaining != 99 {
+		t.Errorf("expected the rate limit of the response, got %+v (%v)", limit, ok)
+	}
+
+	// A response without rate limit headers keeps the last rate limit.
+	req, err := http.NewRequest(http.MethodGet, "http://placeholder/1/indexes", nil)
+	if err != nil {
+		t.Fatalf("cannot create request: %v", err)
+	}
+
+	_, _, err = tr.Request(context.Background(), req, call.Read, transport.RequestConfiguration{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if limit, ok := tr.LastRateLimit(); !ok || limit.Limit != 100 || limit.Remaining != 99 || limit.ReceivedAt.IsZero() {
+		t.Errorf("expected the last rate limit, got %+v (%v)", limit, ok)
+	}
+}
@@ -0,0 +1,177 @@
+package transport_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/call"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/errs"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+// rateLimitedServer answers with a 429 and the given Retry-After header the given number of times, then succeeds.
+func rateLimitedServer(t *testing.T, retryAfter string, limited int32, calls *atomic.Int32) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= limited {
+			if retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"message":"Too many requests","status":429}`))
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestTransportWaitsForRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	tr := newTransport(transport.Configuration{}, rateLimitedServer(t, "0", 2, &calls))
+
+	res, _, err := tr.Request(context.Background(), newRequest(t), call.Write, transport.RequestConfiguration{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if res.StatusCode != http.StatusOK || calls.Load() != 3 {
+		t.Errorf("expected success after 2 rate limited calls, got %d after %d calls", res.StatusCode, calls.Load())
+	}
+}
+
+func TestTransportRateLimitFallsBackToResponse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		retryAfter string
+		policy     *transport.RetryPolicy
+		timeout    time.Duration
+		wantCalls  int32
+	}{
+		{name: "no retry-after", retryAfter: "", wantCalls: 1},
+		{name: "retry-after beyond max", retryAfter: "120", wantCalls: 1},
+		{name: "retry-after beyond deadline", retryAfter: "5", timeout: 100 * time.Millisecond, wantCalls: 1},
+		{name: "rate limit retries exhausted", retryAfter: "0", policy: &transport.RetryPolicy{MaxRateLimitRetries: 2}, wantCalls: 3},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls atomic.Int32
+
+			tr := newTransport(transport.Configuration{WriteRetryPolicy: tt.policy}, rateLimitedServer(t, tt.retryAfter, 100, &calls))
+
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+
+			res, _, err := tr.Request(ctx, newRequest(t), call.Write, transport.RequestConfiguration{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if res.StatusCode != http.StatusTooManyRequests {
+				t.Errorf("expected the 429 response, got %d", res.StatusCode)
+			}
+
+			if calls.Load() != tt.wantCalls {
+				t.Errorf("expected %d calls, got %d", tt.wantCalls, calls.Load())
+			}
+		})
+	}
+}
+
+func TestTransportExposesRateLimitErrors(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	tr := newTransport(transport.Configuration{ExposeRateLimitErrors: true}, rateLimitedServer(t, "5", 1, &calls))
+
+	_, _, err := tr.Request(context.Background(), newRequest(t), call.Read, transport.RequestConfiguration{})
+
+	var rateLimitErr *errs.RateLimitedError
+	if !errors.As(err, &rateLimitErr) {
+		t.Fatalf("expected a RateLimitedError, got %v", err)
+	}
+
+	if rateLimitErr.RetryAfter != 5*time.Second || calls.Load() != 1 {
+		t.Errorf("unexpected error %v after %d calls", rateLimitErr, calls.Load())
+	}
+}
+
+func TestTransportRetryAfterHTTPDate(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	past := time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat)
+	tr := newTransport(transport.Configuration{}, rateLimitedServer(t, past, 1, &calls))
+
+	res, _, err := tr.Request(context.Background(), newRequest(t), call.Read, transport.RequestConfiguration{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if res.StatusCode != http.StatusOK || calls.Load() != 2 {
+		t.Errorf("expected an immediate retry for a past date, got %d after %d calls", res.StatusCode, calls.Load())
+	}
+}
+
+func TestTransportSkipsBackoffAfterRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch calls.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusInternalServerError)
+		case 2:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	tr := newTransport(transport.Configuration{
+		ReadRetryPolicy: &transport.RetryPolicy{MaxAttempts: 2, BaseDelay: 300 * time.Millisecond, MaxDelay: time.Second},
+	}, srv)
+
+	// The backoff fits once in the timeout, but not twice.
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	_, _, err := tr.Request(ctx, newRequest(t), call.Read, transport.RequestConfiguration{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := calls.Load(); got != 3 {
+		t.Errorf("expected 3 calls, got %d", got)
+	}
+}
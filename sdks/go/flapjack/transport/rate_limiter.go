@@ -0,0 +1,130 @@
+package transport
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/call"
+)
+
+// RateLimit caps the calls of a kind sent by a client, for instance to keep a background indexer from starving the
+// search traffic of the same cluster. Calls over the limits wait, until their context is done.
+type RateLimit struct {
+	// OpsPerSecond is the sustained number of calls per second, unlimited
+	// when zero.
+	OpsPerSecond float64
+	// Burst is the number of calls that can be sent at once after a quiet
+	// period, 1 when zero.
+	Burst int
+	// MaxInFlight is the maximum number of calls in progress at once,
+	// unlimited when zero.
+	MaxInFlight int
+}
+
+// rateLimiter enforces a RateLimit with a token bucket and a semaphore.
+type rateLimiter struct {
+	rate     float64
+	burst    float64
+	inFlight chan struct{}
+	clock    Clock
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(limit *RateLimit, clock Clock) *rateLimiter {
+	if limit == nil || limit.OpsPerSecond <= 0 && limit.MaxInFlight <= 0 {
+		return nil
+	}
+
+	l := &rateLimiter{
+		rate:  limit.OpsPerSecond,
+		burst: float64(max(limit.Burst, 1)),
+		clock: clock,
+	}
+
+	l.tokens = l.burst
+
+	if limit.MaxInFlight > 0 {
+		l.inFlight = make(chan struct{}, limit.MaxInFlight)
+	}
+
+	return l
+}
+
+// acquire waits for the call to be allowed, and returns the function to call once it is done.
+func (l *rateLimiter) acquire(ctx context.Context) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	release := func() {}
+
+	if l.inFlight != nil {
+		select {
+		case l.inFlight <- struct{}{}:
+			release = func() { <-l.inFlight }
+		case <-ctx.Done():
+			return nil, fmt.Errorf("rate limiter: %w", ctx.Err())
+		}
+	}
+
+	delay := l.reserve()
+
+	err := l.clock.Sleep(ctx, delay)
+	if err != nil {
+		l.cancel()
+		release()
+
+		return nil, fmt.Errorf("rate limiter: %w", err)
+	}
+
+	return release, nil
+}
+
+// reserve takes a token from the bucket, and returns how long to wait for it to be available.
+func (l *rateLimiter) reserve() time.Duration {
+	if l.rate <= 0 {
+		return 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock.Now()
+	if !l.last.IsZero() {
+		l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+
+	l.last = now
+	l.tokens--
+
+	if l.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// cancel gives back a token reserved by a call which didn't wait for it.
+func (l *rateLimiter) cancel() {
+	if l.rate <= 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.tokens = min(l.burst, l.tokens+1)
+}
+
+func rateLimiterFor(k call.Kind, read *rateLimiter, write *rateLimiter) *rateLimiter {
+	if k == call.Write {
+		return write
+	}
+
+	return read
+}
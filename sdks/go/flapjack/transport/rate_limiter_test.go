@@ -0,0 +1,124 @@
+package transport_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/call"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+func TestRateLimitCapsCallsPerSecond(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	tr := newTransport(transport.Configuration{
+		WriteRateLimit: &transport.RateLimit{OpsPerSecond: 20},
+	}, flakyServer(t, 0, &calls))
+
+	start := time.Now()
+
+	for i := 0; i < 5; i++ {
+		_, _, err := tr.Request(context.Background(), newRequest(t), call.Write, transport.RequestConfiguration{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// The first call is sent right away, the 4 others wait 50ms each.
+	if elapsed := time.Since(start); elapsed < 180*time.Millisecond {
+		t.Errorf("expected the write calls to be spread over 200ms, took %s", elapsed)
+	}
+
+	start = time.Now()
+
+	for i := 0; i < 5; i++ {
+		_, _, err := tr.Request(context.Background(), newRequest(t), call.Read, transport.RequestConfiguration{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed >= 180*time.Millisecond {
+		t.Errorf("expected the read calls not to be limited, took %s", elapsed)
+	}
+}
+
+func TestRateLimitCapsCallsInFlight(t *testing.T) {
+	t.Parallel()
+
+	var inFlight, maxInFlight atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+
+		for {
+			current := maxInFlight.Load()
+			if n <= current || maxInFlight.CompareAndSwap(current, n) {
+				break
+			}
+		}
+
+		time.Sleep(20 * time.Millisecond)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	tr := newTransport(transport.Configuration{WriteRateLimit: &transport.RateLimit{MaxInFlight: 2}}, srv)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_, _, err := tr.Request(context.Background(), newRequest(t), call.Write, transport.RequestConfiguration{})
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if got := maxInFlight.Load(); got != 2 {
+		t.Errorf("expected 2 calls in flight at most, got %d", got)
+	}
+}
+
+func TestRateLimitStopsWaitingWithContext(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	tr := newTransport(transport.Configuration{
+		WriteRateLimit: &transport.RateLimit{OpsPerSecond: 0.1},
+	}, flakyServer(t, 0, &calls))
+
+	_, _, err := tr.Request(context.Background(), newRequest(t), call.Write, transport.RequestConfiguration{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, _, err = tr.Request(ctx, newRequest(t), call.Write, transport.RequestConfiguration{})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the deadline to be exceeded, got %v", err)
+	}
+
+	if calls.Load() != 1 {
+		t.Errorf("expected the second call not to be sent, got %d calls", calls.Load())
+	}
+}
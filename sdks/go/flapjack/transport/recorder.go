@@ -0,0 +1,243 @@
+package transport
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/compression"
+)
+
+// ErrInteractionNotRecorded is returned by the ReplayRequester for requests missing from its golden file.
+var ErrInteractionNotRecorded = errors.New("interaction not recorded")
+
+// Interaction is a request/response pair stored in a golden file. The requests are recorded without their host and
+// headers, so the golden files never contain API keys and can be replayed against any host.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the request of an Interaction.
+type RecordedRequest struct {
+	Method string `json:"method"`
+	// URL is the path and query string of the request.
+	URL  string `json:"url"`
+	Body string `json:"body,omitempty"`
+}
+
+// RecordedResponse is the response of an Interaction.
+type RecordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// RecordingRequester is a Requester sending the requests through another requester and recording the request/response
+// pairs to a golden file, to be served back by a ReplayRequester. The golden file is rewritten after every request.
+type RecordingRequester struct {
+	path      string
+	requester Requester
+
+	mu           sync.Mutex
+	interactions []Interaction
+}
+
+// NewRecordingRequester creates a requester recording the interactions of requester, the default requester when nil,
+// to the golden file at path. Its directory is created if needed.
+func NewRecordingRequester(path string, requester Requester) *RecordingRequester {
+	if requester == nil {
+		requester = NewDefaultRequester(nil)
+	}
+
+	return &RecordingRequester{path: path, requester: requester}
+}
+
+func (r *RecordingRequester) Request(req *http.Request, timeout time.Duration, connectTimeout time.Duration) (*http.Response, error) {
+	recordedReq, err := recordRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := r.requester.Request(req, timeout, connectTimeout)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	recordedRes, err := recordResponse(res)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.interactions = append(r.interactions, Interaction{Request: recordedReq, Response: recordedRes})
+
+	err = writeInteractions(r.path, r.interactions)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// ReplayRequester is a Requester serving the responses of a golden file written by a RecordingRequester, without
+// network access. A request is answered with the first unused interaction of the same method, URL and body, or with
+// the last one used when they all have been, so that polled requests such as WaitForTask keep getting the final
+// response.
+type ReplayRequester struct {
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// NewReplayRequester creates a requester serving the interactions of the golden file at path.
+func NewReplayRequester(path string) (*ReplayRequester, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read golden file: %w", err)
+	}
+
+	var interactions []Interaction
+
+	err = json.Unmarshal(raw, &interactions)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode golden file %s: %w", path, err)
+	}
+
+	return &ReplayRequester{interactions: interactions, used: make([]bool, len(interactions))}, nil
+}
+
+func (r *ReplayRequester) Request(req *http.Request, _, _ time.Duration) (*http.Response, error) {
+	recordedReq, err := recordRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	last := -1
+
+	for i, interaction := range r.interactions {
+		if interaction.Request != recordedReq {
+			continue
+		}
+
+		if !r.used[i] {
+			r.used[i] = true
+
+			return replayResponse(req, interaction.Response), nil
+		}
+
+		last = i
+	}
+
+	if last == -1 {
+		return nil, fmt.Errorf("%w: %s %s", ErrInteractionNotRecorded, recordedReq.Method, recordedReq.URL)
+	}
+
+	return replayResponse(req, r.interactions[last].Response), nil
+}
+
+// recordRequest captures the request, restoring its body for the actual call. Compressed bodies are recorded
+// decompressed, so that the golden files stay readable.
+func recordRequest(req *http.Request) (RecordedRequest, error) {
+	recorded := RecordedRequest{Method: req.Method, URL: req.URL.RequestURI()}
+
+	if req.Body == nil {
+		return recorded, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return recorded, fmt.Errorf("cannot read body: %w", err)
+	}
+
+	_ = req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	if c := compression.FromContentEncoding(req.Header.Get("Content-Encoding")); c != compression.NONE && compression.IsRegistered(c) {
+		reader, err := compression.NewReader(c, bytes.NewReader(body))
+		if err != nil {
+			return recorded, fmt.Errorf("cannot decompress body: %w", err)
+		}
+
+		defer reader.Close()
+
+		body, err = io.ReadAll(reader)
+		if err != nil {
+			return recorded, fmt.Errorf("cannot decompress body: %w", err)
+		}
+	}
+
+	recorded.Body = string(body)
+
+	return recorded, nil
+}
+
+// recordResponse captures the response, restoring its body for the caller. Compressed bodies are decoded before being
+// recorded.
+func recordResponse(res *http.Response) (RecordedResponse, error) {
+	err := decompressBody(res)
+	if err != nil {
+		return RecordedResponse{}, err
+	}
+
+	body, err := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+
+	if err != nil {
+		return RecordedResponse{}, fmt.Errorf("cannot read response: %w", err)
+	}
+
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	return RecordedResponse{StatusCode: res.StatusCode, Header: res.Header.Clone(), Body: string(body)}, nil
+}
+
+func replayResponse(req *http.Request, recorded RecordedResponse) *http.Response {
+	header := recorded.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader([]byte(recorded.Body))),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}
+}
+
+func writeInteractions(path string, interactions []Interaction) error {
+	raw, err := json.MarshalIndent(interactions, "", "  ")
+	if err != nil {
+		return fmt.Errorf("cannot encode interactions: %w", err)
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return fmt.Errorf("cannot create golden file directory: %w", err)
+	}
+
+	err = os.WriteFile(path, append(raw, '\n'), 0o600)
+	if err != nil {
+		return fmt.Errorf("cannot write golden file: %w", err)
+	}
+
+	return nil
+}
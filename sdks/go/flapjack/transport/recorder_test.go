@@ -0,0 +1,118 @@
+package transport_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/call"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"call":%d}`, calls.Add(1))
+	}))
+	t.Cleanup(srv.Close)
+
+	golden := filepath.Join(t.TempDir(), "testdata", "search.json")
+
+	recording := newTransport(transport.Configuration{Requester: transport.NewRecordingRequester(golden, nil)}, srv)
+
+	var recorded []string
+
+	for i := 0; i < 2; i++ {
+		req := newRequest(t)
+		req.Header.Set(transport.APIKeyHeader, "secret-api-key")
+
+		_, body, err := recording.Request(context.Background(), req, call.Read, transport.RequestConfiguration{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		recorded = append(recorded, string(body))
+	}
+
+	raw, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("cannot read golden file: %v", err)
+	}
+
+	if strings.Contains(string(raw), "secret-api-key") {
+		t.Errorf("expected the golden file not to contain the API key:\n%s", raw)
+	}
+
+	srv.Close()
+
+	replay, err := transport.NewReplayRequester(golden)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	replaying := transport.New(transport.Configuration{
+		Hosts:     []transport.StatefulHost{transport.NewStatefulHost("http", "replay.invalid", call.IsReadWrite)},
+		Requester: replay,
+	})
+
+	// The last interaction keeps being served once all of them have been used.
+	for i, want := range append(recorded, recorded[1]) {
+		res, body, err := replaying.Request(context.Background(), newRequest(t), call.Read, transport.RequestConfiguration{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if res.StatusCode != http.StatusOK || string(body) != want {
+			t.Errorf("request %d: expected %s, got %d %s", i, want, res.StatusCode, body)
+		}
+	}
+}
+
+func TestReplayRequesterRejectsUnrecordedRequests(t *testing.T) {
+	t.Parallel()
+
+	golden := filepath.Join(t.TempDir(), "search.json")
+
+	err := os.WriteFile(golden, []byte(`[{
+		"request": {"method": "POST", "url": "/1/indexes/products/query", "body": "{\"query\":\"phone\"}"},
+		"response": {"statusCode": 404, "body": "{\"message\":\"Index does not exist\"}"}
+	}]`), 0o600)
+	if err != nil {
+		t.Fatalf("cannot write golden file: %v", err)
+	}
+
+	replay, err := transport.NewReplayRequester(golden)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	res, err := replay.Request(newRequest(t), time.Second, time.Second)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	body, _ := io.ReadAll(res.Body)
+	if res.StatusCode != http.StatusNotFound || !strings.Contains(string(body), "Index does not exist") {
+		t.Errorf("unexpected replayed response: %d %s", res.StatusCode, body)
+	}
+
+	req, _ := http.NewRequest(http.MethodPost, "http://placeholder/1/indexes/products/query", strings.NewReader(`{"query":"tablet"}`))
+
+	_, err = replay.Request(req, time.Second, time.Second)
+	if !errors.Is(err, transport.ErrInteractionNotRecorded) {
+		t.Errorf("expected ErrInteractionNotRecorded, got %v", err)
+	}
+}
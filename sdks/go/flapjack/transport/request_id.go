@@ -0,0 +1,95 @@
+package transport
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+type (
+	requestIDKey       struct{}
+	requestIDHeaderKey struct{}
+)
+
+// WithRequestID returns a copy of ctx carrying the ID correlating the requests using it with the logs of the
+// application and the server, sent in the request ID header.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request ID of a request's context, if any.
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	requestID, ok := ctx.Value(requestIDKey{}).(string)
+
+	return requestID, ok && requestID != ""
+}
+
+// RequestIDFromResponse returns the ID identifying the request of a response: the one returned by the server in the
+// request ID header of the client, or else the one sent by the client.
+func RequestIDFromResponse(res *http.Response) string {
+	if res == nil {
+		return ""
+	}
+
+	if requestID := res.Header.Get(requestIDHeaderOf(res)); requestID != "" {
+		return requestID
+	}
+
+	if res.Request != nil {
+		requestID, _ := RequestIDFromContext(res.Request.Context())
+
+		return requestID
+	}
+
+	return ""
+}
+
+// requestIDHeaderOf returns the request ID header of the client having sent the request of a response,
+// RequestIDHeader for a response not sent by a Transport.
+func requestIDHeaderOf(res *http.Response) string {
+	if res.Request != nil {
+		if header, ok := res.Request.Context().Value(requestIDHeaderKey{}).(string); ok {
+			return header
+		}
+	}
+
+	return RequestIDHeader
+}
+
+// NewRandomRequestID generates a random request ID, to be used as Configuration.RequestIDGenerator.
+func NewRandomRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
+
+// withRequestID sets the request ID header of the request, from its context, the header already set, or the
+// generator, in that order. The returned context carries the request ID, if any, and the header, read back from the
+// responses.
+func (t *Transport) withRequestID(ctx context.Context, req *http.Request) context.Context {
+	header := t.requestIDHeader
+	if header == "" {
+		header = RequestIDHeader
+	}
+
+	ctx = context.WithValue(ctx, requestIDHeaderKey{}, header)
+
+	requestID, ok := RequestIDFromContext(ctx)
+	if !ok {
+		requestID = req.Header.Get(header)
+	}
+
+	if requestID == "" && t.requestIDGenerator != nil {
+		requestID = t.requestIDGenerator()
+	}
+
+	if requestID == "" {
+		return ctx
+	}
+
+	req.Header.Set(header, requestID)
+
+	return WithRequestID(ctx, requestID)
+}
@@ -0,0 +1,146 @@
+package transport_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/call"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+func TestTransportSendsRequestID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		cfg           transport.Configuration
+		ctx           context.Context
+		header        string
+		serverID      string
+		wantSent      string
+		wantResponse  string
+		wantGenerated bool
+	}{
+		{
+			name:         "from context",
+			ctx:          transport.WithRequestID(context.Background(), "ctx-id"),
+			header:       transport.RequestIDHeader,
+			wantSent:     "ctx-id",
+			wantResponse: "ctx-id",
+		},
+		{
+			name:         "returned by the server",
+			ctx:          transport.WithRequestID(context.Background(), "ctx-id"),
+			header:       transport.RequestIDHeader,
+			serverID:     "server-id",
+			wantSent:     "ctx-id",
+			wantResponse: "server-id",
+		},
+		{
+			name:         "returned by the server with a custom header",
+			cfg:          transport.Configuration{RequestIDHeader: "X-Correlation-Id"},
+			ctx:          transport.WithRequestID(context.Background(), "ctx-id"),
+			header:       "X-Correlation-Id",
+			serverID:     "server-id",
+			wantSent:     "ctx-id",
+			wantResponse: "server-id",
+		},
+		{
+			name:          "generated with a custom header",
+			cfg:           transport.Configuration{RequestIDHeader: "X-Correlation-Id", RequestIDGenerator: transport.NewRandomRequestID},
+			ctx:           context.Background(),
+			header:        "X-Correlation-Id",
+			wantGenerated: true,
+		},
+		{
+			name:   "none",
+			ctx:    context.Background(),
+			header: transport.RequestIDHeader,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var sent string
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				sent = r.Header.Get(tt.header)
+
+				if tt.serverID != "" {
+					w.Header().Set(tt.header, tt.serverID)
+				}
+
+				_, _ = w.Write([]byte(`{}`))
+			}))
+			t.Cleanup(srv.Close)
+
+			res, _, err := newTransport(tt.cfg, srv).Request(tt.ctx, newRequest(t), call.Read, transport.RequestConfiguration{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.wantGenerated {
+				if len(sent) != 32 || transport.RequestIDFromResponse(res) != sent {
+					t.Errorf("expected a generated request ID, sent %q, got %q", sent, transport.RequestIDFromResponse(res))
+				}
+
+				return
+			}
+
+			if sent != tt.wantSent {
+				t.Errorf("expected the request ID %q to be sent, got %q", tt.wantSent, sent)
+			}
+
+			if got := transport.RequestIDFromResponse(res); got != tt.wantResponse {
+				t.Errorf("expected the response request ID %q, got %q", tt.wantResponse, got)
+			}
+		})
+	}
+}
+
+func TestAPIErrorFallsBackToSentRequestID(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Index does not exist"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	ctx := transport.WithRequestID(context.Background(), "ctx-id")
+
+	res, body, err := newTransport(transport.Configuration{}, srv).Request(ctx, newRequest(t), call.Read, transport.RequestConfiguration{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if apiErr := transport.NewAPIError(res, body); apiErr.RequestID != "ctx-id" {
+		t.Errorf("expected the sent request ID, got %q", apiErr.RequestID)
+	}
+}
+
+func TestAPIErrorReadsConfiguredRequestIDHeader(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-Correlation-Id", "server-id")
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(srv.Close)
+
+	res, body, err := newTransport(transport.Configuration{RequestIDHeader: "X-Correlation-Id"}, srv).Request(context.Background(), newRequest(t), call.Read, transport.RequestConfiguration{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if apiErr := transport.NewAPIError(res, body); apiErr.RequestID != "server-id" {
+		t.Errorf("expected the request ID of the configured header, got %q", apiErr.RequestID)
+	}
+}
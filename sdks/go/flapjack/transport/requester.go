@@ -1,8 +1,14 @@
 package transport
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
 	"net"
 	"net/http"
+	"net/url"
+	"os"
 	"time"
 )
 
@@ -14,6 +20,34 @@ const (
 	DefaultTLSHandshakeTimeout = 2 * time.Second
 )
 
+// HTTPTransportOptions tunes the *http.Transport of the default requester.
+type HTTPTransportOptions struct {
+	// MaxIdleConnsPerHost is the maximum number of idle connections kept per
+	// host, DefaultMaxIdleConnsPerHost when zero.
+	MaxIdleConnsPerHost int
+	// MaxConnsPerHost limits the number of connections per host, including
+	// connections in use. Zero means no limit.
+	MaxConnsPerHost int
+	// IdleConnTimeout is how long an idle connection is kept open,
+	// DefaultMaxIdleTimeout when zero.
+	IdleConnTimeout time.Duration
+	// DisableKeepAlives opens a new connection for every request.
+	DisableKeepAlives bool
+	// ForceAttemptHTTP2 negotiates HTTP/2 with the hosts that support it.
+	ForceAttemptHTTP2 bool
+	// Proxy is the URL of the proxy the requests go through. When nil, the
+	// proxy is read from the HTTP_PROXY, HTTPS_PROXY and NO_PROXY
+	// environment variables.
+	Proxy *url.URL
+	// TLSConfig is the TLS configuration of the connections, for instance to
+	// trust a private certificate authority or present a client certificate,
+	// see LoadTLSConfig.
+	TLSConfig *tls.Config
+	// InsecureSkipVerify disables the verification of the hosts'
+	// certificates. It must only be used for testing.
+	InsecureSkipVerify bool
+}
+
 // DefaultHTTPClient exposes the default *http.Client used by the different
 // Client instances of the Flapjack API client.
 //
@@ -30,24 +64,128 @@ func DefaultHTTPClient(connectTimeout *time.Duration) *http.Client {
 	}
 
 	return &http.Client{
-		Transport: &http.Transport{
-			DialContext: (&net.Dialer{
-				KeepAlive: DefaultKeepAliveDuration,
-				Timeout:   connectTimeoutValue,
-			}).DialContext,
-			DisableKeepAlives:   false,
-			MaxIdleConnsPerHost: DefaultMaxIdleConnsPerHost,
-			IdleConnTimeout:     DefaultMaxIdleTimeout,
-			Proxy:               http.ProxyFromEnvironment,
-			TLSHandshakeTimeout: DefaultTLSHandshakeTimeout,
+		Transport: NewHTTPTransport(connectTimeoutValue, HTTPTransportOptions{}),
+	}
+}
+
+// NewHTTPTransport creates the *http.Transport of the default requester, tuned
+// with the given options.
+func NewHTTPTransport(connectTimeout time.Duration, opts HTTPTransportOptions) *http.Transport {
+	maxIdleConnsPerHost := opts.MaxIdleConnsPerHost
+	if maxIdleConnsPerHost == 0 {
+		maxIdleConnsPerHost = DefaultMaxIdleConnsPerHost
+	}
+
+	idleConnTimeout := opts.IdleConnTimeout
+	if idleConnTimeout == 0 {
+		idleConnTimeout = DefaultMaxIdleTimeout
+	}
+
+	proxy := http.ProxyFromEnvironment
+	if opts.Proxy != nil {
+		proxy = http.ProxyURL(opts.Proxy)
+	}
+
+	tlsConfig := opts.TLSConfig
+	if opts.InsecureSkipVerify {
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{} //nolint:gosec
+		} else {
+			tlsConfig = tlsConfig.Clone()
+		}
+
+		tlsConfig.InsecureSkipVerify = true //nolint:gosec
+	}
+
+	dialer := &net.Dialer{
+		KeepAlive: DefaultKeepAliveDuration,
+		Timeout:   connectTimeout,
+	}
+
+	return &http.Transport{
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			if socketPath, ok := UnixSocketFromContext(ctx); ok {
+				return dialer.DialContext(ctx, "unix", socketPath)
+			}
+
+			return dialer.DialContext(ctx, network, addr)
 		},
+		DisableKeepAlives:   opts.DisableKeepAlives,
+		ForceAttemptHTTP2:   opts.ForceAttemptHTTP2,
+		MaxIdleConnsPerHost: maxIdleConnsPerHost,
+		MaxConnsPerHost:     opts.MaxConnsPerHost,
+		IdleConnTimeout:     idleConnTimeout,
+		Proxy: func(req *http.Request) (*url.URL, error) {
+			if _, ok := UnixSocketFromContext(req.Context()); ok {
+				return nil, nil
+			}
+
+			return proxy(req)
+		},
+		TLSClientConfig:     tlsConfig,
+		TLSHandshakeTimeout: DefaultTLSHandshakeTimeout,
+	}
+}
+
+// LoadTLSConfig creates a TLS configuration trusting the certificate
+// authorities of the PEM-encoded caFile in addition to the system ones, and
+// presenting the client certificate of certFile and keyFile for mutual TLS.
+// Empty file names are skipped.
+func LoadTLSConfig(caFile, certFile, keyFile string) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if caFile != "" {
+		caBundle, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read CA bundle: %w", err)
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+
+		if !pool.AppendCertsFromPEM(caBundle) {
+			return nil, fmt.Errorf("no certificate found in %s", caFile)
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load client certificate: %w", err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{cert}
 	}
+
+	return tlsConfig, nil
 }
 
+// Requester sends the HTTP requests of the transport, one per attempt. Wrap the
+// default one, or an existing *http.Client with NewRequesterFromHTTPClient, to
+// add middlewares such as tracing or a corporate authentication proxy.
+//
+// The timeout and connectTimeout of the attempt are given for information: the
+// transport already enforces the timeout through the context of the request.
+// Responses with a non-2xx status must be returned without error, with their
+// body unread, as the transport decides whether to retry them.
 type Requester interface {
 	Request(req *http.Request, timeout time.Duration, connectTimeout time.Duration) (*http.Response, error)
 }
 
+// RequesterFunc is a Requester made of a function.
+type RequesterFunc func(req *http.Request, timeout time.Duration, connectTimeout time.Duration) (*http.Response, error)
+
+// Request calls f.
+func (f RequesterFunc) Request(req *http.Request, timeout time.Duration, connectTimeout time.Duration) (*http.Response, error) {
+	return f(req, timeout, connectTimeout)
+}
+
 type defaultRequester struct {
 	client *http.Client
 }
@@ -58,6 +196,32 @@ func NewDefaultRequester(connectTimeout *time.Duration) *defaultRequester {
 	}
 }
 
+// NewDefaultRequesterWithTransport creates a default requester sending the
+// requests through the given *http.Transport.
+func NewDefaultRequesterWithTransport(httpTransport *http.Transport) *defaultRequester {
+	return &defaultRequester{
+		client: &http.Client{
+			Transport: httpTransport,
+		},
+	}
+}
+
+// NewRequesterFromHTTPClient creates a requester sending the requests through
+// an existing *http.Client, with its RoundTripper chain, cookie jar and
+// redirect policy, http.DefaultClient being used when nil. The timeouts of the
+// client apply on top of the ones of the transport. Unix socket hosts need a
+// RoundTripper dialing the socket of UnixSocketFromContext, like the one of
+// NewHTTPTransport.
+func NewRequesterFromHTTPClient(client *http.Client) *defaultRequester {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &defaultRequester{
+		client: client,
+	}
+}
+
 func (r *defaultRequester) Request(req *http.Request, _, _ time.Duration) (*http.Response, error) {
 	return r.client.Do(req) //nolint:wrapcheck
 }
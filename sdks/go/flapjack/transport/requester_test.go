@@ -0,0 +1,127 @@
+package transport_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/call"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+func TestNewHTTPTransport(t *testing.T) {
+	t.Parallel()
+
+	defaults := transport.NewHTTPTransport(time.Second, transport.HTTPTransportOptions{})
+	if defaults.MaxIdleConnsPerHost != transport.DefaultMaxIdleConnsPerHost || defaults.IdleConnTimeout != transport.DefaultMaxIdleTimeout ||
+		defaults.MaxConnsPerHost != 0 || defaults.DisableKeepAlives || defaults.ForceAttemptHTTP2 {
+		t.Errorf("unexpected default transport %+v", defaults)
+	}
+
+	tuned := transport.NewHTTPTransport(time.Second, transport.HTTPTransportOptions{
+		MaxIdleConnsPerHost: 8,
+		MaxConnsPerHost:     16,
+		IdleConnTimeout:     time.Minute,
+		DisableKeepAlives:   true,
+		ForceAttemptHTTP2:   true,
+	})
+	if tuned.MaxIdleConnsPerHost != 8 || tuned.MaxConnsPerHost != 16 || tuned.IdleConnTimeout != time.Minute ||
+		!tuned.DisableKeepAlives || !tuned.ForceAttemptHTTP2 {
+		t.Errorf("unexpected tuned transport %+v", tuned)
+	}
+}
+
+func TestTransportUsesHTTPTransport(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	var dials atomic.Int32
+
+	httpTransport := &http.Transport{
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			dials.Add(1)
+
+			return (&net.Dialer{}).DialContext(ctx, network, addr)
+		},
+	}
+
+	tr := newTransport(transport.Configuration{HTTPTransport: httpTransport}, srv)
+
+	_, _, err := tr.Request(context.Background(), newRequest(t), call.Read, transport.RequestConfiguration{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if dials.Load() != 1 {
+		t.Errorf("expected the custom transport to dial once, got %d", dials.Load())
+	}
+}
+
+// headerRoundTripper sets a header on the requests, like an authentication proxy would.
+type headerRoundTripper struct {
+	next http.RoundTripper
+}
+
+func (rt headerRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Proxy-Authorization", "Bearer corp-token")
+
+	return rt.next.RoundTrip(req) //nolint:wrapcheck
+}
+
+func TestNewRequesterFromHTTPClient(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Proxy-Authorization"); got != "Bearer corp-token" {
+			t.Errorf("expected the round tripper of the client to run, got %q", got)
+		}
+
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	cfg := transport.Configuration{}
+	transport.WithHTTPClient(&http.Client{Transport: headerRoundTripper{next: http.DefaultTransport}})(&cfg)
+
+	_, _, err := newTransport(cfg, srv).Request(context.Background(), newRequest(t), call.Read, transport.RequestConfiguration{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestRequesterFunc(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	var attempts atomic.Int32
+
+	next := transport.NewRequesterFromHTTPClient(nil)
+
+	requester := transport.RequesterFunc(func(req *http.Request, timeout, connectTimeout time.Duration) (*http.Response, error) {
+		attempts.Add(1)
+
+		return next.Request(req, timeout, connectTimeout)
+	})
+
+	_, _, err := newTransport(transport.Configuration{Requester: requester}, srv).Request(context.Background(), newRequest(t), call.Read, transport.RequestConfiguration{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if attempts.Load() != 1 {
+		t.Errorf("expected the function to send the request, got %d calls", attempts.Load())
+	}
+}
@@ -0,0 +1,48 @@
+package transport
+
+import (
+	"net/http"
+	"time"
+)
+
+// ResponseInfo is the metadata of the response of a call, filled by the transport when given as the
+// RequestConfiguration.ResponseInfo of the call, for debugging and quota tracking.
+type ResponseInfo struct {
+	// StatusCode is the HTTP status of the response, 0 when no host answered.
+	StatusCode int
+	// Header is the header of the response, with its rate limit headers.
+	Header http.Header
+	// RequestID identifies the request, as returned by RequestIDFromResponse.
+	RequestID string
+	// Host is the host which answered.
+	Host string
+	// Attempts is the number of hosts tried, 1 when the first one answered.
+	Attempts int
+	// Duration is the duration of the call, from its start to the reading of the response body, retries and
+	// waits of the rate limiter included.
+	Duration time.Duration
+	// ReceivedAt is when the response was received, zero when no host answered.
+	ReceivedAt time.Time
+}
+
+// fill sets the metadata of the response of a call started at start and finished at now.
+func (i *ResponseInfo) fill(res *http.Response, start, now time.Time) {
+	*i = ResponseInfo{Duration: now.Sub(start)}
+
+	if res == nil {
+		return
+	}
+
+	i.ReceivedAt = now
+	i.StatusCode = res.StatusCode
+	i.Header = res.Header.Clone()
+	i.RequestID = RequestIDFromResponse(res)
+
+	if res.Request != nil {
+		hosts := attemptedHostsFromContext(res.Request.Context())
+		if len(hosts) > 0 {
+			i.Host = hosts[len(hosts)-1]
+			i.Attempts = len(hosts)
+		}
+	}
+}
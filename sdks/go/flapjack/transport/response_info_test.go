@@ -0,0 +1,62 @@
+package transport_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/call"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+func TestTransportFillsResponseInfo(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	failing := flakyServer(t, 1, &calls)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "9")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	var info transport.ResponseInfo
+
+	ctx := transport.WithRequestID(context.Background(), "ctx-id")
+
+	_, _, err := newTransport(transport.Configuration{}, failing, srv).Request(ctx, newRequest(t), call.Write, transport.RequestConfiguration{ResponseInfo: &info})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if info.StatusCode != http.StatusCreated || info.Header.Get("X-RateLimit-Remaining") != "9" || info.RequestID != "ctx-id" {
+		t.Errorf("unexpected response info %+v", info)
+	}
+
+	if info.Host != strings.TrimPrefix(srv.URL, "http://") || info.Attempts != 2 || info.Duration <= 0 {
+		t.Errorf("expected the second host to answer after 2 attempts, got %+v", info)
+	}
+}
+
+func TestTransportFillsResponseInfoWithoutResponse(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	info := transport.ResponseInfo{StatusCode: http.StatusOK, Host: "stale"}
+
+	_, _, err := newTransport(transport.Configuration{}, flakyServer(t, 1, &calls)).Request(context.Background(), newRequest(t), call.Read, transport.RequestConfiguration{ResponseInfo: &info})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if info.StatusCode != 0 || info.Host != "" || info.Header != nil || info.Duration <= 0 {
+		t.Errorf("expected only the duration of the call, got %+v", info)
+	}
+}
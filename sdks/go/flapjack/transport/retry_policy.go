@@ -0,0 +1,206 @@
+package transport
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/call"
+)
+
+const (
+	DefaultRetryMaxDelay       = 5 * time.Second
+	DefaultMaxRateLimitRetries = 3
+	DefaultMaxRetryAfter       = time.Minute
+)
+
+// RetryPolicy controls how many times a call is attempted and how long the
+// transport waits between two attempts.
+//
+// Attempts go through the tryable hosts in order, and start over from the
+// first host once all of them have been tried, so a single host can be retried
+// several times.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts for a call, including the
+	// first one. When zero, each tryable host is attempted once.
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry, doubled after every
+	// subsequent attempt. When zero, hosts are retried without waiting.
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between two attempts. DefaultRetryMaxDelay is
+	// used when zero.
+	MaxDelay time.Duration
+	// Jitter is the fraction, between 0 and 1, of each delay that is
+	// randomized so that clients don't retry in lockstep.
+	Jitter float64
+	// MaxRateLimitRetries is the maximum number of times a call is retried
+	// after a `429 Too Many Requests` response with a `Retry-After` header.
+	// These retries don't count towards MaxAttempts.
+	// DefaultMaxRateLimitRetries is used when zero.
+	MaxRateLimitRetries int
+	// MaxRetryAfter is the longest `Retry-After` delay the transport waits
+	// for, longer delays end the call with the `429` response.
+	// DefaultMaxRetryAfter is used when zero.
+	MaxRetryAfter time.Duration
+	// MinAttemptDuration is the shortest time an attempt needs to finish.
+	// When less time is left before the deadline of the context of the call
+	// once the delay before an attempt is over, the attempt is not started
+	// and the call fails with an *errs.DeadlineExceededError. When zero,
+	// attempts are only given up when their delay ends after the deadline.
+	MinAttemptDuration time.Duration
+	// RetryableStatusCodes, when set, are the response statuses retried on
+	// the next host, such as 408, 429 and the 5xx, any other non-2xx status
+	// ending the call with its response. When nil, every status other than
+	// the 2xx and 4xx is retried.
+	RetryableStatusCodes []int
+	// NoRetryMethods are the HTTP methods of the calls never retried after
+	// a response or a network error, such as POST for writes that must not
+	// be applied twice when a response is lost. Calls answered with a `429`
+	// and a `Retry-After` header are still retried, as the server did not
+	// process them.
+	NoRetryMethods []string
+}
+
+// DefaultRetryPolicy tries every host once, without waiting between attempts.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxDelay: DefaultRetryMaxDelay,
+	}
+}
+
+// Delay returns the time to wait before the given attempt, the first attempt
+// being 0 and having no delay.
+func (p RetryPolicy) Delay(attempt int) time.Duration {
+	if attempt <= 0 || p.BaseDelay <= 0 {
+		return 0
+	}
+
+	maxDelay := p.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = DefaultRetryMaxDelay
+	}
+
+	delay := p.BaseDelay
+	for i := 1; i < attempt && delay < maxDelay; i++ {
+		delay *= 2
+	}
+
+	delay = min(delay, maxDelay)
+
+	jitter := min(max(p.Jitter, 0), 1)
+	if jitter > 0 {
+		delay -= time.Duration(jitter * rand.Float64() * float64(delay))
+	}
+
+	return delay
+}
+
+func (p RetryPolicy) maxRateLimitRetries() int {
+	if p.MaxRateLimitRetries > 0 {
+		return p.MaxRateLimitRetries
+	}
+
+	return DefaultMaxRateLimitRetries
+}
+
+// canWaitFor reports whether the transport should wait for the given
+// `Retry-After` delay, which must fit both in MaxRetryAfter and in the
+// remaining time before the context deadline, as of now.
+func (p RetryPolicy) canWaitFor(ctx context.Context, retryAfter time.Duration, now time.Time) bool {
+	maxRetryAfter := p.MaxRetryAfter
+	if maxRetryAfter <= 0 {
+		maxRetryAfter = DefaultMaxRetryAfter
+	}
+
+	if retryAfter > maxRetryAfter {
+		return false
+	}
+
+	deadline, ok := ctx.Deadline()
+
+	return !ok || deadline.Sub(now) > retryAfter
+}
+
+// decide adjusts the outcome of an attempt decided by the retry strategy to
+// the retryable statuses and methods of the policy.
+func (p RetryPolicy) decide(outcome Outcome, method string, code int) Outcome {
+	if outcome == Success {
+		return outcome
+	}
+
+	if slices.ContainsFunc(p.NoRetryMethods, func(m string) bool { return strings.EqualFold(m, method) }) {
+		return Failure
+	}
+
+	if p.RetryableStatusCodes == nil || code == 0 {
+		return outcome
+	}
+
+	if slices.Contains(p.RetryableStatusCodes, code) {
+		return Retry
+	}
+
+	return Failure
+}
+
+// exceedsDeadline reports whether an attempt started after the given delay
+// cannot finish before the deadline of the context, with the time left as of
+// now.
+func (p RetryPolicy) exceedsDeadline(ctx context.Context, delay time.Duration, now time.Time) (time.Duration, bool) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0, false
+	}
+
+	remaining := deadline.Sub(now)
+
+	return remaining, remaining-delay <= p.MinAttemptDuration
+}
+
+func (p RetryPolicy) maxAttempts(nbHosts int) int {
+	if p.MaxAttempts > 0 {
+		return p.MaxAttempts
+	}
+
+	return nbHosts
+}
+
+// parseRetryAfter parses the value of a `Retry-After` header, either a number
+// of seconds or an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	seconds, err := strconv.Atoi(value)
+	if err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	return max(date.Sub(now), 0), true
+}
+
+func retryPolicyFor(k call.Kind, read *RetryPolicy, write *RetryPolicy) RetryPolicy {
+	switch {
+	case k == call.Read && read != nil:
+		return *read
+	case k == call.Write && write != nil:
+		return *write
+	default:
+		return DefaultRetryPolicy()
+	}
+}
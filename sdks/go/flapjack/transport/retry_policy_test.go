@@ -0,0 +1,331 @@
+package transport_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/call"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/errs"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+func TestRetryPolicyDelay(t *testing.T) {
+	t.Parallel()
+
+	policy := transport.RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{0, 0},
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{3, 400 * time.Millisecond},
+		{4, 800 * time.Millisecond},
+		{5, time.Second},
+		{50, time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := policy.Delay(tt.attempt); got != tt.want {
+			t.Errorf("Delay(%d) = %s, want %s", tt.attempt, got, tt.want)
+		}
+	}
+
+	if got := transport.DefaultRetryPolicy().Delay(3); got != 0 {
+		t.Errorf("expected no delay by default, got %s", got)
+	}
+}
+
+func TestRetryPolicyJitter(t *testing.T) {
+	t.Parallel()
+
+	policy := transport.RetryPolicy{BaseDelay: 100 * time.Millisecond, Jitter: 0.5}
+
+	for i := 0; i < 100; i++ {
+		got := policy.Delay(2)
+		if got < 100*time.Millisecond || got > 200*time.Millisecond {
+			t.Fatalf("expected a delay within [100ms, 200ms], got %s", got)
+		}
+	}
+}
+
+// newTransport returns a transport targeting the given servers, in order.
+func newTransport(cfg transport.Configuration, servers ...*httptest.Server) *transport.Transport {
+	for _, srv := range servers {
+		cfg.Hosts = append(cfg.Hosts, transport.NewStatefulHost("http", strings.TrimPrefix(srv.URL, "http://"), call.IsReadWrite))
+	}
+
+	return transport.New(cfg)
+}
+
+func newRequest(t *testing.T) *http.Request {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, "http://placeholder/1/indexes/products/query", strings.NewReader(`{"query":"phone"}`))
+	if err != nil {
+		t.Fatalf("cannot create request: %v", err)
+	}
+
+	return req
+}
+
+// flakyServer fails with a 500 the given number of times, then succeeds.
+func flakyServer(t *testing.T, failures int32, calls *atomic.Int32) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= failures {
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestTransportTriesEachHostOnceByDefault(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	tr := newTransport(transport.Configuration{}, flakyServer(t, 1, &calls))
+
+	_, _, err := tr.Request(context.Background(), newRequest(t), call.Read, transport.RequestConfiguration{})
+	if !errors.Is(err, errs.ErrNoMoreHostToTry) {
+		t.Fatalf("expected ErrNoMoreHostToTry, got %v", err)
+	}
+
+	if calls.Load() != 1 {
+		t.Errorf("expected a single attempt, got %d", calls.Load())
+	}
+}
+
+func TestTransportRetriesWithBackoff(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	tr := newTransport(transport.Configuration{
+		ReadRetryPolicy: &transport.RetryPolicy{MaxAttempts: 3, BaseDelay: 20 * time.Millisecond},
+	}, flakyServer(t, 2, &calls))
+
+	start := time.Now()
+
+	res, body, err := tr.Request(context.Background(), newRequest(t), call.Read, transport.RequestConfiguration{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if res.StatusCode != http.StatusOK || string(body) != "{}" {
+		t.Errorf("unexpected response %d %s", res.StatusCode, body)
+	}
+
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 attempts, got %d", calls.Load())
+	}
+
+	if elapsed := time.Since(start); elapsed < 60*time.Millisecond {
+		t.Errorf("expected at least 60ms of backoff, took %s", elapsed)
+	}
+}
+
+func TestTransportRetryPolicyPerCallKind(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	tr := newTransport(transport.Configuration{
+		ReadRetryPolicy: &transport.RetryPolicy{MaxAttempts: 5},
+	}, flakyServer(t, 10, &calls))
+
+	_, _, err := tr.Request(context.Background(), newRequest(t), call.Write, transport.RequestConfiguration{})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if calls.Load() != 1 {
+		t.Errorf("expected the read policy not to apply to writes, got %d attempts", calls.Load())
+	}
+}
+
+func TestTransportFailsOverToNextHost(t *testing.T) {
+	t.Parallel()
+
+	var downCalls, upCalls atomic.Int32
+
+	tr := newTransport(transport.Configuration{}, flakyServer(t, 100, &downCalls), flakyServer(t, 0, &upCalls))
+
+	for i := 0; i < 3; i++ {
+		_, _, err := tr.Request(context.Background(), newRequest(t), call.Read, transport.RequestConfiguration{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if downCalls.Load() != 1 || upCalls.Load() != 3 {
+		t.Errorf("expected the failing host to be skipped once marked down, got %d/%d calls", downCalls.Load(), upCalls.Load())
+	}
+}
+
+func TestTransportBackoffHonorsContext(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	tr := newTransport(transport.Configuration{
+		ReadRetryPolicy: &transport.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Hour, MaxDelay: time.Hour},
+	}, flakyServer(t, 10, &calls))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, _, err := tr.Request(ctx, newRequest(t), call.Read, transport.RequestConfiguration{})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+
+	if calls.Load() != 1 {
+		t.Errorf("expected a single attempt before the deadline, got %d", calls.Load())
+	}
+}
+
+func TestTransportRetryableStatusCodes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		policy     transport.RetryPolicy
+		status     int
+		wantCalls  int32
+		wantStatus int
+	}{
+		{
+			name:       "4xx not retried by default",
+			policy:     transport.RetryPolicy{MaxAttempts: 3},
+			status:     http.StatusRequestTimeout,
+			wantCalls:  1,
+			wantStatus: http.StatusRequestTimeout,
+		},
+		{
+			name:       "listed status retried",
+			policy:     transport.RetryPolicy{MaxAttempts: 3, RetryableStatusCodes: []int{http.StatusRequestTimeout, http.StatusServiceUnavailable}},
+			status:     http.StatusRequestTimeout,
+			wantCalls:  2,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "unlisted 5xx not retried",
+			policy:     transport.RetryPolicy{MaxAttempts: 3, RetryableStatusCodes: []int{http.StatusServiceUnavailable}},
+			status:     http.StatusInternalServerError,
+			wantCalls:  1,
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "method never retried",
+			policy:     transport.RetryPolicy{MaxAttempts: 3, NoRetryMethods: []string{"post"}},
+			status:     http.StatusServiceUnavailable,
+			wantCalls:  1,
+			wantStatus: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls atomic.Int32
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if calls.Add(1) == 1 {
+					w.WriteHeader(tt.status)
+
+					return
+				}
+
+				_, _ = w.Write([]byte(`{}`))
+			}))
+			t.Cleanup(srv.Close)
+
+			res, _, err := newTransport(transport.Configuration{WriteRetryPolicy: &tt.policy}, srv).
+				Request(context.Background(), newRequest(t), call.Write, transport.RequestConfiguration{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if res.StatusCode != tt.wantStatus || calls.Load() != tt.wantCalls {
+				t.Errorf("expected status %d after %d calls, got %d after %d", tt.wantStatus, tt.wantCalls, res.StatusCode, calls.Load())
+			}
+		})
+	}
+}
+
+func TestTransportGivesUpRetriesPastDeadline(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		policy    transport.RetryPolicy
+		wantCalls int32
+	}{
+		{
+			name:      "backoff ending after the deadline",
+			policy:    transport.RetryPolicy{MaxAttempts: 3, BaseDelay: 10 * time.Second, MaxDelay: 10 * time.Second},
+			wantCalls: 1,
+		},
+		{
+			name:      "attempt too long for the deadline",
+			policy:    transport.RetryPolicy{MaxAttempts: 3, MinAttemptDuration: 10 * time.Second},
+			wantCalls: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls atomic.Int32
+
+			tr := newTransport(transport.Configuration{ReadRetryPolicy: &tt.policy}, flakyServer(t, 10, &calls))
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			start := time.Now()
+
+			_, _, err := tr.Request(ctx, newRequest(t), call.Read, transport.RequestConfiguration{})
+
+			var deadlineErr *errs.DeadlineExceededError
+			if !errors.As(err, &deadlineErr) || !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("expected an *errs.DeadlineExceededError, got %v", err)
+			}
+
+			if len(deadlineErr.Hosts) != int(tt.wantCalls) || calls.Load() != tt.wantCalls {
+				t.Errorf("expected %d attempts, got %d reported and %d made", tt.wantCalls, len(deadlineErr.Hosts), calls.Load())
+			}
+
+			if tt.wantCalls > 0 && (deadlineErr.Err == nil || !strings.Contains(deadlineErr.Err.Error(), "StatusCode=500")) {
+				t.Errorf("expected the failure of the last attempt, got %v", deadlineErr.Err)
+			}
+
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("expected the call to give up without waiting for the deadline, took %s", elapsed)
+			}
+		})
+	}
+}
@@ -36,9 +36,19 @@ type RetryStrategy struct {
 	hosts        []StatefulHost
 	writeTimeout time.Duration
 	readTimeout  time.Duration
+
+	// failureThreshold is the number of consecutive failures marking a host
+	// down, and expire tells whether down hosts come back after
+	// DefaultResetPeriod rather than through probes.
+	failureThreshold int
+	expire           bool
+	onDown           func()
+
+	// clock dates the changes of the statuses of the hosts.
+	clock Clock
 }
 
-func newRetryStrategy(hosts []StatefulHost, readTimeout, writeTimeout time.Duration) *RetryStrategy {
+func newRetryStrategy(hosts []StatefulHost, readTimeout, writeTimeout time.Duration, clock Clock) *RetryStrategy {
 	if readTimeout == 0 {
 		readTimeout = DefaultReadTimeout
 	}
@@ -47,10 +57,19 @@ func newRetryStrategy(hosts []StatefulHost, readTimeout, writeTimeout time.Durat
 		writeTimeout = DefaultWriteTimeout
 	}
 
+	hosts = append([]StatefulHost(nil), hosts...)
+	for i := range hosts {
+		hosts[i].lastUpdate = clock.Now()
+	}
+
 	return &RetryStrategy{
 		hosts:        hosts,
 		readTimeout:  readTimeout,
 		writeTimeout: writeTimeout,
+
+		failureThreshold: DefaultFailureThreshold,
+		expire:           true,
+		clock:            clock,
 	}
 }
 
@@ -58,9 +77,11 @@ func (s *RetryStrategy) GetTryableHosts(k call.Kind) []Host {
 	s.Lock()
 	defer s.Unlock()
 
-	for _, h := range s.hosts {
-		if h.isExpired() {
-			h.reset()
+	now := s.clock.Now()
+
+	for i := range s.hosts {
+		if s.expire && s.hosts[i].isExpired(now) {
+			s.hosts[i].reset(now)
 		}
 	}
 
@@ -87,13 +108,26 @@ func (s *RetryStrategy) GetTryableHosts(k call.Kind) []Host {
 		return hosts
 	}
 
+	// Once all the hosts of this kind of calls are down, fall back to the
+	// hosts serving them as a fallback only, such as the write hosts for
+	// reads when the read replicas are down.
 	for _, h := range s.hosts {
-		if h.accept(k) {
-			h.reset()
+		if !h.isDown && h.isFallbackFor(k) {
 			hosts = append(hosts, Host{h.scheme, h.host, time.Duration(h.retryCount+1) * baseTimeout})
 		}
 	}
 
+	if len(hosts) > 0 {
+		return hosts
+	}
+
+	for i := range s.hosts {
+		if s.hosts[i].accept(k) {
+			s.hosts[i].reset(now)
+			hosts = append(hosts, Host{s.hosts[i].scheme, s.hosts[i].host, baseTimeout})
+		}
+	}
+
 	return hosts
 }
 
@@ -123,9 +157,9 @@ func (s *RetryStrategy) Decide(h Host, code int, err error) Outcome {
 }
 
 func (s *RetryStrategy) markUp(host Host) {
-	for _, h := range s.hosts {
-		if h.host == host.host {
-			h.markUp()
+	for i := range s.hosts {
+		if s.hosts[i].host == host.host {
+			s.hosts[i].markUp(s.clock.Now())
 
 			return
 		}
@@ -133,9 +167,9 @@ func (s *RetryStrategy) markUp(host Host) {
 }
 
 func (s *RetryStrategy) markTimeout(host Host) {
-	for _, h := range s.hosts {
-		if h.host == host.host {
-			h.markTimeout()
+	for i := range s.hosts {
+		if s.hosts[i].host == host.host {
+			s.hosts[i].markTimeout(s.clock.Now())
 
 			return
 		}
@@ -143,15 +177,53 @@ func (s *RetryStrategy) markTimeout(host Host) {
 }
 
 func (s *RetryStrategy) markDown(host Host) {
-	for _, h := range s.hosts {
-		if h.host == host.host {
-			h.markDown()
+	for i := range s.hosts {
+		if s.hosts[i].host == host.host {
+			if s.hosts[i].markFailure(s.failureThreshold, s.clock.Now()) && s.onDown != nil {
+				s.onDown()
+			}
 
 			return
 		}
 	}
 }
 
+// recover marks a host up after a successful probe.
+func (s *RetryStrategy) recover(host Host) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.markUp(host)
+}
+
+// downHosts returns the hosts that are currently down.
+func (s *RetryStrategy) downHosts() []Host {
+	s.RLock()
+	defer s.RUnlock()
+
+	var hosts []Host
+
+	for _, h := range s.hosts {
+		if h.isDown {
+			hosts = append(hosts, Host{h.scheme, h.host, s.readTimeout})
+		}
+	}
+
+	return hosts
+}
+
+func (s *RetryStrategy) hostStatuses() []HostStatus {
+	s.RLock()
+	defer s.RUnlock()
+
+	statuses := make([]HostStatus, 0, len(s.hosts))
+	for i := range s.hosts {
+		statuses = append(statuses, s.hosts[i].status())
+	}
+
+	return statuses
+}
+
 func isNetworkError(err error) bool {
 	if err == nil {
 		return false
@@ -15,8 +15,12 @@ type StatefulHost struct {
 	host       string
 	isDown     bool
 	retryCount int
+	failures   int
 	lastUpdate time.Time
 	accept     func(k call.Kind) bool
+	// fallback tells the kinds of calls sent to the host only once all the
+	// hosts accepting them are down.
+	fallback func(k call.Kind) bool
 }
 
 func NewStatefulHost(scheme string, host string, accept func(k call.Kind) bool) StatefulHost {
@@ -25,34 +29,49 @@ func NewStatefulHost(scheme string, host string, accept func(k call.Kind) bool)
 		host:       host,
 		isDown:     false,
 		retryCount: 0,
-		lastUpdate: time.Now(),
 		accept:     accept,
 	}
 }
 
-func (h *StatefulHost) markUp() {
-	h.lastUpdate = time.Now()
+func (h *StatefulHost) markUp(now time.Time) {
+	h.lastUpdate = now
 	h.isDown = false
 	h.retryCount = 0
+	h.failures = 0
 }
 
-func (h *StatefulHost) markTimeout() {
-	h.lastUpdate = time.Now()
+func (h *StatefulHost) markTimeout(now time.Time) {
+	h.lastUpdate = now
 	h.retryCount++
 }
 
-func (h *StatefulHost) markDown() {
-	h.lastUpdate = time.Now()
+// markFailure records a failure and marks the host down once it reaches
+// threshold consecutive failures. It tells whether the host went down.
+func (h *StatefulHost) markFailure(threshold int, now time.Time) bool {
+	h.lastUpdate = now
+	h.failures++
+
+	if h.isDown || h.failures < threshold {
+		return false
+	}
+
 	h.isDown = true
 	h.retryCount = 0
+
+	return true
+}
+
+func (h *StatefulHost) isFallbackFor(k call.Kind) bool {
+	return h.fallback != nil && !h.accept(k) && h.fallback(k)
 }
 
-func (h *StatefulHost) isExpired() bool {
-	return h.isDown && time.Since(h.lastUpdate) > DefaultResetPeriod
+func (h *StatefulHost) isExpired(now time.Time) bool {
+	return h.isDown && now.Sub(h.lastUpdate) > DefaultResetPeriod
 }
 
-func (h *StatefulHost) reset() {
-	h.lastUpdate = time.Now()
+func (h *StatefulHost) reset(now time.Time) {
+	h.lastUpdate = now
 	h.isDown = false
 	h.retryCount = 0
+	h.failures = 0
 }
@@ -0,0 +1,125 @@
+package transport_test
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/call"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+func writePEM(t *testing.T, name string, blockType string, der []byte) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+
+	err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600)
+	if err != nil {
+		t.Fatalf("cannot write %s: %v", name, err)
+	}
+
+	return path
+}
+
+func TestTransportTLS(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	srv.Config.ErrorLog = log.New(io.Discard, "", 0) // silence the handshake errors of the untrusted case
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	caFile := writePEM(t, "ca.pem", "CERTIFICATE", srv.Certificate().Raw)
+
+	key, err := x509.MarshalPKCS8PrivateKey(srv.TLS.Certificates[0].PrivateKey)
+	if err != nil {
+		t.Fatalf("cannot marshal key: %v", err)
+	}
+
+	keyFile := writePEM(t, "key.pem", "PRIVATE KEY", key)
+
+	trusted, err := transport.LoadTLSConfig(caFile, caFile, keyFile)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(trusted.Certificates) != 1 {
+		t.Errorf("expected the client certificate to be loaded, got %d", len(trusted.Certificates))
+	}
+
+	tests := []struct {
+		name    string
+		opts    transport.HTTPTransportOptions
+		wantErr bool
+	}{
+		{name: "untrusted", opts: transport.HTTPTransportOptions{}, wantErr: true},
+		{name: "custom CA", opts: transport.HTTPTransportOptions{TLSConfig: trusted}},
+		{name: "insecure skip verify", opts: transport.HTTPTransportOptions{InsecureSkipVerify: true}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tr := transport.New(transport.Configuration{
+				Hosts:                []transport.StatefulHost{transport.NewStatefulHost("https", strings.TrimPrefix(srv.URL, "https://"), call.IsReadWrite)},
+				HTTPTransportOptions: tt.opts,
+			})
+
+			_, _, err := tr.Request(context.Background(), newRequest(t), call.Read, transport.RequestConfiguration{})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expected error: %t, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestTransportProxy(t *testing.T) {
+	t.Parallel()
+
+	var proxied atomic.Int32
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied.Add(1)
+
+		if r.URL.Path != "/1/indexes/products/query" {
+			t.Errorf("unexpected proxied path %q", r.URL.Path)
+		}
+
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(proxy.Close)
+
+	proxyURL, err := url.Parse(proxy.URL)
+	if err != nil {
+		t.Fatalf("cannot parse proxy URL: %v", err)
+	}
+
+	tr := transport.New(transport.Configuration{
+		Hosts:                []transport.StatefulHost{transport.NewStatefulHost("http", "flapjack.internal", call.IsReadWrite)},
+		HTTPTransportOptions: transport.HTTPTransportOptions{Proxy: proxyURL},
+	})
+
+	_, _, err = tr.Request(context.Background(), newRequest(t), call.Read, transport.RequestConfiguration{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if proxied.Load() != 1 {
+		t.Errorf("expected the request to go through the proxy, got %d requests", proxied.Load())
+	}
+}
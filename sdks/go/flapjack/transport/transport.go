@@ -6,8 +6,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net"
 	"net/http"
+	"sync/atomic"
 	"time"
 
 	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/call"
@@ -20,25 +22,76 @@ type Transport struct {
 	requester                       Requester
 	retryStrategy                   *RetryStrategy
 	compression                     compression.Compression
+	compressionScope                compression.Scope
 	connectTimeout                  time.Duration
 	exposeIntermediateNetworkErrors bool
+	exposeRateLimitErrors           bool
+	readRetryPolicy                 *RetryPolicy
+	writeRetryPolicy                *RetryPolicy
+	metricsCollector                MetricsCollector
+	logger                          *slog.Logger
+	circuitBreaker                  CircuitBreakerPolicy
+	probing                         atomic.Bool
+	failover                        *failover
+	requestIDHeader                 string
+	requestIDGenerator              func() string
+	readRateLimiter                 *rateLimiter
+	writeRateLimiter                *rateLimiter
+	lastRateLimit                   atomic.Pointer[RateLimitInfo]
+	closed                          atomic.Bool
+	done                            chan struct{}
+	clock                           Clock
 }
 
 func New(cfg Configuration) *Transport {
 	transport := &Transport{
 		requester:                       cfg.Requester,
-		retryStrategy:                   newRetryStrategy(cfg.Hosts, cfg.ReadTimeout, cfg.WriteTimeout),
+		retryStrategy:                   newRetryStrategy(hostsOf(cfg), cfg.ReadTimeout, cfg.WriteTimeout, ClockOf(cfg)),
 		connectTimeout:                  cfg.ConnectTimeout,
 		compression:                     cfg.Compression,
+		compressionScope:                cfg.CompressionScope,
 		exposeIntermediateNetworkErrors: cfg.ExposeIntermediateNetworkErrors,
+		exposeRateLimitErrors:           cfg.ExposeRateLimitErrors,
+		readRetryPolicy:                 cfg.ReadRetryPolicy,
+		writeRetryPolicy:                cfg.WriteRetryPolicy,
+		metricsCollector:                cfg.MetricsCollector,
+		logger:                          cfg.Logger,
+		requestIDHeader:                 cfg.RequestIDHeader,
+		requestIDGenerator:              cfg.RequestIDGenerator,
+		readRateLimiter:                 newRateLimiter(cfg.ReadRateLimit, ClockOf(cfg)),
+		writeRateLimiter:                newRateLimiter(cfg.WriteRateLimit, ClockOf(cfg)),
+		done:                            make(chan struct{}),
+		clock:                           ClockOf(cfg),
 	}
 
 	if transport.connectTimeout == 0 {
 		transport.connectTimeout = DefaultConnectTimeout
 	}
 
+	if cfg.CircuitBreaker != nil {
+		transport.circuitBreaker = *cfg.CircuitBreaker
+
+		if cfg.CircuitBreaker.FailureThreshold > 0 {
+			transport.retryStrategy.failureThreshold = cfg.CircuitBreaker.FailureThreshold
+		}
+
+		if cfg.CircuitBreaker.ProbeInterval > 0 {
+			transport.retryStrategy.expire = false
+			transport.retryStrategy.onDown = transport.startProbing
+		}
+	}
+
+	if cfg.Failover != nil {
+		transport.failover = newFailover(cfg, transport.retryStrategy.failureThreshold)
+	}
+
 	if transport.requester == nil {
-		transport.requester = NewDefaultRequester(&transport.connectTimeout)
+		httpTransport := cfg.HTTPTransport
+		if httpTransport == nil {
+			httpTransport = NewHTTPTransport(transport.connectTimeout, cfg.HTTPTransportOptions)
+		}
+
+		transport.requester = NewDefaultRequesterWithTransport(httpTransport)
 	}
 
 	return transport
@@ -66,12 +119,53 @@ func prepareRetryableRequest(req *http.Request) (*http.Request, error) {
 	return req, nil
 }
 
-func (t *Transport) Request(ctx context.Context, req *http.Request, k call.Kind, c RequestConfiguration) (*http.Response, []byte, error) {
-	var intermediateNetworkErrors []error
+func (t *Transport) Request(ctx context.Context, req *http.Request, k call.Kind, c RequestConfiguration) (res *http.Response, body []byte, err error) {
+	if t.closed.Load() {
+		return nil, nil, ErrClientClosed
+	}
+
+	ctx = t.withRequestID(ctx, req)
+	log := newLogger(ctx, t.logger, k)
+
+	waitStart := t.clock.Now()
+
+	if c.ResponseInfo != nil {
+		defer func() { c.ResponseInfo.fill(res, waitStart, t.clock.Now()) }()
+	}
+
+	release, err := rateLimiterFor(k, t.readRateLimiter, t.writeRateLimiter).acquire(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer release()
+
+	if waited := t.clock.Now().Sub(waitStart); waited >= time.Millisecond {
+		log.debug(ctx, "flapjack: request delayed by the rate limiter", slog.Duration("delay", waited))
+	}
+
+	stats := newCallStats(ctx, t.metricsCollector, k, t.clock)
+
+	res, body, err = t.requestWithFailover(ctx, req, k, c, stats, log)
+	stats.observeRequest(res, err)
+
+	return res, body, err
+}
+
+func (t *Transport) doRequest(ctx context.Context, req *http.Request, k call.Kind, c RequestConfiguration, stats *callStats, log *logger, strategy *RetryStrategy) (*http.Response, []byte, error) {
+	applyExtraParams(req, c)
+
+	// Compress the body and negotiate the response encoding, if needed
+	if t.compressionScope == compression.ALL_CALLS || k == call.Write {
+		if shouldCompress(t.compression, req.Method, req.Body) {
+			err := compressBody(req, t.compression)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
 
-	// Add Content-Encoding header, if needed
-	if t.compression == compression.GZIP && shouldCompress(t.compression, req.Method, req.Body) {
-		req.Header.Add("Content-Encoding", "gzip")
+		if acceptEncoding := acceptEncoding(t.compression); acceptEncoding != "" {
+			req.Header.Set("Accept-Encoding", acceptEncoding)
+		}
 	}
 
 	// Prepare the request to be retryable.
@@ -80,7 +174,58 @@ func (t *Transport) Request(ctx context.Context, req *http.Request, k call.Kind,
 		return nil, nil, err
 	}
 
-	for i, h := range t.retryStrategy.GetTryableHosts(k) {
+	policy := retryPolicyFor(k, t.readRetryPolicy, t.writeRetryPolicy)
+	hosts := strategy.GetTryableHosts(k)
+	next := 0
+	rateLimitRetries := 0
+
+	// waitedRetryAfter is set once the Retry-After of a 429 has been waited
+	// for, which replaces the backoff before the next attempt.
+	waitedRetryAfter := false
+
+	var (
+		attemptedHosts []string
+		hostErrors     []*errs.HostError
+		lastErr        error
+	)
+
+	for attempt := 0; attempt < policy.maxAttempts(len(hosts)); attempt++ {
+		// Once every host has been tried, start over with the hosts that
+		// are still tryable, which are all of them if they are all down.
+		if next == len(hosts) {
+			hosts = strategy.GetTryableHosts(k)
+			next = 0
+		}
+
+		if len(hosts) == 0 {
+			break
+		}
+
+		h := hosts[next]
+		next++
+
+		delay := policy.Delay(attempt)
+		if waitedRetryAfter {
+			delay = 0
+		}
+
+		if remaining, exceeded := policy.exceedsDeadline(ctx, delay, t.clock.Now()); exceeded {
+			log.debug(ctx, "flapjack: not enough time left for another attempt", slog.Int("attempt", attempt), slog.Duration("remaining", remaining), slog.Duration("delay", delay))
+
+			return nil, nil, errs.NewDeadlineExceededError(attemptedHosts, remaining, lastErr)
+		}
+
+		if attempt > 0 && !waitedRetryAfter {
+			log.debug(ctx, "flapjack: retrying request", slog.String("host", h.host), slog.Int("attempt", attempt), slog.Duration("delay", delay))
+
+			err := t.clock.Sleep(ctx, delay)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		waitedRetryAfter = false
+
 		// Handle per-request timeout by using a context with timeout.
 		// Note that because we are in a loop, the cancel() callback cannot be
 		// deferred. Instead, we call it precisely after the end of each loop or
@@ -94,7 +239,7 @@ func (t *Transport) Request(ctx context.Context, req *http.Request, k call.Kind,
 		)
 
 		// Reassign a fresh body for the retry
-		if i > 0 && req.GetBody != nil {
+		if attempt > 0 && req.GetBody != nil {
 			req.Body, err = req.GetBody()
 			if err != nil {
 				break
@@ -116,15 +261,31 @@ func (t *Transport) Request(ctx context.Context, req *http.Request, k call.Kind,
 			connectTimeout = t.connectTimeout
 		}
 
-		perRequestCtx, cancel := context.WithTimeout(ctx, ctxTimeout)
+		perRequestCtx, cancel := context.WithTimeout(withAttempt(ctx, attempt), ctxTimeout)
 		req = req.WithContext(perRequestCtx)
+		log.debug(ctx, "flapjack: sending request", slog.String("host", h.host), slog.Int("attempt", attempt), slog.String("method", req.Method), slog.String("path", req.URL.Path), slog.Duration("timeout", ctxTimeout))
+
+		attemptStart := t.clock.Now()
 		res, err := t.request(req, h, ctxTimeout, connectTimeout)
+		stats.observeAttempt(h, attempt, attemptStart, res, err)
+		t.recordRateLimit(res)
+
+		attemptedHosts = append(attemptedHosts, h.host)
 
 		code := 0
 		if res != nil {
 			code = res.StatusCode
 		}
 
+		switch {
+		case err == nil:
+			log.debug(ctx, "flapjack: received response", slog.String("host", h.host), slog.Int("attempt", attempt), slog.Int("status", code), slog.Duration("duration", t.clock.Now().Sub(attemptStart)))
+		case isTimeout(err):
+			log.debug(ctx, "flapjack: request timed out", slog.String("host", h.host), slog.Int("attempt", attempt), slog.Duration("timeout", ctxTimeout), slog.Any("error", err))
+		default:
+			log.debug(ctx, "flapjack: request failed", slog.String("host", h.host), slog.Int("attempt", attempt), slog.Any("error", err))
+		}
+
 		// Context error only returns a non-nil error upon context
 		// cancellation, which is a signal we interpret as an early return.
 		// Indeed, we do not want to retry on other hosts if the context is
@@ -135,14 +296,69 @@ func (t *Transport) Request(ctx context.Context, req *http.Request, k call.Kind,
 			return res, nil, err
 		}
 
-		switch t.retryStrategy.Decide(h, code, err) {
+		if code == http.StatusTooManyRequests {
+			retryAfter, hasRetryAfter := parseRetryAfter(res.Header.Get("Retry-After"), t.clock.Now())
+
+			switch {
+			case t.exposeRateLimitErrors:
+				_ = res.Body.Close()
+
+				cancel()
+
+				return nil, nil, errs.NewRateLimitedError(h.host, retryAfter)
+			case hasRetryAfter && rateLimitRetries < policy.maxRateLimitRetries() && policy.canWaitFor(ctx, retryAfter, t.clock.Now()):
+				_, _ = io.Copy(io.Discard, res.Body)
+				_ = res.Body.Close()
+
+				cancel()
+
+				log.debug(ctx, "flapjack: rate limited, waiting before retrying", slog.String("host", h.host), slog.Duration("retry_after", retryAfter))
+
+				err = t.clock.Sleep(ctx, retryAfter)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				// Retry the same host without consuming an attempt, nor
+				// waiting for the backoff on top of Retry-After.
+				rateLimitRetries++
+				waitedRetryAfter = true
+				attempt--
+				next--
+
+				continue
+			}
+		}
+
+		switch outcome := policy.decide(strategy.Decide(h, code, err), req.Method, code); outcome {
 		case Success, Failure:
+			// Errors that are neither network errors nor timeouts come
+			// without a response.
+			if res == nil {
+				cancel()
+
+				return nil, nil, err
+			}
+
+			if res.Request != nil {
+				res.Request = res.Request.WithContext(withAttemptedHosts(res.Request.Context(), attemptedHosts))
+			}
+
+			// Streamed bodies are read by the caller, the per-request
+			// context is only cancelled once they are closed.
+			if outcome == Success && c.Stream {
+				res.Body = &cancelOnCloseBody{ReadCloser: res.Body, cancel: cancel}
+
+				return res, nil, nil
+			}
+
 			body, errBody := io.ReadAll(res.Body)
 			errClose := res.Body.Close()
 
 			cancel()
 
 			res.Body = io.NopCloser(bytes.NewBuffer(body))
+
 			if errBody != nil {
 				return res, nil, fmt.Errorf("cannot read body: %w", errBody)
 			}
@@ -153,11 +369,20 @@ func (t *Transport) Request(ctx context.Context, req *http.Request, k call.Kind,
 
 			return res, body, err
 		default:
-			if err != nil {
-				intermediateNetworkErrors = append(intermediateNetworkErrors, err)
-			} else if res != nil {
-				msg := fmt.Sprintf("cannot perform request:\n\tStatusCode=%d\n\tmethod=%s\n\turl=%s\n\t", res.StatusCode, req.Method, req.URL)
-				intermediateNetworkErrors = append(intermediateNetworkErrors, errors.New(msg))
+			if err == nil {
+				err = fmt.Errorf("cannot perform request:\n\tStatusCode=%d\n\tmethod=%s\n\turl=%s", code, req.Method, req.URL)
+			}
+
+			lastErr = err
+
+			if t.exposeIntermediateNetworkErrors {
+				hostErrors = append(hostErrors, &errs.HostError{
+					Host:       h.host,
+					Attempt:    attempt,
+					Time:       attemptStart,
+					StatusCode: code,
+					Err:        err,
+				})
 			}
 
 			if res != nil && res.Body != nil {
@@ -173,19 +398,30 @@ func (t *Transport) Request(ctx context.Context, req *http.Request, k call.Kind,
 		cancel()
 	}
 
+	log.debug(ctx, "flapjack: no more host to try")
+
 	if t.exposeIntermediateNetworkErrors {
-		return nil, nil, errs.NewNoMoreHostToTryError(intermediateNetworkErrors...)
+		return nil, nil, errs.NewMultiHostError(hostErrors...)
 	}
 
 	return nil, nil, errs.ErrNoMoreHostToTry
 }
 
 func (t *Transport) request(req *http.Request, host Host, timeout time.Duration, connectTimeout time.Duration) (*http.Response, error) {
-	req.URL.Scheme = host.scheme
-	req.URL.Host = host.host
+	if host.scheme == UnixScheme {
+		req = withUnixSocket(req, host.host)
+	} else {
+		req.URL.Scheme = host.scheme
+		req.URL.Host = host.host
+	}
 
 	debug.Display(req)
 	res, err := t.requester.Request(req, timeout, connectTimeout)
+
+	if err == nil {
+		err = decompressBody(res)
+	}
+
 	debug.Display(res)
 
 	if err != nil {
@@ -210,6 +446,107 @@ func (t *Transport) request(req *http.Request, host Host, timeout time.Duration,
 	return res, nil
 }
 
+// cancelOnCloseBody cancels the context of a streamed response once its body
+// is closed.
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+
+	return err //nolint:wrapcheck
+}
+
+// applyExtraParams adds the per-call headers and query parameters to the
+// request. The query parameters are appended to the query string as is, so that
+// the encoding of the existing parameters is kept.
+func applyExtraParams(req *http.Request, c RequestConfiguration) {
+	for k, v := range c.ExtraHeaders {
+		req.Header.Set(k, v)
+	}
+
+	if len(c.ExtraQueryParams) == 0 {
+		return
+	}
+
+	if req.URL.RawQuery != "" {
+		req.URL.RawQuery += "&"
+	}
+
+	req.URL.RawQuery += c.ExtraQueryParams.Encode()
+}
+
+// compressBody replaces the body of the request by its compressed version.
+func compressBody(req *http.Request, c compression.Compression) error {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return fmt.Errorf("cannot read body: %w", err)
+	}
+
+	_ = req.Body.Close()
+
+	compressed, err := compression.Compress(c, body)
+	if err != nil {
+		return fmt.Errorf("cannot compress body: %w", err)
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(compressed))
+	req.ContentLength = int64(len(compressed))
+	req.Header.Set("Content-Encoding", c.ContentEncoding())
+
+	return nil
+}
+
+// acceptEncoding returns the `Accept-Encoding` header of the requests, empty
+// for gzip which the standard library negotiates and decodes by itself.
+func acceptEncoding(c compression.Compression) string {
+	if c == compression.NONE || c == compression.GZIP || !compression.IsRegistered(c) {
+		return ""
+	}
+
+	return c.ContentEncoding() + ", gzip"
+}
+
+// decompressBody decodes the body of a response whose encoding was negotiated
+// through acceptEncoding. Bodies in unknown encodings are left untouched.
+func decompressBody(res *http.Response) error {
+	c := compression.FromContentEncoding(res.Header.Get("Content-Encoding"))
+	if c == compression.NONE || res.Uncompressed || !compression.IsRegistered(c) {
+		return nil
+	}
+
+	reader, err := compression.NewReader(c, res.Body)
+	if err != nil {
+		_ = res.Body.Close()
+
+		return fmt.Errorf("cannot decompress response: %w", err)
+	}
+
+	res.Body = &decompressedBody{ReadCloser: reader, body: res.Body}
+	res.Header.Del("Content-Encoding")
+	res.Header.Del("Content-Length")
+	res.ContentLength = -1
+	res.Uncompressed = true
+
+	return nil
+}
+
+// decompressedBody closes both the decompressing reader and the original body.
+type decompressedBody struct {
+	io.ReadCloser
+	body io.ReadCloser
+}
+
+func (b *decompressedBody) Close() error {
+	err := b.ReadCloser.Close()
+	errBody := b.body.Close()
+
+	return errors.Join(err, errBody)
+}
+
 func shouldCompress(c compression.Compression, method string, body any) bool {
 	isValidMethod := method == http.MethodPut || method == http.MethodPost
 	isCompressionEnabled := c != compression.NONE
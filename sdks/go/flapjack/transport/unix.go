@@ -0,0 +1,70 @@
+package transport
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/call"
+)
+
+// UnixScheme is the scheme of the hosts reached through a Unix domain socket.
+const UnixScheme = "unix"
+
+// NewUnixSocketHost creates a host reached through the Unix domain socket at the given path, for deployments where
+// the engine runs on the same machine as the client. Requests are sent over plain HTTP.
+//
+// The socket is dialed by the default requester. Custom requesters or HTTP transports must dial the path returned by
+// UnixSocketFromContext themselves.
+func NewUnixSocketHost(socketPath string, accept func(k call.Kind) bool) StatefulHost {
+	return NewStatefulHost(UnixScheme, socketPath, accept)
+}
+
+// NewStatefulHostFromURL creates a host from its URL, such as `https://flapjack.example.com` or
+// `unix:///var/run/flapjack.sock`.
+func NewStatefulHostFromURL(rawURL string, accept func(k call.Kind) bool) (StatefulHost, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return StatefulHost{}, fmt.Errorf("cannot parse host URL: %w", err)
+	}
+
+	switch u.Scheme {
+	case UnixScheme:
+		if u.Path == "" {
+			return StatefulHost{}, fmt.Errorf("missing socket path in %q", rawURL)
+		}
+
+		return NewUnixSocketHost(u.Path, accept), nil
+	case "http", "https":
+		if u.Host == "" {
+			return StatefulHost{}, fmt.Errorf("missing host in %q", rawURL)
+		}
+
+		return NewStatefulHost(u.Scheme, u.Host, accept), nil
+	default:
+		return StatefulHost{}, fmt.Errorf("unsupported scheme %q in %q", u.Scheme, rawURL)
+	}
+}
+
+type unixSocketKey struct{}
+
+// UnixSocketFromContext returns the path of the Unix domain socket a request must be sent through, from the context
+// given to the dialer.
+func UnixSocketFromContext(ctx context.Context) (string, bool) {
+	socketPath, ok := ctx.Value(unixSocketKey{}).(string)
+
+	return socketPath, ok
+}
+
+// withUnixSocket routes the request through the given socket. The URL host
+// identifies the socket, so that connections are pooled per socket.
+func withUnixSocket(req *http.Request, socketPath string) *http.Request {
+	req = req.WithContext(context.WithValue(req.Context(), unixSocketKey{}, socketPath))
+	req.URL.Scheme = "http"
+	req.URL.Host = hex.EncodeToString([]byte(socketPath)) + ".sock"
+	req.Host = "localhost"
+
+	return req
+}
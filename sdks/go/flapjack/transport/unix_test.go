@@ -0,0 +1,75 @@
+package transport_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/call"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+func TestTransportUnixSocket(t *testing.T) {
+	t.Parallel()
+
+	// Socket paths are limited in length, t.TempDir may be too long.
+	dir, err := os.MkdirTemp("", "fj")
+	if err != nil {
+		t.Fatalf("cannot create directory: %v", err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	socketPath := filepath.Join(dir, "flapjack.sock")
+
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatalf("cannot listen: %v", err)
+	}
+
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Host != "localhost" || r.URL.Path != "/1/indexes/products/query" {
+			t.Errorf("unexpected request to %s%s", r.Host, r.URL.Path)
+		}
+
+		_, _ = w.Write([]byte(`{"nbHits":1}`))
+	})}
+
+	go func() { _ = srv.Serve(listener) }()
+
+	t.Cleanup(func() { _ = srv.Close() })
+
+	host, err := transport.NewStatefulHostFromURL("unix://"+socketPath, call.IsReadWrite)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tr := transport.New(transport.Configuration{Hosts: []transport.StatefulHost{host}})
+
+	_, body, err := tr.Request(context.Background(), newRequest(t), call.Read, transport.RequestConfiguration{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if string(body) != `{"nbHits":1}` {
+		t.Errorf("unexpected body %q", body)
+	}
+}
+
+func TestNewStatefulHostFromURL(t *testing.T) {
+	t.Parallel()
+
+	for _, rawURL := range []string{"https://flapjack.example.com", "http://localhost:7700", "unix:///var/run/flapjack.sock"} {
+		if _, err := transport.NewStatefulHostFromURL(rawURL, call.IsReadWrite); err != nil {
+			t.Errorf("unexpected error for %q: %v", rawURL, err)
+		}
+	}
+
+	for _, rawURL := range []string{"ftp://flapjack.example.com", "unix://", "https://"} {
+		if _, err := transport.NewStatefulHostFromURL(rawURL, call.IsReadWrite); err == nil {
+			t.Errorf("expected an error for %q", rawURL)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package transport
+
+import (
+	"strings"
+)
+
+// UserAgentSegment formats the user agent segment of an integration, such as
+// `Laravel Scout (10.0.0)`, or its name alone when the version is empty.
+func UserAgentSegment(name, version string) string {
+	if version == "" {
+		return name
+	}
+
+	return name + " (" + version + ")"
+}
+
+// AppendUserAgentSegment returns the user agent with the segment appended,
+// unless it already contains it.
+func AppendUserAgentSegment(userAgent, segment string) string {
+	if segment == "" {
+		return userAgent
+	}
+
+	if userAgent == "" {
+		return segment
+	}
+
+	for _, s := range strings.Split(userAgent, "; ") {
+		if s == segment {
+			return userAgent
+		}
+	}
+
+	return userAgent + "; " + segment
+}
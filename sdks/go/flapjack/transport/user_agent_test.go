@@ -0,0 +1,34 @@
+package transport_test
+
+import (
+	"testing"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+func TestAppendUserAgentSegment(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		userAgent string
+		segment   string
+		want      string
+	}{
+		{name: "append", userAgent: "Flapjack for Go (4.36.0)", segment: "Scout (10.0.0)", want: "Flapjack for Go (4.36.0); Scout (10.0.0)"},
+		{name: "already present", userAgent: "Flapjack for Go (4.36.0); Scout (10.0.0)", segment: "Scout (10.0.0)", want: "Flapjack for Go (4.36.0); Scout (10.0.0)"},
+		{name: "other version", userAgent: "Flapjack for Go (4.36.0); Scout (10.0.0)", segment: "Scout (11.0.0)", want: "Flapjack for Go (4.36.0); Scout (10.0.0); Scout (11.0.0)"},
+		{name: "empty user agent", userAgent: "", segment: "Scout", want: "Scout"},
+		{name: "empty segment", userAgent: "Flapjack for Go (4.36.0)", segment: "", want: "Flapjack for Go (4.36.0)"},
+	}
+
+	for _, tt := range tests {
+		if got := transport.AppendUserAgentSegment(tt.userAgent, tt.segment); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	if got := transport.UserAgentSegment("Scout", ""); got != "Scout" {
+		t.Errorf("expected the name alone without version, got %q", got)
+	}
+}
@@ -0,0 +1,151 @@
+package transport
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/compression"
+)
+
+// Validate checks the configuration, returning an error describing every
+// problem found: missing credentials, no host, malformed hosts, negative
+// timeouts or a compression without a registered implementation.
+func (c *Configuration) Validate() error {
+	var problems []error
+
+	if c.AppID == "" {
+		problems = append(problems, errors.New("`appId` is missing."))
+	}
+
+	if c.ApiKey == "" {
+		problems = append(problems, errors.New("`apiKey` is missing."))
+	}
+
+	if len(c.Hosts) == 0 && len(c.ReadHosts) == 0 && len(c.WriteHosts) == 0 {
+		problems = append(problems, errors.New("no host is configured"))
+	}
+
+	for _, hosts := range [][]StatefulHost{c.Hosts, c.ReadHosts, c.WriteHosts} {
+		for _, h := range hosts {
+			err := h.validate()
+			if err != nil {
+				problems = append(problems, err)
+			}
+		}
+	}
+
+	timeouts := []struct {
+		name    string
+		timeout time.Duration
+	}{
+		{"ReadTimeout", c.ReadTimeout},
+		{"WriteTimeout", c.WriteTimeout},
+		{"ConnectTimeout", c.ConnectTimeout},
+	}
+
+	for _, t := range timeouts {
+		if t.timeout < 0 {
+			problems = append(problems, fmt.Errorf("`%s` must not be negative", t.name))
+		}
+	}
+
+	if c.Compression != compression.NONE && !compression.IsRegistered(c.Compression) {
+		problems = append(problems, fmt.Errorf("`Compression` %q is not registered, call compression.Register first", c.Compression.ContentEncoding()))
+	}
+
+	policies := []struct {
+		name   string
+		policy *RetryPolicy
+	}{
+		{"ReadRetryPolicy", c.ReadRetryPolicy},
+		{"WriteRetryPolicy", c.WriteRetryPolicy},
+	}
+
+	for _, p := range policies {
+		if p.policy != nil && (p.policy.MaxAttempts < 0 || p.policy.BaseDelay < 0 || p.policy.MaxDelay < 0 || p.policy.Jitter < 0 || p.policy.Jitter > 1) {
+			problems = append(problems, fmt.Errorf("`%s` must have no negative values and a jitter between 0 and 1", p.name))
+		}
+
+		if p.policy != nil && slices.ContainsFunc(p.policy.RetryableStatusCodes, func(code int) bool { return code < 100 || code > 599 }) {
+			problems = append(problems, fmt.Errorf("`%s` must have retryable status codes between 100 and 599", p.name))
+		}
+	}
+
+	limits := []struct {
+		name  string
+		limit *RateLimit
+	}{
+		{"ReadRateLimit", c.ReadRateLimit},
+		{"WriteRateLimit", c.WriteRateLimit},
+	}
+
+	for _, l := range limits {
+		if l.limit != nil && (l.limit.OpsPerSecond < 0 || l.limit.Burst < 0 || l.limit.MaxInFlight < 0) {
+			problems = append(problems, fmt.Errorf("`%s` must have no negative values", l.name))
+		}
+	}
+
+	if c.CircuitBreaker != nil && (c.CircuitBreaker.FailureThreshold < 0 || c.CircuitBreaker.ProbeInterval < 0 || c.CircuitBreaker.ProbeTimeout < 0) {
+		problems = append(problems, errors.New("`CircuitBreaker` must have no negative values"))
+	}
+
+	if c.Failover != nil {
+		if c.Failover.AppID == "" || c.Failover.ApiKey == "" {
+			problems = append(problems, errors.New("`Failover` requires an appId and an apiKey"))
+		}
+
+		if len(c.Failover.Hosts) == 0 {
+			problems = append(problems, errors.New("`Failover` requires hosts"))
+		}
+
+		for _, h := range c.Failover.Hosts {
+			err := h.validate()
+			if err != nil {
+				problems = append(problems, fmt.Errorf("failover: %w", err))
+			}
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("invalid configuration: %w", errors.Join(problems...))
+}
+
+func (h StatefulHost) validate() error {
+	if h.accept == nil {
+		return fmt.Errorf("host %q has no accept function, use NewStatefulHost to create it", h.host)
+	}
+
+	if h.host == "" {
+		return errors.New("host is empty")
+	}
+
+	switch h.scheme {
+	case UnixScheme:
+		return nil
+	case "http", "https":
+	default:
+		return fmt.Errorf("host %q has an unsupported scheme %q", h.host, h.scheme)
+	}
+
+	if strings.ContainsAny(h.host, "/?#@ \t") {
+		return fmt.Errorf("host %q must be a host name with an optional port, without scheme or path", h.host)
+	}
+
+	u, err := url.Parse(h.scheme + "://" + h.host)
+	if err != nil {
+		return fmt.Errorf("host %q is malformed: %w", h.host, err)
+	}
+
+	if u.Hostname() == "" {
+		return fmt.Errorf("host %q has no host name", h.host)
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package transport_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/call"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/compression"
+	"github.com/flapjackhq/flapjack-search-go/v4/flapjack/transport"
+)
+
+func TestConfigurationValidate(t *testing.T) {
+	t.Parallel()
+
+	valid := func() transport.Configuration {
+		return transport.Configuration{
+			AppID:  "app",
+			ApiKey: "key",
+			Hosts:  []transport.StatefulHost{transport.NewStatefulHost("http", "localhost:7700", call.IsReadWrite)},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(cfg *transport.Configuration)
+		wantErr string
+	}{
+		{name: "valid", mutate: func(*transport.Configuration) {}},
+		{name: "missing app ID", mutate: func(cfg *transport.Configuration) { cfg.AppID = "" }, wantErr: "`appId` is missing."},
+		{name: "missing API key", mutate: func(cfg *transport.Configuration) { cfg.ApiKey = "" }, wantErr: "`apiKey` is missing."},
+		{name: "no host", mutate: func(cfg *transport.Configuration) { cfg.Hosts = nil }, wantErr: "no host is configured"},
+		{
+			name:   "host groups only",
+			mutate: func(cfg *transport.Configuration) { cfg.ReadHosts, cfg.Hosts = cfg.Hosts, nil },
+		},
+		{
+			name: "host with a scheme",
+			mutate: func(cfg *transport.Configuration) {
+				cfg.Hosts[0] = transport.NewStatefulHost("http", "http://localhost", call.IsRead)
+			},
+			wantErr: "without scheme or path",
+		},
+		{
+			name: "host with an invalid port",
+			mutate: func(cfg *transport.Configuration) {
+				cfg.Hosts[0] = transport.NewStatefulHost("http", "localhost:port", call.IsRead)
+			},
+			wantErr: "is malformed",
+		},
+		{
+			name: "unsupported scheme",
+			mutate: func(cfg *transport.Configuration) {
+				cfg.Hosts[0] = transport.NewStatefulHost("ftp", "localhost", call.IsRead)
+			},
+			wantErr: "unsupported scheme",
+		},
+		{
+			name:    "zero value host",
+			mutate:  func(cfg *transport.Configuration) { cfg.Hosts[0] = transport.StatefulHost{} },
+			wantErr: "use NewStatefulHost",
+		},
+		{
+			name: "unix socket host",
+			mutate: func(cfg *transport.Configuration) {
+				cfg.Hosts[0] = transport.NewUnixSocketHost("/tmp/flapjack.sock", call.IsRead)
+			},
+		},
+		{name: "negative timeout", mutate: func(cfg *transport.Configuration) { cfg.WriteTimeout = -time.Second }, wantErr: "`WriteTimeout` must not be negative"},
+		{name: "gzip compression", mutate: func(cfg *transport.Configuration) { cfg.Compression = compression.GZIP }},
+		{
+			name:    "unregistered compression",
+			mutate:  func(cfg *transport.Configuration) { cfg.Compression = compression.BROTLI },
+			wantErr: "`Compression` \"br\" is not registered",
+		},
+		{
+			name:    "invalid retry policy",
+			mutate:  func(cfg *transport.Configuration) { cfg.ReadRetryPolicy = &transport.RetryPolicy{Jitter: 2} },
+			wantErr: "`ReadRetryPolicy`",
+		},
+		{
+			name: "invalid retryable status code",
+			mutate: func(cfg *transport.Configuration) {
+				cfg.WriteRetryPolicy = &transport.RetryPolicy{RetryableStatusCodes: []int{503, 5000}}
+			},
+			wantErr: "`WriteRetryPolicy` must have retryable status codes between 100 and 599",
+		},
+		{
+			name:    "invalid rate limit",
+			mutate:  func(cfg *transport.Configuration) { cfg.WriteRateLimit = &transport.RateLimit{OpsPerSecond: -1} },
+			wantErr: "`WriteRateLimit` must have no negative values",
+		},
+		{
+			name:    "failover without credentials",
+			mutate:  func(cfg *transport.Configuration) { cfg.Failover = &transport.Failover{Hosts: cfg.Hosts} },
+			wantErr: "`Failover` requires an appId and an apiKey",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := valid()
+			tt.mutate(&cfg)
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestConfigurationValidateReportsEveryProblem(t *testing.T) {
+	t.Parallel()
+
+	cfg := transport.Configuration{ReadTimeout: -time.Second}
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	for _, want := range []string{"`appId` is missing.", "`apiKey` is missing.", "no host is configured", "`ReadTimeout` must not be negative"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected the error to contain %q, got %v", want, err)
+		}
+	}
+}
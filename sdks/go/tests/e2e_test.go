@@ -72,12 +72,24 @@ func seedTestData(t *testing.T, client *search.APIClient) {
 	}
 
 	batchReq := client.NewApiBatchRequest(testIndex, search.NewBatchWriteParams(objects))
-	_, err = client.Batch(batchReq)
+	batchResp, err := client.Batch(batchReq)
 	if err != nil {
 		t.Fatalf("failed to batch save objects: %v", err)
 	}
 
-	time.Sleep(500 * time.Millisecond)
+	waitForTask(t, client, batchResp.TaskID)
+}
+
+func waitForTask(t *testing.T, client *search.APIClient, taskID int64) {
+	t.Helper()
+
+	_, err := client.WaitForTask(testIndex, taskID,
+		search.WithExponentialBackoff(50*time.Millisecond, time.Second),
+		search.WithMaxDuration(30*time.Second),
+	)
+	if err != nil {
+		t.Fatalf("failed to wait for task %d: %v", taskID, err)
+	}
 }
 
 func setupSuite(t *testing.T) *search.APIClient {
@@ -338,12 +350,12 @@ func TestPartialUpdateObject(t *testing.T) {
 	defer cleanupSuite(t, client)
 
 	updateReq := client.NewApiPartialUpdateObjectRequest(testIndex, "phone1", map[string]any{"price": 949})
-	_, err := client.PartialUpdateObject(updateReq)
+	updateResp, err := client.PartialUpdateObject(updateReq)
 	if err != nil {
 		t.Fatalf("PartialUpdateObject failed: %v", err)
 	}
 
-	time.Sleep(300 * time.Millisecond)
+	waitForTask(t, client, updateResp.GetTaskID())
 
 	getReq := client.NewApiGetObjectRequest(testIndex, "phone1")
 	resp, err := client.GetObject(getReq)
@@ -358,8 +370,9 @@ func TestPartialUpdateObject(t *testing.T) {
 
 	// Restore
 	restoreReq := client.NewApiPartialUpdateObjectRequest(testIndex, "phone1", map[string]any{"price": 999})
-	_, _ = client.PartialUpdateObject(restoreReq)
-	time.Sleep(300 * time.Millisecond)
+	if restoreResp, err := client.PartialUpdateObject(restoreReq); err == nil {
+		waitForTask(t, client, restoreResp.GetTaskID())
+	}
 }
 
 func TestSaveAndDeleteObject(t *testing.T) {
@@ -369,12 +382,12 @@ func TestSaveAndDeleteObject(t *testing.T) {
 	saveReq := client.NewApiAddOrUpdateObjectRequest(testIndex, "temp_go_1", map[string]any{
 		"name": "Temp Product", "brand": "Test", "category": "Test", "price": 1,
 	})
-	_, err := client.AddOrUpdateObject(saveReq)
+	saveResp, err := client.AddOrUpdateObject(saveReq)
 	if err != nil {
 		t.Fatalf("AddOrUpdateObject failed: %v", err)
 	}
 
-	time.Sleep(300 * time.Millisecond)
+	waitForTask(t, client, saveResp.GetTaskID())
 
 	getReq := client.NewApiGetObjectRequest(testIndex, "temp_go_1")
 	resp, err := client.GetObject(getReq)
@@ -392,6 +405,34 @@ func TestSaveAndDeleteObject(t *testing.T) {
 	}
 }
 
+func TestClearObjectsKeepsSettings(t *testing.T) {
+	client := setupSuite(t)
+	defer cleanupSuite(t, client)
+
+	clearResp, err := client.ClearObjects(client.NewApiClearObjectsRequest(testIndex))
+	if err != nil {
+		t.Fatalf("ClearObjects failed: %v", err)
+	}
+
+	waitForTask(t, client, clearResp.TaskID)
+
+	resp, err := client.SearchSingleIndex(client.NewApiSearchSingleIndexRequest(testIndex))
+	if err != nil {
+		t.Fatalf("SearchSingleIndex failed: %v", err)
+	}
+	if resp.GetNbHits() != 0 {
+		t.Errorf("expected no records after ClearObjects, got %d", resp.GetNbHits())
+	}
+
+	settings, err := client.GetSettings(client.NewApiGetSettingsRequest(testIndex))
+	if err != nil {
+		t.Fatalf("GetSettings failed: %v", err)
+	}
+	if len(settings.SearchableAttributes) == 0 {
+		t.Error("expected searchableAttributes to be preserved by ClearObjects")
+	}
+}
+
 // =========================================================================
 // Settings Tests
 // =========================================================================
@@ -419,12 +460,12 @@ func TestUpdateSettings(t *testing.T) {
 		search.WithIndexSettingsSearchableAttributes([]string{"name", "brand", "category", "price"}),
 	)
 	setReq := client.NewApiSetSettingsRequest(testIndex, newSettings)
-	_, err := client.SetSettings(setReq)
+	setResp, err := client.SetSettings(setReq)
 	if err != nil {
 		t.Fatalf("SetSettings failed: %v", err)
 	}
 
-	time.Sleep(300 * time.Millisecond)
+	waitForTask(t, client, setResp.TaskID)
 
 	getReq := client.NewApiGetSettingsRequest(testIndex)
 	resp, err := client.GetSettings(getReq)
@@ -448,8 +489,9 @@ func TestUpdateSettings(t *testing.T) {
 		search.WithIndexSettingsSearchableAttributes([]string{"name", "brand", "category"}),
 	)
 	restoreReq := client.NewApiSetSettingsRequest(testIndex, restoreSettings)
-	_, _ = client.SetSettings(restoreReq)
-	time.Sleep(300 * time.Millisecond)
+	if restoreResp, err := client.SetSettings(restoreReq); err == nil {
+		waitForTask(t, client, restoreResp.TaskID)
+	}
 }
 
 // =========================================================================
@@ -465,12 +507,12 @@ func TestSaveAndSearchSynonyms(t *testing.T) {
 	)
 
 	saveReq := client.NewApiSaveSynonymRequest(testIndex, "syn_phone_mobile_go", synonym)
-	_, err := client.SaveSynonym(saveReq)
+	saveResp, err := client.SaveSynonym(saveReq)
 	if err != nil {
 		t.Fatalf("SaveSynonym failed: %v", err)
 	}
 
-	time.Sleep(300 * time.Millisecond)
+	waitForTask(t, client, saveResp.TaskID)
 
 	searchReq := client.NewApiSearchSynonymsRequest(testIndex)
 	resp, err := client.SearchSynonyms(searchReq)
@@ -511,12 +553,12 @@ func TestSaveAndSearchRules(t *testing.T) {
 	)
 
 	saveReq := client.NewApiSaveRuleRequest(testIndex, "rule_budget_go", rule)
-	_, err := client.SaveRule(saveReq)
+	saveResp, err := client.SaveRule(saveReq)
 	if err != nil {
 		t.Fatalf("SaveRule failed: %v", err)
 	}
 
-	time.Sleep(300 * time.Millisecond)
+	waitForTask(t, client, saveResp.TaskID)
 
 	searchReq := client.NewApiSearchRulesRequest(testIndex)
 	resp, err := client.SearchRules(searchReq)